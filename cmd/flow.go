@@ -0,0 +1,19 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// flowCmd represents the flow command
+var flowCmd = &cobra.Command{
+	Use:   "flow",
+	Short: "Inspect the flow runner",
+	Long:  `It inspects the flow runner, such as exporting the dependency graph.`,
+}
+
+func init() {
+	rootCmd.AddCommand(flowCmd)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+var (
+	flowGraphFormat string
+	flowGraphOutput string
+	flowGraphData   []string
+)
+
+// flowGraphCmd represents the flow graph command
+var flowGraphCmd = &cobra.Command{
+	Use:   "graph [file]",
+	Short: "Export the dependency graph of the flow runner",
+	Long: `This command exports the dependency graph of the flow runner as Mermaid or Graphviz DOT.
+Nested flow groups are shown as subgraphs and the edges are labelled with the waited event.
+Dependency cycles and the waits which are never satisfied are reported.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if ctr.Config.Type == config.ConfigTypeSlave {
+			color.Red("This command is not available in slave mode")
+			return
+		}
+
+		data, err := parseRunnerData(flowGraphData)
+		if err != nil {
+			color.Red("Failed to parse data: %v\n", err)
+			return
+		}
+
+		var w io.Writer = os.Stdout
+		if flowGraphOutput != "" {
+			f, err := os.Create(flowGraphOutput)
+			if err != nil {
+				color.Red("Failed to create the output file: %v\n", err)
+				return
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := runner.GraphFlow(ctr, args[0], data, runner.FlowGraphFormat(flowGraphFormat), w); err != nil {
+			color.Red("Failed to export the flow graph: %v\n", err)
+			return
+		}
+		if flowGraphOutput != "" {
+			fmt.Printf("Exported the flow graph to %s\n", flowGraphOutput)
+		}
+	},
+}
+
+func init() {
+	flowCmd.AddCommand(flowGraphCmd)
+
+	flowGraphCmd.Flags().StringVarP(&flowGraphFormat, "format", "F", string(runner.FlowGraphFormatMermaid),
+		"The format of the graph (mermaid, dot)")
+	flowGraphCmd.Flags().StringVarP(&flowGraphOutput, "output", "o", "", "The file to write the graph")
+	flowGraphCmd.Flags().StringArrayVarP(&flowGraphData, "data", "d", []string{}, "The data to render the flow")
+}
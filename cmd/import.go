@@ -0,0 +1,65 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+var (
+	importOutputDir     string
+	importKind          string
+	importCount         int
+	importInterval      string
+	importOutputIDs     []string
+	importIncludeStatic bool
+	importForce         bool
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import the recorded requests into the runner files",
+	Long: `It converts the browser sessions recorded as HAR or the copied curl commands
+into the OneExecute or MassExecute runner files and a Flow which runs them in order.
+The hosts are mapped to the targets of the config, the auth headers are replaced by the auth of the config,
+and the tokens or IDs returned by a response and sent by a later request are extracted by memory_data.`,
+}
+
+// importOptions returns the options and the output directory of the import from the flags
+func importOptions() (runner.ImportOptions, string) {
+	outDir := importOutputDir
+	if outDir == "" {
+		outDir = filepath.Join(ctr.Config.Loader.BasePath, "imported")
+	}
+	return runner.ImportOptions{
+		Kind:          runner.ImportKind(importKind),
+		OutputIDs:     importOutputIDs,
+		IncludeStatic: importIncludeStatic,
+		Count:         importCount,
+		Interval:      importInterval,
+		Force:         importForce,
+	}, outDir
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.PersistentFlags().StringVarP(&importOutputDir, "output", "o", "",
+		"The directory under the loader base path to write the files (default <base_path>/imported)")
+	importCmd.PersistentFlags().StringVarP(&importKind, "kind", "k", string(runner.ImportKindOne),
+		"The kind of the runner files (one, mass)")
+	importCmd.PersistentFlags().IntVar(&importCount, "count", runner.DefaultImportMassCount,
+		"The count of the requests of the mass kind")
+	importCmd.PersistentFlags().StringVar(&importInterval, "interval", runner.DefaultImportMassInterval,
+		"The interval of the requests of the mass kind")
+	importCmd.PersistentFlags().StringSliceVar(&importOutputIDs, "output-ids", []string{},
+		"The output IDs of the runner files")
+	importCmd.PersistentFlags().BoolVar(&importForce, "force", false,
+		"Overwrite the existing files in the output directory")
+}
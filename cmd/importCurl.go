@@ -0,0 +1,58 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+var importCurlFile string
+
+// importCurlCmd represents the import curl command
+var importCurlCmd = &cobra.Command{
+	Use:   "curl ['curl command']...",
+	Short: "Import the curl commands",
+	Long: `This command imports the curl commands, such as the ones copied from the browser, in the given order.
+The commands are given as the arguments or by the file, where they are separated by the newlines, ; or &&.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if ctr.Config.Type == config.ConfigTypeSlave {
+			color.Red("This command is not available in slave mode")
+			return
+		}
+
+		script := strings.Join(args, "\n")
+		if importCurlFile != "" {
+			content, err := os.ReadFile(importCurlFile)
+			if err != nil {
+				color.Red("Failed to read the curl file: %v\n", err)
+				return
+			}
+			script = string(content) + "\n" + script
+		}
+		requests, err := runner.ParseCurl(script)
+		if err != nil {
+			color.Red("Failed to parse the curl command: %v\n", err)
+			return
+		}
+
+		opts, outDir := importOptions()
+		if err := runner.Import(ctr, requests, opts, outDir, os.Stdout); err != nil {
+			color.Red("Failed to import the curl command: %v\n", err)
+			return
+		}
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importCurlCmd)
+
+	importCurlCmd.Flags().StringVarP(&importCurlFile, "file", "f", "", "The file of the curl commands")
+}
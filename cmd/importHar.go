@@ -0,0 +1,53 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// importHarCmd represents the import har command
+var importHarCmd = &cobra.Command{
+	Use:   "har [file]",
+	Short: "Import the browser session recorded as HAR",
+	Long: `This command imports the requests of the HAR file exported by the browser in the recorded order.
+The static assets such as images, stylesheets and scripts are skipped unless --include-static is given.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if ctr.Config.Type == config.ConfigTypeSlave {
+			color.Red("This command is not available in slave mode")
+			return
+		}
+
+		f, err := os.Open(args[0])
+		if err != nil {
+			color.Red("Failed to open the har file: %v\n", err)
+			return
+		}
+		defer f.Close()
+		requests, err := runner.ParseHAR(f)
+		if err != nil {
+			color.Red("Failed to parse the har file: %v\n", err)
+			return
+		}
+
+		opts, outDir := importOptions()
+		if err := runner.Import(ctr, requests, opts, outDir, os.Stdout); err != nil {
+			color.Red("Failed to import the har file: %v\n", err)
+			return
+		}
+	},
+}
+
+func init() {
+	importCmd.AddCommand(importHarCmd)
+
+	importHarCmd.Flags().BoolVar(&importIncludeStatic, "include-static", false, "Import the static assets")
+}
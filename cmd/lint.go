@@ -0,0 +1,53 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+var (
+	lintFile string
+	lintData []string
+)
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Render and validate the load test without sending any request",
+	Long: `This command walks the runner file and its flow tree recursively.
+Each template is rendered with the given data and mock dynamic values, then validated,
+and targets, outputs, auth, store buckets and depends_on are resolved against the config.
+The execution plan is printed at the end.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if ctr.Config.Type == config.ConfigTypeSlave {
+			color.Red("This command is not available in slave mode")
+			return
+		}
+
+		data, err := parseRunnerData(lintData)
+		if err != nil {
+			color.Red("Failed to parse data: %v\n", err)
+			return
+		}
+
+		if err := runner.Lint(ctr, lintFile, data, os.Stdout); err != nil {
+			color.Red("Failed to lint the load test: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVarP(&lintFile, "file", "f", "", "The file to lint")
+	lintCmd.Flags().StringArrayVarP(&lintData, "data", "d", []string{}, "The data to render the load test")
+}
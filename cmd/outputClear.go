@@ -10,23 +10,48 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
 	"github.com/cresplanex/bloader/internal/utils"
 )
 
-var outputClearAll bool
+var (
+	outputClearAll bool
+	outputClearRun string
+)
 
 // outputClearCmd represents the outputClear command
 var outputClearCmd = &cobra.Command{
 	Use:   "clear",
 	Short: "Clear the output file",
 	Long: `This command clears the output.
-It removes all the output file.`,
+It removes all the output file, together with the manifests of the runs written in the output.
+With the --run flag, only the output files recorded in the manifest of the run are removed.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if ctr.Config.Type == config.ConfigTypeSlave {
 			color.Red("This command is not available in slave mode")
 			return
 		}
 
+		if outputClearRun != "" {
+			dirs := runner.ManifestDirs(ctr.Config.Env, ctr.Config.Outputs)
+			m, err := runner.FindManifest(dirs, outputClearRun)
+			if err != nil {
+				color.Red("Failed to find the run: %v", err)
+				return
+			}
+			if err := runner.ClearRunOutputs(m); err != nil {
+				color.Red("Failed to clear the output: %v", err)
+				return
+			}
+			m.Outputs = []runner.ManifestOutput{}
+			if err := m.Save(dirs); err != nil {
+				color.Red("Failed to save the manifest: %v", err)
+				return
+			}
+			color.Green("Output files of run %s cleared successfully", m.RunID)
+			return
+		}
+
 		if !outputClearAll && len(outputIDs) == 0 {
 			color.Yellow("Please specify the output ID to clear or use the --all flag to clear all the outputs")
 			return
@@ -54,4 +79,5 @@ func init() {
 
 	outputClearCmd.Flags().StringSliceVarP(&outputIDs, "id", "i", []string{}, "ID of the output to clear")
 	outputClearCmd.Flags().BoolVarP(&outputClearAll, "all", "A", false, "Clear all the outputs")
+	outputClearCmd.Flags().StringVar(&outputClearRun, "run", "", "Clear only the outputs of the run")
 }
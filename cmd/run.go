@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"os/signal"
 	"strconv"
@@ -19,8 +20,10 @@ import (
 )
 
 var (
-	runnerFile string
-	runnerData []string
+	runnerFile   string
+	runnerData   []string
+	runnerDryRun bool
+	runnerResume string
 )
 
 const (
@@ -63,107 +66,117 @@ It sends requests to the specified server and measures the response time.`,
 			cancel()
 		}()
 
-		data := make(map[string]any)
-		var err error
-		for _, d := range runnerData {
-			kv := strings.Split(d, "=")
-			if len(kv) != 2 {
-				color.Red("Invalid data: %s\n", d)
-				return
-			}
+		data, err := parseRunnerData(runnerData)
+		if err != nil {
+			color.Red("Failed to parse data: %v\n", err)
+			return
+		}
 
-			vt := defaultRunnerDataTypes
-			var vo string
-			if strings.Contains(kv[1], ":") {
-				v := strings.Split(kv[1], ":")
-				vt = v[1]
-				vo = v[0]
+		if runnerDryRun {
+			if err := runner.Lint(ctr, runnerFile, data, os.Stdout); err != nil {
+				color.Red("Failed to lint the load test: %v\n", err)
+				os.Exit(1)
 			}
+			return
+		}
+
+		if err := runner.Run(ctr, runnerFile, data, runnerResume, func(runID string) {
+			fmt.Printf("Run ID: %s\n", runID)
+		}); err != nil {
+			color.Red("Failed to run the load test: %v\n", err)
+			return
+		}
+	},
+}
+
+// parseRunnerData parses the data passed with --data flags, the format is key=value:type
+func parseRunnerData(runnerData []string) (map[string]any, error) {
+	data := make(map[string]any)
+	var err error
+	for _, d := range runnerData {
+		kv := strings.Split(d, "=")
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid data: %s", d)
+		}
+
+		vt := defaultRunnerDataTypes
+		var vo string
+		if strings.Contains(kv[1], ":") {
+			v := strings.Split(kv[1], ":")
+			vt = v[1]
+			vo = v[0]
+		}
 
-			switch vt {
-			case runnerDataTypesInt:
-				data[kv[0]], err = strconv.Atoi(vo)
+		switch vt {
+		case runnerDataTypesInt:
+			data[kv[0]], err = strconv.Atoi(vo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse int: %w", err)
+			}
+		case runnerDataTypesString:
+			data[kv[0]] = vo
+		case runnerDataTypesBool:
+			data[kv[0]], err = strconv.ParseBool(vo)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse bool: %w", err)
+			}
+		case runnerDataTypesFloat:
+			data[kv[0]], err = strconv.ParseFloat(vo, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse float: %w", err)
+			}
+		case runnerDataTypesUint:
+			data[kv[0]], err = strconv.ParseUint(vo, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse uint: %w", err)
+			}
+		case runnerDataTypesArrayInt:
+			var arr []int
+			for _, v := range strings.Split(vo, ",") {
+				i, err := strconv.Atoi(v)
 				if err != nil {
-					color.Red("Failed to parse int: %v\n", err)
-					return
+					return nil, fmt.Errorf("failed to parse int: %w", err)
 				}
-			case runnerDataTypesString:
-				data[kv[0]] = vo
-			case runnerDataTypesBool:
-				data[kv[0]], err = strconv.ParseBool(vo)
+				arr = append(arr, i)
+			}
+			data[kv[0]] = arr
+		case runnerDataTypesArrayString:
+			data[kv[0]] = strings.Split(vo, ",")
+		case runnerDataTypesArrayBool:
+			var arr []bool
+			for _, v := range strings.Split(vo, ",") {
+				b, err := strconv.ParseBool(v)
 				if err != nil {
-					color.Red("Failed to parse bool: %v\n", err)
-					return
+					return nil, fmt.Errorf("failed to parse bool: %w", err)
 				}
-			case runnerDataTypesFloat:
-				data[kv[0]], err = strconv.ParseFloat(vo, 64)
+				arr = append(arr, b)
+			}
+			data[kv[0]] = arr
+		case runnerDataTypesArrayFloat:
+			var arr []float64
+			for _, v := range strings.Split(vo, ",") {
+				f, err := strconv.ParseFloat(v, 64)
 				if err != nil {
-					color.Red("Failed to parse float: %v\n", err)
-					return
+					return nil, fmt.Errorf("failed to parse float: %w", err)
 				}
-			case runnerDataTypesUint:
-				data[kv[0]], err = strconv.ParseUint(vo, 10, 64)
+				arr = append(arr, f)
+			}
+			data[kv[0]] = arr
+		case runnerDataTypesArrayUint:
+			var arr []uint64
+			for _, v := range strings.Split(vo, ",") {
+				u, err := strconv.ParseUint(v, 10, 64)
 				if err != nil {
-					color.Red("Failed to parse uint: %v\n", err)
-					return
-				}
-			case runnerDataTypesArrayInt:
-				var arr []int
-				for _, v := range strings.Split(vo, ",") {
-					i, err := strconv.Atoi(v)
-					if err != nil {
-						color.Red("Failed to parse int: %v\n", err)
-						return
-					}
-					arr = append(arr, i)
-				}
-				data[kv[0]] = arr
-			case runnerDataTypesArrayString:
-				data[kv[0]] = strings.Split(vo, ",")
-			case runnerDataTypesArrayBool:
-				var arr []bool
-				for _, v := range strings.Split(vo, ",") {
-					b, err := strconv.ParseBool(v)
-					if err != nil {
-						color.Red("Failed to parse bool: %v\n", err)
-						return
-					}
-					arr = append(arr, b)
+					return nil, fmt.Errorf("failed to parse uint: %w", err)
 				}
-				data[kv[0]] = arr
-			case runnerDataTypesArrayFloat:
-				var arr []float64
-				for _, v := range strings.Split(vo, ",") {
-					f, err := strconv.ParseFloat(v, 64)
-					if err != nil {
-						color.Red("Failed to parse float: %v\n", err)
-						return
-					}
-					arr = append(arr, f)
-				}
-				data[kv[0]] = arr
-			case runnerDataTypesArrayUint:
-				var arr []uint64
-				for _, v := range strings.Split(vo, ",") {
-					u, err := strconv.ParseUint(v, 10, 64)
-					if err != nil {
-						color.Red("Failed to parse uint: %v\n", err)
-						return
-					}
-					arr = append(arr, u)
-				}
-				data[kv[0]] = arr
-			default:
-				color.Red("Invalid data type: %s\n", vt)
-				return
+				arr = append(arr, u)
 			}
+			data[kv[0]] = arr
+		default:
+			return nil, fmt.Errorf("invalid data type: %s", vt)
 		}
-
-		if err := runner.Run(ctr, runnerFile, data); err != nil {
-			color.Red("Failed to run the load test: %v\n", err)
-			return
-		}
-	},
+	}
+	return data, nil
 }
 
 func init() {
@@ -171,4 +184,6 @@ func init() {
 
 	runCmd.Flags().StringVarP(&runnerFile, "file", "f", "", "The file to run the load test")
 	runCmd.Flags().StringArrayVarP(&runnerData, "data", "d", []string{}, "The data to run the load test")
+	runCmd.Flags().BoolVar(&runnerDryRun, "dry-run", false, "Render and validate the load test without sending any request")
+	runCmd.Flags().StringVar(&runnerResume, "resume", "", "The run ID of the interrupted load test to resume")
 }
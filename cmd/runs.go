@@ -0,0 +1,20 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// runsCmd represents the runs command
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "Perform run history management in client cli",
+	Long: `It operates the history of the load test runs in the client cli.
+The history is read from the manifest.json written in the output root of each run.`,
+}
+
+func init() {
+	rootCmd.AddCommand(runsCmd)
+}
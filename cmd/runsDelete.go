@@ -0,0 +1,52 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// runsDeleteCmd represents the runsDelete command
+var runsDeleteCmd = &cobra.Command{
+	Use:     "delete",
+	Aliases: []string{"del"},
+	Short:   "Delete the load test run",
+	Long: `This command deletes the load test run.
+It removes the output files, the manifest and the checkpoint of the run.
+For example:
+
+bloader runs delete 20240101_120000`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if ctr.Config.Type == config.ConfigTypeSlave {
+			color.Red("This command is not available in slave mode")
+			return
+		}
+
+		if len(args) == 0 {
+			fmt.Println("Please provide the run ID")
+			return
+		}
+		dirs := runner.ManifestDirs(ctr.Config.Env, ctr.Config.Outputs)
+		m, err := runner.FindManifest(dirs, args[0])
+		if err != nil {
+			color.Red("Failed to find the run: %v", err)
+			return
+		}
+		if err := runner.DeleteRun(ctr.Store, dirs, m); err != nil {
+			color.Red("Failed to delete the run: %v", err)
+			return
+		}
+		color.Green("Run %s deleted", m.RunID)
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsDeleteCmd)
+}
@@ -0,0 +1,61 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// runsListCmd represents the runsList command
+var runsListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the load test runs",
+	Long: `This command lists the load test runs recorded in the manifests.
+For example:
+
+bloader runs list`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if ctr.Config.Type == config.ConfigTypeSlave {
+			color.Red("This command is not available in slave mode")
+			return
+		}
+
+		manifests, err := runner.ListManifests(runner.ManifestDirs(ctr.Config.Env, ctr.Config.Outputs))
+		if err != nil {
+			color.Red("Failed to list the runs: %v", err)
+			return
+		}
+		if len(manifests) == 0 {
+			color.Yellow("No runs found")
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RUN ID\tSTATUS\tFILE\tSTARTED AT\tDURATION")
+		for _, m := range manifests {
+			duration := "-"
+			if m.EndedAt != nil {
+				duration = m.EndedAt.Sub(m.StartedAt).Round(time.Millisecond).String()
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				m.RunID, m.Status, m.File, m.StartedAt.Format(time.RFC3339), duration)
+		}
+		if err := w.Flush(); err != nil {
+			color.Red("Failed to print the runs: %v", err)
+		}
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsListCmd)
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © 2024 cresplanex <open-source-github@cresplanex.com>
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// runsShowCmd represents the runsShow command
+var runsShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the manifest of the load test run",
+	Long: `This command shows the manifest of the load test run.
+For example:
+
+bloader runs show 20240101_120000`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if ctr.Config.Type == config.ConfigTypeSlave {
+			color.Red("This command is not available in slave mode")
+			return
+		}
+
+		if len(args) == 0 {
+			fmt.Println("Please provide the run ID")
+			return
+		}
+		m, err := runner.FindManifest(runner.ManifestDirs(ctr.Config.Env, ctr.Config.Outputs), args[0])
+		if err != nil {
+			color.Red("Failed to find the run: %v", err)
+			return
+		}
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			color.Red("Failed to marshal the manifest: %v", err)
+			return
+		}
+		fmt.Println(string(data))
+	},
+}
+
+func init() {
+	runsCmd.AddCommand(runsShowCmd)
+}
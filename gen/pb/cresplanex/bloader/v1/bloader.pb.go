@@ -127,6 +127,7 @@ const (
 	RequestType_REQUEST_TYPE_STORE                   RequestType = 3
 	RequestType_REQUEST_TYPE_REQUEST_RESOURCE_STORE  RequestType = 4
 	RequestType_REQUEST_TYPE_REQUEST_RESOURCE_TARGET RequestType = 5
+	RequestType_REQUEST_TYPE_BARRIER                 RequestType = 6
 )
 
 // Enum value maps for RequestType.
@@ -138,6 +139,7 @@ var (
 		3: "REQUEST_TYPE_STORE",
 		4: "REQUEST_TYPE_REQUEST_RESOURCE_STORE",
 		5: "REQUEST_TYPE_REQUEST_RESOURCE_TARGET",
+		6: "REQUEST_TYPE_BARRIER",
 	}
 	RequestType_value = map[string]int32{
 		"REQUEST_TYPE_UNSPECIFIED":             0,
@@ -146,6 +148,7 @@ var (
 		"REQUEST_TYPE_STORE":                   3,
 		"REQUEST_TYPE_REQUEST_RESOURCE_STORE":  4,
 		"REQUEST_TYPE_REQUEST_RESOURCE_TARGET": 5,
+		"REQUEST_TYPE_BARRIER":                 6,
 	}
 )
 
@@ -176,6 +179,58 @@ func (RequestType) EnumDescriptor() ([]byte, []int) {
 	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{2}
 }
 
+type BarrierReleaseStatus int32
+
+const (
+	BarrierReleaseStatus_BARRIER_RELEASE_STATUS_UNSPECIFIED BarrierReleaseStatus = 0
+	BarrierReleaseStatus_BARRIER_RELEASE_STATUS_RELEASED    BarrierReleaseStatus = 1
+	BarrierReleaseStatus_BARRIER_RELEASE_STATUS_TIMEOUT     BarrierReleaseStatus = 2
+	BarrierReleaseStatus_BARRIER_RELEASE_STATUS_ERROR       BarrierReleaseStatus = 3
+)
+
+// Enum value maps for BarrierReleaseStatus.
+var (
+	BarrierReleaseStatus_name = map[int32]string{
+		0: "BARRIER_RELEASE_STATUS_UNSPECIFIED",
+		1: "BARRIER_RELEASE_STATUS_RELEASED",
+		2: "BARRIER_RELEASE_STATUS_TIMEOUT",
+		3: "BARRIER_RELEASE_STATUS_ERROR",
+	}
+	BarrierReleaseStatus_value = map[string]int32{
+		"BARRIER_RELEASE_STATUS_UNSPECIFIED": 0,
+		"BARRIER_RELEASE_STATUS_RELEASED":    1,
+		"BARRIER_RELEASE_STATUS_TIMEOUT":     2,
+		"BARRIER_RELEASE_STATUS_ERROR":       3,
+	}
+)
+
+func (x BarrierReleaseStatus) Enum() *BarrierReleaseStatus {
+	p := new(BarrierReleaseStatus)
+	*p = x
+	return p
+}
+
+func (x BarrierReleaseStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BarrierReleaseStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_cresplanex_bloader_v1_bloader_proto_enumTypes[3].Descriptor()
+}
+
+func (BarrierReleaseStatus) Type() protoreflect.EnumType {
+	return &file_cresplanex_bloader_v1_bloader_proto_enumTypes[3]
+}
+
+func (x BarrierReleaseStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BarrierReleaseStatus.Descriptor instead.
+func (BarrierReleaseStatus) EnumDescriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{3}
+}
+
 type ConnectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Environment   string                 `protobuf:"bytes,1,opt,name=environment,proto3" json:"environment,omitempty"`
@@ -801,6 +856,7 @@ type ReceiveChanelConnectResponse struct {
 	//	*ReceiveChanelConnectResponse_Store
 	//	*ReceiveChanelConnectResponse_StoreResourceRequest
 	//	*ReceiveChanelConnectResponse_TargetResourceRequest
+	//	*ReceiveChanelConnectResponse_BarrierRequest
 	Request       isReceiveChanelConnectResponse_Request `protobuf_oneof:"request"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -902,6 +958,15 @@ func (x *ReceiveChanelConnectResponse) GetTargetResourceRequest() *ReceiveChanel
 	return nil
 }
 
+func (x *ReceiveChanelConnectResponse) GetBarrierRequest() *ReceiveChanelConnectBarrierRequest {
+	if x != nil {
+		if x, ok := x.Request.(*ReceiveChanelConnectResponse_BarrierRequest); ok {
+			return x.BarrierRequest
+		}
+	}
+	return nil
+}
+
 type isReceiveChanelConnectResponse_Request interface {
 	isReceiveChanelConnectResponse_Request()
 }
@@ -926,6 +991,10 @@ type ReceiveChanelConnectResponse_TargetResourceRequest struct {
 	TargetResourceRequest *ReceiveChanelConnectTargetResourceRequest `protobuf:"bytes,7,opt,name=target_resource_request,json=targetResourceRequest,proto3,oneof"`
 }
 
+type ReceiveChanelConnectResponse_BarrierRequest struct {
+	BarrierRequest *ReceiveChanelConnectBarrierRequest `protobuf:"bytes,8,opt,name=barrier_request,json=barrierRequest,proto3,oneof"`
+}
+
 func (*ReceiveChanelConnectResponse_LoaderResourceRequest) isReceiveChanelConnectResponse_Request() {}
 
 func (*ReceiveChanelConnectResponse_AuthResourceRequest) isReceiveChanelConnectResponse_Request() {}
@@ -936,6 +1005,8 @@ func (*ReceiveChanelConnectResponse_StoreResourceRequest) isReceiveChanelConnect
 
 func (*ReceiveChanelConnectResponse_TargetResourceRequest) isReceiveChanelConnectResponse_Request() {}
 
+func (*ReceiveChanelConnectResponse_BarrierRequest) isReceiveChanelConnectResponse_Request() {}
+
 type ReceiveChanelConnectLoaderResourceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LoaderId      string                 `protobuf:"bytes,1,opt,name=loader_id,json=loaderId,proto3" json:"loader_id,omitempty"`
@@ -1196,6 +1267,74 @@ func (x *ReceiveChanelConnectTargetResourceRequest) GetTargetId() string {
 	return ""
 }
 
+type ReceiveChanelConnectBarrierRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	BarrierId           string                 `protobuf:"bytes,1,opt,name=barrier_id,json=barrierId,proto3" json:"barrier_id,omitempty"`
+	Participants        int32                  `protobuf:"varint,2,opt,name=participants,proto3" json:"participants,omitempty"`
+	TimeoutMilliseconds int64                  `protobuf:"varint,3,opt,name=timeout_milliseconds,json=timeoutMilliseconds,proto3" json:"timeout_milliseconds,omitempty"`
+	LeaveRequestId      string                 `protobuf:"bytes,4,opt,name=leave_request_id,json=leaveRequestId,proto3" json:"leave_request_id,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ReceiveChanelConnectBarrierRequest) Reset() {
+	*x = ReceiveChanelConnectBarrierRequest{}
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveChanelConnectBarrierRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveChanelConnectBarrierRequest) ProtoMessage() {}
+
+func (x *ReceiveChanelConnectBarrierRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveChanelConnectBarrierRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveChanelConnectBarrierRequest) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReceiveChanelConnectBarrierRequest) GetBarrierId() string {
+	if x != nil {
+		return x.BarrierId
+	}
+	return ""
+}
+
+func (x *ReceiveChanelConnectBarrierRequest) GetParticipants() int32 {
+	if x != nil {
+		return x.Participants
+	}
+	return 0
+}
+
+func (x *ReceiveChanelConnectBarrierRequest) GetTimeoutMilliseconds() int64 {
+	if x != nil {
+		return x.TimeoutMilliseconds
+	}
+	return 0
+}
+
+func (x *ReceiveChanelConnectBarrierRequest) GetLeaveRequestId() string {
+	if x != nil {
+		return x.LeaveRequestId
+	}
+	return ""
+}
+
 type SendLoaderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
@@ -1208,7 +1347,7 @@ type SendLoaderRequest struct {
 
 func (x *SendLoaderRequest) Reset() {
 	*x = SendLoaderRequest{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[18]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1220,7 +1359,7 @@ func (x *SendLoaderRequest) String() string {
 func (*SendLoaderRequest) ProtoMessage() {}
 
 func (x *SendLoaderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[18]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1233,7 +1372,7 @@ func (x *SendLoaderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendLoaderRequest.ProtoReflect.Descriptor instead.
 func (*SendLoaderRequest) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{18}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *SendLoaderRequest) GetRequestId() string {
@@ -1272,7 +1411,7 @@ type SendLoaderResponse struct {
 
 func (x *SendLoaderResponse) Reset() {
 	*x = SendLoaderResponse{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[19]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1284,7 +1423,7 @@ func (x *SendLoaderResponse) String() string {
 func (*SendLoaderResponse) ProtoMessage() {}
 
 func (x *SendLoaderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[19]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1297,7 +1436,7 @@ func (x *SendLoaderResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendLoaderResponse.ProtoReflect.Descriptor instead.
 func (*SendLoaderResponse) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{19}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{20}
 }
 
 type SendAuthRequest struct {
@@ -1312,7 +1451,7 @@ type SendAuthRequest struct {
 
 func (x *SendAuthRequest) Reset() {
 	*x = SendAuthRequest{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[20]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1324,7 +1463,7 @@ func (x *SendAuthRequest) String() string {
 func (*SendAuthRequest) ProtoMessage() {}
 
 func (x *SendAuthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[20]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1337,7 +1476,7 @@ func (x *SendAuthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendAuthRequest.ProtoReflect.Descriptor instead.
 func (*SendAuthRequest) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{20}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *SendAuthRequest) GetRequestId() string {
@@ -1376,7 +1515,7 @@ type SendAuthResponse struct {
 
 func (x *SendAuthResponse) Reset() {
 	*x = SendAuthResponse{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[21]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1388,7 +1527,7 @@ func (x *SendAuthResponse) String() string {
 func (*SendAuthResponse) ProtoMessage() {}
 
 func (x *SendAuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[21]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1401,7 +1540,7 @@ func (x *SendAuthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendAuthResponse.ProtoReflect.Descriptor instead.
 func (*SendAuthResponse) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{21}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{22}
 }
 
 type SendStoreDataRequest struct {
@@ -1415,7 +1554,7 @@ type SendStoreDataRequest struct {
 
 func (x *SendStoreDataRequest) Reset() {
 	*x = SendStoreDataRequest{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[22]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1427,7 +1566,7 @@ func (x *SendStoreDataRequest) String() string {
 func (*SendStoreDataRequest) ProtoMessage() {}
 
 func (x *SendStoreDataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[22]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1440,7 +1579,7 @@ func (x *SendStoreDataRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendStoreDataRequest.ProtoReflect.Descriptor instead.
 func (*SendStoreDataRequest) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{22}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *SendStoreDataRequest) GetRequestId() string {
@@ -1472,7 +1611,7 @@ type SendStoreDataResponse struct {
 
 func (x *SendStoreDataResponse) Reset() {
 	*x = SendStoreDataResponse{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[23]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1484,7 +1623,7 @@ func (x *SendStoreDataResponse) String() string {
 func (*SendStoreDataResponse) ProtoMessage() {}
 
 func (x *SendStoreDataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[23]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1497,7 +1636,7 @@ func (x *SendStoreDataResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendStoreDataResponse.ProtoReflect.Descriptor instead.
 func (*SendStoreDataResponse) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{23}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{24}
 }
 
 type SendStoreOkRequest struct {
@@ -1509,7 +1648,7 @@ type SendStoreOkRequest struct {
 
 func (x *SendStoreOkRequest) Reset() {
 	*x = SendStoreOkRequest{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[24]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1521,7 +1660,7 @@ func (x *SendStoreOkRequest) String() string {
 func (*SendStoreOkRequest) ProtoMessage() {}
 
 func (x *SendStoreOkRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[24]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1534,7 +1673,7 @@ func (x *SendStoreOkRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendStoreOkRequest.ProtoReflect.Descriptor instead.
 func (*SendStoreOkRequest) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{24}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *SendStoreOkRequest) GetRequestId() string {
@@ -1552,7 +1691,7 @@ type SendStoreOkResponse struct {
 
 func (x *SendStoreOkResponse) Reset() {
 	*x = SendStoreOkResponse{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[25]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1564,7 +1703,7 @@ func (x *SendStoreOkResponse) String() string {
 func (*SendStoreOkResponse) ProtoMessage() {}
 
 func (x *SendStoreOkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[25]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1577,7 +1716,7 @@ func (x *SendStoreOkResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendStoreOkResponse.ProtoReflect.Descriptor instead.
 func (*SendStoreOkResponse) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{25}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{26}
 }
 
 type SendTargetRequest struct {
@@ -1591,7 +1730,7 @@ type SendTargetRequest struct {
 
 func (x *SendTargetRequest) Reset() {
 	*x = SendTargetRequest{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[26]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1603,7 +1742,7 @@ func (x *SendTargetRequest) String() string {
 func (*SendTargetRequest) ProtoMessage() {}
 
 func (x *SendTargetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[26]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1616,7 +1755,7 @@ func (x *SendTargetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendTargetRequest.ProtoReflect.Descriptor instead.
 func (*SendTargetRequest) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{26}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *SendTargetRequest) GetRequestId() string {
@@ -1648,7 +1787,7 @@ type SendTargetResponse struct {
 
 func (x *SendTargetResponse) Reset() {
 	*x = SendTargetResponse{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[27]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1660,7 +1799,7 @@ func (x *SendTargetResponse) String() string {
 func (*SendTargetResponse) ProtoMessage() {}
 
 func (x *SendTargetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[27]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1673,7 +1812,7 @@ func (x *SendTargetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendTargetResponse.ProtoReflect.Descriptor instead.
 func (*SendTargetResponse) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{27}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{28}
 }
 
 type ReceiveLoadTermChannelRequest struct {
@@ -1686,7 +1825,7 @@ type ReceiveLoadTermChannelRequest struct {
 
 func (x *ReceiveLoadTermChannelRequest) Reset() {
 	*x = ReceiveLoadTermChannelRequest{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[28]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1698,7 +1837,7 @@ func (x *ReceiveLoadTermChannelRequest) String() string {
 func (*ReceiveLoadTermChannelRequest) ProtoMessage() {}
 
 func (x *ReceiveLoadTermChannelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[28]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1711,7 +1850,7 @@ func (x *ReceiveLoadTermChannelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReceiveLoadTermChannelRequest.ProtoReflect.Descriptor instead.
 func (*ReceiveLoadTermChannelRequest) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{28}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ReceiveLoadTermChannelRequest) GetConnectionId() string {
@@ -1737,7 +1876,7 @@ type ReceiveLoadTermChannelResponse struct {
 
 func (x *ReceiveLoadTermChannelResponse) Reset() {
 	*x = ReceiveLoadTermChannelResponse{}
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[29]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1749,7 +1888,7 @@ func (x *ReceiveLoadTermChannelResponse) String() string {
 func (*ReceiveLoadTermChannelResponse) ProtoMessage() {}
 
 func (x *ReceiveLoadTermChannelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[29]
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1762,7 +1901,7 @@ func (x *ReceiveLoadTermChannelResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReceiveLoadTermChannelResponse.ProtoReflect.Descriptor instead.
 func (*ReceiveLoadTermChannelResponse) Descriptor() ([]byte, []int) {
-	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{29}
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ReceiveLoadTermChannelResponse) GetSuccess() bool {
@@ -1772,6 +1911,110 @@ func (x *ReceiveLoadTermChannelResponse) GetSuccess() bool {
 	return false
 }
 
+type SendBarrierReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	BarrierId     string                 `protobuf:"bytes,2,opt,name=barrier_id,json=barrierId,proto3" json:"barrier_id,omitempty"`
+	Status        BarrierReleaseStatus   `protobuf:"varint,3,opt,name=status,proto3,enum=cresplanex.bloader.v1.BarrierReleaseStatus" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendBarrierReleaseRequest) Reset() {
+	*x = SendBarrierReleaseRequest{}
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendBarrierReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendBarrierReleaseRequest) ProtoMessage() {}
+
+func (x *SendBarrierReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendBarrierReleaseRequest.ProtoReflect.Descriptor instead.
+func (*SendBarrierReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SendBarrierReleaseRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *SendBarrierReleaseRequest) GetBarrierId() string {
+	if x != nil {
+		return x.BarrierId
+	}
+	return ""
+}
+
+func (x *SendBarrierReleaseRequest) GetStatus() BarrierReleaseStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BarrierReleaseStatus_BARRIER_RELEASE_STATUS_UNSPECIFIED
+}
+
+func (x *SendBarrierReleaseRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SendBarrierReleaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendBarrierReleaseResponse) Reset() {
+	*x = SendBarrierReleaseResponse{}
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendBarrierReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendBarrierReleaseResponse) ProtoMessage() {}
+
+func (x *SendBarrierReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_bloader_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendBarrierReleaseResponse.ProtoReflect.Descriptor instead.
+func (*SendBarrierReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP(), []int{32}
+}
+
 var File_cresplanex_bloader_v1_bloader_proto protoreflect.FileDescriptor
 
 var file_cresplanex_bloader_v1_bloader_proto_rawDesc = []byte{
@@ -1852,7 +2095,7 @@ var file_cresplanex_bloader_v1_bloader_proto_rawDesc = []byte{
 	0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
 	0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x64, 0x22, 0xc0, 0x05, 0x0a, 0x1c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x6f, 0x6e, 0x49, 0x64, 0x22, 0xa6, 0x06, 0x0a, 0x1c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
 	0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
 	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
@@ -1895,225 +2138,277 @@ var file_cresplanex_bloader_v1_bloader_proto_rawDesc = []byte{
 	0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x61,
 	0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x15, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x48, 0x0a, 0x29, 0x52, 0x65, 0x63, 0x65, 0x69,
-	0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4c,
-	0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x49,
-	0x64, 0x22, 0x61, 0x0a, 0x27, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e,
-	0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07,
-	0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61,
-	0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x66, 0x61,
-	0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x22, 0x65, 0x0a, 0x19, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43,
-	0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x6c, 0x61,
-	0x73, 0x74, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
-	0x69, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x74, 0x0a, 0x28, 0x52,
-	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x64, 0x0a, 0x0f,
+	0x62, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x42, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x48, 0x00, 0x52, 0x0e, 0x62, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x48, 0x0a,
+	0x29, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x6f, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x22, 0x61, 0x0a, 0x27, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x41,
+	0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x73, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x69, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22, 0x65, 0x0a, 0x19, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
 	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x0a,
 	0x0d, 0x69, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e,
-	0x6b, 0x22, 0x48, 0x0a, 0x29, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e,
-	0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
-	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
-	0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x22, 0x8d, 0x01, 0x0a, 0x11,
-	0x53, 0x65, 0x6e, 0x64, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64,
-	0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x6c, 0x61,
-	0x73, 0x74, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
-	0x69, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x14, 0x0a, 0x12, 0x53,
-	0x65, 0x6e, 0x64, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x99, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x2f, 0x0a,
-	0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72,
-	0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x1d,
-	0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22, 0x12, 0x0a,
-	0x10, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x6d, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61,
-	0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0d,
-	0x69, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b,
-	0x22, 0x17, 0x0a, 0x15, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x0a, 0x12, 0x53, 0x65, 0x6e,
-	0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x15,
-	0x0a, 0x13, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c,
-	0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x14,
-	0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x63, 0x0a, 0x1d, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c,
-	0x6f, 0x61, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x1e, 0x52, 0x65, 0x63,
-	0x65, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
-	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x2a, 0xe7, 0x01, 0x0a, 0x1c, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x2c, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f,
-	0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f,
-	0x53, 0x54, 0x4f, 0x52, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x2a, 0x0a, 0x26, 0x53, 0x4c, 0x41, 0x56,
-	0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c,
-	0x54, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x4f,
-	0x52, 0x45, 0x10, 0x01, 0x12, 0x36, 0x0a, 0x32, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f, 0x43, 0x4f,
-	0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x53, 0x54,
-	0x4f, 0x52, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x41, 0x44, 0x5f,
-	0x4f, 0x4e, 0x4c, 0x59, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x10, 0x02, 0x12, 0x31, 0x0a, 0x2d,
+	0x6b, 0x22, 0x74, 0x0a, 0x28, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x4c, 0x61,
+	0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x48, 0x0a, 0x29, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49,
+	0x64, 0x22, 0xc4, 0x01, 0x0a, 0x22, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61,
+	0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x42, 0x61, 0x72, 0x72, 0x69, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x72, 0x72,
+	0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61,
+	0x72, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69,
+	0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x14, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x65, 0x61, 0x76, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x8d, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x6e,
+	0x64, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a,
+	0x09, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x4c,
+	0x61, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x14, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64,
+	0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x99,
+	0x01, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x61, 0x75,
+	0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x73, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x69, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x22, 0x12, 0x0a, 0x10, 0x53, 0x65,
+	0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6d,
+	0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x73, 0x5f,
+	0x6c, 0x61, 0x73, 0x74, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x69, 0x73, 0x4c, 0x61, 0x73, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x17, 0x0a,
+	0x15, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x53,
+	0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x53,
+	0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x63, 0x0a, 0x1d, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x61, 0x64,
+	0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x22, 0x3a, 0x0a, 0x1e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x19, 0x53, 0x65, 0x6e, 0x64, 0x42, 0x61, 0x72, 0x72, 0x69,
+	0x65, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x49, 0x64, 0x12, 0x43,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b,
+	0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x1c, 0x0a,
+	0x1a, 0x53, 0x65, 0x6e, 0x64, 0x42, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0xe7, 0x01, 0x0a, 0x1c,
+	0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x30, 0x0a, 0x2c,
 	0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x44, 0x45,
 	0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x53, 0x10, 0x03, 0x2a,
-	0x5b, 0x0a, 0x12, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x4f, 0x75, 0x74, 0x70, 0x75,
-	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x21, 0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x45, 0x58,
-	0x45, 0x43, 0x5f, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55,
-	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a,
-	0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x5f, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x2a, 0xe8, 0x01, 0x0a,
-	0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18,
-	0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53,
-	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x28, 0x0a, 0x24, 0x52, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x2a,
+	0x0a, 0x26, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f,
+	0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x10, 0x01, 0x12, 0x36, 0x0a, 0x32, 0x53, 0x4c,
+	0x41, 0x56, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x41, 0x4e, 0x44, 0x5f, 0x44, 0x45, 0x46, 0x41,
+	0x55, 0x4c, 0x54, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54,
+	0x48, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x4f, 0x4e, 0x4c, 0x59, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45,
+	0x10, 0x02, 0x12, 0x31, 0x0a, 0x2d, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f, 0x43, 0x4f, 0x4d, 0x4d,
+	0x41, 0x4e, 0x44, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x53, 0x54, 0x4f, 0x52,
+	0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4c, 0x41, 0x56, 0x45, 0x5f, 0x56, 0x41, 0x4c,
+	0x55, 0x45, 0x53, 0x10, 0x03, 0x2a, 0x5b, 0x0a, 0x12, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78, 0x65,
+	0x63, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x21, 0x43,
+	0x41, 0x4c, 0x4c, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x5f, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x5f,
+	0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x48, 0x54, 0x54, 0x50,
+	0x10, 0x01, 0x2a, 0x82, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x28, 0x0a, 0x24, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43,
+	0x45, 0x5f, 0x4c, 0x4f, 0x41, 0x44, 0x45, 0x52, 0x10, 0x01, 0x12, 0x26, 0x0a, 0x22, 0x52, 0x45,
 	0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45,
-	0x53, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x4c, 0x4f, 0x41, 0x44,
-	0x45, 0x52, 0x10, 0x01, 0x12, 0x26, 0x0a, 0x22, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x53,
-	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12,
-	0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x4f,
-	0x52, 0x45, 0x10, 0x03, 0x12, 0x27, 0x0a, 0x23, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x53,
-	0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x10, 0x04, 0x12, 0x28, 0x0a,
-	0x24, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45,
-	0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x54,
-	0x41, 0x52, 0x47, 0x45, 0x54, 0x10, 0x05, 0x32, 0xaf, 0x0a, 0x0a, 0x13, 0x42, 0x6c, 0x6f, 0x61,
-	0x64, 0x65, 0x72, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x58, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x25, 0x2e, 0x63, 0x72, 0x65,
-	0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x26, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62,
-	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0a, 0x44, 0x69, 0x73,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c,
-	0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x29, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62,
-	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x0c,
-	0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x2a, 0x2e, 0x63,
-	0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70,
+	0x53, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x48,
+	0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x10, 0x03, 0x12, 0x27, 0x0a, 0x23, 0x52, 0x45,
+	0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45,
+	0x53, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x52,
+	0x45, 0x10, 0x04, 0x12, 0x28, 0x0a, 0x24, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x4f,
+	0x55, 0x52, 0x43, 0x45, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x10, 0x05, 0x12, 0x18, 0x0a,
+	0x14, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x41,
+	0x52, 0x52, 0x49, 0x45, 0x52, 0x10, 0x06, 0x2a, 0xa9, 0x01, 0x0a, 0x14, 0x42, 0x61, 0x72, 0x72,
+	0x69, 0x65, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x26, 0x0a, 0x22, 0x42, 0x41, 0x52, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x4c, 0x45,
+	0x41, 0x53, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x42, 0x41, 0x52, 0x52,
+	0x49, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x44, 0x10, 0x01, 0x12, 0x22, 0x0a,
+	0x1e, 0x42, 0x41, 0x52, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10,
+	0x02, 0x12, 0x20, 0x0a, 0x1c, 0x42, 0x41, 0x52, 0x52, 0x49, 0x45, 0x52, 0x5f, 0x52, 0x45, 0x4c,
+	0x45, 0x41, 0x53, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x10, 0x03, 0x32, 0xaa, 0x0b, 0x0a, 0x13, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x53,
+	0x6c, 0x61, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x58, 0x0a, 0x07, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x25, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e,
+	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78,
+	0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e,
+	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x67, 0x0a, 0x0c, 0x53, 0x6c, 0x61, 0x76,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x2a, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70,
 	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x6f,
-	0x72, 0x65, 0x12, 0x36, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e,
-	0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65,
-	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74,
-	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x63, 0x72, 0x65,
-	0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x44,
-	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x5d, 0x0a, 0x08, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78, 0x65,
-	0x63, 0x12, 0x26, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62,
-	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78,
-	0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x63, 0x72, 0x65, 0x73,
+	0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6c, 0x61,
+	0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x36,
+	0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x37, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x6c, 0x61, 0x76, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x44, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28,
+	0x01, 0x12, 0x5d, 0x0a, 0x08, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x12, 0x26, 0x2e,
+	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
+	0x6c, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
+	0x12, 0x81, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x32, 0x2e, 0x63, 0x72, 0x65, 0x73,
 	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x30, 0x01, 0x12, 0x81, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
-	0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x32, 0x2e,
+	0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x33, 0x2e,
 	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
 	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x68, 0x61,
-	0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x33, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62,
-	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
-	0x65, 0x43, 0x68, 0x61, 0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x63, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64,
-	0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61,
-	0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x65, 0x6e, 0x64, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x29, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c,
-	0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4c, 0x6f, 0x61,
-	0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x5b, 0x0a,
-	0x08, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x12, 0x26, 0x2e, 0x63, 0x72, 0x65, 0x73,
-	0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x27, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62,
-	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x75,
-	0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a, 0x0d, 0x53, 0x65,
-	0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x2e, 0x63, 0x72,
-	0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
-	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74,
-	0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70,
-	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x64, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64,
-	0x53, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x12, 0x29, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c,
-	0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
-	0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e,
-	0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53,
-	0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61,
-	0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x28, 0x2e, 0x63,
+	0x6e, 0x65, 0x6c, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x63, 0x0a, 0x0a, 0x53, 0x65, 0x6e, 0x64, 0x4c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e,
+	0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4c,
+	0x6f, 0x61, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x63,
 	0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61,
-	0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x85, 0x01, 0x0a, 0x16, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x61,
-	0x64, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x34, 0x2e, 0x63,
-	0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x61, 0x64,
-	0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x35, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e,
-	0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69,
-	0x76, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65,
-	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0xe5, 0x01, 0x0a, 0x19, 0x63, 0x6f,
-	0x6d, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f,
-	0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x42, 0x0c, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
-	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
-	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62,
-	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x72,
-	0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
-	0x2f, 0x76, 0x31, 0x3b, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x76, 0x31, 0xa2, 0x02, 0x03,
-	0x43, 0x42, 0x58, 0xaa, 0x02, 0x15, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78,
-	0x2e, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x15, 0x43, 0x72,
-	0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x5c, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
-	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x21, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78,
-	0x5c, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x17, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c,
-	0x61, 0x6e, 0x65, 0x78, 0x3a, 0x3a, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x3a, 0x3a, 0x56,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x4c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x5b, 0x0a, 0x08, 0x53, 0x65, 0x6e,
+	0x64, 0x41, 0x75, 0x74, 0x68, 0x12, 0x26, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e,
+	0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65,
+	0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e,
+	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a, 0x0d, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74,
+	0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x2b, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e,
+	0x64, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x28, 0x01, 0x12, 0x64, 0x0a, 0x0b, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x4f, 0x6b, 0x12, 0x29, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78,
+	0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x4f, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a,
+	0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x0a, 0x53, 0x65,
+	0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x28, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x29, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e,
+	0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x54,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x85, 0x01,
+	0x0a, 0x16, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x72,
+	0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x34, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x72, 0x6d,
+	0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x35,
+	0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4c, 0x6f,
+	0x61, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x79, 0x0a, 0x12, 0x53, 0x65, 0x6e, 0x64, 0x42, 0x61, 0x72,
+	0x72, 0x69, 0x65, 0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x30, 0x2e, 0x63, 0x72,
+	0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x42, 0x61, 0x72, 0x72, 0x69, 0x65, 0x72, 0x52,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x31, 0x2e,
+	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x42, 0x61, 0x72, 0x72, 0x69, 0x65,
+	0x72, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0xe5, 0x01, 0x0a, 0x19, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61,
+	0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x42, 0x0c,
+	0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x44,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x65, 0x73, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2f, 0x67, 0x65,
+	0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f,
+	0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x3b, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x42, 0x58, 0xaa, 0x02, 0x15, 0x43, 0x72, 0x65,
+	0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e,
+	0x56, 0x31, 0xca, 0x02, 0x15, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x5c,
+	0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x21, 0x43, 0x72, 0x65,
+	0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x5c, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5c,
+	0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02,
+	0x17, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x3a, 0x3a, 0x42, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x72, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2128,86 +2423,94 @@ func file_cresplanex_bloader_v1_bloader_proto_rawDescGZIP() []byte {
 	return file_cresplanex_bloader_v1_bloader_proto_rawDescData
 }
 
-var file_cresplanex_bloader_v1_bloader_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_cresplanex_bloader_v1_bloader_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_cresplanex_bloader_v1_bloader_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_cresplanex_bloader_v1_bloader_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_cresplanex_bloader_v1_bloader_proto_goTypes = []any{
 	(SlaveCommandDefaultStoreType)(0),                 // 0: cresplanex.bloader.v1.SlaveCommandDefaultStoreType
 	(CallExecOutputType)(0),                           // 1: cresplanex.bloader.v1.CallExecOutputType
 	(RequestType)(0),                                  // 2: cresplanex.bloader.v1.RequestType
-	(*ConnectRequest)(nil),                            // 3: cresplanex.bloader.v1.ConnectRequest
-	(*ConnectResponse)(nil),                           // 4: cresplanex.bloader.v1.ConnectResponse
-	(*DisconnectRequest)(nil),                         // 5: cresplanex.bloader.v1.DisconnectRequest
-	(*DisconnectResponse)(nil),                        // 6: cresplanex.bloader.v1.DisconnectResponse
-	(*SlaveCommandRequest)(nil),                       // 7: cresplanex.bloader.v1.SlaveCommandRequest
-	(*SlaveCommandResponse)(nil),                      // 8: cresplanex.bloader.v1.SlaveCommandResponse
-	(*SlaveCommandDefaultStoreRequest)(nil),           // 9: cresplanex.bloader.v1.SlaveCommandDefaultStoreRequest
-	(*SlaveCommandDefaultStoreResponse)(nil),          // 10: cresplanex.bloader.v1.SlaveCommandDefaultStoreResponse
-	(*CallExecRequest)(nil),                           // 11: cresplanex.bloader.v1.CallExecRequest
-	(*CallExecResponse)(nil),                          // 12: cresplanex.bloader.v1.CallExecResponse
-	(*CallExecOutputHTTP)(nil),                        // 13: cresplanex.bloader.v1.CallExecOutputHTTP
-	(*ReceiveChanelConnectRequest)(nil),               // 14: cresplanex.bloader.v1.ReceiveChanelConnectRequest
-	(*ReceiveChanelConnectResponse)(nil),              // 15: cresplanex.bloader.v1.ReceiveChanelConnectResponse
-	(*ReceiveChanelConnectLoaderResourceRequest)(nil), // 16: cresplanex.bloader.v1.ReceiveChanelConnectLoaderResourceRequest
-	(*ReceiveChanelConnectAuthResourceRequest)(nil),   // 17: cresplanex.bloader.v1.ReceiveChanelConnectAuthResourceRequest
-	(*ReceiveChanelConnectStore)(nil),                 // 18: cresplanex.bloader.v1.ReceiveChanelConnectStore
-	(*ReceiveChanelConnectStoreResourceRequest)(nil),  // 19: cresplanex.bloader.v1.ReceiveChanelConnectStoreResourceRequest
-	(*ReceiveChanelConnectTargetResourceRequest)(nil), // 20: cresplanex.bloader.v1.ReceiveChanelConnectTargetResourceRequest
-	(*SendLoaderRequest)(nil),                         // 21: cresplanex.bloader.v1.SendLoaderRequest
-	(*SendLoaderResponse)(nil),                        // 22: cresplanex.bloader.v1.SendLoaderResponse
-	(*SendAuthRequest)(nil),                           // 23: cresplanex.bloader.v1.SendAuthRequest
-	(*SendAuthResponse)(nil),                          // 24: cresplanex.bloader.v1.SendAuthResponse
-	(*SendStoreDataRequest)(nil),                      // 25: cresplanex.bloader.v1.SendStoreDataRequest
-	(*SendStoreDataResponse)(nil),                     // 26: cresplanex.bloader.v1.SendStoreDataResponse
-	(*SendStoreOkRequest)(nil),                        // 27: cresplanex.bloader.v1.SendStoreOkRequest
-	(*SendStoreOkResponse)(nil),                       // 28: cresplanex.bloader.v1.SendStoreOkResponse
-	(*SendTargetRequest)(nil),                         // 29: cresplanex.bloader.v1.SendTargetRequest
-	(*SendTargetResponse)(nil),                        // 30: cresplanex.bloader.v1.SendTargetResponse
-	(*ReceiveLoadTermChannelRequest)(nil),             // 31: cresplanex.bloader.v1.ReceiveLoadTermChannelRequest
-	(*ReceiveLoadTermChannelResponse)(nil),            // 32: cresplanex.bloader.v1.ReceiveLoadTermChannelResponse
-	(*Auth)(nil),                                      // 33: cresplanex.bloader.v1.Auth
-	(*Target)(nil),                                    // 34: cresplanex.bloader.v1.Target
+	(BarrierReleaseStatus)(0),                         // 3: cresplanex.bloader.v1.BarrierReleaseStatus
+	(*ConnectRequest)(nil),                            // 4: cresplanex.bloader.v1.ConnectRequest
+	(*ConnectResponse)(nil),                           // 5: cresplanex.bloader.v1.ConnectResponse
+	(*DisconnectRequest)(nil),                         // 6: cresplanex.bloader.v1.DisconnectRequest
+	(*DisconnectResponse)(nil),                        // 7: cresplanex.bloader.v1.DisconnectResponse
+	(*SlaveCommandRequest)(nil),                       // 8: cresplanex.bloader.v1.SlaveCommandRequest
+	(*SlaveCommandResponse)(nil),                      // 9: cresplanex.bloader.v1.SlaveCommandResponse
+	(*SlaveCommandDefaultStoreRequest)(nil),           // 10: cresplanex.bloader.v1.SlaveCommandDefaultStoreRequest
+	(*SlaveCommandDefaultStoreResponse)(nil),          // 11: cresplanex.bloader.v1.SlaveCommandDefaultStoreResponse
+	(*CallExecRequest)(nil),                           // 12: cresplanex.bloader.v1.CallExecRequest
+	(*CallExecResponse)(nil),                          // 13: cresplanex.bloader.v1.CallExecResponse
+	(*CallExecOutputHTTP)(nil),                        // 14: cresplanex.bloader.v1.CallExecOutputHTTP
+	(*ReceiveChanelConnectRequest)(nil),               // 15: cresplanex.bloader.v1.ReceiveChanelConnectRequest
+	(*ReceiveChanelConnectResponse)(nil),              // 16: cresplanex.bloader.v1.ReceiveChanelConnectResponse
+	(*ReceiveChanelConnectLoaderResourceRequest)(nil), // 17: cresplanex.bloader.v1.ReceiveChanelConnectLoaderResourceRequest
+	(*ReceiveChanelConnectAuthResourceRequest)(nil),   // 18: cresplanex.bloader.v1.ReceiveChanelConnectAuthResourceRequest
+	(*ReceiveChanelConnectStore)(nil),                 // 19: cresplanex.bloader.v1.ReceiveChanelConnectStore
+	(*ReceiveChanelConnectStoreResourceRequest)(nil),  // 20: cresplanex.bloader.v1.ReceiveChanelConnectStoreResourceRequest
+	(*ReceiveChanelConnectTargetResourceRequest)(nil), // 21: cresplanex.bloader.v1.ReceiveChanelConnectTargetResourceRequest
+	(*ReceiveChanelConnectBarrierRequest)(nil),        // 22: cresplanex.bloader.v1.ReceiveChanelConnectBarrierRequest
+	(*SendLoaderRequest)(nil),                         // 23: cresplanex.bloader.v1.SendLoaderRequest
+	(*SendLoaderResponse)(nil),                        // 24: cresplanex.bloader.v1.SendLoaderResponse
+	(*SendAuthRequest)(nil),                           // 25: cresplanex.bloader.v1.SendAuthRequest
+	(*SendAuthResponse)(nil),                          // 26: cresplanex.bloader.v1.SendAuthResponse
+	(*SendStoreDataRequest)(nil),                      // 27: cresplanex.bloader.v1.SendStoreDataRequest
+	(*SendStoreDataResponse)(nil),                     // 28: cresplanex.bloader.v1.SendStoreDataResponse
+	(*SendStoreOkRequest)(nil),                        // 29: cresplanex.bloader.v1.SendStoreOkRequest
+	(*SendStoreOkResponse)(nil),                       // 30: cresplanex.bloader.v1.SendStoreOkResponse
+	(*SendTargetRequest)(nil),                         // 31: cresplanex.bloader.v1.SendTargetRequest
+	(*SendTargetResponse)(nil),                        // 32: cresplanex.bloader.v1.SendTargetResponse
+	(*ReceiveLoadTermChannelRequest)(nil),             // 33: cresplanex.bloader.v1.ReceiveLoadTermChannelRequest
+	(*ReceiveLoadTermChannelResponse)(nil),            // 34: cresplanex.bloader.v1.ReceiveLoadTermChannelResponse
+	(*SendBarrierReleaseRequest)(nil),                 // 35: cresplanex.bloader.v1.SendBarrierReleaseRequest
+	(*SendBarrierReleaseResponse)(nil),                // 36: cresplanex.bloader.v1.SendBarrierReleaseResponse
+	(*Auth)(nil),                                      // 37: cresplanex.bloader.v1.Auth
+	(*Target)(nil),                                    // 38: cresplanex.bloader.v1.Target
 }
 var file_cresplanex_bloader_v1_bloader_proto_depIdxs = []int32{
 	0,  // 0: cresplanex.bloader.v1.SlaveCommandDefaultStoreRequest.store_type:type_name -> cresplanex.bloader.v1.SlaveCommandDefaultStoreType
 	1,  // 1: cresplanex.bloader.v1.CallExecResponse.output_type:type_name -> cresplanex.bloader.v1.CallExecOutputType
-	13, // 2: cresplanex.bloader.v1.CallExecResponse.output_http:type_name -> cresplanex.bloader.v1.CallExecOutputHTTP
+	14, // 2: cresplanex.bloader.v1.CallExecResponse.output_http:type_name -> cresplanex.bloader.v1.CallExecOutputHTTP
 	2,  // 3: cresplanex.bloader.v1.ReceiveChanelConnectResponse.request_type:type_name -> cresplanex.bloader.v1.RequestType
-	16, // 4: cresplanex.bloader.v1.ReceiveChanelConnectResponse.loader_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectLoaderResourceRequest
-	17, // 5: cresplanex.bloader.v1.ReceiveChanelConnectResponse.auth_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectAuthResourceRequest
-	18, // 6: cresplanex.bloader.v1.ReceiveChanelConnectResponse.store:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectStore
-	19, // 7: cresplanex.bloader.v1.ReceiveChanelConnectResponse.store_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectStoreResourceRequest
-	20, // 8: cresplanex.bloader.v1.ReceiveChanelConnectResponse.target_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectTargetResourceRequest
-	33, // 9: cresplanex.bloader.v1.SendAuthRequest.auth:type_name -> cresplanex.bloader.v1.Auth
-	34, // 10: cresplanex.bloader.v1.SendTargetRequest.target:type_name -> cresplanex.bloader.v1.Target
-	3,  // 11: cresplanex.bloader.v1.BloaderSlaveService.Connect:input_type -> cresplanex.bloader.v1.ConnectRequest
-	5,  // 12: cresplanex.bloader.v1.BloaderSlaveService.Disconnect:input_type -> cresplanex.bloader.v1.DisconnectRequest
-	7,  // 13: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommand:input_type -> cresplanex.bloader.v1.SlaveCommandRequest
-	9,  // 14: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommandDefaultStore:input_type -> cresplanex.bloader.v1.SlaveCommandDefaultStoreRequest
-	11, // 15: cresplanex.bloader.v1.BloaderSlaveService.CallExec:input_type -> cresplanex.bloader.v1.CallExecRequest
-	14, // 16: cresplanex.bloader.v1.BloaderSlaveService.ReceiveChanelConnect:input_type -> cresplanex.bloader.v1.ReceiveChanelConnectRequest
-	21, // 17: cresplanex.bloader.v1.BloaderSlaveService.SendLoader:input_type -> cresplanex.bloader.v1.SendLoaderRequest
-	23, // 18: cresplanex.bloader.v1.BloaderSlaveService.SendAuth:input_type -> cresplanex.bloader.v1.SendAuthRequest
-	25, // 19: cresplanex.bloader.v1.BloaderSlaveService.SendStoreData:input_type -> cresplanex.bloader.v1.SendStoreDataRequest
-	27, // 20: cresplanex.bloader.v1.BloaderSlaveService.SendStoreOk:input_type -> cresplanex.bloader.v1.SendStoreOkRequest
-	29, // 21: cresplanex.bloader.v1.BloaderSlaveService.SendTarget:input_type -> cresplanex.bloader.v1.SendTargetRequest
-	31, // 22: cresplanex.bloader.v1.BloaderSlaveService.ReceiveLoadTermChannel:input_type -> cresplanex.bloader.v1.ReceiveLoadTermChannelRequest
-	4,  // 23: cresplanex.bloader.v1.BloaderSlaveService.Connect:output_type -> cresplanex.bloader.v1.ConnectResponse
-	6,  // 24: cresplanex.bloader.v1.BloaderSlaveService.Disconnect:output_type -> cresplanex.bloader.v1.DisconnectResponse
-	8,  // 25: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommand:output_type -> cresplanex.bloader.v1.SlaveCommandResponse
-	10, // 26: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommandDefaultStore:output_type -> cresplanex.bloader.v1.SlaveCommandDefaultStoreResponse
-	12, // 27: cresplanex.bloader.v1.BloaderSlaveService.CallExec:output_type -> cresplanex.bloader.v1.CallExecResponse
-	15, // 28: cresplanex.bloader.v1.BloaderSlaveService.ReceiveChanelConnect:output_type -> cresplanex.bloader.v1.ReceiveChanelConnectResponse
-	22, // 29: cresplanex.bloader.v1.BloaderSlaveService.SendLoader:output_type -> cresplanex.bloader.v1.SendLoaderResponse
-	24, // 30: cresplanex.bloader.v1.BloaderSlaveService.SendAuth:output_type -> cresplanex.bloader.v1.SendAuthResponse
-	26, // 31: cresplanex.bloader.v1.BloaderSlaveService.SendStoreData:output_type -> cresplanex.bloader.v1.SendStoreDataResponse
-	28, // 32: cresplanex.bloader.v1.BloaderSlaveService.SendStoreOk:output_type -> cresplanex.bloader.v1.SendStoreOkResponse
-	30, // 33: cresplanex.bloader.v1.BloaderSlaveService.SendTarget:output_type -> cresplanex.bloader.v1.SendTargetResponse
-	32, // 34: cresplanex.bloader.v1.BloaderSlaveService.ReceiveLoadTermChannel:output_type -> cresplanex.bloader.v1.ReceiveLoadTermChannelResponse
-	23, // [23:35] is the sub-list for method output_type
-	11, // [11:23] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	17, // 4: cresplanex.bloader.v1.ReceiveChanelConnectResponse.loader_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectLoaderResourceRequest
+	18, // 5: cresplanex.bloader.v1.ReceiveChanelConnectResponse.auth_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectAuthResourceRequest
+	19, // 6: cresplanex.bloader.v1.ReceiveChanelConnectResponse.store:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectStore
+	20, // 7: cresplanex.bloader.v1.ReceiveChanelConnectResponse.store_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectStoreResourceRequest
+	21, // 8: cresplanex.bloader.v1.ReceiveChanelConnectResponse.target_resource_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectTargetResourceRequest
+	22, // 9: cresplanex.bloader.v1.ReceiveChanelConnectResponse.barrier_request:type_name -> cresplanex.bloader.v1.ReceiveChanelConnectBarrierRequest
+	37, // 10: cresplanex.bloader.v1.SendAuthRequest.auth:type_name -> cresplanex.bloader.v1.Auth
+	38, // 11: cresplanex.bloader.v1.SendTargetRequest.target:type_name -> cresplanex.bloader.v1.Target
+	3,  // 12: cresplanex.bloader.v1.SendBarrierReleaseRequest.status:type_name -> cresplanex.bloader.v1.BarrierReleaseStatus
+	4,  // 13: cresplanex.bloader.v1.BloaderSlaveService.Connect:input_type -> cresplanex.bloader.v1.ConnectRequest
+	6,  // 14: cresplanex.bloader.v1.BloaderSlaveService.Disconnect:input_type -> cresplanex.bloader.v1.DisconnectRequest
+	8,  // 15: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommand:input_type -> cresplanex.bloader.v1.SlaveCommandRequest
+	10, // 16: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommandDefaultStore:input_type -> cresplanex.bloader.v1.SlaveCommandDefaultStoreRequest
+	12, // 17: cresplanex.bloader.v1.BloaderSlaveService.CallExec:input_type -> cresplanex.bloader.v1.CallExecRequest
+	15, // 18: cresplanex.bloader.v1.BloaderSlaveService.ReceiveChanelConnect:input_type -> cresplanex.bloader.v1.ReceiveChanelConnectRequest
+	23, // 19: cresplanex.bloader.v1.BloaderSlaveService.SendLoader:input_type -> cresplanex.bloader.v1.SendLoaderRequest
+	25, // 20: cresplanex.bloader.v1.BloaderSlaveService.SendAuth:input_type -> cresplanex.bloader.v1.SendAuthRequest
+	27, // 21: cresplanex.bloader.v1.BloaderSlaveService.SendStoreData:input_type -> cresplanex.bloader.v1.SendStoreDataRequest
+	29, // 22: cresplanex.bloader.v1.BloaderSlaveService.SendStoreOk:input_type -> cresplanex.bloader.v1.SendStoreOkRequest
+	31, // 23: cresplanex.bloader.v1.BloaderSlaveService.SendTarget:input_type -> cresplanex.bloader.v1.SendTargetRequest
+	33, // 24: cresplanex.bloader.v1.BloaderSlaveService.ReceiveLoadTermChannel:input_type -> cresplanex.bloader.v1.ReceiveLoadTermChannelRequest
+	35, // 25: cresplanex.bloader.v1.BloaderSlaveService.SendBarrierRelease:input_type -> cresplanex.bloader.v1.SendBarrierReleaseRequest
+	5,  // 26: cresplanex.bloader.v1.BloaderSlaveService.Connect:output_type -> cresplanex.bloader.v1.ConnectResponse
+	7,  // 27: cresplanex.bloader.v1.BloaderSlaveService.Disconnect:output_type -> cresplanex.bloader.v1.DisconnectResponse
+	9,  // 28: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommand:output_type -> cresplanex.bloader.v1.SlaveCommandResponse
+	11, // 29: cresplanex.bloader.v1.BloaderSlaveService.SlaveCommandDefaultStore:output_type -> cresplanex.bloader.v1.SlaveCommandDefaultStoreResponse
+	13, // 30: cresplanex.bloader.v1.BloaderSlaveService.CallExec:output_type -> cresplanex.bloader.v1.CallExecResponse
+	16, // 31: cresplanex.bloader.v1.BloaderSlaveService.ReceiveChanelConnect:output_type -> cresplanex.bloader.v1.ReceiveChanelConnectResponse
+	24, // 32: cresplanex.bloader.v1.BloaderSlaveService.SendLoader:output_type -> cresplanex.bloader.v1.SendLoaderResponse
+	26, // 33: cresplanex.bloader.v1.BloaderSlaveService.SendAuth:output_type -> cresplanex.bloader.v1.SendAuthResponse
+	28, // 34: cresplanex.bloader.v1.BloaderSlaveService.SendStoreData:output_type -> cresplanex.bloader.v1.SendStoreDataResponse
+	30, // 35: cresplanex.bloader.v1.BloaderSlaveService.SendStoreOk:output_type -> cresplanex.bloader.v1.SendStoreOkResponse
+	32, // 36: cresplanex.bloader.v1.BloaderSlaveService.SendTarget:output_type -> cresplanex.bloader.v1.SendTargetResponse
+	34, // 37: cresplanex.bloader.v1.BloaderSlaveService.ReceiveLoadTermChannel:output_type -> cresplanex.bloader.v1.ReceiveLoadTermChannelResponse
+	36, // 38: cresplanex.bloader.v1.BloaderSlaveService.SendBarrierRelease:output_type -> cresplanex.bloader.v1.SendBarrierReleaseResponse
+	26, // [26:39] is the sub-list for method output_type
+	13, // [13:26] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_cresplanex_bloader_v1_bloader_proto_init() }
@@ -2226,14 +2529,15 @@ func file_cresplanex_bloader_v1_bloader_proto_init() {
 		(*ReceiveChanelConnectResponse_Store)(nil),
 		(*ReceiveChanelConnectResponse_StoreResourceRequest)(nil),
 		(*ReceiveChanelConnectResponse_TargetResourceRequest)(nil),
+		(*ReceiveChanelConnectResponse_BarrierRequest)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cresplanex_bloader_v1_bloader_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   30,
+			NumEnums:      4,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
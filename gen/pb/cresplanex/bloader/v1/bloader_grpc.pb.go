@@ -31,6 +31,7 @@ const (
 	BloaderSlaveService_SendStoreOk_FullMethodName              = "/cresplanex.bloader.v1.BloaderSlaveService/SendStoreOk"
 	BloaderSlaveService_SendTarget_FullMethodName               = "/cresplanex.bloader.v1.BloaderSlaveService/SendTarget"
 	BloaderSlaveService_ReceiveLoadTermChannel_FullMethodName   = "/cresplanex.bloader.v1.BloaderSlaveService/ReceiveLoadTermChannel"
+	BloaderSlaveService_SendBarrierRelease_FullMethodName       = "/cresplanex.bloader.v1.BloaderSlaveService/SendBarrierRelease"
 )
 
 // BloaderSlaveServiceClient is the client API for BloaderSlaveService service.
@@ -49,6 +50,7 @@ type BloaderSlaveServiceClient interface {
 	SendStoreOk(ctx context.Context, in *SendStoreOkRequest, opts ...grpc.CallOption) (*SendStoreOkResponse, error)
 	SendTarget(ctx context.Context, in *SendTargetRequest, opts ...grpc.CallOption) (*SendTargetResponse, error)
 	ReceiveLoadTermChannel(ctx context.Context, in *ReceiveLoadTermChannelRequest, opts ...grpc.CallOption) (*ReceiveLoadTermChannelResponse, error)
+	SendBarrierRelease(ctx context.Context, in *SendBarrierReleaseRequest, opts ...grpc.CallOption) (*SendBarrierReleaseResponse, error)
 }
 
 type bloaderSlaveServiceClient struct {
@@ -206,6 +208,16 @@ func (c *bloaderSlaveServiceClient) ReceiveLoadTermChannel(ctx context.Context,
 	return out, nil
 }
 
+func (c *bloaderSlaveServiceClient) SendBarrierRelease(ctx context.Context, in *SendBarrierReleaseRequest, opts ...grpc.CallOption) (*SendBarrierReleaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendBarrierReleaseResponse)
+	err := c.cc.Invoke(ctx, BloaderSlaveService_SendBarrierRelease_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BloaderSlaveServiceServer is the server API for BloaderSlaveService service.
 // All implementations must embed UnimplementedBloaderSlaveServiceServer
 // for forward compatibility.
@@ -222,6 +234,7 @@ type BloaderSlaveServiceServer interface {
 	SendStoreOk(context.Context, *SendStoreOkRequest) (*SendStoreOkResponse, error)
 	SendTarget(context.Context, *SendTargetRequest) (*SendTargetResponse, error)
 	ReceiveLoadTermChannel(context.Context, *ReceiveLoadTermChannelRequest) (*ReceiveLoadTermChannelResponse, error)
+	SendBarrierRelease(context.Context, *SendBarrierReleaseRequest) (*SendBarrierReleaseResponse, error)
 	mustEmbedUnimplementedBloaderSlaveServiceServer()
 }
 
@@ -268,6 +281,9 @@ func (UnimplementedBloaderSlaveServiceServer) SendTarget(context.Context, *SendT
 func (UnimplementedBloaderSlaveServiceServer) ReceiveLoadTermChannel(context.Context, *ReceiveLoadTermChannelRequest) (*ReceiveLoadTermChannelResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReceiveLoadTermChannel not implemented")
 }
+func (UnimplementedBloaderSlaveServiceServer) SendBarrierRelease(context.Context, *SendBarrierReleaseRequest) (*SendBarrierReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendBarrierRelease not implemented")
+}
 func (UnimplementedBloaderSlaveServiceServer) mustEmbedUnimplementedBloaderSlaveServiceServer() {}
 func (UnimplementedBloaderSlaveServiceServer) testEmbeddedByValue()                             {}
 
@@ -458,6 +474,24 @@ func _BloaderSlaveService_ReceiveLoadTermChannel_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BloaderSlaveService_SendBarrierRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendBarrierReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BloaderSlaveServiceServer).SendBarrierRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BloaderSlaveService_SendBarrierRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BloaderSlaveServiceServer).SendBarrierRelease(ctx, req.(*SendBarrierReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BloaderSlaveService_ServiceDesc is the grpc.ServiceDesc for BloaderSlaveService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -493,6 +527,10 @@ var BloaderSlaveService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReceiveLoadTermChannel",
 			Handler:    _BloaderSlaveService_ReceiveLoadTermChannel_Handler,
 		},
+		{
+			MethodName: "SendBarrierRelease",
+			Handler:    _BloaderSlaveService_SendBarrierRelease_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
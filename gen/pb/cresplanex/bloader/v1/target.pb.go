@@ -25,6 +25,13 @@ type TargetType int32
 const (
 	TargetType_TARGET_TYPE_UNSPECIFIED TargetType = 0
 	TargetType_TARGET_TYPE_HTTP        TargetType = 1
+	TargetType_TARGET_TYPE_WEBSOCKET   TargetType = 2
+	TargetType_TARGET_TYPE_GRPC        TargetType = 3
+	TargetType_TARGET_TYPE_TCP         TargetType = 4
+	TargetType_TARGET_TYPE_UDP         TargetType = 5
+	TargetType_TARGET_TYPE_SQL         TargetType = 6
+	TargetType_TARGET_TYPE_REDIS       TargetType = 7
+	TargetType_TARGET_TYPE_MQTT        TargetType = 8
 )
 
 // Enum value maps for TargetType.
@@ -32,10 +39,24 @@ var (
 	TargetType_name = map[int32]string{
 		0: "TARGET_TYPE_UNSPECIFIED",
 		1: "TARGET_TYPE_HTTP",
+		2: "TARGET_TYPE_WEBSOCKET",
+		3: "TARGET_TYPE_GRPC",
+		4: "TARGET_TYPE_TCP",
+		5: "TARGET_TYPE_UDP",
+		6: "TARGET_TYPE_SQL",
+		7: "TARGET_TYPE_REDIS",
+		8: "TARGET_TYPE_MQTT",
 	}
 	TargetType_value = map[string]int32{
 		"TARGET_TYPE_UNSPECIFIED": 0,
 		"TARGET_TYPE_HTTP":        1,
+		"TARGET_TYPE_WEBSOCKET":   2,
+		"TARGET_TYPE_GRPC":        3,
+		"TARGET_TYPE_TCP":         4,
+		"TARGET_TYPE_UDP":         5,
+		"TARGET_TYPE_SQL":         6,
+		"TARGET_TYPE_REDIS":       7,
+		"TARGET_TYPE_MQTT":        8,
 	}
 )
 
@@ -72,6 +93,13 @@ type Target struct {
 	// Types that are valid to be assigned to Target:
 	//
 	//	*Target_Http
+	//	*Target_Websocket
+	//	*Target_Grpc
+	//	*Target_Tcp
+	//	*Target_Udp
+	//	*Target_Sql
+	//	*Target_Redis
+	//	*Target_Mqtt
 	Target        isTarget_Target `protobuf_oneof:"target"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -130,6 +158,69 @@ func (x *Target) GetHttp() *TargetHTTPData {
 	return nil
 }
 
+func (x *Target) GetWebsocket() *TargetWebSocketData {
+	if x != nil {
+		if x, ok := x.Target.(*Target_Websocket); ok {
+			return x.Websocket
+		}
+	}
+	return nil
+}
+
+func (x *Target) GetGrpc() *TargetGRPCData {
+	if x != nil {
+		if x, ok := x.Target.(*Target_Grpc); ok {
+			return x.Grpc
+		}
+	}
+	return nil
+}
+
+func (x *Target) GetTcp() *TargetTCPData {
+	if x != nil {
+		if x, ok := x.Target.(*Target_Tcp); ok {
+			return x.Tcp
+		}
+	}
+	return nil
+}
+
+func (x *Target) GetUdp() *TargetUDPData {
+	if x != nil {
+		if x, ok := x.Target.(*Target_Udp); ok {
+			return x.Udp
+		}
+	}
+	return nil
+}
+
+func (x *Target) GetSql() *TargetSQLData {
+	if x != nil {
+		if x, ok := x.Target.(*Target_Sql); ok {
+			return x.Sql
+		}
+	}
+	return nil
+}
+
+func (x *Target) GetRedis() *TargetRedisData {
+	if x != nil {
+		if x, ok := x.Target.(*Target_Redis); ok {
+			return x.Redis
+		}
+	}
+	return nil
+}
+
+func (x *Target) GetMqtt() *TargetMQTTData {
+	if x != nil {
+		if x, ok := x.Target.(*Target_Mqtt); ok {
+			return x.Mqtt
+		}
+	}
+	return nil
+}
+
 type isTarget_Target interface {
 	isTarget_Target()
 }
@@ -138,11 +229,55 @@ type Target_Http struct {
 	Http *TargetHTTPData `protobuf:"bytes,2,opt,name=http,proto3,oneof"`
 }
 
+type Target_Websocket struct {
+	Websocket *TargetWebSocketData `protobuf:"bytes,3,opt,name=websocket,proto3,oneof"`
+}
+
+type Target_Grpc struct {
+	Grpc *TargetGRPCData `protobuf:"bytes,4,opt,name=grpc,proto3,oneof"`
+}
+
+type Target_Tcp struct {
+	Tcp *TargetTCPData `protobuf:"bytes,5,opt,name=tcp,proto3,oneof"`
+}
+
+type Target_Udp struct {
+	Udp *TargetUDPData `protobuf:"bytes,6,opt,name=udp,proto3,oneof"`
+}
+
+type Target_Sql struct {
+	Sql *TargetSQLData `protobuf:"bytes,7,opt,name=sql,proto3,oneof"`
+}
+
+type Target_Redis struct {
+	Redis *TargetRedisData `protobuf:"bytes,8,opt,name=redis,proto3,oneof"`
+}
+
+type Target_Mqtt struct {
+	Mqtt *TargetMQTTData `protobuf:"bytes,9,opt,name=mqtt,proto3,oneof"`
+}
+
 func (*Target_Http) isTarget_Target() {}
 
+func (*Target_Websocket) isTarget_Target() {}
+
+func (*Target_Grpc) isTarget_Target() {}
+
+func (*Target_Tcp) isTarget_Target() {}
+
+func (*Target_Udp) isTarget_Target() {}
+
+func (*Target_Sql) isTarget_Target() {}
+
+func (*Target_Redis) isTarget_Target() {}
+
+func (*Target_Mqtt) isTarget_Target() {}
+
 type TargetHTTPData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Protocol      string                 `protobuf:"bytes,2,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Http3         *TargetHTTP3Data       `protobuf:"bytes,3,opt,name=http3,proto3" json:"http3,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -184,13 +319,403 @@ func (x *TargetHTTPData) GetUrl() string {
 	return ""
 }
 
+func (x *TargetHTTPData) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *TargetHTTPData) GetHttp3() *TargetHTTP3Data {
+	if x != nil {
+		return x.Http3
+	}
+	return nil
+}
+
+type TargetHTTP3Data struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	Fallback                     bool                   `protobuf:"varint,1,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	ZeroRtt                      bool                   `protobuf:"varint,2,opt,name=zero_rtt,json=zeroRtt,proto3" json:"zero_rtt,omitempty"`
+	HandshakeTimeoutMilliseconds int64                  `protobuf:"varint,3,opt,name=handshake_timeout_milliseconds,json=handshakeTimeoutMilliseconds,proto3" json:"handshake_timeout_milliseconds,omitempty"`
+	InsecureSkipVerify           bool                   `protobuf:"varint,4,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	unknownFields                protoimpl.UnknownFields
+	sizeCache                    protoimpl.SizeCache
+}
+
+func (x *TargetHTTP3Data) Reset() {
+	*x = TargetHTTP3Data{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetHTTP3Data) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetHTTP3Data) ProtoMessage() {}
+
+func (x *TargetHTTP3Data) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetHTTP3Data.ProtoReflect.Descriptor instead.
+func (*TargetHTTP3Data) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TargetHTTP3Data) GetFallback() bool {
+	if x != nil {
+		return x.Fallback
+	}
+	return false
+}
+
+func (x *TargetHTTP3Data) GetZeroRtt() bool {
+	if x != nil {
+		return x.ZeroRtt
+	}
+	return false
+}
+
+func (x *TargetHTTP3Data) GetHandshakeTimeoutMilliseconds() int64 {
+	if x != nil {
+		return x.HandshakeTimeoutMilliseconds
+	}
+	return 0
+}
+
+func (x *TargetHTTP3Data) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+type TargetWebSocketData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetWebSocketData) Reset() {
+	*x = TargetWebSocketData{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetWebSocketData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetWebSocketData) ProtoMessage() {}
+
+func (x *TargetWebSocketData) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetWebSocketData.ProtoReflect.Descriptor instead.
+func (*TargetWebSocketData) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TargetWebSocketData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TargetGRPCData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetGRPCData) Reset() {
+	*x = TargetGRPCData{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetGRPCData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetGRPCData) ProtoMessage() {}
+
+func (x *TargetGRPCData) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetGRPCData.ProtoReflect.Descriptor instead.
+func (*TargetGRPCData) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TargetGRPCData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TargetTCPData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetTCPData) Reset() {
+	*x = TargetTCPData{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetTCPData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetTCPData) ProtoMessage() {}
+
+func (x *TargetTCPData) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetTCPData.ProtoReflect.Descriptor instead.
+func (*TargetTCPData) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TargetTCPData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TargetUDPData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetUDPData) Reset() {
+	*x = TargetUDPData{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetUDPData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetUDPData) ProtoMessage() {}
+
+func (x *TargetUDPData) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetUDPData.ProtoReflect.Descriptor instead.
+func (*TargetUDPData) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TargetUDPData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TargetSQLData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetSQLData) Reset() {
+	*x = TargetSQLData{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetSQLData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetSQLData) ProtoMessage() {}
+
+func (x *TargetSQLData) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetSQLData.ProtoReflect.Descriptor instead.
+func (*TargetSQLData) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TargetSQLData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TargetRedisData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetRedisData) Reset() {
+	*x = TargetRedisData{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetRedisData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetRedisData) ProtoMessage() {}
+
+func (x *TargetRedisData) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetRedisData.ProtoReflect.Descriptor instead.
+func (*TargetRedisData) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TargetRedisData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type TargetMQTTData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TargetMQTTData) Reset() {
+	*x = TargetMQTTData{}
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetMQTTData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetMQTTData) ProtoMessage() {}
+
+func (x *TargetMQTTData) ProtoReflect() protoreflect.Message {
+	mi := &file_cresplanex_bloader_v1_target_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TargetMQTTData.ProtoReflect.Descriptor instead.
+func (*TargetMQTTData) Descriptor() ([]byte, []int) {
+	return file_cresplanex_bloader_v1_target_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TargetMQTTData) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
 var File_cresplanex_bloader_v1_target_proto protoreflect.FileDescriptor
 
 var file_cresplanex_bloader_v1_target_proto_rawDesc = []byte{
 	0x0a, 0x22, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62, 0x6c, 0x6f,
 	0x61, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x15, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78,
-	0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x86, 0x01, 0x0a, 0x06,
+	0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0xba, 0x04, 0x0a, 0x06,
 	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65,
 	0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72,
@@ -198,29 +723,100 @@ var file_cresplanex_bloader_v1_target_proto_rawDesc = []byte{
 	0x04, 0x68, 0x74, 0x74, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x72,
 	0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72,
 	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x48, 0x54, 0x54, 0x50, 0x44, 0x61,
-	0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x42, 0x08, 0x0a, 0x06, 0x74, 0x61,
-	0x72, 0x67, 0x65, 0x74, 0x22, 0x22, 0x0a, 0x0e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x48, 0x54,
-	0x54, 0x50, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x2a, 0x3f, 0x0a, 0x0a, 0x54, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54,
-	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45,
-	0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x54, 0x59,
-	0x50, 0x45, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x42, 0xe4, 0x01, 0x0a, 0x19, 0x63, 0x6f,
-	0x6d, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f,
-	0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62, 0x6c,
-	0x6f, 0x61, 0x64, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x72, 0x65,
-	0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2f,
-	0x76, 0x31, 0x3b, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43,
-	0x42, 0x58, 0xaa, 0x02, 0x15, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e,
-	0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x15, 0x43, 0x72, 0x65,
-	0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x5c, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5c,
-	0x56, 0x31, 0xe2, 0x02, 0x21, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x5c,
-	0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x17, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61,
-	0x6e, 0x65, 0x78, 0x3a, 0x3a, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x3a, 0x3a, 0x56, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x68, 0x74, 0x74, 0x70, 0x12, 0x4a, 0x0a, 0x09, 0x77, 0x65,
+	0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x57, 0x65, 0x62, 0x53,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x09, 0x77, 0x65, 0x62,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x3b, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x47, 0x52, 0x50, 0x43, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x67,
+	0x72, 0x70, 0x63, 0x12, 0x38, 0x0a, 0x03, 0x74, 0x63, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c,
+	0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54,
+	0x43, 0x50, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x03, 0x74, 0x63, 0x70, 0x12, 0x38, 0x0a,
+	0x03, 0x75, 0x64, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x72, 0x65,
+	0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x55, 0x44, 0x50, 0x44, 0x61, 0x74, 0x61,
+	0x48, 0x00, 0x52, 0x03, 0x75, 0x64, 0x70, 0x12, 0x38, 0x0a, 0x03, 0x73, 0x71, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x03, 0x73, 0x71,
+	0x6c, 0x12, 0x3e, 0x0a, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x26, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c,
+	0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52,
+	0x65, 0x64, 0x69, 0x73, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x05, 0x72, 0x65, 0x64, 0x69,
+	0x73, 0x12, 0x3b, 0x0a, 0x04, 0x6d, 0x71, 0x74, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x25, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4d, 0x51,
+	0x54, 0x54, 0x44, 0x61, 0x74, 0x61, 0x48, 0x00, 0x52, 0x04, 0x6d, 0x71, 0x74, 0x74, 0x42, 0x08,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x22, 0x7c, 0x0a, 0x0e, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x48, 0x54, 0x54, 0x50, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x3c, 0x0a, 0x05, 0x68, 0x74, 0x74, 0x70,
+	0x33, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c,
+	0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x48, 0x54, 0x54, 0x50, 0x33, 0x44, 0x61, 0x74, 0x61, 0x52,
+	0x05, 0x68, 0x74, 0x74, 0x70, 0x33, 0x22, 0xc0, 0x01, 0x0a, 0x0f, 0x54, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x48, 0x54, 0x54, 0x50, 0x33, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x61,
+	0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x19, 0x0a, 0x08, 0x7a, 0x65, 0x72, 0x6f, 0x5f, 0x72,
+	0x74, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x7a, 0x65, 0x72, 0x6f, 0x52, 0x74,
+	0x74, 0x12, 0x44, 0x0a, 0x1e, 0x68, 0x61, 0x6e, 0x64, 0x73, 0x68, 0x61, 0x6b, 0x65, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x1c, 0x68, 0x61, 0x6e, 0x64, 0x73,
+	0x68, 0x61, 0x6b, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x69, 0x6e, 0x73, 0x65, 0x63,
+	0x75, 0x72, 0x65, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x53,
+	0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x22, 0x27, 0x0a, 0x13, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x57, 0x65, 0x62, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x22, 0x22, 0x0a, 0x0e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x47, 0x52, 0x50, 0x43,
+	0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x21, 0x0a, 0x0d, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x54, 0x43, 0x50, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x21, 0x0a, 0x0d, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x55, 0x44, 0x50, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0x21, 0x0a, 0x0d,
+	0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22,
+	0x23, 0x0a, 0x0f, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x65, 0x64, 0x69, 0x73, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x75, 0x72, 0x6c, 0x22, 0x22, 0x0a, 0x0e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4d, 0x51,
+	0x54, 0x54, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x2a, 0xdc, 0x01, 0x0a, 0x0a, 0x54, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x52, 0x47, 0x45,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x41,
+	0x52, 0x47, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x57, 0x45, 0x42, 0x53, 0x4f, 0x43,
+	0x4b, 0x45, 0x54, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x47, 0x52, 0x50, 0x43, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x54,
+	0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x43, 0x50, 0x10, 0x04,
+	0x12, 0x13, 0x0a, 0x0f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x55, 0x44, 0x50, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x51, 0x4c, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x41,
+	0x52, 0x47, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x44, 0x49, 0x53, 0x10,
+	0x07, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x4d, 0x51, 0x54, 0x54, 0x10, 0x08, 0x42, 0xe4, 0x01, 0x0a, 0x19, 0x63, 0x6f, 0x6d, 0x2e,
+	0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x62, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x42, 0x0b, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x50, 0x01, 0x5a, 0x44, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62, 0x6c, 0x6f, 0x61,
+	0x64, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x72, 0x65, 0x73, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2f, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x2f, 0x76, 0x31,
+	0x3b, 0x62, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x42, 0x58,
+	0xaa, 0x02, 0x15, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x2e, 0x42, 0x6c,
+	0x6f, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x15, 0x43, 0x72, 0x65, 0x73, 0x70,
+	0x6c, 0x61, 0x6e, 0x65, 0x78, 0x5c, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5c, 0x56, 0x31,
+	0xe2, 0x02, 0x21, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65, 0x78, 0x5c, 0x42, 0x6c,
+	0x6f, 0x61, 0x64, 0x65, 0x72, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x17, 0x43, 0x72, 0x65, 0x73, 0x70, 0x6c, 0x61, 0x6e, 0x65,
+	0x78, 0x3a, 0x3a, 0x42, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -236,20 +832,36 @@ func file_cresplanex_bloader_v1_target_proto_rawDescGZIP() []byte {
 }
 
 var file_cresplanex_bloader_v1_target_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_cresplanex_bloader_v1_target_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_cresplanex_bloader_v1_target_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_cresplanex_bloader_v1_target_proto_goTypes = []any{
-	(TargetType)(0),        // 0: cresplanex.bloader.v1.TargetType
-	(*Target)(nil),         // 1: cresplanex.bloader.v1.Target
-	(*TargetHTTPData)(nil), // 2: cresplanex.bloader.v1.TargetHTTPData
+	(TargetType)(0),             // 0: cresplanex.bloader.v1.TargetType
+	(*Target)(nil),              // 1: cresplanex.bloader.v1.Target
+	(*TargetHTTPData)(nil),      // 2: cresplanex.bloader.v1.TargetHTTPData
+	(*TargetHTTP3Data)(nil),     // 3: cresplanex.bloader.v1.TargetHTTP3Data
+	(*TargetWebSocketData)(nil), // 4: cresplanex.bloader.v1.TargetWebSocketData
+	(*TargetGRPCData)(nil),      // 5: cresplanex.bloader.v1.TargetGRPCData
+	(*TargetTCPData)(nil),       // 6: cresplanex.bloader.v1.TargetTCPData
+	(*TargetUDPData)(nil),       // 7: cresplanex.bloader.v1.TargetUDPData
+	(*TargetSQLData)(nil),       // 8: cresplanex.bloader.v1.TargetSQLData
+	(*TargetRedisData)(nil),     // 9: cresplanex.bloader.v1.TargetRedisData
+	(*TargetMQTTData)(nil),      // 10: cresplanex.bloader.v1.TargetMQTTData
 }
 var file_cresplanex_bloader_v1_target_proto_depIdxs = []int32{
-	0, // 0: cresplanex.bloader.v1.Target.type:type_name -> cresplanex.bloader.v1.TargetType
-	2, // 1: cresplanex.bloader.v1.Target.http:type_name -> cresplanex.bloader.v1.TargetHTTPData
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: cresplanex.bloader.v1.Target.type:type_name -> cresplanex.bloader.v1.TargetType
+	2,  // 1: cresplanex.bloader.v1.Target.http:type_name -> cresplanex.bloader.v1.TargetHTTPData
+	4,  // 2: cresplanex.bloader.v1.Target.websocket:type_name -> cresplanex.bloader.v1.TargetWebSocketData
+	5,  // 3: cresplanex.bloader.v1.Target.grpc:type_name -> cresplanex.bloader.v1.TargetGRPCData
+	6,  // 4: cresplanex.bloader.v1.Target.tcp:type_name -> cresplanex.bloader.v1.TargetTCPData
+	7,  // 5: cresplanex.bloader.v1.Target.udp:type_name -> cresplanex.bloader.v1.TargetUDPData
+	8,  // 6: cresplanex.bloader.v1.Target.sql:type_name -> cresplanex.bloader.v1.TargetSQLData
+	9,  // 7: cresplanex.bloader.v1.Target.redis:type_name -> cresplanex.bloader.v1.TargetRedisData
+	10, // 8: cresplanex.bloader.v1.Target.mqtt:type_name -> cresplanex.bloader.v1.TargetMQTTData
+	3,  // 9: cresplanex.bloader.v1.TargetHTTPData.http3:type_name -> cresplanex.bloader.v1.TargetHTTP3Data
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_cresplanex_bloader_v1_target_proto_init() }
@@ -259,6 +871,13 @@ func file_cresplanex_bloader_v1_target_proto_init() {
 	}
 	file_cresplanex_bloader_v1_target_proto_msgTypes[0].OneofWrappers = []any{
 		(*Target_Http)(nil),
+		(*Target_Websocket)(nil),
+		(*Target_Grpc)(nil),
+		(*Target_Tcp)(nil),
+		(*Target_Udp)(nil),
+		(*Target_Sql)(nil),
+		(*Target_Redis)(nil),
+		(*Target_Mqtt)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -266,7 +885,7 @@ func file_cresplanex_bloader_v1_target_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cresplanex_bloader_v1_target_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
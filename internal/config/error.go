@@ -161,6 +161,14 @@ var (
 	ErrTargetTypeRequired = fmt.Errorf("target type is required")
 	// ErrTargetTypeInvalid is the error for the invalid target type.
 	ErrTargetTypeInvalid = fmt.Errorf("target type is invalid")
+	// ErrTargetProtocolInvalid is the error for the invalid target protocol.
+	ErrTargetProtocolInvalid = fmt.Errorf("target protocol is invalid")
+	// ErrTargetProtocolNotSupported is the error for the protocol set on the target other than HTTP.
+	ErrTargetProtocolNotSupported = fmt.Errorf("target protocol is only supported on the http target")
+	// ErrTargetHTTP3HandshakeTimeoutInvalid is the error for the invalid HTTP/3 handshake timeout.
+	ErrTargetHTTP3HandshakeTimeoutInvalid = fmt.Errorf("target http3 handshake timeout is invalid")
+	// ErrTargetHTTP3URLInvalid is the error for the HTTP/3 target URL which is not https.
+	ErrTargetHTTP3URLInvalid = fmt.Errorf("target URL must be https for the h3 protocol")
 	// ErrTargetValueEnvRequired is the error for the required target value env.
 	ErrTargetValueEnvRequired = fmt.Errorf("target value env is required")
 	// ErrTargetValueURLRequired is the error for the required target value URL.
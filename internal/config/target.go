@@ -1,6 +1,10 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 // TargetType represents the type of the target service
 type TargetType string
@@ -8,8 +12,68 @@ type TargetType string
 const (
 	// TargetTypeHTTP represents the HTTP target service
 	TargetTypeHTTP TargetType = "http"
+	// TargetTypeWebSocket represents the WebSocket target service
+	TargetTypeWebSocket TargetType = "websocket"
+	// TargetTypeGRPC represents the gRPC target service
+	TargetTypeGRPC TargetType = "grpc"
+	// TargetTypeTCP represents the raw TCP socket target service
+	TargetTypeTCP TargetType = "tcp"
+	// TargetTypeUDP represents the raw UDP socket target service
+	TargetTypeUDP TargetType = "udp"
+	// TargetTypeSQL represents the SQL database target service
+	TargetTypeSQL TargetType = "sql"
+	// TargetTypeRedis represents the Redis target service
+	TargetTypeRedis TargetType = "redis"
+	// TargetTypeMQTT represents the MQTT broker target service
+	TargetTypeMQTT TargetType = "mqtt"
+)
+
+// TargetProtocol represents the protocol of the HTTP target service
+type TargetProtocol string
+
+const (
+	// TargetProtocolAuto represents HTTP/1.1 or HTTP/2 negotiated over TCP
+	TargetProtocolAuto TargetProtocol = "auto"
+	// TargetProtocolH3 represents HTTP/3 over QUIC
+	TargetProtocolH3 TargetProtocol = "h3"
 )
 
+// TargetHTTP3Config represents the configuration for the HTTP/3 target service
+type TargetHTTP3Config struct {
+	Fallback           bool    `mapstructure:"fallback"`
+	ZeroRTT            bool    `mapstructure:"zero_rtt"`
+	HandshakeTimeout   *string `mapstructure:"handshake_timeout"`
+	InsecureSkipVerify bool    `mapstructure:"insecure_skip_verify"`
+}
+
+// ValidTargetHTTP3Config represents the configuration for the HTTP/3 target service
+type ValidTargetHTTP3Config struct {
+	// Fallback falls back to HTTP/1.1 or HTTP/2 over TCP when the server does not negotiate h3
+	Fallback bool
+	// ZeroRTT sends the GET and HEAD requests in 0-RTT when the session is resumed
+	ZeroRTT bool
+	// HandshakeTimeout is the timeout of the QUIC handshake, the default of QUIC is used if it is 0
+	HandshakeTimeout   time.Duration
+	InsecureSkipVerify bool
+}
+
+// Validate validates the HTTP/3 target configuration
+func (c TargetHTTP3Config) Validate() (ValidTargetHTTP3Config, error) {
+	valid := ValidTargetHTTP3Config{
+		Fallback:           c.Fallback,
+		ZeroRTT:            c.ZeroRTT,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+	if c.HandshakeTimeout != nil {
+		timeout, err := time.ParseDuration(*c.HandshakeTimeout)
+		if err != nil {
+			return ValidTargetHTTP3Config{}, fmt.Errorf("%w: %w", ErrTargetHTTP3HandshakeTimeoutInvalid, err)
+		}
+		valid.HandshakeTimeout = timeout
+	}
+	return valid, nil
+}
+
 // TargetRespectiveValueConfig represents the configuration for the target respective service value
 type TargetRespectiveValueConfig struct {
 	Env *string `mapstructure:"env"`
@@ -40,16 +104,20 @@ func (c TargetRespectiveValueConfig) Validate() (ValidTargetRespectiveValueConfi
 
 // TargetRespectiveConfig represents the configuration for the target respective service
 type TargetRespectiveConfig struct {
-	ID     *string                       `mapstructure:"id"`
-	Type   *string                       `mapstructure:"type"`
-	Values []TargetRespectiveValueConfig `mapstructure:"values"`
+	ID       *string                       `mapstructure:"id"`
+	Type     *string                       `mapstructure:"type"`
+	Protocol *string                       `mapstructure:"protocol"`
+	HTTP3    TargetHTTP3Config             `mapstructure:"http3"`
+	Values   []TargetRespectiveValueConfig `mapstructure:"values"`
 }
 
 // ValidTargetRespectiveConfig represents the configuration for the target respective service
 type ValidTargetRespectiveConfig struct {
-	ID     string
-	Type   TargetType
-	Values []ValidTargetRespectiveValueConfig
+	ID       string
+	Type     TargetType
+	Protocol TargetProtocol
+	HTTP3    ValidTargetHTTP3Config
+	Values   []ValidTargetRespectiveValueConfig
 }
 
 // TargetConfig represents the configuration for the target service
@@ -79,15 +147,50 @@ func (c TargetConfig) Validate() (ValidTargetConfig, error) {
 		switch *target.Type {
 		case string(TargetTypeHTTP):
 			validRespective.Type = TargetTypeHTTP
+		case string(TargetTypeWebSocket):
+			validRespective.Type = TargetTypeWebSocket
+		case string(TargetTypeGRPC):
+			validRespective.Type = TargetTypeGRPC
+		case string(TargetTypeTCP):
+			validRespective.Type = TargetTypeTCP
+		case string(TargetTypeUDP):
+			validRespective.Type = TargetTypeUDP
+		case string(TargetTypeSQL):
+			validRespective.Type = TargetTypeSQL
+		case string(TargetTypeRedis):
+			validRespective.Type = TargetTypeRedis
+		case string(TargetTypeMQTT):
+			validRespective.Type = TargetTypeMQTT
 		default:
 			return ValidTargetConfig{}, fmt.Errorf("target[%d].type: %w", i, ErrTargetTypeInvalid)
 		}
+		validRespective.Protocol = TargetProtocolAuto
+		if target.Protocol != nil {
+			if validRespective.Type != TargetTypeHTTP {
+				return ValidTargetConfig{}, fmt.Errorf("target[%d].protocol: %w", i, ErrTargetProtocolNotSupported)
+			}
+			switch *target.Protocol {
+			case string(TargetProtocolAuto):
+			case string(TargetProtocolH3):
+				validRespective.Protocol = TargetProtocolH3
+			default:
+				return ValidTargetConfig{}, fmt.Errorf("target[%d].protocol: %w", i, ErrTargetProtocolInvalid)
+			}
+		}
+		validHTTP3, err := target.HTTP3.Validate()
+		if err != nil {
+			return ValidTargetConfig{}, fmt.Errorf("target[%d].http3: %w", i, err)
+		}
+		validRespective.HTTP3 = validHTTP3
 		var validValues []ValidTargetRespectiveValueConfig
 		for j, value := range target.Values {
 			validValue, err := value.Validate()
 			if err != nil {
 				return ValidTargetConfig{}, fmt.Errorf("target[%d].values[%d]: %w", i, j, err)
 			}
+			if validRespective.Protocol == TargetProtocolH3 && !strings.HasPrefix(validValue.URL, "https://") {
+				return ValidTargetConfig{}, fmt.Errorf("target[%d].values[%d]: %w", i, j, ErrTargetHTTP3URLInvalid)
+			}
 			validValues = append(validValues, validValue)
 		}
 		validRespective.Values = validValues
@@ -58,4 +58,10 @@ func (e *DynamicEncrypter) Decrypt(ciphertextBase64 string) ([]byte, error) {
 	return plaintext, nil
 }
 
+// Key returns the key of the dynamic encrypter.
+func (e *DynamicEncrypter) Key() []byte {
+	return e.key
+}
+
 var _ Encrypter = (*DynamicEncrypter)(nil)
+var _ KeyHolder = (*DynamicEncrypter)(nil)
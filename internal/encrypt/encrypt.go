@@ -24,6 +24,12 @@ type Encrypter interface {
 	Decrypt(ciphertextBase64 string) ([]byte, error)
 }
 
+// KeyHolder is the interface for the encrypter which exposes its key,
+// it is used for signing such as JWT.
+type KeyHolder interface {
+	Key() []byte
+}
+
 // Container is the container for the encrypter.
 type Container map[string]Encrypter
 
@@ -32,4 +32,10 @@ func (e *StaticEncrypter) Decrypt(ciphertextBase64 string) ([]byte, error) {
 	return plaintext, nil
 }
 
+// Key returns the key of the static encrypter.
+func (e *StaticEncrypter) Key() []byte {
+	return e.key
+}
+
 var _ Encrypter = (*StaticEncrypter)(nil)
+var _ KeyHolder = (*StaticEncrypter)(nil)
@@ -0,0 +1,147 @@
+package grpcexec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DescriptorResolver resolves the descriptors by the full name
+type DescriptorResolver interface {
+	FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error)
+}
+
+// ParseProtoFiles parses the .proto files and returns the resolver of their descriptors.
+// The files and their imports are read by the accessor relative to the import paths,
+// and the well-known types are available without the files.
+func ParseProtoFiles(
+	ctx context.Context,
+	accessor func(path string) (io.ReadCloser, error),
+	importPaths []string,
+	files []string,
+) (DescriptorResolver, error) {
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.WithStandardImports(&protocompile.SourceResolver{
+			ImportPaths: importPaths,
+			Accessor:    accessor,
+		}),
+	}
+	compiled, err := compiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile proto files: %w", err)
+	}
+	return compiled.AsResolver(), nil
+}
+
+// ResolveByReflection fetches the descriptors of the service and its dependencies by the server reflection
+func ResolveByReflection(ctx context.Context, conn *grpc.ClientConn, service string) (DescriptorResolver, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open reflection stream: %w", err)
+	}
+
+	fileProtos := make(map[string]*descriptorpb.FileDescriptorProto)
+	var pending []string
+	fetch := func(req *rpb.ServerReflectionRequest) error {
+		if err := stream.Send(req); err != nil {
+			return fmt.Errorf("failed to send reflection request: %w", err)
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("failed to receive reflection response: %w", err)
+		}
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return fmt.Errorf("reflection error: %s", errResp.GetErrorMessage())
+		}
+		for _, b := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			var fd descriptorpb.FileDescriptorProto
+			if err := proto.Unmarshal(b, &fd); err != nil {
+				return fmt.Errorf("failed to unmarshal file descriptor: %w", err)
+			}
+			if _, ok := fileProtos[fd.GetName()]; ok {
+				continue
+			}
+			fileProtos[fd.GetName()] = &fd
+			pending = append(pending, fd.GetDependency()...)
+		}
+		return nil
+	}
+
+	if err := fetch(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}); err != nil {
+		return nil, err
+	}
+	// the dependencies which are not sent with the file are fetched by their names
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if _, ok := fileProtos[name]; ok {
+			continue
+		}
+		if err := fetch(&rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: name},
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, fmt.Errorf("failed to close reflection stream: %w", err)
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, fd := range fileProtos {
+		set.File = append(set.File, fd)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create files: %w", err)
+	}
+	return files, nil
+}
+
+// SplitMethod splits the method such as package.Service/Method into the service and the method name
+func SplitMethod(method string) (string, string, error) {
+	method = strings.TrimPrefix(method, "/")
+	i := strings.LastIndex(method, "/")
+	if i <= 0 || i == len(method)-1 {
+		return "", "", fmt.Errorf("method must be package.Service/Method: %s", method)
+	}
+	return method[:i], method[i+1:], nil
+}
+
+// FindMethod finds the descriptor of the method such as package.Service/Method,
+// the client streaming methods are not supported
+func FindMethod(resolver DescriptorResolver, method string) (protoreflect.MethodDescriptor, error) {
+	service, name, err := SplitMethod(method)
+	if err != nil {
+		return nil, err
+	}
+	d, err := resolver.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service %s: %w", service, err)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(name))
+	if md == nil {
+		return nil, fmt.Errorf("method %s not found in service %s", name, service)
+	}
+	if md.IsStreamingClient() {
+		return nil, fmt.Errorf("client streaming method is not supported: %s", md.FullName())
+	}
+	return md, nil
+}
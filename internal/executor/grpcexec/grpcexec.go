@@ -0,0 +1,177 @@
+// Package grpcexec provides the executor for the gRPC request, the messages are built dynamically from the descriptors.
+package grpcexec
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// TLSConfig represents the TLS configuration of the connection
+type TLSConfig struct {
+	Enabled            bool
+	InsecureSkipVerify bool
+	ServerName         string
+}
+
+// Dial creates the client connection to the target, the connection is established lazily on the first call
+func Dial(target string, tlsConfig TLSConfig) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if tlsConfig.Enabled {
+		creds = credentials.NewTLS(&tls.Config{
+			//nolint:gosec // the verification is skipped only when configured
+			InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
+			ServerName:         tlsConfig.ServerName,
+		})
+	}
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return conn, nil
+}
+
+// Request represents the gRPC request
+type Request struct {
+	Method   protoreflect.MethodDescriptor
+	Message  proto.Message
+	Metadata metadata.MD
+}
+
+// NewRequest creates the request whose message is unmarshaled from the JSON body
+func NewRequest(method protoreflect.MethodDescriptor, body []byte, md metadata.MD) (Request, error) {
+	if method.IsStreamingClient() {
+		return Request{}, fmt.Errorf("client streaming method is not supported: %s", method.FullName())
+	}
+	msg := dynamicpb.NewMessage(method.Input())
+	if len(body) > 0 {
+		if err := protojson.Unmarshal(body, msg); err != nil {
+			return Request{}, fmt.Errorf("failed to unmarshal request: %w", err)
+		}
+	}
+	return Request{
+		Method:   method,
+		Message:  msg,
+		Metadata: md,
+	}, nil
+}
+
+// FullMethod returns the full method name of the request, such as /package.Service/Method
+func (r Request) FullMethod() string {
+	return fmt.Sprintf("/%s/%s", r.Method.Parent().FullName(), r.Method.Name())
+}
+
+// Response represents the gRPC response.
+// Res is the message of the unary call or the slice of the messages of the server-streaming call.
+type Response struct {
+	Res          any
+	ByteResponse []byte
+	BodyBytes    int64
+	StatusCode   int
+}
+
+// Invoke invokes the unary or the server-streaming call.
+// The error of the call is returned as the status code, the error is returned only when the response
+// cannot be converted. If parse is false, the messages are not converted to the JSON values.
+func Invoke(ctx context.Context, conn *grpc.ClientConn, req Request, parse bool) (Response, error) {
+	ctx = metadata.NewOutgoingContext(ctx, req.Metadata)
+
+	var messages []proto.Message
+	var callErr error
+	if req.Method.IsStreamingServer() {
+		messages, callErr = invokeServerStream(ctx, conn, req)
+	} else {
+		resMsg := dynamicpb.NewMessage(req.Method.Output())
+		if callErr = conn.Invoke(ctx, req.FullMethod(), req.Message, resMsg); callErr == nil {
+			messages = append(messages, resMsg)
+		}
+	}
+
+	response := Response{
+		StatusCode: int(status.Code(callErr)),
+	}
+	values := make([]any, 0, len(messages))
+	for _, m := range messages {
+		response.BodyBytes += int64(proto.Size(m))
+		if !parse {
+			continue
+		}
+		b, err := protojson.Marshal(m)
+		if err != nil {
+			return response, fmt.Errorf("failed to marshal response: %w", err)
+		}
+		var v any
+		if err := json.Unmarshal(b, &v); err != nil {
+			return response, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		values = append(values, v)
+	}
+	if !parse {
+		return response, nil
+	}
+	if req.Method.IsStreamingServer() {
+		response.Res = values
+	} else if len(values) > 0 {
+		response.Res = values[0]
+	}
+	b, err := json.Marshal(response.Res)
+	if err != nil {
+		return response, fmt.Errorf("failed to marshal response: %w", err)
+	}
+	response.ByteResponse = b
+	return response, nil
+}
+
+// invokeServerStream invokes the server-streaming call and receives the messages until the end of the stream
+func invokeServerStream(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	req Request,
+) ([]proto.Message, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, req.FullMethod())
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(req.Message); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	var messages []proto.Message
+	for {
+		resMsg := dynamicpb.NewMessage(req.Method.Output())
+		if err := stream.RecvMsg(resMsg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return messages, nil
+			}
+			return messages, err
+		}
+		messages = append(messages, resMsg)
+	}
+}
+
+// MetadataFromHeader converts the HTTP header to the metadata, the keys are lower cased
+func MetadataFromHeader(header map[string][]string) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range header {
+		md.Append(strings.ToLower(key), values...)
+	}
+	return md
+}
@@ -0,0 +1,83 @@
+package grpcexec
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// ExecReq represents the request executor
+type ExecReq interface {
+	// CreateRequest creates the gRPC request for the count
+	CreateRequest(ctx context.Context, log logger.Logger, count int) (Request, error)
+}
+
+// MassRequestContent represents the request content.
+// The responses are sent as the HTTP responses with the gRPC status code, so that they are handled in the same way.
+type MassRequestContent[Req ExecReq] struct {
+	httpexec.MassDriver
+	Conn *grpc.ClientConn
+	Req  Req
+	// SkipParse skips the conversion of the response messages when nothing refers to them
+	SkipParse bool
+}
+
+// MassRequestExecute executes the request
+func (q MassRequestContent[Req]) MassRequestExecute(
+	ctx context.Context,
+	log logger.Logger,
+) error {
+	q.Run(ctx, log, q.send, nil)
+	return nil
+}
+
+// send sends the request of the count
+func (q MassRequestContent[Req]) send(ctx context.Context, log logger.Logger, count int) httpexec.ResponseContent {
+	req, err := q.Req.CreateRequest(ctx, log, count)
+	if err != nil {
+		log.Error(ctx, "failed to create request",
+			logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		return httpexec.ResponseContent{
+			Success:      false,
+			HasSystemErr: true,
+			Count:        count,
+		}
+	}
+
+	log.Debug(ctx, "sending request",
+		logger.Value("method", req.FullMethod()),
+		logger.Value("count", count),
+	)
+	startTime := time.Now()
+	res, err := Invoke(ctx, q.Conn, req, !q.SkipParse)
+	endTime := time.Now()
+	log.Debug(ctx, "received response",
+		logger.Value("method", req.FullMethod()),
+		logger.Value("count", count),
+		logger.Value("statusCode", res.StatusCode),
+	)
+	responseContent := httpexec.ResponseContent{
+		Success:      true,
+		Res:          res.Res,
+		ByteResponse: res.ByteResponse,
+		BodyBytes:    res.BodyBytes,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Count:        count,
+		ResponseTime: endTime.Sub(startTime).Milliseconds(),
+		StatusCode:   res.StatusCode,
+	}
+	if err != nil {
+		log.Error(ctx, "failed to invoke",
+			logger.Value("error", err), logger.Value("method", req.FullMethod()))
+		responseContent.Success = false
+		responseContent.ParseResHasErr = true
+	}
+	return responseContent
+}
+
+var _ httpexec.MassRequestExecutor = MassRequestContent[ExecReq]{}
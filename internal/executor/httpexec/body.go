@@ -0,0 +1,178 @@
+package httpexec
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrBodyTooLarge is returned when the response body exceeds the max body bytes
+var ErrBodyTooLarge = errors.New("response body exceeds max_body_bytes")
+
+// countingReader counts the bytes read from the underlying reader
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read reads from the underlying reader and counts the bytes
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ResponseBody represents the response body read by the executor
+type ResponseBody struct {
+	Res          any
+	ByteResponse []byte
+	BodyBytes    int64
+}
+
+// ReadResponseBody reads the response body by the response type.
+// The body is read up to maxBodyBytes if it is greater than 0, and ErrBodyTooLarge is returned when it exceeds.
+// If parse is false, the body is read but not parsed, the raw bytes are still returned for the buffering types.
+// The discard type only counts the bytes, and the stream type decodes the JSON directly from the body
+// without holding the raw bytes.
+func ReadResponseBody(body io.Reader, responseType ResponseType, maxBodyBytes int64, parse bool) (ResponseBody, error) {
+	return ReadResponseBodyFields(body, responseType, maxBodyBytes, parse, nil)
+}
+
+// ReadResponseBodyFields reads the response body as ReadResponseBody,
+// and the stream type decodes only the fields of the top level JSON object, the other values are skipped
+// without being decoded. The whole body is decoded if fields is nil,
+// and the body other than the JSON object is decoded to nil.
+func ReadResponseBodyFields(
+	body io.Reader,
+	responseType ResponseType,
+	maxBodyBytes int64,
+	parse bool,
+	fields []string,
+) (ResponseBody, error) {
+	cr := &countingReader{r: body}
+	var r io.Reader = cr
+	if maxBodyBytes > 0 {
+		r = io.LimitReader(cr, maxBodyBytes+1)
+	}
+	exceeded := func() bool {
+		return maxBodyBytes > 0 && cr.n > maxBodyBytes
+	}
+
+	var result ResponseBody
+	switch responseType {
+	case ResponseTypeDiscard:
+		_, err := io.Copy(io.Discard, r)
+		result.BodyBytes = cr.n
+		if err != nil {
+			return result, fmt.Errorf("failed to read response: %w", err)
+		}
+		if exceeded() {
+			return result, ErrBodyTooLarge
+		}
+		return result, nil
+	case ResponseTypeStream:
+		var err error
+		switch {
+		case parse && fields == nil:
+			err = json.NewDecoder(r).Decode(&result.Res)
+		case parse:
+			result.Res, err = decodeFields(json.NewDecoder(r), fields)
+		}
+		// the rest of the body is drained so that the connection can be reused
+		if _, drainErr := io.Copy(io.Discard, r); err == nil && drainErr != nil {
+			err = fmt.Errorf("failed to read response: %w", drainErr)
+		}
+		result.BodyBytes = cr.n
+		if exceeded() {
+			return result, ErrBodyTooLarge
+		}
+		return result, err
+	case ResponseTypeJSON, ResponseTypeXML, ResponseTypeYAML, ResponseTypeText, ResponseTypeHTML:
+	default:
+		return result, fmt.Errorf("invalid response type: %s", responseType)
+	}
+
+	responseByte, err := io.ReadAll(r)
+	result.BodyBytes = cr.n
+	if err != nil {
+		return result, fmt.Errorf("failed to read response: %w", err)
+	}
+	if exceeded() {
+		return result, ErrBodyTooLarge
+	}
+	result.ByteResponse = responseByte
+	if !parse {
+		return result, nil
+	}
+	switch responseType {
+	case ResponseTypeJSON:
+		err = json.Unmarshal(responseByte, &result.Res)
+	case ResponseTypeXML:
+		err = xml.Unmarshal(responseByte, &result.Res)
+	case ResponseTypeYAML:
+		err = yaml.Unmarshal(responseByte, &result.Res)
+	case ResponseTypeText, ResponseTypeHTML:
+		result.Res = string(responseByte)
+	}
+	return result, err
+}
+
+// decodeFields decodes only the fields of the top level JSON object
+func decodeFields(dec *json.Decoder, fields []string) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, nil
+	}
+	res := make(map[string]any, len(fields))
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		if !slices.Contains(fields, key) {
+			if err := skipValue(dec); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, err
+		}
+		res[key] = v
+	}
+	// the closing brace of the object
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// skipValue skips the next value by its tokens without building it
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -3,15 +3,10 @@ package httpexec
 
 import (
 	"context"
-	"encoding/json"
-	"encoding/xml"
 	"fmt"
-	"io"
 	"net/http"
 	"time"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/cresplanex/bloader/internal/logger"
 	"github.com/cresplanex/bloader/internal/utils"
 )
@@ -20,6 +15,11 @@ import (
 type RequestContent[Req ExecReq] struct {
 	Req          Req
 	ResponseType ResponseType
+	// MaxBodyBytes is the max bytes of the response body, it is unlimited if it is 0
+	MaxBodyBytes int64
+	// CookieJar returns the cookie jar of the request, the cookies are disabled if it is nil
+	CookieJar func() http.CookieJar
+	Transport TransportConfig
 }
 
 // RequestExecute executes the request
@@ -34,16 +34,26 @@ func (q RequestContent[Req]) RequestExecute(
 		return ResponseContent{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	transport := http.DefaultTransport
+	if q.Transport.HTTP3 {
+		transport = NewTransport(ctx, log, q.Transport, http.DefaultTransport.(*http.Transport)) //nolint:forcetypeassert
+		defer closeTransport(ctx, log, transport)
+	}
 	client := &http.Client{
 		Timeout: 10 * time.Minute,
 		Transport: &utils.DelayedTransport{
-			Transport: http.DefaultTransport,
+			Transport: transport,
 			// Delay:     2 * time.Second,
 		},
 	}
+	if q.CookieJar != nil {
+		client.Jar = q.CookieJar()
+	}
 
 	log.Debug(ctx, "sending request",
 		logger.Value("url", req.URL))
+	trace := &Trace{}
+	req = req.WithContext(WithTrace(req.Context(), trace))
 	startTime := time.Now()
 	resp, err := client.Do(req)
 	endTime := time.Now()
@@ -64,47 +74,23 @@ func (q RequestContent[Req]) RequestExecute(
 	defer resp.Body.Close()
 
 	statusCode := resp.StatusCode
-	var response any
-	responseByte, err := io.ReadAll(resp.Body)
+	stats := trace.Stats(ctx)
+	body, err := ReadResponseBody(resp.Body, q.ResponseType, q.MaxBodyBytes, true)
 	if err != nil {
 		log.Error(ctx, "failed to read response",
 			logger.Value("error", err), logger.Value("url", req.URL))
-
-		return ResponseContent{
-			Success:        false,
-			Res:            response,
-			StartTime:      startTime,
-			EndTime:        endTime,
-			ResponseTime:   endTime.Sub(startTime).Milliseconds(),
-			StatusCode:     statusCode,
-			ParseResHasErr: true,
-		}, nil
-	}
-	switch ResponseType(q.ResponseType) {
-	case ResponseTypeJSON:
-		err = json.Unmarshal(responseByte, &response)
-	case ResponseTypeXML:
-		err = xml.Unmarshal(responseByte, &response)
-	case ResponseTypeYAML:
-		err = yaml.Unmarshal(responseByte, &response)
-	case ResponseTypeText:
-		response = string(responseByte)
-	case ResponseTypeHTML:
-		response = string(responseByte)
-	default:
-		err = fmt.Errorf("invalid response type: %s", q.ResponseType)
-	}
-	if err != nil {
-		log.Error(ctx, "failed to parse response",
-			logger.Value("error", err), logger.Value("url", req.URL))
 		return ResponseContent{
 			Success:        false,
-			Res:            response,
-			ByteResponse:   responseByte,
+			Res:            body.Res,
+			ByteResponse:   body.ByteResponse,
+			BodyBytes:      body.BodyBytes,
 			StartTime:      startTime,
 			EndTime:        endTime,
 			ResponseTime:   endTime.Sub(startTime).Milliseconds(),
 			StatusCode:     statusCode,
+			ConnectTime:    stats.ConnectTime.Milliseconds(),
+			Protocol:       resp.Proto,
+			ZeroRTT:        stats.ZeroRTT,
 			ParseResHasErr: true,
 		}, nil
 	}
@@ -112,12 +98,16 @@ func (q RequestContent[Req]) RequestExecute(
 		logger.Value("url", req.URL))
 	return ResponseContent{
 		Success:      true,
-		ByteResponse: responseByte,
-		Res:          response,
+		ByteResponse: body.ByteResponse,
+		BodyBytes:    body.BodyBytes,
+		Res:          body.Res,
 		StartTime:    startTime,
 		EndTime:      endTime,
 		ResponseTime: endTime.Sub(startTime).Milliseconds(),
 		StatusCode:   statusCode,
+		ConnectTime:  stats.ConnectTime.Milliseconds(),
+		Protocol:     resp.Proto,
+		ZeroRTT:      stats.ZeroRTT,
 	}, nil
 }
 
@@ -2,15 +2,10 @@ package httpexec
 
 import (
 	"context"
-	"encoding/json"
-	"encoding/xml"
-	"fmt"
-	"io"
 	"net/http"
+	"sync"
 	"time"
 
-	"gopkg.in/yaml.v3"
-
 	"github.com/cresplanex/bloader/internal/logger"
 	"github.com/cresplanex/bloader/internal/utils"
 )
@@ -21,249 +16,241 @@ type RequestCountLimit struct {
 	Count   int
 }
 
-// MassRequestContent represents the request content
-type MassRequestContent[Req ExecReq] struct {
-	Req          Req
+// MassSendFunc sends the request of the count and returns its response
+type MassSendFunc func(ctx context.Context, log logger.Logger, count int) ResponseContent
+
+// MassDriver sends the requests by the interval until the count limit or the termination of the context,
+// and sends their responses to ResChan, so each protocol only supplies how the request is sent
+type MassDriver struct {
 	Interval     time.Duration
 	ResponseWait bool
 	ResChan      chan<- ResponseContent
 	CountLimit   RequestCountLimit
-	ResponseType ResponseType
 }
 
-// MassRequestExecute executes the request
-func (q MassRequestContent[Req]) MassRequestExecute(
-	ctx context.Context,
-	log logger.Logger,
-) error {
+// Run runs the driver in the background,
+// release is called after the requests in flight when the driver terminates, unless it is nil
+func (d MassDriver) Run(ctx context.Context, log logger.Logger, send MassSendFunc, release func()) {
 	go func() {
-		// defer close(q.ResChan) // TODO: close channel
-		waitForResponse := q.ResponseWait
+		var inFlight sync.WaitGroup
+		if release != nil {
+			defer func() {
+				go func() {
+					inFlight.Wait()
+					release()
+				}()
+			}()
+		}
 		var count int
 		chanForWait := make(chan struct{})
-		// defer close(chanForWait) // TODO: close channel
 
-		client := &http.Client{
-			Timeout: 10 * time.Minute,
-			Transport: &utils.DelayedTransport{
-				Transport: &http.Transport{
-					MaxIdleConns:        200,
-					MaxIdleConnsPerHost: 180,
-					IdleConnTimeout:     5 * time.Minute,
-				},
-				// Delay:     2 * time.Second,
-			},
-		}
-		ticker := time.NewTicker(q.Interval)
+		ticker := time.NewTicker(d.Interval)
 		defer ticker.Stop()
 
+		respond := func(res ResponseContent) {
+			select {
+			case <-ctx.Done():
+				log.Info(ctx, "request processing is interrupted due to context termination",
+					logger.Value("on", "MassDriver.Run"))
+			case d.ResChan <- res:
+			}
+		}
+
 		for {
 			select {
 			case <-ctx.Done():
 				log.Info(ctx, "request processing is interrupted due to context termination",
-					logger.Value("on", "RequestContent.QueryExecute"))
+					logger.Value("on", "MassDriver.Run"))
 				return
 			case <-ticker.C:
-				if count > 0 && waitForResponse {
+				if count > 0 && d.ResponseWait {
 					select {
 					case <-ctx.Done():
 						log.Info(ctx, "request processing is interrupted due to context termination",
-							logger.Value("on", "RequestContent.QueryExecute"))
+							logger.Value("on", "MassDriver.Run"))
 						return
 					case <-chanForWait:
 					}
 				}
 
-				if q.CountLimit.Enabled && count >= q.CountLimit.Count {
+				if d.CountLimit.Enabled && count >= d.CountLimit.Count {
 					log.Info(ctx, "request processing is interrupted due to count limit",
-						logger.Value("on", "RequestContent.QueryExecute"))
-					select {
-					case <-ctx.Done():
-						log.Info(ctx, "request processing is interrupted due to context termination",
-							logger.Value("on", "RequestContent.QueryExecute"))
-						return
-					case q.ResChan <- ResponseContent{
+						logger.Value("on", "MassDriver.Run"))
+					respond(ResponseContent{
 						WithCountLimit: true,
-					}: // do nothing
-					}
-
+					})
 					return
 				}
 
+				inFlight.Add(1)
 				go func(countInternal int) {
+					defer inFlight.Done()
 					defer func() {
-						if waitForResponse {
-							chanForWait <- struct{}{}
+						if d.ResponseWait {
+							// nobody waits for the response once the driver is terminated
+							select {
+							case <-ctx.Done():
+							case chanForWait <- struct{}{}:
+							}
 						}
 					}()
+					respond(send(ctx, log, countInternal))
+				}(count)
 
-					req, err := q.Req.CreateRequest(ctx, log, countInternal)
-					if err != nil {
-						log.Error(ctx, "failed to create request",
-							logger.Value("error", err), logger.Value("on", "RequestContent.QueryExecute"))
-						select {
-						case <-ctx.Done():
-							log.Info(ctx, "request processing is interrupted due to context termination",
-								logger.Value("on", "RequestContent.QueryExecute"))
-							return
-						case q.ResChan <- ResponseContent{
-							Success:      false,
-							HasSystemErr: true,
-							Count:        countInternal,
-						}: // do nothing
-						}
-
-						return
-					}
-
-					log.Debug(ctx, "sending request",
+				count++
+			}
+		}
+	}()
+}
 
-						logger.Value("url", req.URL),
-						logger.Value("count", countInternal),
-					)
-					startTime := time.Now()
-					resp, err := client.Do(req)
-					endTime := time.Now()
-					log.Debug(ctx, "received response",
+// MassRequestContent represents the request content
+type MassRequestContent[Req ExecReq] struct {
+	MassDriver
+	Req          Req
+	ResponseType ResponseType
+	// MaxBodyBytes is the max bytes of each response body, it is unlimited if it is 0
+	MaxBodyBytes int64
+	// SkipParse skips the parse of the response body when nothing refers to it
+	SkipParse bool
+	// BodyFields is the top level fields decoded from the stream body, the whole body is decoded if it is nil
+	BodyFields []string
+	// CookieJar returns the cookie jar of each request, the cookies are disabled if it is nil
+	CookieJar func() http.CookieJar
+	Transport TransportConfig
+}
 
-						logger.Value("url", req.URL),
-						logger.Value("count", countInternal),
-					)
-					if err != nil {
-						log.Error(ctx, "response error",
-							logger.Value("error", err), logger.Value("url", req.URL))
-						select {
-						case <-ctx.Done():
-							log.Info(ctx, "request processing is interrupted due to context termination",
-								logger.Value("url", req.URL))
-							return
-						case q.ResChan <- ResponseContent{
-							Success:      false,
-							StartTime:    startTime,
-							EndTime:      endTime,
-							Count:        countInternal,
-							ResponseTime: endTime.Sub(startTime).Milliseconds(),
-							HasSystemErr: true,
-						}: // do nothing
-							log.Error(ctx, "response error",
-								logger.Value("startTime", startTime),
-								logger.Value("endTime", endTime),
-								logger.Value("count", countInternal),
-								logger.Value("responseTime", endTime.Sub(startTime).Milliseconds()),
-								logger.Value("err", err),
-							)
-						}
+// MassRequestExecute executes the request
+func (q MassRequestContent[Req]) MassRequestExecute(
+	ctx context.Context,
+	log logger.Logger,
+) error {
+	transport := NewTransport(ctx, log, q.Transport, &http.Transport{
+		MaxIdleConns:        200,
+		MaxIdleConnsPerHost: 180,
+		IdleConnTimeout:     5 * time.Minute,
+	})
+	client := &http.Client{
+		Timeout: 10 * time.Minute,
+		Transport: &utils.DelayedTransport{
+			Transport: transport,
+			// Delay:     2 * time.Second,
+		},
+	}
+	q.Run(ctx, log, func(ctx context.Context, log logger.Logger, count int) ResponseContent {
+		res := q.send(ctx, log, client, count)
+		if q.Transport.HTTP3 {
+			res.Columns = res.HTTP3Columns()
+		}
+		return res
+	}, func() {
+		// the transport is closed after the requests in flight
+		closeTransport(ctx, log, transport)
+	})
 
-						return
-					}
-					defer resp.Body.Close()
+	return nil
+}
 
-					statusCode := resp.StatusCode
-					var response any
-					responseByte, err := io.ReadAll(resp.Body)
-					if err != nil {
-						log.Error(ctx, "failed to read response",
-							logger.Value("error", err), logger.Value("url", req.URL))
-						select {
-						case <-ctx.Done():
-							log.Info(ctx, "request processing is interrupted due to context termination",
-								logger.Value("url", req.URL))
-							return
-						case q.ResChan <- ResponseContent{
-							Success:        false,
-							Res:            response,
-							StartTime:      startTime,
-							EndTime:        endTime,
-							Count:          countInternal,
-							ResponseTime:   endTime.Sub(startTime).Milliseconds(),
-							StatusCode:     statusCode,
-							ParseResHasErr: true,
-						}: // do nothing
-							log.Error(ctx, "failed to read response",
-								logger.Value("responseByte", string(responseByte)),
-								logger.Value("response", response),
-								logger.Value("startTime", startTime),
-								logger.Value("endTime", endTime),
-								logger.Value("count", countInternal),
-								logger.Value("statusCode", statusCode),
-								logger.Value("err", err),
-							)
-						}
-						return
-					}
-					switch ResponseType(q.ResponseType) {
-					case ResponseTypeJSON:
-						err = json.Unmarshal(responseByte, &response)
-					case ResponseTypeXML:
-						err = xml.Unmarshal(responseByte, &response)
-					case ResponseTypeYAML:
-						err = yaml.Unmarshal(responseByte, &response)
-					case ResponseTypeText:
-						response = string(responseByte)
-					case ResponseTypeHTML:
-						response = string(responseByte)
-					default:
-						err = fmt.Errorf("invalid response type: %s", q.ResponseType)
-					}
-					if err != nil {
-						log.Error(ctx, "failed to parse response",
-							logger.Value("error", err), logger.Value("url", req.URL))
-						select {
-						case <-ctx.Done():
-							log.Info(ctx, "request processing is interrupted due to context termination",
-								logger.Value("url", req.URL))
-							return
-						case q.ResChan <- ResponseContent{
-							Success:        false,
-							Res:            response,
-							ByteResponse:   responseByte,
-							StartTime:      startTime,
-							EndTime:        endTime,
-							Count:          countInternal,
-							ResponseTime:   endTime.Sub(startTime).Milliseconds(),
-							StatusCode:     statusCode,
-							ParseResHasErr: true,
-						}: // do nothing
-							log.Error(ctx, "failed to parse response",
-								logger.Value("responseByte", string(responseByte)),
-								logger.Value("response", response),
-								logger.Value("startTime", startTime),
-								logger.Value("endTime", endTime),
-								logger.Value("count", countInternal),
-								logger.Value("statusCode", statusCode),
-								logger.Value("err", err),
-							)
-						}
-						return
-					}
+// send sends the request of the count with the client
+func (q MassRequestContent[Req]) send(
+	ctx context.Context,
+	log logger.Logger,
+	client *http.Client,
+	count int,
+) ResponseContent {
+	req, err := q.Req.CreateRequest(ctx, log, count)
+	if err != nil {
+		log.Error(ctx, "failed to create request",
+			logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		return ResponseContent{
+			Success:      false,
+			HasSystemErr: true,
+			Count:        count,
+		}
+	}
 
-					log.Debug(ctx, "response OK",
-						logger.Value("url", req.URL))
-					responseContent := ResponseContent{
-						Success:      true,
-						ByteResponse: responseByte,
-						Res:          response,
-						StartTime:    startTime,
-						EndTime:      endTime,
-						Count:        countInternal,
-						ResponseTime: endTime.Sub(startTime).Milliseconds(),
-						StatusCode:   statusCode,
-					}
-					select {
-					case q.ResChan <- responseContent:
-					case <-ctx.Done():
-						log.Info(ctx, "request processing is interrupted due to context termination",
-							logger.Value("url", req.URL))
-						return
-					}
-				}(count)
+	log.Debug(ctx, "sending request",
+		logger.Value("url", req.URL),
+		logger.Value("count", count),
+	)
+	if q.CookieJar != nil {
+		// the client is copied so that the jar of the request does not race with the others
+		client = &http.Client{
+			Timeout:   client.Timeout,
+			Transport: client.Transport,
+			Jar:       q.CookieJar(),
+		}
+	}
+	trace := &Trace{}
+	req = req.WithContext(WithTrace(req.Context(), trace))
+	startTime := time.Now()
+	resp, err := client.Do(req)
+	endTime := time.Now()
+	log.Debug(ctx, "received response",
+		logger.Value("url", req.URL),
+		logger.Value("count", count),
+	)
+	if err != nil {
+		log.Error(ctx, "response error",
+			logger.Value("error", err), logger.Value("url", req.URL),
+			logger.Value("count", count),
+			logger.Value("responseTime", endTime.Sub(startTime).Milliseconds()),
+		)
+		return ResponseContent{
+			Success:      false,
+			StartTime:    startTime,
+			EndTime:      endTime,
+			Count:        count,
+			ResponseTime: endTime.Sub(startTime).Milliseconds(),
+			HasSystemErr: true,
+		}
+	}
+	defer resp.Body.Close()
 
-				count++
-			}
+	statusCode := resp.StatusCode
+	stats := trace.Stats(ctx)
+	body, err := ReadResponseBodyFields(
+		resp.Body, q.ResponseType, q.MaxBodyBytes, !q.SkipParse, q.BodyFields,
+	)
+	if err != nil {
+		log.Error(ctx, "failed to read response",
+			logger.Value("error", err), logger.Value("url", req.URL),
+			logger.Value("bodyBytes", body.BodyBytes),
+			logger.Value("count", count),
+			logger.Value("statusCode", statusCode),
+		)
+		return ResponseContent{
+			Success:        false,
+			Res:            body.Res,
+			ByteResponse:   body.ByteResponse,
+			BodyBytes:      body.BodyBytes,
+			StartTime:      startTime,
+			EndTime:        endTime,
+			Count:          count,
+			ResponseTime:   endTime.Sub(startTime).Milliseconds(),
+			StatusCode:     statusCode,
+			ConnectTime:    stats.ConnectTime.Milliseconds(),
+			Protocol:       resp.Proto,
+			ZeroRTT:        stats.ZeroRTT,
+			ParseResHasErr: true,
 		}
-	}()
+	}
 
-	return nil
+	log.Debug(ctx, "response OK",
+		logger.Value("url", req.URL))
+	return ResponseContent{
+		Success:      true,
+		ByteResponse: body.ByteResponse,
+		BodyBytes:    body.BodyBytes,
+		Res:          body.Res,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Count:        count,
+		ResponseTime: endTime.Sub(startTime).Milliseconds(),
+		StatusCode:   statusCode,
+		ConnectTime:  stats.ConnectTime.Milliseconds(),
+		Protocol:     resp.Proto,
+		ZeroRTT:      stats.ZeroRTT,
+	}
 }
 
 var _ MassRequestExecutor = MassRequestContent[ExecReq]{}
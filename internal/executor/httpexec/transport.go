@@ -0,0 +1,281 @@
+package httpexec
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// TransportConfig represents the configuration of the transport of the HTTP requests
+type TransportConfig struct {
+	// HTTP3 sends the requests in HTTP/3 over QUIC, otherwise HTTP/1.1 or HTTP/2 is negotiated over TCP
+	HTTP3 bool
+	// Fallback sends the requests over TCP once the server does not negotiate h3, otherwise they fail
+	Fallback bool
+	// ZeroRTT sends the GET and HEAD requests in 0-RTT when the session is resumed
+	ZeroRTT            bool
+	HandshakeTimeout   time.Duration
+	InsecureSkipVerify bool
+}
+
+// NegotiationError represents the error that the QUIC connection for h3 is not established
+type NegotiationError struct {
+	Err error
+}
+
+// Error returns the error message
+func (e *NegotiationError) Error() string {
+	return fmt.Sprintf("server did not negotiate h3: %v", e.Err)
+}
+
+// Unwrap returns the underlying error
+func (e *NegotiationError) Unwrap() error {
+	return e.Err
+}
+
+// Trace records how the connection of the request is established,
+// only the request which opens the new connection records its handshake
+type Trace struct {
+	mu      sync.Mutex
+	conn    quic.EarlyConnection
+	done    chan struct{}
+	connect time.Duration
+}
+
+// TraceStats represents the recorded values of the Trace
+type TraceStats struct {
+	// ConnectTime is the time to complete the handshake of the new connection
+	ConnectTime time.Duration
+	// ZeroRTT reports whether the new connection sent the request in 0-RTT
+	ZeroRTT bool
+}
+
+type traceKey struct{}
+
+// WithTrace returns the context which the transport records the trace into
+func WithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey{}, trace)
+}
+
+// traceFromContext returns the trace of the context, it is nil if not set
+func traceFromContext(ctx context.Context) *Trace {
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+	return trace
+}
+
+// dialed records the new connection, the handshake is completed later if the connection is in 0-RTT
+func (t *Trace) dialed(conn quic.EarlyConnection, start time.Time) {
+	done := make(chan struct{})
+	t.mu.Lock()
+	t.conn = conn
+	t.done = done
+	t.mu.Unlock()
+	go func() {
+		defer close(done)
+		select {
+		case <-conn.HandshakeComplete():
+			t.mu.Lock()
+			t.connect = time.Since(start)
+			t.mu.Unlock()
+		case <-conn.Context().Done():
+		}
+	}()
+}
+
+// Stats returns the recorded values, it waits for the handshake of the new connection to complete
+func (t *Trace) Stats(ctx context.Context) TraceStats {
+	t.mu.Lock()
+	conn, done := t.conn, t.done
+	t.mu.Unlock()
+	if conn == nil {
+		return TraceStats{}
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return TraceStats{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return TraceStats{
+		ConnectTime: t.connect,
+		ZeroRTT:     conn.ConnectionState().Used0RTT,
+	}
+}
+
+// sessionCaches keeps the TLS sessions of each address across the transports,
+// so that the new connections resume them in 0-RTT
+var sessionCaches sync.Map
+
+// sessionCache returns the TLS session cache of the address
+func sessionCache(addr string) tls.ClientSessionCache {
+	cache, ok := sessionCaches.Load(addr)
+	if !ok {
+		cache, _ = sessionCaches.LoadOrStore(addr, tls.NewLRUClientSessionCache(0))
+	}
+	return cache.(tls.ClientSessionCache) //nolint:forcetypeassert
+}
+
+// earlyConnection represents the connection which may be in 0-RTT.
+// quic-go keeps the requests waiting for the handshake when the connection is closed before it completes,
+// so that they are released by the close.
+type earlyConnection struct {
+	quic.EarlyConnection
+	handshakeDone chan struct{}
+}
+
+// HandshakeComplete returns the channel closed when the handshake completes or the connection is closed
+func (c *earlyConnection) HandshakeComplete() <-chan struct{} {
+	return c.handshakeDone
+}
+
+// http3Transport represents the HTTP/3 transport which falls back to the TCP transport
+type http3Transport struct {
+	ctx      context.Context
+	log      logger.Logger
+	config   TransportConfig
+	h3       *http3.Transport
+	fallback http.RoundTripper
+	// unsupported is set once the server does not negotiate h3, then the requests skip the QUIC dial
+	unsupported atomic.Bool
+}
+
+// NewTransport creates the transport by the configuration, the base is used as it is unless HTTP3 is set
+func NewTransport(ctx context.Context, log logger.Logger, config TransportConfig, base *http.Transport) http.RoundTripper {
+	if !config.HTTP3 {
+		return base
+	}
+	fallback := base.Clone()
+	fallback.TLSClientConfig = &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify} //nolint:gosec
+	fallback.ForceAttemptHTTP2 = true
+	t := &http3Transport{
+		ctx:      ctx,
+		log:      log,
+		config:   config,
+		fallback: fallback,
+	}
+	t.h3 = &http3.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}, //nolint:gosec
+		QUICConfig: &quic.Config{
+			HandshakeIdleTimeout: config.HandshakeTimeout,
+		},
+		Dial: t.dial,
+	}
+	return t
+}
+
+// dial opens the QUIC connection, it returns before the handshake completes if the session is resumed in 0-RTT
+func (t *http3Transport) dial(
+	ctx context.Context,
+	addr string,
+	tlsCfg *tls.Config,
+	cfg *quic.Config,
+) (quic.EarlyConnection, error) {
+	if t.config.ZeroRTT {
+		tlsCfg = tlsCfg.Clone()
+		tlsCfg.ClientSessionCache = sessionCache(addr)
+	}
+	start := time.Now()
+	conn, err := quic.DialAddrEarly(ctx, addr, tlsCfg, cfg)
+	if err != nil {
+		return nil, &NegotiationError{Err: err}
+	}
+	if trace := traceFromContext(ctx); trace != nil {
+		trace.dialed(conn, start)
+	}
+	early := &earlyConnection{EarlyConnection: conn, handshakeDone: make(chan struct{})}
+	go func() {
+		defer close(early.handshakeDone)
+		select {
+		case <-conn.HandshakeComplete():
+		case <-conn.Context().Done():
+			t.negotiationFailed(addr, context.Cause(conn.Context()))
+		}
+	}()
+	return early, nil
+}
+
+// negotiationFailed switches the requests to the fallback, it is no-op if the fallback is disabled
+func (t *http3Transport) negotiationFailed(host string, err error) {
+	if !t.config.Fallback || t.unsupported.Swap(true) {
+		return
+	}
+	t.log.Warn(t.ctx, "falling back to TCP since the server did not negotiate h3",
+		logger.Value("host", host), logger.Value("error", err))
+}
+
+// RoundTrip executes a single HTTP transaction
+func (t *http3Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.unsupported.Load() {
+		return t.fallback.RoundTrip(req)
+	}
+	h3Req := req
+	if t.config.ZeroRTT {
+		switch req.Method {
+		case http.MethodGet:
+			h3Req = req.Clone(req.Context())
+			h3Req.Method = http3.MethodGet0RTT
+		case http.MethodHead:
+			h3Req = req.Clone(req.Context())
+			h3Req.Method = http3.MethodHead0RTT
+		}
+	}
+	res, err := t.h3.RoundTrip(h3Req)
+	if err == nil {
+		res.Request = req
+		return res, nil
+	}
+	var negotiationErr *NegotiationError
+	if errors.As(err, &negotiationErr) {
+		t.negotiationFailed(req.URL.Host, err)
+	}
+	// the connection in 0-RTT also marks the fallback when its handshake fails
+	if !t.unsupported.Load() || req.Context().Err() != nil {
+		return nil, err
+	}
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get body: %w", err)
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	return t.fallback.RoundTrip(req)
+}
+
+// Close closes the QUIC connections and the idle TCP connections
+func (t *http3Transport) Close() error {
+	if f, ok := t.fallback.(interface{ CloseIdleConnections() }); ok {
+		f.CloseIdleConnections()
+	}
+	if err := t.h3.Close(); err != nil {
+		return fmt.Errorf("failed to close http3 transport: %w", err)
+	}
+	return nil
+}
+
+var _ io.Closer = (*http3Transport)(nil)
+
+// closeTransport closes the transport if it holds the connections to close
+func closeTransport(ctx context.Context, log logger.Logger, transport http.RoundTripper) {
+	closer, ok := transport.(io.Closer)
+	if !ok {
+		return
+	}
+	if err := closer.Close(); err != nil {
+		log.Debug(ctx, "failed to close transport",
+			logger.Value("error", err))
+	}
+}
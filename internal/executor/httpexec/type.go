@@ -16,12 +16,26 @@ type ResponseContent struct {
 	Res             any
 	Count           int
 	ByteResponse    []byte
+	BodyBytes       int64
 	ResponseTime    int64
+	ConnectTime     int64
+	Protocol        string
+	ZeroRTT         bool
 	StatusCode      int
 	ReqCreateHasErr bool
 	ParseResHasErr  bool
 	HasSystemErr    bool
 	WithCountLimit  bool
+	// Columns is the values of the extra columns of the protocol, in the order of its column header
+	Columns []string
+}
+
+// HTTP3ColumnHeader is the header of the extra columns of the responses over the HTTP/3 transport
+var HTTP3ColumnHeader = []string{"Protocol", "ConnectTime", "ZeroRTT"}
+
+// HTTP3Columns returns the values of the columns of HTTP3ColumnHeader
+func (r ResponseContent) HTTP3Columns() []string {
+	return []string{r.Protocol, strconv.FormatInt(r.ConnectTime, 10), strconv.FormatBool(r.ZeroRTT)}
 }
 
 // ToWriteHTTPData converts the ResponseContent to WriteHTTPData
@@ -72,6 +86,10 @@ const (
 	ResponseTypeText ResponseType = "text"
 	// ResponseTypeHTML represents the HTML response type
 	ResponseTypeHTML ResponseType = "html"
+	// ResponseTypeDiscard represents the response body is discarded, only the bytes are counted
+	ResponseTypeDiscard ResponseType = "discard"
+	// ResponseTypeStream represents the JSON response body is decoded as it streams, without holding the raw bytes
+	ResponseTypeStream ResponseType = "stream"
 )
 
 // RequestExecutor represents the request executor
@@ -0,0 +1,121 @@
+package mqttexec
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	// CorrelationTTL is how long the publish times are kept for the subscribers of the same scope
+	CorrelationTTL = 5 * time.Minute
+	// CorrelationLimit is the number of the publish times kept in the scope
+	CorrelationLimit = 100000
+)
+
+// PublishTimeField is the field of the JSON object payload in which the publish time is embedded,
+// it is written in RFC3339 with the nanoseconds
+const PublishTimeField = "_publish_time"
+
+// correlationEntry is the published message in the order of publishing
+type correlationEntry struct {
+	id          string
+	publishTime time.Time
+}
+
+// Correlations holds the publish times by the correlation ID, it is shared by the publishers
+// and the subscribers of the same runner, so the messages published by the other runners or the other slaves
+// are not matched with it, and their publish time is read from the message.
+// The entries older than CorrelationTTL are dropped,
+// and the oldest ones are dropped when more than CorrelationLimit entries are held.
+type Correlations struct {
+	mu        sync.Mutex
+	published map[string]time.Time
+	order     []correlationEntry
+}
+
+// NewCorrelations creates a new Correlations
+func NewCorrelations() *Correlations {
+	return &Correlations{
+		published: make(map[string]time.Time),
+	}
+}
+
+// Record records the publish time of the correlation ID, and drops the expired and the overflowed entries
+func (c *Correlations) Record(id string, publishTime time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.published[id] = publishTime
+	c.order = append(c.order, correlationEntry{id: id, publishTime: publishTime})
+	for len(c.order) > 0 {
+		oldest := c.order[0]
+		if t, ok := c.published[oldest.id]; ok && t.Equal(oldest.publishTime) {
+			if len(c.published) <= CorrelationLimit && publishTime.Sub(oldest.publishTime) <= CorrelationTTL {
+				break
+			}
+			delete(c.published, oldest.id)
+		}
+		c.order = c.order[1:]
+	}
+	// the republished IDs are left in the order, so it is compacted when it grows
+	if len(c.order) > 2*CorrelationLimit {
+		order := make([]correlationEntry, 0, len(c.published))
+		for _, e := range c.order {
+			if t, ok := c.published[e.id]; ok && t.Equal(e.publishTime) {
+				order = append(order, e)
+			}
+		}
+		c.order = order
+	}
+}
+
+// Lookup returns the publish time of the correlation ID.
+// The entry is kept, because the message may be received by the multiple subscribers.
+func (c *Correlations) Lookup(id string) (time.Time, bool) {
+	if c == nil {
+		return time.Time{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t, ok := c.published[id]
+	return t, ok
+}
+
+// EmbedPublishTime embeds the publish time in the PublishTimeField of the JSON object payload,
+// the payload other than the JSON object is returned as it is
+func EmbedPublishTime(payload []byte, publishTime time.Time) []byte {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &object); err != nil || object == nil {
+		return payload
+	}
+	ts, err := json.Marshal(publishTime.Format(time.RFC3339Nano))
+	if err != nil {
+		return payload
+	}
+	object[PublishTimeField] = ts
+	embedded, err := json.Marshal(object)
+	if err != nil {
+		return payload
+	}
+	return embedded
+}
+
+// EmbeddedPublishTime returns the publish time embedded in the decoded message by EmbedPublishTime
+func EmbeddedPublishTime(message any) (time.Time, bool) {
+	object, ok := message.(map[string]any)
+	if !ok {
+		return time.Time{}, false
+	}
+	str, ok := object[PublishTimeField].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
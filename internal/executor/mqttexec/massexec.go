@@ -0,0 +1,125 @@
+package mqttexec
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// PublishMessage represents the message to publish for the count
+type PublishMessage struct {
+	ClientID string
+	Topic    string
+	QoS      QoS
+	Retain   bool
+	Payload  []byte
+	// CorrelationID is recorded with the publish time for the subscribers if it is not empty
+	CorrelationID string
+	// EmbedPublishTime embeds the publish time in the payload, see EmbedPublishTime
+	EmbedPublishTime bool
+}
+
+// ExecReq represents the request executor
+type ExecReq interface {
+	// CreateMessage creates the message to publish for the count
+	CreateMessage(ctx context.Context, log logger.Logger, count int) (PublishMessage, error)
+}
+
+// PublishValue converts the published message to the value referred by the extractors and the matchers
+func PublishValue(msg PublishMessage) map[string]any {
+	return map[string]any{
+		"client_id":      msg.ClientID,
+		"topic":          msg.Topic,
+		"correlation_id": msg.CorrelationID,
+		"payload":        string(msg.Payload),
+	}
+}
+
+// ColumnHeader is the header of the extra columns of the published messages
+var ColumnHeader = []string{"ClientID", "Topic", "CorrelationID", "ConnectTime"}
+
+// Columns returns the values of the columns of ColumnHeader
+func (msg PublishMessage) Columns(connectTime time.Duration) []string {
+	return []string{msg.ClientID, msg.Topic, msg.CorrelationID, strconv.FormatInt(connectTime.Milliseconds(), 10)}
+}
+
+// MassRequestContent represents the request content.
+// The acknowledgements of the messages are sent as the HTTP responses, so that they are handled in the same way.
+// The publish times of the correlation IDs are recorded in Correlations, which is shared with the subscribers.
+type MassRequestContent[Req ExecReq] struct {
+	httpexec.MassDriver
+	Pool         *Pool
+	Req          Req
+	Correlations *Correlations
+}
+
+// MassRequestExecute executes the request
+func (q MassRequestContent[Req]) MassRequestExecute(
+	ctx context.Context,
+	log logger.Logger,
+) error {
+	// the pool is shared by the publishers, so it is closed by the runner
+	q.Run(ctx, log, q.send, nil)
+	return nil
+}
+
+// send publishes the message of the count
+func (q MassRequestContent[Req]) send(ctx context.Context, log logger.Logger, count int) httpexec.ResponseContent {
+	msg, err := q.Req.CreateMessage(ctx, log, count)
+	if err != nil {
+		log.Error(ctx, "failed to create message",
+			logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		return httpexec.ResponseContent{
+			Success:         false,
+			ReqCreateHasErr: true,
+			Count:           count,
+			Columns:         PublishMessage{}.Columns(0),
+		}
+	}
+
+	responseContent := httpexec.ResponseContent{
+		Count: count,
+		Res:   PublishValue(msg),
+	}
+	client, connectTime, err := q.Pool.Get(ctx, msg.ClientID)
+	responseContent.ConnectTime = connectTime.Milliseconds()
+	responseContent.Columns = msg.Columns(connectTime)
+	if err != nil {
+		log.Error(ctx, "failed to connect",
+			logger.Value("error", err), logger.Value("client_id", msg.ClientID), logger.Value("count", count))
+		now := time.Now()
+		responseContent.StartTime = now
+		responseContent.EndTime = now
+		responseContent.HasSystemErr = true
+		return responseContent
+	}
+
+	log.Debug(ctx, "publishing message",
+		logger.Value("topic", msg.Topic),
+		logger.Value("count", count),
+	)
+	publishTime := time.Now()
+	if msg.EmbedPublishTime {
+		msg.Payload = EmbedPublishTime(msg.Payload, publishTime)
+		responseContent.Res = PublishValue(msg)
+	}
+	if msg.CorrelationID != "" {
+		q.Correlations.Record(msg.CorrelationID, publishTime)
+	}
+	res, err := client.Publish(ctx, msg.Topic, msg.QoS, msg.Retain, msg.Payload)
+	responseContent.Success = err == nil
+	responseContent.StartTime = res.StartTime
+	responseContent.EndTime = res.EndTime
+	responseContent.ResponseTime = res.EndTime.Sub(res.StartTime).Milliseconds()
+	if err != nil {
+		log.Error(ctx, "failed to publish message",
+			logger.Value("error", err), logger.Value("count", count))
+		responseContent.HasSystemErr = true
+	}
+	return responseContent
+}
+
+var _ httpexec.MassRequestExecutor = MassRequestContent[ExecReq]{}
@@ -0,0 +1,295 @@
+// Package mqttexec provides the executor for the MQTT publishers and subscribers.
+package mqttexec
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// DefaultConnectTimeout is the default timeout to connect to the broker
+const DefaultConnectTimeout = 10 * time.Second
+
+// QoS represents the quality of service level of the MQTT messages
+type QoS byte
+
+const (
+	// QoSAtMostOnce represents the message is delivered at most once, it is not acknowledged
+	QoSAtMostOnce QoS = 0
+	// QoSAtLeastOnce represents the message is acknowledged by PUBACK
+	QoSAtLeastOnce QoS = 1
+	// QoSExactlyOnce represents the message is acknowledged by PUBREC and PUBCOMP
+	QoSExactlyOnce QoS = 2
+)
+
+// subackFailure is the return code of SUBACK when the subscription is refused
+const subackFailure = 0x80
+
+// Config represents the configuration of the MQTT client
+type Config struct {
+	// Broker is the URL for the client in the form of tcp://, ssl://, ws:// or wss://
+	Broker   string
+	Username string
+	Password string
+	// ConnectTimeout limits the connection and the CONNACK
+	ConnectTimeout time.Duration
+	// Timeout limits the acknowledgements of PUBLISH and SUBSCRIBE, it is not limited if 0
+	Timeout time.Duration
+}
+
+// ParseURL parses the target URL in the form of mqtt://[user:password@]host:port,
+// mqtts:// connects over TLS, and ws:// or wss:// connects over WebSocket
+func ParseURL(rawURL string) (Config, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "mqtt://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to parse url: %w", err)
+	}
+	if u.Host == "" {
+		return Config{}, fmt.Errorf("host is required in the mqtt url")
+	}
+	config := Config{ConnectTimeout: DefaultConnectTimeout}
+	if u.User != nil {
+		config.Username = u.User.Username()
+		config.Password, _ = u.User.Password()
+	}
+	host := u.Host
+	switch u.Scheme {
+	case "mqtt", "tcp":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "1883")
+		}
+		config.Broker = "tcp://" + host
+	case "mqtts", "ssl", "tls":
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "8883")
+		}
+		config.Broker = "ssl://" + host
+	case "ws", "wss":
+		config.Broker = fmt.Sprintf("%s://%s%s", u.Scheme, host, u.EscapedPath())
+	default:
+		return Config{}, fmt.Errorf("unsupported mqtt scheme: %s", u.Scheme)
+	}
+	return config, nil
+}
+
+// RedactURL returns the URL without the password, it is recorded in the results and the plans
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	if _, ok := u.User.Password(); !ok {
+		return rawURL
+	}
+	u.User = url.UserPassword(u.User.Username(), "xxxxx")
+	return u.String()
+}
+
+// Message represents the message received by the subscription
+type Message struct {
+	Topic        string
+	Payload      []byte
+	QoS          QoS
+	Retained     bool
+	ReceivedTime time.Time
+}
+
+// PublishResponse represents the result of the PUBLISH,
+// the end time is when the message is written for QoS 0, or acknowledged by the broker otherwise
+type PublishResponse struct {
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// Client represents the MQTT client connected to the broker
+type Client struct {
+	config    Config
+	client    paho.Client
+	lost      chan error
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Connect connects to the broker with the client ID, and returns the time taken until CONNACK.
+// The session is clean and the client does not reconnect, so that the lost connections are recorded.
+func Connect(ctx context.Context, config Config, clientID string) (*Client, time.Duration, error) {
+	opts := paho.NewClientOptions().
+		AddBroker(config.Broker).
+		SetClientID(clientID).
+		SetCleanSession(true).
+		SetAutoReconnect(false).
+		SetConnectRetry(false).
+		SetConnectTimeout(config.ConnectTimeout)
+	c := &Client{config: config, lost: make(chan error, 1), done: make(chan struct{})}
+	opts.SetConnectionLostHandler(func(_ paho.Client, err error) {
+		select {
+		case c.lost <- err:
+		default:
+		}
+	})
+	if config.Username != "" {
+		opts.SetUsername(config.Username)
+		opts.SetPassword(config.Password)
+	}
+	if strings.HasPrefix(config.Broker, "ssl://") || strings.HasPrefix(config.Broker, "wss://") {
+		opts.SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	c.client = paho.NewClient(opts)
+	startTime := time.Now()
+	if err := wait(ctx, c.client.Connect(), config.ConnectTimeout); err != nil {
+		return nil, time.Since(startTime), fmt.Errorf("failed to connect to broker: %w", err)
+	}
+	return c, time.Since(startTime), nil
+}
+
+// wait waits for the token to complete
+func wait(ctx context.Context, token paho.Token, timeout time.Duration) error {
+	var timer <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		timer = t.C
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer:
+		return fmt.Errorf("timed out after %s", timeout)
+	case <-token.Done():
+	}
+	return token.Error()
+}
+
+// Publish publishes the message and waits for the acknowledgement of the QoS
+func (c *Client) Publish(ctx context.Context, topic string, qos QoS, retain bool, payload []byte) (PublishResponse, error) {
+	response := PublishResponse{StartTime: time.Now()}
+	err := wait(ctx, c.client.Publish(topic, byte(qos), retain, payload), c.config.Timeout)
+	response.EndTime = time.Now()
+	if err != nil {
+		return response, fmt.Errorf("failed to publish message: %w", err)
+	}
+	return response, nil
+}
+
+// Subscribe subscribes to the topic filters, the messages are sent to the returned channel until the client is closed
+func (c *Client) Subscribe(ctx context.Context, topics []string, qos QoS) (<-chan Message, error) {
+	messages := make(chan Message)
+	filters := make(map[string]byte, len(topics))
+	for _, topic := range topics {
+		filters[topic] = byte(qos)
+	}
+	token := c.client.SubscribeMultiple(filters, func(_ paho.Client, msg paho.Message) {
+		received := Message{
+			Topic:        msg.Topic(),
+			Payload:      msg.Payload(),
+			QoS:          QoS(msg.Qos()),
+			Retained:     msg.Retained(),
+			ReceivedTime: time.Now(),
+		}
+		select {
+		case messages <- received:
+		case <-c.done:
+		}
+	})
+	if err := wait(ctx, token, c.config.Timeout); err != nil {
+		return nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+	if subToken, ok := token.(*paho.SubscribeToken); ok {
+		for topic, code := range subToken.Result() {
+			if code == subackFailure {
+				return nil, fmt.Errorf("subscription to %s is refused by broker", topic)
+			}
+		}
+	}
+	return messages, nil
+}
+
+// Lost returns the channel which receives the error when the connection is lost
+func (c *Client) Lost() <-chan error {
+	return c.lost
+}
+
+// Close disconnects from the broker
+func (c *Client) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.client.Disconnect(250)
+	})
+}
+
+// Pool holds the connections of the publishers by the client ID,
+// each client ID has a single connection because the broker disconnects the older one with the same ID
+type Pool struct {
+	config  Config
+	mu      sync.Mutex
+	clients map[string]*poolEntry
+}
+
+// poolEntry represents the connection of the client ID, ready is closed when the connection is settled
+type poolEntry struct {
+	ready  chan struct{}
+	client *Client
+	err    error
+}
+
+// NewPool creates a new Pool, the connections are established on the first message of the client ID
+func NewPool(config Config) *Pool {
+	return &Pool{config: config, clients: make(map[string]*poolEntry)}
+}
+
+// Get returns the connection of the client ID, the connect time is 0 if the connection is reused.
+// The failed connection is not kept, so it is retried on the next message.
+func (p *Pool) Get(ctx context.Context, clientID string) (*Client, time.Duration, error) {
+	p.mu.Lock()
+	entry, ok := p.clients[clientID]
+	if ok {
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		case <-entry.ready:
+		}
+		if entry.err != nil {
+			return nil, 0, entry.err
+		}
+		return entry.client, 0, nil
+	}
+	entry = &poolEntry{ready: make(chan struct{})}
+	p.clients[clientID] = entry
+	p.mu.Unlock()
+
+	var connectTime time.Duration
+	entry.client, connectTime, entry.err = Connect(ctx, p.config, clientID)
+	close(entry.ready)
+	if entry.err != nil {
+		p.mu.Lock()
+		if p.clients[clientID] == entry {
+			delete(p.clients, clientID)
+		}
+		p.mu.Unlock()
+	}
+	return entry.client, connectTime, entry.err
+}
+
+// Close disconnects all the connections of the pool
+func (p *Pool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, entry := range p.clients {
+		<-entry.ready
+		if entry.client != nil {
+			entry.client.Close()
+		}
+		delete(p.clients, id)
+	}
+}
@@ -0,0 +1,79 @@
+package redisexec
+
+import (
+	"context"
+
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// ExecReq represents the request executor
+type ExecReq interface {
+	// CreateCommand creates the command arguments to send for the count
+	CreateCommand(ctx context.Context, log logger.Logger, count int) ([]string, error)
+}
+
+// MassRequestContent represents the request content.
+// The replies are sent as the HTTP responses, so that they are handled in the same way.
+type MassRequestContent[Req ExecReq] struct {
+	httpexec.MassDriver
+	Client *Client
+	Req    Req
+}
+
+// MassRequestExecute executes the request
+func (q MassRequestContent[Req]) MassRequestExecute(
+	ctx context.Context,
+	log logger.Logger,
+) error {
+	q.Run(ctx, log, q.send, func() {
+		// the connection pool is closed after the commands in flight
+		if err := q.Client.Close(); err != nil {
+			log.Debug(ctx, "failed to close redis client",
+				logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		}
+	})
+	return nil
+}
+
+// send sends the command of the count and reads its reply
+func (q MassRequestContent[Req]) send(ctx context.Context, log logger.Logger, count int) httpexec.ResponseContent {
+	args, err := q.Req.CreateCommand(ctx, log, count)
+	if err != nil {
+		log.Error(ctx, "failed to create command",
+			logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		return httpexec.ResponseContent{
+			Success:         false,
+			ReqCreateHasErr: true,
+			Count:           count,
+			Columns:         Response{}.Columns(),
+		}
+	}
+
+	log.Debug(ctx, "sending command",
+		logger.Value("command", args[0]),
+		logger.Value("count", count),
+	)
+	res, err := q.Client.Do(ctx, args)
+	responseContent := httpexec.ResponseContent{
+		Success:      err == nil,
+		StartTime:    res.StartTime,
+		EndTime:      res.EndTime,
+		Count:        count,
+		ResponseTime: res.RoundTrip.Milliseconds(),
+		ConnectTime:  res.ConnectTime.Milliseconds(),
+		Columns:      res.Columns(),
+	}
+	if err != nil {
+		log.Error(ctx, "failed to send command",
+			logger.Value("error", err), logger.Value("count", count))
+		responseContent.HasSystemErr = true
+	} else {
+		// the error reply is the failure of the command, its message is still extracted
+		responseContent.Success = res.Reply.Type != ReplyTypeError
+		responseContent.Res = ReplyValue(res.Reply)
+	}
+	return responseContent
+}
+
+var _ httpexec.MassRequestExecutor = MassRequestContent[ExecReq]{}
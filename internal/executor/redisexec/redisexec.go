@@ -0,0 +1,411 @@
+// Package redisexec provides the executor for the Redis commands over the RESP protocol.
+package redisexec
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReplyType represents the type of the RESP reply
+type ReplyType string
+
+const (
+	// ReplyTypeSimpleString represents the simple string reply such as OK
+	ReplyTypeSimpleString ReplyType = "simple_string"
+	// ReplyTypeError represents the error reply
+	ReplyTypeError ReplyType = "error"
+	// ReplyTypeInteger represents the integer reply
+	ReplyTypeInteger ReplyType = "integer"
+	// ReplyTypeBulkString represents the bulk string reply
+	ReplyTypeBulkString ReplyType = "bulk_string"
+	// ReplyTypeArray represents the array reply
+	ReplyTypeArray ReplyType = "array"
+	// ReplyTypeNil represents the nil bulk string or the nil array reply
+	ReplyTypeNil ReplyType = "nil"
+)
+
+// MaxBulkSize is the max size of the bulk string reply, it is the limit of Redis
+const MaxBulkSize = 512 << 20
+
+// Reply represents the RESP reply
+type Reply struct {
+	Type ReplyType
+	// Str holds the simple string, the error message and the bulk string
+	Str     string
+	Integer int64
+	Array   []Reply
+}
+
+// Value converts the reply to the value decoded from JSON, the integers are float64
+func (r Reply) Value() any {
+	switch r.Type {
+	case ReplyTypeSimpleString, ReplyTypeError, ReplyTypeBulkString:
+		return r.Str
+	case ReplyTypeInteger:
+		return float64(r.Integer)
+	case ReplyTypeArray:
+		values := make([]any, len(r.Array))
+		for i, elem := range r.Array {
+			values[i] = elem.Value()
+		}
+		return values
+	}
+	return nil
+}
+
+// ReplyValue converts the reply to the value referred by the extractors and the matchers.
+// The json field holds the decoded bulk string if it is the JSON object or array, like the cached responses.
+func ReplyValue(reply Reply) map[string]any {
+	value := map[string]any{
+		"type":  string(reply.Type),
+		"value": reply.Value(),
+	}
+	if reply.Type == ReplyTypeBulkString {
+		trimmed := strings.TrimSpace(reply.Str)
+		if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+			var decoded any
+			if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+				value["json"] = decoded
+			}
+		}
+	}
+	return value
+}
+
+// Config represents the configuration of the Redis client
+type Config struct {
+	Address  string
+	Username string
+	Password string
+	DB       int
+	TLS      bool
+	// PoolSize limits the connections of the pool, the commands wait for the idle connection
+	PoolSize    int
+	DialTimeout time.Duration
+	// Timeout limits each command, it is not limited if 0
+	Timeout time.Duration
+}
+
+// DefaultPoolSize is the default size of the connection pool
+const DefaultPoolSize = 10
+
+// ParseURL parses the target URL in the form of redis://[user:password@]host:port[/db],
+// rediss:// connects over TLS
+func ParseURL(rawURL string) (Config, error) {
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "redis://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to parse url: %w", err)
+	}
+	var config Config
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		config.TLS = true
+	default:
+		return Config{}, fmt.Errorf("unsupported redis scheme: %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return Config{}, fmt.Errorf("host is required in the redis url")
+	}
+	config.Address = u.Host
+	if u.Port() == "" {
+		config.Address = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		config.Username = u.User.Username()
+		config.Password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if config.DB, err = strconv.Atoi(db); err != nil {
+			return Config{}, fmt.Errorf("invalid db in the redis url: %s", db)
+		}
+	}
+	return config, nil
+}
+
+// RedactURL returns the URL without the password, it is recorded in the results and the plans
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	return u.Redacted()
+}
+
+// Response represents the result of the command
+type Response struct {
+	StartTime time.Time
+	EndTime   time.Time
+	// ConnectTime is the time to open the new connection, it is 0 if the idle connection is used
+	ConnectTime time.Duration
+	RoundTrip   time.Duration
+	Reply       Reply
+}
+
+// ColumnHeader is the header of the extra columns of the replies
+var ColumnHeader = []string{"ReplyType", "ConnectTime"}
+
+// Columns returns the values of the columns of ColumnHeader, the reply type is empty if the command failed
+func (r Response) Columns() []string {
+	return []string{string(r.Reply.Type), strconv.FormatInt(r.ConnectTime.Milliseconds(), 10)}
+}
+
+// conn represents the connection with its buffered reader
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// Client represents the Redis client backed by the connection pool
+type Client struct {
+	config Config
+	// slots limits the connections in use
+	slots  chan struct{}
+	mu     sync.Mutex
+	idle   []*conn
+	closed bool
+}
+
+// NewClient creates the Redis client, the connections are opened on demand
+func NewClient(config Config) *Client {
+	if config.PoolSize <= 0 {
+		config.PoolSize = DefaultPoolSize
+	}
+	return &Client{config: config, slots: make(chan struct{}, config.PoolSize)}
+}
+
+// dial opens the connection, and authenticates and selects the db if configured
+func (c *Client) dial(ctx context.Context) (*conn, error) {
+	dialer := net.Dialer{Timeout: c.config.DialTimeout}
+	var nc net.Conn
+	var err error
+	if c.config.TLS {
+		host, _, _ := net.SplitHostPort(c.config.Address)
+		tlsDialer := tls.Dialer{NetDialer: &dialer, Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+		nc, err = tlsDialer.DialContext(ctx, "tcp", c.config.Address)
+	} else {
+		nc, err = dialer.DialContext(ctx, "tcp", c.config.Address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial: %w", err)
+	}
+	cn := &conn{Conn: nc, reader: bufio.NewReader(nc)}
+	var setup [][]string
+	if c.config.Password != "" {
+		if c.config.Username != "" {
+			setup = append(setup, []string{"AUTH", c.config.Username, c.config.Password})
+		} else {
+			setup = append(setup, []string{"AUTH", c.config.Password})
+		}
+	}
+	if c.config.DB != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.config.DB)})
+	}
+	for _, args := range setup {
+		reply, err := cn.roundTrip(ctx, args)
+		if err == nil && reply.Type == ReplyTypeError {
+			err = errors.New(reply.Str)
+		}
+		if err != nil {
+			_ = cn.Close()
+			return nil, fmt.Errorf("failed to %s: %w", strings.ToLower(args[0]), err)
+		}
+	}
+	return cn, nil
+}
+
+// get takes the idle connection or opens the new one, it waits for the slot of the pool
+func (c *Client) get(ctx context.Context) (*conn, time.Duration, error) {
+	select {
+	case c.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	}
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return cn, 0, nil
+	}
+	c.mu.Unlock()
+	start := time.Now()
+	cn, err := c.dial(ctx)
+	if err != nil {
+		<-c.slots
+		return nil, time.Since(start), err
+	}
+	return cn, time.Since(start), nil
+}
+
+// put returns the connection to the pool, the broken connection is closed
+func (c *Client) put(cn *conn, broken bool) {
+	c.mu.Lock()
+	if broken || c.closed {
+		_ = cn.Close()
+	} else {
+		c.idle = append(c.idle, cn)
+	}
+	c.mu.Unlock()
+	<-c.slots
+}
+
+// Do sends the command and reads its reply.
+// The error reply is not the error, the error is returned only if the connection fails.
+func (c *Client) Do(ctx context.Context, args []string) (Response, error) {
+	if len(args) == 0 {
+		return Response{}, fmt.Errorf("command is empty")
+	}
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+	response := Response{StartTime: time.Now()}
+	cn, connectTime, err := c.get(ctx)
+	response.ConnectTime = connectTime
+	if err != nil {
+		response.EndTime = time.Now()
+		return response, err
+	}
+	sentTime := time.Now()
+	reply, err := cn.roundTrip(ctx, args)
+	response.EndTime = time.Now()
+	response.RoundTrip = response.EndTime.Sub(sentTime)
+	c.put(cn, err != nil)
+	if err != nil {
+		return response, err
+	}
+	response.Reply = reply
+	return response, nil
+}
+
+// roundTrip writes the command and reads the reply, the blocking IO is interrupted when the context is done
+func (cn *conn) roundTrip(ctx context.Context, args []string) (Reply, error) {
+	_ = cn.SetDeadline(time.Time{})
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = cn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = cn.SetDeadline(time.Now())
+	})
+	defer stop()
+	if _, err := cn.Write(AppendCommand(nil, args)); err != nil {
+		return Reply{}, fmt.Errorf("failed to write: %w", err)
+	}
+	reply, err := ReadReply(cn.reader)
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to read: %w", err)
+	}
+	return reply, nil
+}
+
+// Close closes the idle connections, the connections in use are closed when they are returned
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	var errs []error
+	for _, cn := range c.idle {
+		if err := cn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.idle = nil
+	return errors.Join(errs...)
+}
+
+// AppendCommand appends the command encoded as the RESP array of the bulk strings
+func AppendCommand(b []byte, args []string) []byte {
+	b = append(b, '*')
+	b = strconv.AppendInt(b, int64(len(args)), 10)
+	b = append(b, '\r', '\n')
+	for _, arg := range args {
+		b = append(b, '$')
+		b = strconv.AppendInt(b, int64(len(arg)), 10)
+		b = append(b, '\r', '\n')
+		b = append(b, arg...)
+		b = append(b, '\r', '\n')
+	}
+	return b
+}
+
+// ReadReply reads the RESP2 reply
+func ReadReply(r *bufio.Reader) (Reply, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return Reply{}, err
+	}
+	if len(line) == 0 {
+		return Reply{}, fmt.Errorf("empty reply line")
+	}
+	switch line[0] {
+	case '+':
+		return Reply{Type: ReplyTypeSimpleString, Str: line[1:]}, nil
+	case '-':
+		return Reply{Type: ReplyTypeError, Str: line[1:]}, nil
+	case ':':
+		n, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return Reply{}, fmt.Errorf("invalid integer reply: %w", err)
+		}
+		return Reply{Type: ReplyTypeInteger, Integer: n}, nil
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return Reply{}, fmt.Errorf("invalid bulk string size: %w", err)
+		}
+		if size < 0 {
+			return Reply{Type: ReplyTypeNil}, nil
+		}
+		if size > MaxBulkSize {
+			return Reply{}, fmt.Errorf("bulk string of %d bytes exceeds the limit", size)
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return Reply{}, err
+		}
+		return Reply{Type: ReplyTypeBulkString, Str: string(buf[:size])}, nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return Reply{}, fmt.Errorf("invalid array size: %w", err)
+		}
+		if size < 0 {
+			return Reply{Type: ReplyTypeNil}, nil
+		}
+		array := make([]Reply, 0, size)
+		for i := 0; i < size; i++ {
+			elem, err := ReadReply(r)
+			if err != nil {
+				return Reply{}, err
+			}
+			array = append(array, elem)
+		}
+		return Reply{Type: ReplyTypeArray, Array: array}, nil
+	}
+	return Reply{}, fmt.Errorf("unsupported reply type: %q", line[0])
+}
+
+// readLine reads the line without the CRLF
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
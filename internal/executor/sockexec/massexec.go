@@ -0,0 +1,95 @@
+package sockexec
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// ExecReq represents the request executor
+type ExecReq interface {
+	// CreatePayload creates the payload to send for the count
+	CreatePayload(ctx context.Context, log logger.Logger, count int) ([]byte, error)
+}
+
+// ReplyValue converts the reply to the value referred by the extractors and the matchers
+func ReplyValue(reply []byte) map[string]any {
+	return map[string]any{
+		"text":   string(reply),
+		"hex":    hex.EncodeToString(reply),
+		"base64": base64.StdEncoding.EncodeToString(reply),
+		"size":   len(reply),
+	}
+}
+
+// ColumnHeader is the header of the extra columns of the replies
+var ColumnHeader = []string{"ConnectTime"}
+
+// MassRequestContent represents the request content.
+// The replies are sent as the HTTP responses, so that they are handled in the same way.
+type MassRequestContent[Req ExecReq] struct {
+	httpexec.MassDriver
+	Client *Client
+	Req    Req
+}
+
+// MassRequestExecute executes the request
+func (q MassRequestContent[Req]) MassRequestExecute(
+	ctx context.Context,
+	log logger.Logger,
+) error {
+	q.Run(ctx, log, q.send, func() {
+		// the socket is closed after the payloads in flight
+		if err := q.Client.Close(); err != nil {
+			log.Debug(ctx, "failed to close socket",
+				logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		}
+	})
+	return nil
+}
+
+// send sends the payload of the count and reads its reply
+func (q MassRequestContent[Req]) send(ctx context.Context, log logger.Logger, count int) httpexec.ResponseContent {
+	payload, err := q.Req.CreatePayload(ctx, log, count)
+	if err != nil {
+		log.Error(ctx, "failed to create payload",
+			logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		return httpexec.ResponseContent{
+			Success:         false,
+			ReqCreateHasErr: true,
+			Count:           count,
+			Columns:         []string{"0"},
+		}
+	}
+
+	log.Debug(ctx, "sending payload",
+		logger.Value("address", q.Client.config.Address),
+		logger.Value("count", count),
+	)
+	res, err := q.Client.Exchange(ctx, payload)
+	responseContent := httpexec.ResponseContent{
+		Success:      err == nil,
+		StartTime:    res.StartTime,
+		EndTime:      res.EndTime,
+		Count:        count,
+		ByteResponse: res.Reply,
+		BodyBytes:    int64(len(res.Reply)),
+		ResponseTime: res.RoundTrip.Milliseconds(),
+		ConnectTime:  res.ConnectTime.Milliseconds(),
+		Columns:      []string{strconv.FormatInt(res.ConnectTime.Milliseconds(), 10)},
+	}
+	if err != nil {
+		log.Error(ctx, "failed to exchange",
+			logger.Value("error", err), logger.Value("address", q.Client.config.Address))
+		responseContent.HasSystemErr = true
+	} else {
+		responseContent.Res = ReplyValue(res.Reply)
+	}
+	return responseContent
+}
+
+var _ httpexec.MassRequestExecutor = MassRequestContent[ExecReq]{}
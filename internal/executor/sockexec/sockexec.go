@@ -0,0 +1,327 @@
+// Package sockexec provides the executor for the raw TCP and UDP socket.
+package sockexec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Protocol represents the transport protocol of the socket
+type Protocol string
+
+const (
+	// ProtocolTCP represents the TCP protocol
+	ProtocolTCP Protocol = "tcp"
+	// ProtocolUDP represents the UDP protocol
+	ProtocolUDP Protocol = "udp"
+)
+
+// Encoding represents the encoding of the payload written in the runner file
+type Encoding string
+
+const (
+	// EncodingText represents the payload is sent as it is
+	EncodingText Encoding = "text"
+	// EncodingHex represents the hex encoded payload
+	EncodingHex Encoding = "hex"
+	// EncodingBase64 represents the base64 encoded payload
+	EncodingBase64 Encoding = "base64"
+)
+
+// Decode decodes the value by the encoding
+func (e Encoding) Decode(value string) ([]byte, error) {
+	switch e {
+	case EncodingText:
+		return []byte(value), nil
+	case EncodingHex:
+		// the spaces and the 0x prefix are allowed for the readability
+		value = strings.TrimPrefix(strings.Join(strings.Fields(value), ""), "0x")
+		b, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode hex: %w", err)
+		}
+		return b, nil
+	case EncodingBase64:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64: %w", err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("invalid encoding: %s", e)
+}
+
+// ReadMode represents how the reply is read
+type ReadMode string
+
+const (
+	// ReadModeNone represents the reply is not read
+	ReadModeNone ReadMode = "none"
+	// ReadModeDelimiter represents the reply is read until the delimiter, the delimiter is not included
+	ReadModeDelimiter ReadMode = "delimiter"
+	// ReadModeLength represents the reply has the fixed length
+	ReadModeLength ReadMode = "length"
+	// ReadModeLengthPrefix represents the reply is framed by the length prefix like the payload
+	ReadModeLengthPrefix ReadMode = "length_prefix"
+	// ReadModeDatagram represents the reply is the single UDP datagram
+	ReadModeDatagram ReadMode = "datagram"
+)
+
+// MaxDatagramSize is the max size of the UDP datagram
+const MaxDatagramSize = 65535
+
+// DefaultMaxReplyBytes is the max size of the reply read by the length_prefix and delimiter modes
+// when max_bytes is not set, so that a broken reply does not allocate without limit
+const DefaultMaxReplyBytes = 16 << 20
+
+// Framing represents the length prefix framing of the messages, the prefix is not added if the size is 0
+type Framing struct {
+	LengthPrefix int
+	LittleEndian bool
+}
+
+// Frame adds the length prefix to the payload
+func (f Framing) Frame(payload []byte) ([]byte, error) {
+	if f.LengthPrefix == 0 {
+		return payload, nil
+	}
+	if f.LengthPrefix < 8 && uint64(len(payload)) >= 1<<(8*f.LengthPrefix) {
+		return nil, fmt.Errorf("payload of %d bytes exceeds the %d bytes length prefix", len(payload), f.LengthPrefix)
+	}
+	prefix := make([]byte, 8)
+	if f.LittleEndian {
+		binary.LittleEndian.PutUint64(prefix, uint64(len(payload)))
+		prefix = prefix[:f.LengthPrefix]
+	} else {
+		binary.BigEndian.PutUint64(prefix, uint64(len(payload)))
+		prefix = prefix[8-f.LengthPrefix:]
+	}
+	return append(prefix, payload...), nil
+}
+
+// readLength reads the length prefix
+func (f Framing) readLength(r io.Reader) (uint64, error) {
+	prefix := make([]byte, 8)
+	if f.LittleEndian {
+		if _, err := io.ReadFull(r, prefix[:f.LengthPrefix]); err != nil {
+			return 0, err
+		}
+		return binary.LittleEndian.Uint64(prefix), nil
+	}
+	if _, err := io.ReadFull(r, prefix[8-f.LengthPrefix:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(prefix), nil
+}
+
+// ReadConfig represents the configuration to read the reply
+type ReadConfig struct {
+	Mode      ReadMode
+	Delimiter []byte
+	Length    int
+	Timeout   time.Duration
+	// MaxBytes limits the size of the reply, DefaultMaxReplyBytes is used if 0
+	MaxBytes int
+}
+
+// Config represents the configuration of the socket client
+type Config struct {
+	Protocol    Protocol
+	Address     string
+	DialTimeout time.Duration
+	// KeepAlive reuses the connection for the requests, the exchanges are serialized
+	KeepAlive bool
+	Framing   Framing
+	Read      ReadConfig
+}
+
+// Response represents the result of the exchange
+type Response struct {
+	StartTime   time.Time
+	EndTime     time.Time
+	ConnectTime time.Duration
+	RoundTrip   time.Duration
+	Sent        int
+	Reply       []byte
+}
+
+// conn represents the connection with its buffered reader, the reader keeps the bytes after the reply
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// Client represents the socket client
+type Client struct {
+	config Config
+	mu     *sync.Mutex
+	conn   *conn
+}
+
+// NewClient creates the socket client
+func NewClient(config Config) *Client {
+	return &Client{config: config, mu: &sync.Mutex{}}
+}
+
+// dial opens the connection
+func (c *Client) dial(ctx context.Context) (*conn, time.Duration, error) {
+	dialer := net.Dialer{Timeout: c.config.DialTimeout}
+	start := time.Now()
+	nc, err := dialer.DialContext(ctx, string(c.config.Protocol), c.config.Address)
+	connectTime := time.Since(start)
+	if err != nil {
+		return nil, connectTime, fmt.Errorf("failed to dial: %w", err)
+	}
+	return &conn{Conn: nc, reader: bufio.NewReader(nc)}, connectTime, nil
+}
+
+// Exchange sends the payload and reads the reply.
+// The connection is opened for each exchange unless KeepAlive is set, then the connect time is 0 after the first.
+func (c *Client) Exchange(ctx context.Context, payload []byte) (Response, error) {
+	framed, err := c.config.Framing.Frame(payload)
+	if err != nil {
+		return Response{}, err
+	}
+	response := Response{StartTime: time.Now()}
+	var cn *conn
+	if c.config.KeepAlive {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.conn == nil {
+			if c.conn, response.ConnectTime, err = c.dial(ctx); err != nil {
+				response.EndTime = time.Now()
+				return response, err
+			}
+		}
+		cn = c.conn
+	} else {
+		if cn, response.ConnectTime, err = c.dial(ctx); err != nil {
+			response.EndTime = time.Now()
+			return response, err
+		}
+		defer cn.Close()
+	}
+
+	_ = cn.SetDeadline(time.Time{})
+	// the blocking write and read are interrupted when the context is done
+	stop := context.AfterFunc(ctx, func() {
+		_ = cn.SetDeadline(time.Now())
+	})
+	defer stop()
+	sentTime := time.Now()
+	n, err := cn.Write(framed)
+	response.Sent = n
+	if err != nil {
+		c.discard(cn)
+		response.EndTime = time.Now()
+		return response, fmt.Errorf("failed to write: %w", err)
+	}
+	if c.config.Read.Mode != ReadModeNone {
+		if c.config.Read.Timeout > 0 {
+			_ = cn.SetReadDeadline(time.Now().Add(c.config.Read.Timeout))
+		}
+		if response.Reply, err = c.read(cn); err != nil {
+			c.discard(cn)
+			response.EndTime = time.Now()
+			response.RoundTrip = response.EndTime.Sub(sentTime)
+			return response, fmt.Errorf("failed to read: %w", err)
+		}
+	}
+	response.EndTime = time.Now()
+	response.RoundTrip = response.EndTime.Sub(sentTime)
+	return response, nil
+}
+
+// discard closes the kept connection after the error, so that the next exchange opens the new one
+func (c *Client) discard(cn *conn) {
+	if c.config.KeepAlive && c.conn == cn {
+		_ = cn.Close()
+		c.conn = nil
+	}
+}
+
+// read reads the reply by the read mode
+func (c *Client) read(cn *conn) ([]byte, error) {
+	cfg := c.config.Read
+	maxBytes := cfg.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxReplyBytes
+	}
+	switch cfg.Mode {
+	case ReadModeDatagram:
+		size := MaxDatagramSize
+		if cfg.MaxBytes > 0 {
+			size = cfg.MaxBytes
+		}
+		buf := make([]byte, size)
+		n, err := cn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case ReadModeLength:
+		buf := make([]byte, cfg.Length)
+		if _, err := io.ReadFull(cn.reader, buf); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	case ReadModeLengthPrefix:
+		length, err := c.config.Framing.readLength(cn.reader)
+		if err != nil {
+			return nil, err
+		}
+		// the length is compared before the conversion, which may overflow int
+		if length > uint64(maxBytes) {
+			return nil, fmt.Errorf("reply of %d bytes exceeds max_bytes %d", length, maxBytes)
+		}
+		buf := make([]byte, length)
+		if _, err := io.ReadFull(cn.reader, buf); err != nil {
+			return nil, err
+		}
+		return buf, nil
+	case ReadModeDelimiter:
+		var buf []byte
+		last := cfg.Delimiter[len(cfg.Delimiter)-1]
+		for {
+			// the bytes are taken one by one from the buffered reader,
+			// so that the reply is not buffered beyond max_bytes while the delimiter is awaited
+			b, err := cn.reader.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			buf = append(buf, b)
+			if b == last && bytes.HasSuffix(buf, cfg.Delimiter) {
+				return buf[:len(buf)-len(cfg.Delimiter)], nil
+			}
+			if len(buf) > maxBytes+len(cfg.Delimiter) {
+				return nil, fmt.Errorf("reply exceeds max_bytes %d before the delimiter", maxBytes)
+			}
+		}
+	}
+	return nil, fmt.Errorf("invalid read mode: %s", cfg.Mode)
+}
+
+// Close closes the kept connection
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	if err != nil {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package sqlexec
+
+import (
+	"context"
+
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// ExecReq represents the request executor
+type ExecReq interface {
+	// CreateQuery creates the statement to execute for the count
+	CreateQuery(ctx context.Context, log logger.Logger, count int) (Query, error)
+}
+
+// MassRequestContent represents the request content.
+// The results are sent as the HTTP responses with the rows as the JSON array, so that they are handled in the same way.
+type MassRequestContent[Req ExecReq] struct {
+	httpexec.MassDriver
+	Client *Client
+	Req    Req
+}
+
+// MassRequestExecute executes the request
+func (q MassRequestContent[Req]) MassRequestExecute(
+	ctx context.Context,
+	log logger.Logger,
+) error {
+	q.Run(ctx, log, q.send, func() {
+		// the connection pool is closed after the statements in flight
+		if err := q.Client.Close(); err != nil {
+			log.Debug(ctx, "failed to close database",
+				logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		}
+	})
+	return nil
+}
+
+// send executes the statement of the count
+func (q MassRequestContent[Req]) send(ctx context.Context, log logger.Logger, count int) httpexec.ResponseContent {
+	query, err := q.Req.CreateQuery(ctx, log, count)
+	if err != nil {
+		log.Error(ctx, "failed to create query",
+			logger.Value("error", err), logger.Value("on", "MassRequestContent.MassRequestExecute"))
+		return httpexec.ResponseContent{
+			Success:         false,
+			ReqCreateHasErr: true,
+			Count:           count,
+			Columns:         Response{}.Columns(),
+		}
+	}
+
+	log.Debug(ctx, "executing statement",
+		logger.Value("mode", query.Mode),
+		logger.Value("count", count),
+	)
+	res, err := q.Client.Execute(ctx, query)
+	responseContent := httpexec.ResponseContent{
+		Success:      err == nil,
+		StartTime:    res.StartTime,
+		EndTime:      res.EndTime,
+		Count:        count,
+		ResponseTime: res.EndTime.Sub(res.StartTime).Milliseconds(),
+		Columns:      res.Columns(),
+	}
+	if err != nil {
+		log.Error(ctx, "failed to execute statement",
+			logger.Value("error", err), logger.Value("count", count))
+		responseContent.HasSystemErr = true
+	} else {
+		responseContent.Res = RowsValue(res.Rows)
+	}
+	return responseContent
+}
+
+var _ httpexec.MassRequestExecutor = MassRequestContent[ExecReq]{}
@@ -0,0 +1,145 @@
+// Package sseexec provides the executor for the Server-Sent Events subscription.
+package sseexec
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// ContentType is the content type of the event stream
+const ContentType = "text/event-stream"
+
+// DefaultEvent is the type of the event without the event field
+const DefaultEvent = "message"
+
+// ConnectContent represents the result of the subscription request
+type ConnectContent struct {
+	Success     bool
+	StartTime   time.Time
+	EndTime     time.Time
+	ConnectTime int64
+	StatusCode  int
+}
+
+// Event represents the event dispatched from the event stream
+type Event struct {
+	ReceivedTime time.Time
+	ID           string
+	Event        string
+	Data         string
+	Retry        int
+}
+
+// Stream represents the event stream of the subscription
+type Stream struct {
+	body   io.ReadCloser
+	reader *bufio.Reader
+	lastID string
+}
+
+// Connect sends the subscription request and waits for the response header.
+// The body is not read here, the events are read from the stream until it is closed.
+func Connect(ctx context.Context, log logger.Logger, client *http.Client, req *http.Request) (*Stream, ConnectContent, error) {
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", ContentType)
+	req.Header.Set("Cache-Control", "no-cache")
+	log.Debug(ctx, "subscribing event stream",
+		logger.Value("url", req.URL.String()))
+	startTime := time.Now()
+	resp, err := client.Do(req)
+	endTime := time.Now()
+	content := ConnectContent{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		ConnectTime: endTime.Sub(startTime).Milliseconds(),
+	}
+	if err != nil {
+		log.Error(ctx, "failed to subscribe event stream",
+			logger.Value("error", err), logger.Value("url", req.URL.String()))
+		return nil, content, fmt.Errorf("failed to send request: %w", err)
+	}
+	content.StatusCode = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, content, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err != nil || mediaType != ContentType {
+		_ = resp.Body.Close()
+		return nil, content, fmt.Errorf("unexpected content type: %s", resp.Header.Get("Content-Type"))
+	}
+	content.Success = true
+	return &Stream{body: resp.Body, reader: bufio.NewReader(resp.Body)}, content, nil
+}
+
+// Next reads the stream until the next event is dispatched, it blocks until the event arrives or the stream ends.
+// The comments and the events without data are skipped, io.EOF is returned when the stream ends.
+func (s *Stream) Next() (Event, error) {
+	var event Event
+	var data []string
+	hasData := false
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// the incomplete event at the end of the stream is discarded
+				return Event{}, io.EOF
+			}
+			return Event{}, fmt.Errorf("failed to read stream: %w", err)
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		if line == "" {
+			if !hasData {
+				event = Event{}
+				continue
+			}
+			event.ReceivedTime = time.Now()
+			event.Data = strings.Join(data, "\n")
+			if event.Event == "" {
+				event.Event = DefaultEvent
+			}
+			if event.ID == "" {
+				event.ID = s.lastID
+			}
+			return event, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		case "id":
+			if !strings.Contains(value, "\x00") {
+				event.ID = value
+				s.lastID = value
+			}
+		case "retry":
+			if retry, err := strconv.Atoi(value); err == nil {
+				event.Retry = retry
+			}
+		}
+	}
+}
+
+// Close closes the stream
+func (s *Stream) Close() error {
+	if err := s.body.Close(); err != nil {
+		return fmt.Errorf("failed to close stream: %w", err)
+	}
+	return nil
+}
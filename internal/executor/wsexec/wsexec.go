@@ -0,0 +1,137 @@
+// Package wsexec provides the executor for the WebSocket connection.
+package wsexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// MessageType represents the type of the WebSocket message
+type MessageType string
+
+const (
+	// MessageTypeText represents the text message
+	MessageTypeText MessageType = "text"
+	// MessageTypeBinary represents the binary message
+	MessageTypeBinary MessageType = "binary"
+)
+
+// HandshakeTimeout is the timeout of the opening handshake
+const HandshakeTimeout = 30 * time.Second
+
+// closeTimeout is the time to wait for the close frame to be written
+const closeTimeout = time.Second
+
+// ConnectContent represents the result of the opening handshake
+type ConnectContent struct {
+	Success     bool
+	StartTime   time.Time
+	EndTime     time.Time
+	ConnectTime int64
+	StatusCode  int
+}
+
+// MessageContent represents the received message
+type MessageContent struct {
+	ReceivedTime time.Time
+	Type         MessageType
+	Payload      []byte
+}
+
+// Conn represents the WebSocket connection, the writes are serialized
+type Conn struct {
+	conn *websocket.Conn
+	mu   *sync.Mutex
+}
+
+// Dial opens the WebSocket connection and measures the time of the opening handshake
+func Dial(ctx context.Context, log logger.Logger, url string, header http.Header) (*Conn, ConnectContent, error) {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: HandshakeTimeout,
+	}
+	log.Debug(ctx, "connecting websocket",
+		logger.Value("url", url))
+	startTime := time.Now()
+	conn, resp, err := dialer.DialContext(ctx, url, header)
+	endTime := time.Now()
+	content := ConnectContent{
+		StartTime:   startTime,
+		EndTime:     endTime,
+		ConnectTime: endTime.Sub(startTime).Milliseconds(),
+	}
+	if resp != nil {
+		content.StatusCode = resp.StatusCode
+		if resp.Body != nil {
+			_ = resp.Body.Close()
+		}
+	}
+	if err != nil {
+		log.Error(ctx, "failed to connect websocket",
+			logger.Value("error", err), logger.Value("url", url))
+		return nil, content, fmt.Errorf("failed to dial: %w", err)
+	}
+	content.Success = true
+	return &Conn{conn: conn, mu: &sync.Mutex{}}, content, nil
+}
+
+// Send sends the message and returns the time it is sent
+func (c *Conn) Send(messageType MessageType, payload []byte) (time.Time, error) {
+	wsType := websocket.TextMessage
+	if messageType == MessageTypeBinary {
+		wsType = websocket.BinaryMessage
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sentTime := time.Now()
+	if err := c.conn.WriteMessage(wsType, payload); err != nil {
+		return sentTime, fmt.Errorf("failed to write message: %w", err)
+	}
+	return sentTime, nil
+}
+
+// Receive receives the next message, it blocks until the message arrives or the connection is closed
+func (c *Conn) Receive() (MessageContent, error) {
+	wsType, payload, err := c.conn.ReadMessage()
+	if err != nil {
+		return MessageContent{}, fmt.Errorf("failed to read message: %w", err)
+	}
+	messageType := MessageTypeText
+	if wsType == websocket.BinaryMessage {
+		messageType = MessageTypeBinary
+	}
+	return MessageContent{
+		ReceivedTime: time.Now(),
+		Type:         messageType,
+		Payload:      payload,
+	}, nil
+}
+
+// IsClosed reports whether the error is caused by the close of the connection
+func IsClosed(err error) bool {
+	var closeErr *websocket.CloseError
+	return errors.As(err, &closeErr) || errors.Is(err, websocket.ErrCloseSent)
+}
+
+// Close sends the close frame and closes the connection
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	msg := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+	writeErr := c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(closeTimeout))
+	c.mu.Unlock()
+	if err := c.conn.Close(); err != nil {
+		return fmt.Errorf("failed to close connection: %w", err)
+	}
+	if writeErr != nil && !errors.Is(writeErr, websocket.ErrCloseSent) {
+		return fmt.Errorf("failed to write close message: %w", writeErr)
+	}
+	return nil
+}
@@ -26,6 +26,15 @@ func NewLocalOutput(cfg config.ValidOutputRespectiveValueConfig) LocalOutput {
 	}
 }
 
+// FilePath returns the path of the file written for the unique name
+func (o LocalOutput) FilePath(uniqueName string) (string, error) {
+	switch o.Format {
+	case config.OutputFormatCSV:
+		return fmt.Sprintf("%s/%s.csv", o.BasePath, uniqueName), nil
+	}
+	return "", fmt.Errorf("unsupported output format: %s", o.Format)
+}
+
 // HTTPDataWriteFactory returns the HTTPDataWrite function
 func (o LocalOutput) HTTPDataWriteFactory(
 	ctx context.Context,
@@ -34,12 +43,9 @@ func (o LocalOutput) HTTPDataWriteFactory(
 	uniqueName string,
 	header []string,
 ) (HTTPDataWrite, Close, error) {
-	var filePath string
-	switch o.Format {
-	case config.OutputFormatCSV:
-		filePath = fmt.Sprintf("%s/%s.csv", o.BasePath, uniqueName)
-	default:
-		return nil, nil, fmt.Errorf("unsupported output format: %s", o.Format)
+	filePath, err := o.FilePath(uniqueName)
+	if err != nil {
+		return nil, nil, err
 	}
 	f, err := utils.CreateFileWithDir(filePath)
 	if err != nil {
@@ -83,4 +89,4 @@ func (o LocalOutput) HTTPDataWriteFactory(
 		}, nil
 }
 
-var _ Output = LocalOutput{}
+var _ FileOutput = LocalOutput{}
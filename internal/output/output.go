@@ -23,6 +23,13 @@ type Output interface {
 	) (HTTPDataWrite, Close, error)
 }
 
+// FileOutput represents a output which writes the data to the file
+type FileOutput interface {
+	Output
+	// FilePath returns the path of the file written for the unique name
+	FilePath(uniqueName string) (string, error)
+}
+
 // Container is a map of outputs
 type Container map[string]Output
 
@@ -0,0 +1,147 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBarrierTimeout is returned when the barrier timeout expires before all the participants arrive
+var ErrBarrierTimeout = errors.New("barrier timeout")
+
+// Barrier is an interface for waiting at the named barrier shared by the master and the slaves
+type Barrier interface {
+	// Wait blocks until all the participants arrive at the barrier or the timeout expires
+	Wait(ctx context.Context, id string, participants int, timeout time.Duration) error
+}
+
+// BarrierWait represents the Barrier runner, it waits at the named barrier
+type BarrierWait struct {
+	ID           *string `yaml:"id"`
+	Participants *int    `yaml:"participants"`
+	Timeout      *string `yaml:"timeout"`
+}
+
+// ValidBarrierWait represents the valid Barrier runner
+type ValidBarrierWait struct {
+	ID           string
+	Participants int
+	Timeout      time.Duration
+}
+
+// Validate validates the Barrier runner
+func (r BarrierWait) Validate() (ValidBarrierWait, error) {
+	var valid ValidBarrierWait
+	if r.ID == nil {
+		return ValidBarrierWait{}, fmt.Errorf("id is required")
+	}
+	valid.ID = *r.ID
+	if r.Participants == nil {
+		return ValidBarrierWait{}, fmt.Errorf("participants is required")
+	}
+	if *r.Participants < 1 {
+		return ValidBarrierWait{}, fmt.Errorf("participants must be greater than 0")
+	}
+	valid.Participants = *r.Participants
+	if r.Timeout != nil {
+		timeout, err := time.ParseDuration(*r.Timeout)
+		if err != nil {
+			return ValidBarrierWait{}, fmt.Errorf("failed to parse timeout: %w", err)
+		}
+		valid.Timeout = timeout
+	}
+	return valid, nil
+}
+
+// Run waits at the barrier
+func (r ValidBarrierWait) Run(ctx context.Context, barrier Barrier) error {
+	if barrier == nil {
+		return fmt.Errorf("barrier is not available")
+	}
+	return barrier.Wait(ctx, r.ID, r.Participants, r.Timeout)
+}
+
+// barrierGeneration represents one round of the barrier, the barrier can be reused after the release
+type barrierGeneration struct {
+	participants int
+	arrived      int
+	timedOut     bool
+	timer        *time.Timer
+	done         chan struct{}
+}
+
+// LocalBarrier represents the barrier coordinated in the master process,
+// the arrivals of the slaves are forwarded to it through the slave request handler
+type LocalBarrier struct {
+	mu          *sync.Mutex
+	generations map[string]*barrierGeneration
+}
+
+// NewLocalBarrier creates a new LocalBarrier
+func NewLocalBarrier() *LocalBarrier {
+	return &LocalBarrier{
+		mu:          &sync.Mutex{},
+		generations: make(map[string]*barrierGeneration),
+	}
+}
+
+// Wait blocks until all the participants arrive at the barrier or the timeout expires.
+// The timeout starts with the first arrival.
+func (b *LocalBarrier) Wait(ctx context.Context, id string, participants int, timeout time.Duration) error {
+	// the cancelled participant must not complete the barrier of the others
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	g, ok := b.generations[id]
+	if !ok {
+		g = &barrierGeneration{
+			participants: participants,
+			done:         make(chan struct{}),
+		}
+		if timeout > 0 {
+			g.timer = time.AfterFunc(timeout, func() {
+				b.mu.Lock()
+				defer b.mu.Unlock()
+				if b.generations[id] != g {
+					return
+				}
+				delete(b.generations, id)
+				g.timedOut = true
+				close(g.done)
+			})
+		}
+		b.generations[id] = g
+	} else if g.participants != participants {
+		b.mu.Unlock()
+		return fmt.Errorf("barrier %s participants mismatch: %d != %d", id, participants, g.participants)
+	}
+	g.arrived++
+	if g.arrived == g.participants {
+		if g.timer != nil {
+			g.timer.Stop()
+		}
+		delete(b.generations, id)
+		close(g.done)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		b.mu.Lock()
+		if b.generations[id] == g {
+			g.arrived--
+		}
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-g.done:
+	}
+	if g.timedOut {
+		return fmt.Errorf("%w: %s (%d/%d arrived)", ErrBarrierTimeout, id, g.arrived, g.participants)
+	}
+	return nil
+}
+
+var _ Barrier = (*LocalBarrier)(nil)
@@ -0,0 +1,290 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/cresplanex/bloader/gen/pb/cresplanex/bloader/v1"
+
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// waitAsync waits at the barrier in the goroutine, the error is sent to the returned channel
+func waitAsync(ctx context.Context, b runner.Barrier, id string, participants int, timeout time.Duration) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Wait(ctx, id, participants, timeout)
+	}()
+	return done
+}
+
+// expectBlocked fails the test if the wait returns within a short time
+func expectBlocked(t *testing.T, done <-chan error) {
+	t.Helper()
+	select {
+	case err := <-done:
+		t.Fatalf("expected the wait to block, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// expectDone returns the result of the wait, the test fails if it does not return in time
+func expectDone(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the wait did not return in time")
+	}
+	return nil
+}
+
+// TestLocalBarrier tests the release, the timeout and the cancel of the local barrier.
+func TestLocalBarrier(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Release", func(tt *testing.T) {
+		b := runner.NewLocalBarrier()
+		first := waitAsync(ctx, b, "start", 3, 0)
+		second := waitAsync(ctx, b, "start", 3, 0)
+		expectBlocked(tt, first)
+		if err := b.Wait(ctx, "start", 3, 0); err != nil {
+			tt.Fatalf("failed to wait: %v", err)
+		}
+		for _, done := range []<-chan error{first, second} {
+			if err := expectDone(tt, done); err != nil {
+				tt.Errorf("failed to wait: %v", err)
+			}
+		}
+	})
+
+	t.Run("Timeout", func(tt *testing.T) {
+		b := runner.NewLocalBarrier()
+		err := b.Wait(ctx, "start", 2, 20*time.Millisecond)
+		if !errors.Is(err, runner.ErrBarrierTimeout) || !strings.Contains(err.Error(), "1/2 arrived") {
+			tt.Fatalf("expected the timeout, got %v", err)
+		}
+		// the timed out round is removed, the next arrival starts a new one
+		done := waitAsync(ctx, b, "start", 2, 0)
+		if err := b.Wait(ctx, "start", 2, 0); err != nil {
+			tt.Fatalf("failed to wait: %v", err)
+		}
+		if err := expectDone(tt, done); err != nil {
+			tt.Errorf("failed to wait: %v", err)
+		}
+	})
+
+	t.Run("ParticipantsMismatch", func(tt *testing.T) {
+		b := runner.NewLocalBarrier()
+		waitCtx, cancel := context.WithCancel(ctx)
+		done := waitAsync(waitCtx, b, "start", 2, 0)
+		expectBlocked(tt, done)
+		if err := b.Wait(ctx, "start", 3, 0); err == nil || !strings.Contains(err.Error(), "mismatch") {
+			tt.Errorf("expected the participants mismatch, got %v", err)
+		}
+		cancel()
+		if err := expectDone(tt, done); !errors.Is(err, context.Canceled) {
+			tt.Errorf("expected the cancel, got %v", err)
+		}
+	})
+
+	t.Run("CancelDecrements", func(tt *testing.T) {
+		b := runner.NewLocalBarrier()
+		waitCtx, cancel := context.WithCancel(ctx)
+		left := waitAsync(waitCtx, b, "start", 2, 0)
+		expectBlocked(tt, left)
+		cancel()
+		if err := expectDone(tt, left); !errors.Is(err, context.Canceled) {
+			tt.Fatalf("expected the cancel, got %v", err)
+		}
+		// the cancelled arrival is not counted, so one more arrival does not release the barrier
+		done := waitAsync(ctx, b, "start", 2, 0)
+		expectBlocked(tt, done)
+		if err := b.Wait(ctx, "start", 2, 0); err != nil {
+			tt.Fatalf("failed to wait: %v", err)
+		}
+		if err := expectDone(tt, done); err != nil {
+			tt.Errorf("failed to wait: %v", err)
+		}
+	})
+
+	t.Run("Reuse", func(tt *testing.T) {
+		b := runner.NewLocalBarrier()
+		for round, participants := range []int{2, 3} {
+			dones := make([]<-chan error, 0, participants-1)
+			for range participants - 1 {
+				dones = append(dones, waitAsync(ctx, b, "step", participants, 0))
+			}
+			expectBlocked(tt, dones[0])
+			if err := b.Wait(ctx, "step", participants, 0); err != nil {
+				tt.Fatalf("failed to wait in round %d: %v", round, err)
+			}
+			for _, done := range dones {
+				if err := expectDone(tt, done); err != nil {
+					tt.Errorf("failed to wait in round %d: %v", round, err)
+				}
+			}
+		}
+	})
+}
+
+// releaseRecorder records the barrier releases sent to the slave
+type releaseRecorder struct {
+	pb.BloaderSlaveServiceClient
+	releases chan *pb.SendBarrierReleaseRequest
+}
+
+// SendBarrierRelease records the release
+func (r releaseRecorder) SendBarrierRelease(
+	_ context.Context,
+	in *pb.SendBarrierReleaseRequest,
+	_ ...grpc.CallOption,
+) (*pb.SendBarrierReleaseResponse, error) {
+	r.releases <- in
+	return &pb.SendBarrierReleaseResponse{}, nil
+}
+
+// barrierSlave is the slave connected to the master, the requests of the slave are handled by the master
+type barrierSlave struct {
+	requests chan *pb.ReceiveChanelConnectResponse
+	releases chan *pb.SendBarrierReleaseRequest
+}
+
+// newBarrierSlave starts the handler of the requests of the slave
+func newBarrierSlave(ctx context.Context, t *testing.T, wg *sync.WaitGroup, barrier runner.Barrier) *barrierSlave {
+	t.Helper()
+	s := &barrierSlave{
+		requests: make(chan *pb.ReceiveChanelConnectResponse),
+		releases: make(chan *pb.SendBarrierReleaseRequest, 10),
+	}
+	handler := runner.NewSlaveRequestHandler(
+		s.requests,
+		releaseRecorder{releases: s.releases},
+		make(chan runner.ReceiveTermType),
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := handler.HandleResponse(ctx, logger.NewSlogLogger(), nil, nil, nil, nil, barrier); err != nil {
+			t.Errorf("failed to handle response: %v", err)
+		}
+	}()
+	return s
+}
+
+// arrive sends the arrival at the barrier
+func (s *barrierSlave) arrive(requestID, barrierID string, participants int) {
+	s.requests <- &pb.ReceiveChanelConnectResponse{
+		RequestId:   requestID,
+		RequestType: pb.RequestType_REQUEST_TYPE_BARRIER,
+		Request: &pb.ReceiveChanelConnectResponse_BarrierRequest{
+			BarrierRequest: &pb.ReceiveChanelConnectBarrierRequest{
+				BarrierId:    barrierID,
+				Participants: int32(participants), //nolint:gosec
+			},
+		},
+	}
+}
+
+// leave withdraws the arrival at the barrier
+func (s *barrierSlave) leave(requestID, barrierID string) {
+	s.requests <- &pb.ReceiveChanelConnectResponse{
+		RequestId:   requestID + "-leave",
+		RequestType: pb.RequestType_REQUEST_TYPE_BARRIER,
+		Request: &pb.ReceiveChanelConnectResponse_BarrierRequest{
+			BarrierRequest: &pb.ReceiveChanelConnectBarrierRequest{
+				BarrierId:      barrierID,
+				LeaveRequestId: requestID,
+			},
+		},
+	}
+}
+
+// expectRelease returns the release sent to the slave
+func (s *barrierSlave) expectRelease(t *testing.T) *pb.SendBarrierReleaseRequest {
+	t.Helper()
+	select {
+	case release := <-s.releases:
+		return release
+	case <-time.After(5 * time.Second):
+		t.Fatalf("the release was not sent in time")
+	}
+	return nil
+}
+
+// expectNoRelease fails the test if the release is sent to the slave within a short time
+func (s *barrierSlave) expectNoRelease(t *testing.T) {
+	t.Helper()
+	select {
+	case release := <-s.releases:
+		t.Fatalf("expected no release, got %v", release)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestMasterBarrier tests the barrier aggregating the arrivals of the master and the slaves.
+func TestMasterBarrier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	defer cancel()
+
+	barrier := runner.NewLocalBarrier()
+	first := newBarrierSlave(ctx, t, &wg, barrier)
+	second := newBarrierSlave(ctx, t, &wg, barrier)
+
+	t.Run("Release", func(tt *testing.T) {
+		first.arrive("a1", "start", 3)
+		second.arrive("b1", "start", 3)
+		first.expectNoRelease(tt)
+		if err := barrier.Wait(ctx, "start", 3, 0); err != nil {
+			tt.Fatalf("failed to wait: %v", err)
+		}
+		for id, s := range map[string]*barrierSlave{"a1": first, "b1": second} {
+			release := s.expectRelease(tt)
+			if release.RequestId != id || release.Status != pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_RELEASED {
+				tt.Errorf("expected the release of %s, got %v", id, release)
+			}
+		}
+	})
+
+	t.Run("Timeout", func(tt *testing.T) {
+		first.requests <- &pb.ReceiveChanelConnectResponse{
+			RequestId:   "a2",
+			RequestType: pb.RequestType_REQUEST_TYPE_BARRIER,
+			Request: &pb.ReceiveChanelConnectResponse_BarrierRequest{
+				BarrierRequest: &pb.ReceiveChanelConnectBarrierRequest{
+					BarrierId:           "timeout",
+					Participants:        2,
+					TimeoutMilliseconds: 20,
+				},
+			},
+		}
+		if release := first.expectRelease(tt); release.Status != pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_TIMEOUT {
+			tt.Errorf("expected the timeout, got %v", release)
+		}
+	})
+
+	t.Run("Leave", func(tt *testing.T) {
+		first.arrive("a3", "step", 2)
+		first.leave("a3", "step")
+		// the left arrival is not counted, so the arrival of the other slave does not release the barrier
+		second.arrive("b3", "step", 2)
+		second.expectNoRelease(tt)
+		if err := barrier.Wait(ctx, "step", 2, 0); err != nil {
+			tt.Fatalf("failed to wait: %v", err)
+		}
+		if release := second.expectRelease(tt); release.RequestId != "b3" {
+			tt.Errorf("expected the release of b3, got %v", release)
+		}
+		first.expectNoRelease(tt)
+	})
+}
@@ -7,9 +7,7 @@ import (
 	"io"
 	"sync"
 	"sync/atomic"
-	"text/template"
 
-	"github.com/Masterminds/sprig/v3"
 	"gopkg.in/yaml.v3"
 
 	"github.com/cresplanex/bloader/internal/encrypt"
@@ -27,6 +25,12 @@ type BaseExecutor struct {
 	AuthFactor            AuthenticatorFactor
 	OutputFactor          OutputFactor
 	TargetFactor          TargetFactor
+	Barrier               Barrier
+	Checkpoint            *Checkpoint
+	Results               *FlowResults
+	CookieJars            *CookieJarContainer
+	// FlowID is the ID of the flow which executes the runner, the results of the runner are stored by it
+	FlowID string
 }
 
 // Execute executes the base executor
@@ -68,7 +72,8 @@ func (e BaseExecutor) Execute(
 		return fmt.Errorf("failed to factorize template: %w", err)
 	}
 
-	tmpl, err := template.New("yaml").Funcs(sprig.TxtFuncMap()).Parse(tmplStr)
+	tmplSet := NewTmplSet(ctx, e.TmplFactor, NewTmplFuncMap(ctx, e.Store, e.EncryptCtr))
+	tmpl, err := tmplSet.Parse("yaml", tmplStr)
 	if err != nil {
 		return fmt.Errorf("failed to parse yaml: %w", err)
 	}
@@ -93,6 +98,7 @@ func (e BaseExecutor) Execute(
 		"SlaveValues":  slaveValues,
 		"Values":       replacedValuesData,
 		"ThreadValues": replaceThreadValuesData,
+		"Results":      e.Results.Values(),
 		"Dynamic": map[string]any{
 			"OutputRoot": outputRoot,
 			"LoopCount":  index,
@@ -145,6 +151,7 @@ func (e BaseExecutor) Execute(
 			"SlaveValues":  slaveValues,
 			"Values":       replacedValuesData,
 			"ThreadValues": replaceThreadValuesData,
+			"Results":      e.Results.Values(),
 			"Dynamic": map[string]any{
 				"OutputRoot": outputRoot,
 				"LoopCount":  index,
@@ -259,13 +266,25 @@ func (e BaseExecutor) Execute(
 		}); err != nil {
 			return err
 		}
-		if err := validOneExec.Run(ctx, outputRoot, str, e.Logger, e.Store); err != nil {
+		if err := validOneExec.Run(
+			ctx,
+			outputRoot,
+			str,
+			e.Logger,
+			e.Store,
+			e.CookieJars.Factory(validOneExec.Cookies, threadOnlyStr),
+		); err != nil {
 			if err := wait(ctx, e.Logger, validRunner, RunnerSleepValueAfterFailedExec, filename); err != nil {
 				return fmt.Errorf("failed to wait: %w", err)
 			}
 			return fmt.Errorf("failed to execute one exec: %w", err)
 		}
 		e.Logger.Info(ctx, "executed one exec")
+		for _, event := range validOneExec.Emit {
+			if err := eventCaster.CastEvent(ctx, event); err != nil {
+				return fmt.Errorf("failed to cast event: %w", err)
+			}
+		}
 	case RunnerKindMassExecute:
 		var massExec MassExec
 		decoder := yaml.NewDecoder(&rawData)
@@ -280,7 +299,8 @@ func (e BaseExecutor) Execute(
 				e.AuthFactor,
 				e.OutputFactor,
 				e.TargetFactor,
-				tmplStr,
+				tmplSet,
+				tmpl,
 				data,
 			); err != nil {
 				return fmt.Errorf("failed to validate mass exec: %w", err)
@@ -289,20 +309,85 @@ func (e BaseExecutor) Execute(
 		}); err != nil {
 			return err
 		}
-		if err := validMassExec.Run(
+		results, err := validMassExec.Run(
 			ctx,
 			e.Logger,
 			outputRoot,
 			e.AuthFactor,
 			e.OutputFactor,
 			e.TargetFactor,
-		); err != nil {
+			eventCaster,
+			e.CookieJars.Factory(validMassExec.Cookies, threadOnlyStr),
+		)
+		e.Results.Set(e.FlowID, MassExecResultsToValue(results))
+		e.Checkpoint.ReportRequests(results)
+		if err != nil {
 			if err := wait(ctx, e.Logger, validRunner, RunnerSleepValueAfterFailedExec, filename); err != nil {
 				return fmt.Errorf("failed to wait: %w", err)
 			}
 			return fmt.Errorf("failed to execute mass exec: %w", err)
 		}
 		e.Logger.Info(ctx, "executed mass exec")
+	case RunnerKindScenario:
+		var scenario Scenario
+		decoder := yaml.NewDecoder(&rawData)
+		if err := decoder.Decode(&scenario); err != nil {
+			return fmt.Errorf("failed to decode yaml: %w", err)
+		}
+		var validScenario ValidScenario
+		if err := validate(ctx, eventCaster, func() error {
+			if validScenario, err = scenario.Validate(
+				ctx,
+				e.AuthFactor,
+				e.OutputFactor,
+				e.TargetFactor,
+				tmplSet,
+				tmpl,
+				data,
+			); err != nil {
+				return fmt.Errorf("failed to validate scenario: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if err := validScenario.Run(
+			ctx,
+			e.Logger,
+			outputRoot,
+			e.CookieJars.Factory(validScenario.Cookies, threadOnlyStr),
+		); err != nil {
+			if err := wait(ctx, e.Logger, validRunner, RunnerSleepValueAfterFailedExec, filename); err != nil {
+				return fmt.Errorf("failed to wait: %w", err)
+			}
+			return fmt.Errorf("failed to execute scenario: %w", err)
+		}
+		e.Logger.Info(ctx, "executed scenario")
+	case RunnerKindBarrier:
+		var barrierWait BarrierWait
+		decoder := yaml.NewDecoder(&rawData)
+		if err := decoder.Decode(&barrierWait); err != nil {
+			return fmt.Errorf("failed to decode yaml: %w", err)
+		}
+		var validBarrierWait ValidBarrierWait
+		if err := validate(ctx, eventCaster, func() error {
+			if validBarrierWait, err = barrierWait.Validate(); err != nil {
+				return fmt.Errorf("failed to validate barrier: %w", err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		e.Logger.Info(ctx, "waiting at barrier",
+			logger.Value("id", validBarrierWait.ID), logger.Value("participants", validBarrierWait.Participants))
+		if err := validBarrierWait.Run(ctx, e.Barrier); err != nil {
+			if err := wait(ctx, e.Logger, validRunner, RunnerSleepValueAfterFailedExec, filename); err != nil {
+				return fmt.Errorf("failed to wait: %w", err)
+			}
+			return fmt.Errorf("failed to wait at barrier: %w", err)
+		}
+		e.Logger.Info(ctx, "released from barrier",
+			logger.Value("id", validBarrierWait.ID))
 	case RunnerKindSlaveConnect:
 		var slaveConnect SlaveConnect
 		decoder := yaml.NewDecoder(&rawData)
@@ -318,6 +403,8 @@ func (e BaseExecutor) Execute(
 		}); err != nil {
 			return err
 		}
+		// the connections do not survive the process, so they are established again on resume
+		e.Checkpoint.MarkVolatile()
 		if err := e.SlaveConnectContainer.Connect(
 			ctx,
 			e.Logger,
@@ -349,6 +436,7 @@ func (e BaseExecutor) Execute(
 					e.AuthFactor,
 					e.TargetFactor,
 					e.Store,
+					e.Barrier,
 				); err != nil {
 					atomicErr.Store(&syncError{Err: err})
 					e.Logger.Error(ctx, "failed to handle response: %v",
@@ -391,6 +479,10 @@ func (e BaseExecutor) Execute(
 			e.AuthFactor,
 			e.OutputFactor,
 			e.TargetFactor,
+			e.Barrier,
+			e.Checkpoint.WithScope(filename),
+			e.Results,
+			e.CookieJars,
 			str,
 			outputRoot,
 			callCount,
@@ -0,0 +1,272 @@
+package runner_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// TestReadResponseBody tests the reading of the response body by the response type.
+func TestReadResponseBody(t *testing.T) {
+	const body = `{"id": 1, "items": [{"name": "a"}, {"name": "b"}], "meta": {"next": null}}`
+	cases := []struct {
+		name         string
+		body         string
+		responseType httpexec.ResponseType
+		maxBodyBytes int64
+		parse        bool
+		fields       []string
+		want         any
+		wantBytes    bool
+		wantErr      error
+	}{
+		{
+			name:         "JSON",
+			body:         body,
+			responseType: httpexec.ResponseTypeJSON,
+			parse:        true,
+			want: map[string]any{
+				"id":    float64(1),
+				"items": []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}},
+				"meta":  map[string]any{"next": nil},
+			},
+			wantBytes: true,
+		},
+		{
+			name:         "NotParsed",
+			body:         body,
+			responseType: httpexec.ResponseTypeJSON,
+			wantBytes:    true,
+		},
+		{
+			name:         "Text",
+			body:         "hello",
+			responseType: httpexec.ResponseTypeText,
+			parse:        true,
+			want:         "hello",
+			wantBytes:    true,
+		},
+		{
+			name:         "Discard",
+			body:         body,
+			responseType: httpexec.ResponseTypeDiscard,
+			parse:        true,
+		},
+		{
+			name:         "Stream",
+			body:         body,
+			responseType: httpexec.ResponseTypeStream,
+			parse:        true,
+			want: map[string]any{
+				"id":    float64(1),
+				"items": []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}},
+				"meta":  map[string]any{"next": nil},
+			},
+		},
+		{
+			name:         "StreamFields",
+			body:         body,
+			responseType: httpexec.ResponseTypeStream,
+			parse:        true,
+			fields:       []string{"id", "missing"},
+			want:         map[string]any{"id": float64(1)},
+		},
+		{
+			name:         "StreamNoFields",
+			body:         body,
+			responseType: httpexec.ResponseTypeStream,
+			parse:        true,
+			fields:       []string{},
+			want:         map[string]any{},
+		},
+		{
+			name:         "StreamFieldsOfArray",
+			body:         `[{"id": 1}]`,
+			responseType: httpexec.ResponseTypeStream,
+			parse:        true,
+			fields:       []string{"id"},
+		},
+		{
+			name:         "StreamFieldsInvalid",
+			body:         `{"id": 1, "items": [1, }`,
+			responseType: httpexec.ResponseTypeStream,
+			parse:        true,
+			fields:       []string{"id"},
+			wantErr:      errors.New("invalid character"),
+		},
+		{
+			name:         "MaxBodyBytes",
+			body:         body,
+			responseType: httpexec.ResponseTypeJSON,
+			maxBodyBytes: 10,
+			parse:        true,
+			wantErr:      httpexec.ErrBodyTooLarge,
+		},
+		{
+			name:         "MaxBodyBytesDiscard",
+			body:         body,
+			responseType: httpexec.ResponseTypeDiscard,
+			maxBodyBytes: 10,
+			wantErr:      httpexec.ErrBodyTooLarge,
+		},
+		{
+			name:         "InvalidType",
+			body:         body,
+			responseType: "unknown",
+			wantErr:      errors.New("invalid response type: unknown"),
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			res, err := httpexec.ReadResponseBodyFields(
+				strings.NewReader(c.body), c.responseType, c.maxBodyBytes, c.parse, c.fields,
+			)
+			if c.wantErr != nil {
+				if err == nil || (!errors.Is(err, c.wantErr) && !strings.Contains(err.Error(), c.wantErr.Error())) {
+					tt.Fatalf("expected the error of %v, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				tt.Fatalf("failed to read body: %v", err)
+			}
+			if !reflect.DeepEqual(res.Res, c.want) {
+				tt.Errorf("expected %#v, got %#v", c.want, res.Res)
+			}
+			if res.BodyBytes != int64(len(c.body)) {
+				tt.Errorf("expected %d bytes, got %d", len(c.body), res.BodyBytes)
+			}
+			if c.wantBytes != (string(res.ByteResponse) == c.body) {
+				tt.Errorf("unexpected raw bytes: %q", res.ByteResponse)
+			}
+		})
+	}
+}
+
+// validMassExecRequest validates the request of the MassExec runner
+func validMassExecRequest(t *testing.T, request string) (runner.ValidMassExecRequest, error) {
+	t.Helper()
+	var req runner.MassExecRequest
+	if err := yaml.Unmarshal([]byte(request), &req); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	targetFactor := runner.NewLocalTargetFactor(target.Container{
+		"api": {Type: config.TargetTypeHTTP, URL: "http://api.test"},
+	})
+	return req.Validate(context.Background(), logger.NewSlogLogger(), targetFactor, nil, nil, nil)
+}
+
+// TestMassExecRequestBody tests whether the body is parsed, and which fields are decoded from the stream.
+func TestMassExecRequestBody(t *testing.T) {
+	const base = `target_id: api
+endpoint: /
+method: POST
+interval: 1ms
+`
+	cases := []struct {
+		name         string
+		responseType string
+		handling     string
+		wantNeeds    bool
+		wantFields   []string
+		wantErr      string
+	}{
+		{
+			name:         "NotReferred",
+			responseType: "json",
+			handling:     "break:\n  count: 1\n  status_code: [{id: ok, op: eq, value: 200}]\n",
+			wantFields:   []string{},
+		},
+		{
+			name:         "Data",
+			responseType: "stream",
+			handling:     "data:\n  - {key: id, extractor: {type: jmesPath, jmes_path: 'items[0].id'}}\n",
+			wantNeeds:    true,
+			wantFields:   []string{"items"},
+		},
+		{
+			name:         "BreakAndFilter",
+			responseType: "stream",
+			handling: `break:
+  response_body: [{id: done, extractor: {type: jmesPath, jmes_path: meta.done}}]
+record_exclude_filter:
+  response_body: [{id: skip, extractor: {type: jmesPath, jmes_path: skipped}}]
+emit:
+  - {event: found, response_body: [{id: found, extractor: {type: jmesPath, jmes_path: meta.found}}]}
+`,
+			wantNeeds:  true,
+			wantFields: []string{"meta", "skipped"},
+		},
+		{
+			name:         "WholeBody",
+			responseType: "stream",
+			handling: `break:
+  response_body: [{id: done, extractor: {type: jmesPath, jmes_path: "status == 'done'"}}]
+`,
+			wantNeeds: true,
+		},
+		{
+			name:         "ParseError",
+			responseType: "json",
+			handling:     "break:\n  parse_error: true\n",
+			wantNeeds:    true,
+			wantFields:   []string{},
+		},
+		{
+			name:         "ResponseBytes",
+			responseType: "discard",
+			handling:     "break:\n  response_bytes: [{id: large, op: contains, value: error}]\n",
+			wantNeeds:    true,
+			wantFields:   []string{},
+		},
+		{
+			name:         "GraphQL",
+			responseType: "stream",
+			handling:     "body_type: graphql\nbody: {query: '{ me { id } }'}\n",
+			wantNeeds:    true,
+			wantFields:   []string{"data", "errors"},
+		},
+		{
+			name:         "DiscardData",
+			responseType: "discard",
+			handling:     "data:\n  - {key: id, extractor: {type: jmesPath, jmes_path: id}}\n",
+			wantErr:      "response_type discard",
+		},
+		{
+			name:         "DiscardBreak",
+			responseType: "discard",
+			handling:     "break:\n  response_body: [{id: done, extractor: {type: jmesPath, jmes_path: done}}]\n",
+			wantErr:      "response_type discard",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			valid, err := validMassExecRequest(tt, base+"response_type: "+c.responseType+"\n"+c.handling)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					tt.Fatalf("expected the error of %q, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				tt.Fatalf("failed to validate: %v", err)
+			}
+			if valid.NeedsBody() != c.wantNeeds {
+				tt.Errorf("expected NeedsBody to be %v", c.wantNeeds)
+			}
+			if fields := valid.BodyFields.Fields(); !reflect.DeepEqual(fields, c.wantFields) {
+				tt.Errorf("expected the fields %#v, got %#v", c.wantFields, fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/store"
+)
+
+// CheckpointBucketID is the bucket of the store which holds the checkpoints of the runs
+const CheckpointBucketID = "bloader_checkpoints"
+
+// CheckpointRecord represents the persisted progress of the run
+type CheckpointRecord struct {
+	RunID      string                    `json:"run_id"`
+	File       string                    `json:"file"`
+	Data       CheckpointValues          `json:"data"`
+	OutputRoot string                    `json:"output_root"`
+	Values     CheckpointValues          `json:"values"`
+	Results    CheckpointValues          `json:"results"`
+	Flows      map[string]FlowCheckpoint `json:"flows"`
+	UpdatedAt  time.Time                 `json:"updated_at"`
+}
+
+// FlowCheckpoint represents the flow which reached sys:terminated successfully
+type FlowCheckpoint struct {
+	Events      []Event   `json:"events"`
+	OutputRoot  string    `json:"output_root"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// LoadCheckpointRecord loads the checkpoint of the run from the store
+func LoadCheckpointRecord(str store.Store, runID string) (CheckpointRecord, error) {
+	data, err := str.GetObject(CheckpointBucketID, runID)
+	if err != nil {
+		return CheckpointRecord{}, fmt.Errorf("failed to get checkpoint: %w", err)
+	}
+	if data == nil {
+		return CheckpointRecord{}, fmt.Errorf("checkpoint of run %s not found", runID)
+	}
+	var record CheckpointRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return CheckpointRecord{}, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+	return record, nil
+}
+
+// checkpointState is the state shared by the scopes of the checkpoint
+type checkpointState struct {
+	mu       *sync.Mutex
+	str      store.Store
+	values   *sync.Map
+	results  *FlowResults
+	record   CheckpointRecord
+	resumed  map[string]FlowCheckpoint
+	volatile []string
+	manifest *RunManifest
+}
+
+// Checkpoint records the progress of the flows in the store, so the interrupted run can be resumed.
+// The flows are identified by the scope, which is the path of the flow IDs from the root runner file.
+type Checkpoint struct {
+	state *checkpointState
+	scope string
+}
+
+// NewCheckpoint creates a new Checkpoint and persists the initial record.
+// The completed flows of the record are replayed instead of being executed again,
+// and the results of the flows are reported to the manifest.
+func NewCheckpoint(
+	str store.Store,
+	record CheckpointRecord,
+	values *sync.Map,
+	results *FlowResults,
+	manifest *RunManifest,
+) (*Checkpoint, error) {
+	if err := str.CreateBuckets(config.ValidStoreConfig{Buckets: []string{CheckpointBucketID}}); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint bucket: %w", err)
+	}
+	resumed := record.Flows
+	if resumed == nil {
+		resumed = make(map[string]FlowCheckpoint)
+	}
+	record.Flows = make(map[string]FlowCheckpoint, len(resumed))
+	for k, v := range resumed {
+		record.Flows[k] = v
+	}
+	c := &Checkpoint{
+		state: &checkpointState{
+			mu:       &sync.Mutex{},
+			str:      str,
+			values:   values,
+			results:  results,
+			record:   record,
+			resumed:  resumed,
+			manifest: manifest,
+		},
+	}
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	if err := c.state.save(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// save persists the record, the lock must be held by the caller
+func (s *checkpointState) save() error {
+	s.record.Values = syncMapToMap(s.values)
+	s.record.Results = s.results.Values()
+	s.record.UpdatedAt = time.Now()
+	data, err := json.Marshal(s.record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+	if err := s.str.PutObject(CheckpointBucketID, s.record.RunID, data); err != nil {
+		return fmt.Errorf("failed to put checkpoint: %w", err)
+	}
+	return nil
+}
+
+// WithScope returns the Checkpoint of the child scope
+func (c *Checkpoint) WithScope(name string) *Checkpoint {
+	if c == nil {
+		return nil
+	}
+	scope := name
+	if c.scope != "" {
+		scope = c.scope + "/" + name
+	}
+	return &Checkpoint{state: c.state, scope: scope}
+}
+
+// Completed returns the record of the scope if it had been completed before the resume
+func (c *Checkpoint) Completed() (FlowCheckpoint, bool) {
+	if c == nil {
+		return FlowCheckpoint{}, false
+	}
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	fc, ok := c.state.resumed[c.scope]
+	return fc, ok
+}
+
+// Report reports the result of the scope to the manifest
+func (c *Checkpoint) Report(status FlowStatus, reason string, outputRoot string) {
+	if c == nil {
+		return
+	}
+	c.state.manifest.SetFlow(c.scope, status, reason, outputRoot)
+}
+
+// ReportRequests reports how the requests of the scope were terminated to the manifest
+func (c *Checkpoint) ReportRequests(results []MassExecResult) {
+	if c == nil {
+		return
+	}
+	c.state.manifest.SetFlowRequests(c.scope, results)
+}
+
+// MarkVolatile marks the scope and its parents as volatile.
+// The volatile scopes are executed again on resume, e.g. the connections to the slaves do not survive the process.
+func (c *Checkpoint) MarkVolatile() {
+	if c == nil {
+		return
+	}
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	c.state.volatile = append(c.state.volatile, c.scope)
+}
+
+// Complete records the scope as completed together with the current values
+func (c *Checkpoint) Complete(events []Event, outputRoot string) error {
+	if c == nil {
+		return nil
+	}
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	for _, v := range c.state.volatile {
+		if v == c.scope || strings.HasPrefix(v, c.scope+"/") {
+			return nil
+		}
+	}
+	c.state.record.Flows[c.scope] = FlowCheckpoint{
+		Events:      events,
+		OutputRoot:  outputRoot,
+		CompletedAt: time.Now(),
+	}
+	return c.state.save()
+}
+
+// Finish deletes the record of the finished run, the finished run cannot be resumed
+func (c *Checkpoint) Finish() error {
+	if c == nil {
+		return nil
+	}
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+	if err := c.state.str.DeleteObject(CheckpointBucketID, c.state.record.RunID); err != nil {
+		return fmt.Errorf("failed to delete checkpoint: %w", err)
+	}
+	return nil
+}
+
+// recordingEventCaster records the events cast by the flow, they are replayed when the flow is resumed
+type recordingEventCaster struct {
+	EventCaster
+	mu     *sync.Mutex
+	events []Event
+}
+
+func newRecordingEventCaster(caster EventCaster) *recordingEventCaster {
+	return &recordingEventCaster{
+		EventCaster: caster,
+		mu:          &sync.Mutex{},
+	}
+}
+
+func (c *recordingEventCaster) record(event Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+}
+
+// Events returns the recorded events
+func (c *recordingEventCaster) Events() []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Event(nil), c.events...)
+}
+
+// CastEvent casts and records the event
+func (c *recordingEventCaster) CastEvent(ctx context.Context, event Event) error {
+	c.record(event)
+	return c.EventCaster.CastEvent(ctx, event)
+}
+
+// CastEventWithWait casts and records the event with wait
+func (c *recordingEventCaster) CastEventWithWait(ctx context.Context, event Event) error {
+	c.record(event)
+	return c.EventCaster.CastEventWithWait(ctx, event)
+}
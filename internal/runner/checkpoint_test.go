@@ -0,0 +1,289 @@
+package runner_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/container"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// TestCheckpointValues tests that the values keep their types through the checkpoint.
+func TestCheckpointValues(t *testing.T) {
+	startedAt := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	values := runner.CheckpointValues{
+		"int":     3,
+		"int64":   int64(-4),
+		"uint64":  uint64(5),
+		"float":   1.5,
+		"bool":    true,
+		"string":  "a",
+		"nil":     nil,
+		"ints":    []int{1, 2},
+		"strings": []string{"a", "b"},
+		"list":    []any{1, "a", nil, []any{2.5}},
+		"map":     map[string]any{"n": 1, "nested": map[string]any{"l": []any{int64(2)}}},
+		"time":    startedAt,
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	var got runner.CheckpointValues
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Errorf("expected %#v, got %#v", values, got)
+	}
+
+	// the named types are resumed as their kinds, and the other types as the JSON decoded values
+	data, err = json.Marshal(runner.CheckpointValues{
+		"status": runner.FlowStatusSucceeded,
+		"struct": struct {
+			Count int `json:"count"`
+		}{Count: 2},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+	want := runner.CheckpointValues{
+		"status": string(runner.FlowStatusSucceeded),
+		"struct": map[string]any{"count": float64(2)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %#v, got %#v", want, got)
+	}
+}
+
+// TestResume tests that the resumed run skips the completed flows and replays their events to the dependents.
+func TestResume(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	outDir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	write("main.yaml", `kind: Flow
+step:
+  concurrency: -1
+  flows:
+    - id: seed
+      type: file
+      file: seed.yaml
+    - id: emit
+      type: file
+      file: emit.yaml
+    - id: check
+      type: file
+      file: check.yaml
+      depends_on:
+        - {flow: seed, event: "sys:terminated"}
+        - {flow: emit, event: "seed:done"}
+`)
+	write("other.yaml", setValue("other", "true"))
+	write("seed.yaml", setValue("seeded", "{{ add1 (default 0 .Values.seeded) }}"))
+	write("emit.yaml", `kind: OneExecute
+type: http
+output:
+  enabled: false
+request:
+  target_id: server
+  endpoint: /
+  method: GET
+  response_type: json
+emit:
+  - "seed:done"
+`)
+	write("check.yaml", failRunner)
+
+	ctr := &container.Container{
+		Ctx: context.Background(),
+		Config: config.ValidConfig{
+			Env:    "test",
+			Loader: config.ValidLoaderConfig{BasePath: dir},
+			Outputs: config.ValidOutputConfig{{ID: "local", Values: []config.ValidOutputRespectiveValueConfig{
+				{Env: "test", Type: config.OutputTypeLocal, Format: config.OutputFormatCSV, BasePath: outDir},
+			}}},
+		},
+		Logger:          logger.NewSlogLogger(),
+		Store:           newMemoryStore(),
+		TargetContainer: target.Container{"server": {Type: config.TargetTypeHTTP, URL: server.URL}},
+	}
+	run := func(file, resumeID string) (string, error) {
+		t.Helper()
+		var runID string
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		ctr.Ctx = ctx
+		err := runner.Run(ctr, file, map[string]any{"count": 2}, resumeID, func(id string) { runID = id })
+		if ctx.Err() != nil {
+			t.Fatalf("the run did not finish in time: %v", err)
+		}
+		return runID, err
+	}
+
+	runID, err := run("main.yaml", "")
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected the run to fail, got %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Fatalf("expected the request to be sent once, got %d", hits.Load())
+	}
+
+	if _, err := run("other.yaml", runID); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("expected the error of the file mismatch, got %v", err)
+	}
+
+	// the values resumed from the checkpoint keep their types
+	write("check.yaml", `{{ if not (and (eq (printf "%T" .Values.seeded) "int") (eq .Values.seeded 1)) }}`+
+		`{{ fail (printf "seeded is %T %v" .Values.seeded .Values.seeded) }}{{ end }}`+
+		`{{ if ne (printf "%T" .Values.count) "int" }}{{ fail "count is not int" }}{{ end }}`+
+		setValue("checked", "true"))
+	if _, err := run("", runID); err != nil {
+		t.Fatalf("failed to resume: %v", err)
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected the completed flow to be skipped, got %d requests", hits.Load())
+	}
+	m, err := runner.FindManifest([]string{outDir}, runID)
+	if err != nil {
+		t.Fatalf("failed to find manifest: %v", err)
+	}
+	if m.Status != runner.RunStatusSucceeded || len(m.ResumedAt) != 1 {
+		t.Errorf("expected the resumed run to succeed, got %+v", m)
+	}
+	for _, scope := range []string{"main.yaml/seed#0", "main.yaml/emit#0"} {
+		if f := m.Flows[scope]; f.Reason != "resumed from the checkpoint" {
+			t.Errorf("expected %s to be resumed, got %+v", scope, m.Flows)
+		}
+	}
+	if f := m.Flows["main.yaml/check#0"]; f.Status != runner.FlowStatusSucceeded {
+		t.Errorf("expected check to succeed, got %+v", m.Flows)
+	}
+
+	if _, err := runner.LoadCheckpointRecord(ctr.Store, runID); err == nil {
+		t.Errorf("expected the checkpoint of the finished run to be deleted")
+	}
+	if _, err := run("", runID); err == nil || !strings.Contains(err.Error(), "already finished") {
+		t.Errorf("expected the error of the finished run, got %v", err)
+	}
+}
+
+// TestManifestFlows tests that the manifest records how the requests of the flows were terminated,
+// and the flows interrupted by the cancel of the run.
+func TestManifestFlows(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			// the run is interrupted while the slow flow is sending the requests
+			cancel()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	outDir := t.TempDir()
+	mass := func(endpoint, breakYAML string) string {
+		return `kind: MassExecute
+type: http
+output:
+  enabled: false
+requests:
+  - target_id: server
+    endpoint: ` + endpoint + `
+    method: GET
+    response_type: json
+    interval: 1ms
+    success_break:
+      - count
+    break:
+      ` + breakYAML + `
+`
+	}
+	for name, content := range map[string]string{
+		"main.yaml": `kind: Flow
+step:
+  concurrency: 0
+  flows:
+    - id: mass
+      type: file
+      file: mass.yaml
+    - id: slow
+      type: file
+      file: slow.yaml
+`,
+		"mass.yaml": mass("/mass", "count: 2"),
+		"slow.yaml": mass("/slow", "time: 5s"),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	ctr := &container.Container{
+		Ctx: ctx,
+		Config: config.ValidConfig{
+			Env:    "test",
+			Loader: config.ValidLoaderConfig{BasePath: dir},
+			Outputs: config.ValidOutputConfig{{ID: "local", Values: []config.ValidOutputRespectiveValueConfig{
+				{Env: "test", Type: config.OutputTypeLocal, Format: config.OutputFormatCSV, BasePath: outDir},
+			}}},
+		},
+		Logger:          logger.NewSlogLogger(),
+		Store:           newMemoryStore(),
+		TargetContainer: target.Container{"server": {Type: config.TargetTypeHTTP, URL: server.URL}},
+	}
+	var runID string
+	err := runner.Run(ctr, "main.yaml", map[string]any{}, "", func(id string) { runID = id })
+	if err == nil || !strings.Contains(err.Error(), "interrupted") {
+		t.Fatalf("expected the run to be interrupted, got %v", err)
+	}
+	m, err := runner.FindManifest([]string{outDir}, runID)
+	if err != nil {
+		t.Fatalf("failed to find manifest: %v", err)
+	}
+	for scope, want := range map[string]runner.ManifestFlow{
+		"main.yaml/mass#0": {
+			Status:   runner.FlowStatusSucceeded,
+			Requests: []runner.ManifestRequest{{RequestIndex: 0, TerminateType: "count"}},
+		},
+		"main.yaml/slow#0": {
+			Status:   runner.FlowStatusInterrupted,
+			Requests: []runner.ManifestRequest{{RequestIndex: 0, TerminateType: "context"}},
+		},
+	} {
+		f := m.Flows[scope]
+		if f.Status != want.Status || f.Reason != "" || !slices.Equal(f.Requests, want.Requests) {
+			t.Errorf("expected %s to be %+v, got %+v", scope, want, f)
+		}
+	}
+}
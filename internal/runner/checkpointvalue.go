@@ -0,0 +1,235 @@
+package runner
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CheckpointValues represents the values persisted in the checkpoint.
+// Each value is written with its type, so the resumed run sees the values of the same types as the original run,
+// e.g. an int stays an int instead of becoming a float64.
+// The values of the types other than the basic kinds, time.Time, and the slices and string keyed maps of them
+// are written as plain JSON, they are resumed as the JSON decoded values.
+type CheckpointValues map[string]any
+
+// checkpointValue is the typed envelope of the value in the checkpoint
+type checkpointValue struct {
+	Type  string                     `json:"type"`
+	Value json.RawMessage            `json:"value,omitempty"`
+	Items []checkpointValue          `json:"items,omitempty"`
+	Keys  map[string]checkpointValue `json:"keys,omitempty"`
+}
+
+const (
+	checkpointTypeNil  = "nil"
+	checkpointTypeJSON = "json"
+	checkpointTypeAny  = "any"
+	checkpointTypeTime = "time"
+)
+
+var (
+	checkpointAnyType  = reflect.TypeOf((*any)(nil)).Elem()
+	checkpointTimeType = reflect.TypeOf(time.Time{})
+	// checkpointBasicTypes is the basic types by their names, the named types are resumed as their basic types
+	checkpointBasicTypes = map[string]reflect.Type{
+		"bool":    reflect.TypeOf(false),
+		"string":  reflect.TypeOf(""),
+		"int":     reflect.TypeOf(int(0)),
+		"int8":    reflect.TypeOf(int8(0)),
+		"int16":   reflect.TypeOf(int16(0)),
+		"int32":   reflect.TypeOf(int32(0)),
+		"int64":   reflect.TypeOf(int64(0)),
+		"uint":    reflect.TypeOf(uint(0)),
+		"uint8":   reflect.TypeOf(uint8(0)),
+		"uint16":  reflect.TypeOf(uint16(0)),
+		"uint32":  reflect.TypeOf(uint32(0)),
+		"uint64":  reflect.TypeOf(uint64(0)),
+		"float32": reflect.TypeOf(float32(0)),
+		"float64": reflect.TypeOf(float64(0)),
+	}
+)
+
+// checkpointTypeName returns the name of the type, false if the type cannot be restored from the name
+func checkpointTypeName(t reflect.Type) (string, bool) {
+	switch {
+	case t == checkpointAnyType:
+		return checkpointTypeAny, true
+	case t == checkpointTimeType:
+		return checkpointTypeTime, true
+	case t.Kind() == reflect.Slice:
+		elem, ok := checkpointTypeName(t.Elem())
+		return "[]" + elem, ok
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		elem, ok := checkpointTypeName(t.Elem())
+		return "map[string]" + elem, ok
+	}
+	name := t.Kind().String()
+	_, ok := checkpointBasicTypes[name]
+	return name, ok
+}
+
+// checkpointType returns the type of the name
+func checkpointType(name string) (reflect.Type, error) {
+	switch {
+	case name == checkpointTypeAny:
+		return checkpointAnyType, nil
+	case name == checkpointTypeTime:
+		return checkpointTimeType, nil
+	case strings.HasPrefix(name, "[]"):
+		elem, err := checkpointType(strings.TrimPrefix(name, "[]"))
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elem), nil
+	case strings.HasPrefix(name, "map[string]"):
+		elem, err := checkpointType(strings.TrimPrefix(name, "map[string]"))
+		if err != nil {
+			return nil, err
+		}
+		return reflect.MapOf(checkpointBasicTypes["string"], elem), nil
+	}
+	t, ok := checkpointBasicTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s", name)
+	}
+	return t, nil
+}
+
+// encodeCheckpointValue wraps the value into the typed envelope
+func encodeCheckpointValue(v any) (checkpointValue, error) {
+	if v == nil {
+		return checkpointValue{Type: checkpointTypeNil}, nil
+	}
+	rv := reflect.ValueOf(v)
+	name, ok := checkpointTypeName(rv.Type())
+	if !ok {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return checkpointValue{}, fmt.Errorf("failed to marshal value: %w", err)
+		}
+		return checkpointValue{Type: checkpointTypeJSON, Value: raw}, nil
+	}
+	encoded := checkpointValue{Type: name}
+	switch {
+	case rv.Type() == checkpointTimeType:
+	case rv.Kind() == reflect.Slice:
+		encoded.Items = make([]checkpointValue, rv.Len())
+		for i := range rv.Len() {
+			item, err := encodeCheckpointValue(rv.Index(i).Interface())
+			if err != nil {
+				return checkpointValue{}, err
+			}
+			encoded.Items[i] = item
+		}
+		return encoded, nil
+	case rv.Kind() == reflect.Map:
+		encoded.Keys = make(map[string]checkpointValue, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			item, err := encodeCheckpointValue(iter.Value().Interface())
+			if err != nil {
+				return checkpointValue{}, err
+			}
+			encoded.Keys[iter.Key().String()] = item
+		}
+		return encoded, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return checkpointValue{}, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	encoded.Value = raw
+	return encoded, nil
+}
+
+// decode restores the value from the typed envelope
+func (c checkpointValue) decode() (any, error) {
+	switch c.Type {
+	case checkpointTypeNil:
+		return nil, nil
+	case checkpointTypeJSON:
+		var v any
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal value: %w", err)
+		}
+		return v, nil
+	}
+	t, err := checkpointType(c.Type)
+	if err != nil {
+		return nil, err
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		rv := reflect.MakeSlice(t, len(c.Items), len(c.Items))
+		for i, item := range c.Items {
+			v, err := item.decode()
+			if err != nil {
+				return nil, err
+			}
+			if v != nil {
+				rv.Index(i).Set(reflect.ValueOf(v).Convert(t.Elem()))
+			}
+		}
+		return rv.Interface(), nil
+	case reflect.Map:
+		rv := reflect.MakeMapWithSize(t, len(c.Keys))
+		for k, item := range c.Keys {
+			v, err := item.decode()
+			if err != nil {
+				return nil, err
+			}
+			elem := reflect.Zero(t.Elem())
+			if v != nil {
+				elem = reflect.ValueOf(v).Convert(t.Elem())
+			}
+			rv.SetMapIndex(reflect.ValueOf(k), elem)
+		}
+		return rv.Interface(), nil
+	}
+	ptr := reflect.New(t)
+	if err := json.Unmarshal(c.Value, ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal value: %w", err)
+	}
+	return ptr.Elem().Interface(), nil
+}
+
+// MarshalJSON writes the values with their types
+func (v CheckpointValues) MarshalJSON() ([]byte, error) {
+	if v == nil {
+		return []byte("null"), nil
+	}
+	encoded := make(map[string]checkpointValue, len(v))
+	for k, value := range v {
+		ev, err := encodeCheckpointValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		encoded[k] = ev
+	}
+	return json.Marshal(encoded)
+}
+
+// UnmarshalJSON restores the values with their types
+func (v *CheckpointValues) UnmarshalJSON(data []byte) error {
+	var encoded map[string]checkpointValue
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	if encoded == nil {
+		*v = nil
+		return nil
+	}
+	values := make(CheckpointValues, len(encoded))
+	for k, ev := range encoded {
+		value, err := ev.decode()
+		if err != nil {
+			return fmt.Errorf("%s: %w", k, err)
+		}
+		values[k] = value
+	}
+	*v = values
+	return nil
+}
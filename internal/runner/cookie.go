@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+)
+
+// CookieScope represents the scope in which the cookies are shared
+type CookieScope string
+
+const (
+	// CookieScopeRequest represents the cookies are kept only while the request follows the redirects
+	CookieScopeRequest CookieScope = "request"
+	// CookieScopeFlow represents the cookies are shared by the runners with the same thread only values
+	CookieScopeFlow CookieScope = "flow"
+	// CookieScopeGlobal represents the cookies are shared by all the runners of the run
+	CookieScopeGlobal CookieScope = "global"
+)
+
+// Cookies represents the cookie configuration of the runner
+type Cookies struct {
+	Enabled bool    `yaml:"enabled"`
+	Scope   *string `yaml:"scope"`
+}
+
+// ValidCookies represents the valid cookie configuration of the runner
+type ValidCookies struct {
+	Enabled bool
+	Scope   CookieScope
+}
+
+// Validate validates the Cookies
+func (c Cookies) Validate() (ValidCookies, error) {
+	if !c.Enabled {
+		return ValidCookies{}, nil
+	}
+	scope := CookieScopeFlow
+	if c.Scope != nil {
+		switch CookieScope(*c.Scope) {
+		case CookieScopeRequest, CookieScopeFlow, CookieScopeGlobal:
+			scope = CookieScope(*c.Scope)
+		default:
+			return ValidCookies{}, fmt.Errorf("invalid scope value: %s", *c.Scope)
+		}
+	}
+	return ValidCookies{
+		Enabled: c.Enabled,
+		Scope:   scope,
+	}, nil
+}
+
+// newCookieJar creates a new in-memory cookie jar
+func newCookieJar() http.CookieJar {
+	// cookiejar.New never returns an error
+	jar, _ := cookiejar.New(nil)
+	return jar
+}
+
+// CookieJarFactory returns the cookie jar of the request, nil disables the cookies
+type CookieJarFactory func() http.CookieJar
+
+// CookieJarContainer holds the cookie jars of the run.
+// The jars of the flow scope are identified by the thread only store, so the flows are isolated from each other,
+// and they are released when the flow ends.
+type CookieJarContainer struct {
+	mu     *sync.Mutex
+	global http.CookieJar
+	flows  map[*sync.Map]http.CookieJar
+}
+
+// NewCookieJarContainer creates a new CookieJarContainer
+func NewCookieJarContainer() *CookieJarContainer {
+	return &CookieJarContainer{
+		mu:     &sync.Mutex{},
+		global: newCookieJar(),
+		flows:  make(map[*sync.Map]http.CookieJar),
+	}
+}
+
+// Factory returns the CookieJarFactory for the cookie configuration
+func (c *CookieJarContainer) Factory(cookies ValidCookies, threadOnlyStr *sync.Map) CookieJarFactory {
+	if c == nil || !cookies.Enabled {
+		return nil
+	}
+	switch cookies.Scope {
+	case CookieScopeRequest:
+		return newCookieJar
+	case CookieScopeGlobal:
+		return func() http.CookieJar { return c.global }
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	jar, ok := c.flows[threadOnlyStr]
+	if !ok {
+		jar = newCookieJar()
+		c.flows[threadOnlyStr] = jar
+	}
+	return func() http.CookieJar { return jar }
+}
+
+// Release releases the jar of the flow scope identified by the thread only store
+func (c *CookieJarContainer) Release(threadOnlyStr *sync.Map) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.flows, threadOnlyStr)
+}
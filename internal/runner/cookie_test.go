@@ -0,0 +1,206 @@
+package runner_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// TestCookieJarContainer tests the jars returned for each scope.
+func TestCookieJarContainer(t *testing.T) {
+	jars := runner.NewCookieJarContainer()
+	first, second := &sync.Map{}, &sync.Map{}
+	cookies := func(scope runner.CookieScope) runner.ValidCookies {
+		return runner.ValidCookies{Enabled: true, Scope: scope}
+	}
+
+	if jars.Factory(runner.ValidCookies{}, first) != nil {
+		t.Errorf("expected no jar when the cookies are disabled")
+	}
+	var nilJars *runner.CookieJarContainer
+	if nilJars.Factory(cookies(runner.CookieScopeGlobal), first) != nil {
+		t.Errorf("expected no jar without the container")
+	}
+
+	request := jars.Factory(cookies(runner.CookieScopeRequest), first)
+	if request() == request() {
+		t.Errorf("expected a new jar for each request")
+	}
+	if jars.Factory(cookies(runner.CookieScopeGlobal), first)() != jars.Factory(cookies(runner.CookieScopeGlobal), second)() {
+		t.Errorf("expected the global jar to be shared by the flows")
+	}
+
+	flow := jars.Factory(cookies(runner.CookieScopeFlow), first)()
+	if jars.Factory(cookies(runner.CookieScopeFlow), first)() != flow {
+		t.Errorf("expected the jar to be shared in the same flow")
+	}
+	if jars.Factory(cookies(runner.CookieScopeFlow), second)() == flow {
+		t.Errorf("expected the jar not to be shared with the other flow")
+	}
+	if n := jars.FlowJars(); n != 2 {
+		t.Errorf("expected the jars of the 2 flows, got %d", n)
+	}
+	jars.Release(first)
+	if n := jars.FlowJars(); n != 1 {
+		t.Errorf("expected the jar of the first flow to be released, got %d", n)
+	}
+	if jars.Factory(cookies(runner.CookieScopeFlow), first)() == flow {
+		t.Errorf("expected a new jar after the release")
+	}
+}
+
+// sessionServer issues the session cookie to the requests without it, and records whether the cookie was sent
+type sessionServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	issued int
+	seen   []string
+}
+
+func newSessionServer(t *testing.T) *sessionServer {
+	t.Helper()
+	s := &sessionServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if r.URL.Path == "/login" {
+			// the cookie is set on the redirect, so it is kept even in the request scope
+			s.issued++
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: fmt.Sprint(s.issued), Path: "/"})
+			http.Redirect(w, r, "/session", http.StatusFound)
+			return
+		}
+		if c, err := r.Cookie("sid"); err == nil {
+			s.seen = append(s.seen, "seen:"+c.Value)
+		} else {
+			s.issued++
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: fmt.Sprint(s.issued), Path: "/"})
+			s.seen = append(s.seen, "new")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// sessionRequest returns the OneExecute runner file which sends the request with the cookies of the scope
+func sessionRequest(endpoint, scope string) string {
+	return `kind: OneExecute
+type: http
+output:
+  enabled: false
+cookies:
+  enabled: true
+  scope: ` + scope + `
+request:
+  target_id: server
+  endpoint: ` + endpoint + `
+  method: GET
+  response_type: json
+`
+}
+
+// TestCookieScopes tests which runners share the cookies in each scope, and that the flow jars are released.
+func TestCookieScopes(t *testing.T) {
+	cases := []struct {
+		name  string
+		flows string
+		want  []string
+	}{
+		{
+			name: "RequestScope",
+			flows: `    - id: counted
+      type: file
+      file: request.yaml
+      count: 2
+`,
+			want: []string{"new", "new"},
+		},
+		{
+			name: "RequestScopeRedirect",
+			flows: `    - id: login
+      type: file
+      file: login.yaml
+`,
+			want: []string{"seen:1"},
+		},
+		{
+			name: "FlowScope",
+			flows: `    - id: counted
+      type: file
+      file: flow.yaml
+      count: 2
+`,
+			want: []string{"new", "seen:1"},
+		},
+		{
+			name: "FlowScopeIsolated",
+			flows: `    - id: first
+      type: file
+      file: flow.yaml
+    - id: second
+      type: file
+      file: flow.yaml
+      depends_on: [{flow: first, event: "sys:terminated"}]
+`,
+			want: []string{"new", "new"},
+		},
+		{
+			name: "FlowScopeForEach",
+			flows: `    - id: each
+      type: file
+      file: flow.yaml
+      for_each: {enabled: true, items: .Values.items, as: item}
+`,
+			want: []string{"new", "new"},
+		},
+		{
+			name: "GlobalScope",
+			flows: `    - id: first
+      type: file
+      file: global.yaml
+    - id: second
+      type: file
+      file: global.yaml
+      depends_on: [{flow: first, event: "sys:terminated"}]
+`,
+			want: []string{"new", "seen:1"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			srv := newSessionServer(tt)
+			env := newFlowEnv(tt, map[string]string{
+				"main.yaml":    "kind: Flow\nstep:\n  concurrency: 1\n  flows:\n" + c.flows,
+				"request.yaml": sessionRequest("/session", "request"),
+				"login.yaml":   sessionRequest("/login", "request"),
+				"flow.yaml":    sessionRequest("/session", "flow"),
+				"global.yaml":  sessionRequest("/session", "global"),
+			})
+			env.targets["server"] = target.Target{Type: config.TargetTypeHTTP, URL: srv.URL}
+			values := &sync.Map{}
+			values.Store("items", []any{1, 2})
+			if err := env.run(tt, "main.yaml", values); err != nil {
+				tt.Fatalf("failed to run: %v", err)
+			}
+			srv.mu.Lock()
+			seen := slices.Clone(srv.seen)
+			srv.mu.Unlock()
+			slices.Sort(seen)
+			if !slices.Equal(seen, c.want) {
+				tt.Errorf("expected the requests %v, got %v", c.want, seen)
+			}
+			if n := env.jars.FlowJars(); n != 0 {
+				tt.Errorf("expected the flow jars to be released, got %d", n)
+			}
+		})
+	}
+}
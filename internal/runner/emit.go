@@ -0,0 +1,141 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+)
+
+// ValidateEmitEvents validates the user-defined events emitted by the runner
+func ValidateEmitEvents(names []string) ([]Event, error) {
+	var events []Event
+	for i, name := range names {
+		event, err := NewUserDefinedEvent(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate emit[%d]: %w", i, err)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// MassExecRequestEmit represents the user-defined event emitted by the MassExec request.
+// The event is emitted once, when the first of the conditions is satisfied.
+type MassExecRequestEmit struct {
+	Event        *string                `yaml:"event"`
+	Count        *int                   `yaml:"count"`
+	ResponseBody matcher.BodyConditions `yaml:"response_body"`
+	OnBreak      []string               `yaml:"on_break"`
+}
+
+// ValidMassExecRequestEmit represents the valid user-defined event emitted by the MassExec request
+type ValidMassExecRequestEmit struct {
+	Event               Event
+	Count               int
+	ResponseBodyEnabled bool
+	ResponseBodyMatcher matcher.BodyConditionsMatcher
+	OnBreak             matcher.TerminateTypeAndParamsSlice
+}
+
+// Validate validates the MassExecRequestEmit
+func (e MassExecRequestEmit) Validate(ctx context.Context, log logger.Logger) (ValidMassExecRequestEmit, error) {
+	var valid ValidMassExecRequestEmit
+	var err error
+	if e.Event == nil {
+		return ValidMassExecRequestEmit{}, fmt.Errorf("event is required")
+	}
+	if valid.Event, err = NewUserDefinedEvent(*e.Event); err != nil {
+		return ValidMassExecRequestEmit{}, err
+	}
+	if e.Count != nil {
+		if *e.Count < 1 {
+			return ValidMassExecRequestEmit{}, fmt.Errorf("count must be greater than 0")
+		}
+		valid.Count = *e.Count
+	}
+	if len(e.ResponseBody) > 0 {
+		valid.ResponseBodyEnabled = true
+		if valid.ResponseBodyMatcher, err = e.ResponseBody.MatcherGenerate(ctx, log); err != nil {
+			return ValidMassExecRequestEmit{}, fmt.Errorf("failed to generate response body matcher: %w", err)
+		}
+	}
+	if valid.OnBreak, err = matcher.NewTerminateTypeAndParamsSliceFromStringSlice(e.OnBreak); err != nil {
+		return ValidMassExecRequestEmit{}, fmt.Errorf("failed to parse on break: %w", err)
+	}
+	if valid.Count == 0 && !valid.ResponseBodyEnabled && len(valid.OnBreak) == 0 {
+		return ValidMassExecRequestEmit{}, fmt.Errorf("one of count, response_body or on_break is required")
+	}
+	return valid, nil
+}
+
+// RequestEventEmitter emits the user-defined events of the MassExec request
+type RequestEventEmitter struct {
+	eventCaster EventCaster
+	emits       []ValidMassExecRequestEmit
+	once        []sync.Once
+}
+
+// NewRequestEventEmitter creates a new RequestEventEmitter
+func NewRequestEventEmitter(eventCaster EventCaster, emits []ValidMassExecRequestEmit) *RequestEventEmitter {
+	return &RequestEventEmitter{
+		eventCaster: eventCaster,
+		emits:       emits,
+		once:        make([]sync.Once, len(emits)),
+	}
+}
+
+func (e *RequestEventEmitter) emit(ctx context.Context, log logger.Logger, i int) {
+	e.once[i].Do(func() {
+		log.Info(ctx, "Emit event",
+			logger.Value("event", e.emits[i].Event))
+		if err := e.eventCaster.CastEvent(ctx, e.emits[i].Event); err != nil {
+			log.Error(ctx, "failed to cast event",
+				logger.Value("event", e.emits[i].Event), logger.Value("error", err))
+		}
+	})
+}
+
+// OnResponse emits the events whose count or response body condition is satisfied
+func (e *RequestEventEmitter) OnResponse(ctx context.Context, log logger.Logger, count int, body any) {
+	if e == nil {
+		return
+	}
+	for i, emit := range e.emits {
+		if emit.Count > 0 && count >= emit.Count {
+			e.emit(ctx, log, i)
+			continue
+		}
+		if !emit.ResponseBodyEnabled {
+			continue
+		}
+		_, isMatch, err := emit.ResponseBodyMatcher(body)
+		if err != nil {
+			log.Warn(ctx, "failed to match response body for emit",
+				logger.Value("event", emit.Event), logger.Value("error", err), logger.Value("count", count))
+			continue
+		}
+		if isMatch {
+			e.emit(ctx, log, i)
+		}
+	}
+}
+
+// OnBreak emits the events whose break condition matches, before the request terminates
+func (e *RequestEventEmitter) OnBreak(
+	ctx context.Context,
+	log logger.Logger,
+	termType matcher.TerminateType,
+	param string,
+) {
+	if e == nil {
+		return
+	}
+	for i, emit := range e.emits {
+		if emit.OnBreak.Match(termType, param) {
+			e.emit(ctx, log, i)
+		}
+	}
+}
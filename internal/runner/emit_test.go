@@ -0,0 +1,262 @@
+package runner_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// recordCaster records the events cast by the emitter
+type recordCaster struct {
+	*runner.DefaultEventCaster
+	mu     sync.Mutex
+	events []runner.Event
+}
+
+// CastEvent records the event
+func (c *recordCaster) CastEvent(_ context.Context, event runner.Event) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+	return nil
+}
+
+// cast returns the events cast so far
+func (c *recordCaster) cast() []runner.Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.events)
+}
+
+// validEmits validates the emits written in YAML
+func validEmits(t *testing.T, emits string) ([]runner.ValidMassExecRequestEmit, error) {
+	t.Helper()
+	var raw []runner.MassExecRequestEmit
+	if err := yaml.Unmarshal([]byte(emits), &raw); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	var valid []runner.ValidMassExecRequestEmit
+	for _, e := range raw {
+		v, err := e.Validate(context.Background(), logger.NewSlogLogger())
+		if err != nil {
+			return nil, err
+		}
+		valid = append(valid, v)
+	}
+	return valid, nil
+}
+
+// TestRequestEventEmitter tests that each event is emitted once, when the first of its conditions is satisfied.
+func TestRequestEventEmitter(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewSlogLogger()
+	emits, err := validEmits(t, `
+- {event: "req:third", count: 3}
+- {event: "req:done", response_body: [{id: done, extractor: {type: jmesPath, jmes_path: done}}]}
+- {event: "req:failed", on_break: ["statusCode/500,503"]}
+- {event: "req:any", count: 10, on_break: ["time"]}
+`)
+	if err != nil {
+		t.Fatalf("failed to validate emits: %v", err)
+	}
+	caster := &recordCaster{DefaultEventCaster: runner.NewDefaultEventCaster()}
+	emitter := runner.NewRequestEventEmitter(caster, emits)
+
+	steps := []struct {
+		name  string
+		do    func()
+		wants []runner.Event
+	}{
+		{
+			name:  "BelowCount",
+			do:    func() { emitter.OnResponse(ctx, log, 2, map[string]any{"done": false}) },
+			wants: nil,
+		},
+		{
+			name:  "ReachCount",
+			do:    func() { emitter.OnResponse(ctx, log, 3, map[string]any{"done": false}) },
+			wants: []runner.Event{"req:third"},
+		},
+		{
+			name:  "OverCountOnce",
+			do:    func() { emitter.OnResponse(ctx, log, 4, map[string]any{"done": false}) },
+			wants: []runner.Event{"req:third"},
+		},
+		{
+			name:  "BodyNotMatched",
+			do:    func() { emitter.OnResponse(ctx, log, 5, "not an object") },
+			wants: []runner.Event{"req:third"},
+		},
+		{
+			name: "BodyMatchedOnce",
+			do: func() {
+				emitter.OnResponse(ctx, log, 6, map[string]any{"done": true})
+				emitter.OnResponse(ctx, log, 7, map[string]any{"done": true})
+			},
+			wants: []runner.Event{"req:third", "req:done"},
+		},
+		{
+			name:  "BreakOtherParam",
+			do:    func() { emitter.OnBreak(ctx, log, matcher.TerminateTypeByStatusCode, "404") },
+			wants: []runner.Event{"req:third", "req:done"},
+		},
+		{
+			name:  "BreakMatched",
+			do:    func() { emitter.OnBreak(ctx, log, matcher.TerminateTypeByStatusCode, "503") },
+			wants: []runner.Event{"req:third", "req:done", "req:failed"},
+		},
+		{
+			name: "BreakOfOtherCondition",
+			do: func() {
+				emitter.OnBreak(ctx, log, matcher.TerminateTypeByTimeout, "")
+				emitter.OnResponse(ctx, log, 10, nil)
+			},
+			wants: []runner.Event{"req:third", "req:done", "req:failed", "req:any"},
+		},
+	}
+	for _, s := range steps {
+		s.do()
+		if got := caster.cast(); !slices.Equal(got, s.wants) {
+			t.Fatalf("%s: expected the events %v, got %v", s.name, s.wants, got)
+		}
+	}
+
+	t.Run("Nil", func(tt *testing.T) {
+		var nilEmitter *runner.RequestEventEmitter
+		nilEmitter.OnResponse(ctx, log, 1, nil)
+		nilEmitter.OnBreak(ctx, log, matcher.TerminateTypeByCount, "")
+	})
+
+	t.Run("Concurrent", func(tt *testing.T) {
+		emits, err := validEmits(tt, `[{event: "req:first", count: 1}]`)
+		if err != nil {
+			tt.Fatalf("failed to validate emits: %v", err)
+		}
+		caster := &recordCaster{DefaultEventCaster: runner.NewDefaultEventCaster()}
+		emitter := runner.NewRequestEventEmitter(caster, emits)
+		var wg sync.WaitGroup
+		for i := range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				emitter.OnResponse(ctx, log, i+1, nil)
+			}()
+		}
+		wg.Wait()
+		if got := caster.cast(); len(got) != 1 {
+			tt.Errorf("expected the event once, got %v", got)
+		}
+	})
+}
+
+// TestMassExecRequestEmitValidate tests the validation of the emits.
+func TestMassExecRequestEmitValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		emits   string
+		wantErr string
+	}{
+		{name: "Valid", emits: `[{event: "req:done", count: 1}]`},
+		{name: "MissingEvent", emits: `[{count: 1}]`, wantErr: "event is required"},
+		{name: "ReservedPrefix", emits: `[{event: "sys:done", count: 1}]`, wantErr: "reserved prefix"},
+		{name: "ZeroCount", emits: `[{event: "req:done", count: 0}]`, wantErr: "count must be greater than 0"},
+		{name: "NoCondition", emits: `[{event: "req:done"}]`, wantErr: "one of count, response_body or on_break"},
+		{name: "InvalidBreak", emits: `[{event: "req:done", on_break: [nope]}]`, wantErr: "invalid terminate type"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			_, err := validEmits(tt, c.emits)
+			switch {
+			case c.wantErr == "" && err != nil:
+				tt.Fatalf("failed to validate: %v", err)
+			case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+				tt.Fatalf("expected the error of %q, got %v", c.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestMassExecEmitDependsOn tests that the event emitted by the request starts all the flows depending on it,
+// while the request keeps running.
+func TestMassExecEmitDependsOn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ready": true}`))
+	}))
+	defer server.Close()
+
+	env := newFlowEnv(t, map[string]string{
+		"main.yaml": `kind: Flow
+step:
+  concurrency: -1
+  flows:
+    - id: load
+      type: file
+      file: load.yaml
+    - id: first
+      type: file
+      file: first.yaml
+      depends_on: [{flow: load, event: "load:ready"}]
+    - id: second
+      type: file
+      file: second.yaml
+      depends_on: [{flow: load, event: "load:ready"}]
+    - id: third
+      type: file
+      file: third.yaml
+      depends_on: [{flow: load, event: "load:never"}]
+`,
+		// the request breaks by the count, after the event is emitted by the first response
+		"load.yaml": `kind: MassExecute
+type: http
+output:
+  enabled: false
+requests:
+  - target_id: server
+    endpoint: /
+    method: GET
+    response_type: json
+    interval: 1ms
+    success_break:
+      - count
+    break:
+      count: 5
+    emit:
+      - event: "load:ready"
+        response_body:
+          - {id: ready, extractor: {type: jmesPath, jmes_path: ready}}
+      - event: "load:never"
+        response_body:
+          - {id: never, extractor: {type: jmesPath, jmes_path: never}}
+`,
+		"first.yaml":  setValue("first", "true"),
+		"second.yaml": setValue("second", "true"),
+		"third.yaml":  setValue("third", "true"),
+	})
+	env.targets["server"] = target.Target{Type: config.TargetTypeHTTP, URL: server.URL}
+	values := &sync.Map{}
+	err := env.run(t, "main.yaml", values)
+	if err == nil || !strings.Contains(err.Error(), "without casting the event") {
+		t.Fatalf("expected the flow waiting for the event never emitted to fail, got %v", err)
+	}
+	for _, key := range []string{"first", "second"} {
+		if got, _ := values.Load(key); got != true {
+			t.Errorf("expected %s to run on the emitted event, got %v", key, got)
+		}
+	}
+	if got, ok := values.Load("third"); ok {
+		t.Errorf("expected third not to run, got %v", got)
+	}
+}
@@ -2,6 +2,8 @@ package runner
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cresplanex/bloader/internal/utils"
 )
@@ -22,8 +24,37 @@ const (
 	RunnerEventValidated Event = "sys:validated"
 	// RunnerEventTerminated represents the event terminated
 	RunnerEventTerminated Event = "sys:terminated"
+	// RunnerEventFailed represents the event failed, it is cast before terminated
+	RunnerEventFailed Event = "sys:failed"
+	// RunnerEventSkipped represents the event skipped, it is cast before terminated
+	RunnerEventSkipped Event = "sys:skipped"
 )
 
+// reservedEventPrefixes are the prefixes of the events which are cast by the system
+var reservedEventPrefixes = []string{"sys:", "slaveConnect:"}
+
+// IsUserDefined returns true if the event is not cast by the system
+func (e Event) IsUserDefined() bool {
+	for _, p := range reservedEventPrefixes {
+		if strings.HasPrefix(string(e), p) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewUserDefinedEvent creates the user-defined event, the reserved prefixes are rejected
+func NewUserDefinedEvent(name string) (Event, error) {
+	if name == "" {
+		return "", fmt.Errorf("event name is required")
+	}
+	e := Event(name)
+	if !e.IsUserDefined() {
+		return "", fmt.Errorf("event %s uses the reserved prefix", name)
+	}
+	return e, nil
+}
+
 // EventCaster is an interface for casting event
 type EventCaster interface {
 	// CastEvent casts the event
@@ -0,0 +1,11 @@
+package runner
+
+// FlowJars returns the number of the jars of the flow scope held by the container
+func (c *CookieJarContainer) FlowJars() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.flows)
+}
+
+// NewFieldTmpl exports newFieldTmpl for the tests
+var NewFieldTmpl = newFieldTmpl
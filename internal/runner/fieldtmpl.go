@@ -0,0 +1,443 @@
+package runner
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"gopkg.in/yaml.v3"
+)
+
+// newFieldTmpl returns the template which renders only the field at the path of the runner file,
+// the path is the keys of the mappings and the index of the sequence, e.g. "websocket", "message" or "requests", 0.
+// The rendered field keeps the nesting of the runner file and the preceding items of the sequence are rendered empty,
+// so it is decoded in the same way as the whole runner file.
+// nil is returned when the field does not change per request, it is used as validated instead of being rendered again,
+// and the whole runner template is returned when the field cannot be separated from it.
+// The field is found by the indentation of the YAML, so it is used only when it renders the same value as the field
+// of the whole runner template, e.g. the field referring to the anchor out of it is not separated.
+func newFieldTmpl(tmplSet *TmplSet, tmpl *template.Template, replaceData map[string]any, path ...any) *template.Template {
+	if tmpl == nil || tmpl.Tree == nil {
+		return tmpl
+	}
+	loaded, _ := replaceData["Dynamic"].(map[string]any)
+	fieldTmpl := tmpl
+	if text, ok := extractTmplField(tmpl.Tree.Root, path); ok && tmplSet != nil {
+		t, err := tmplSet.Parse(fmt.Sprintf("%s#%v", tmpl.Name(), path), text)
+		if err == nil && sameTmplField(tmplSet, tmpl, t, replaceData, path) {
+			fieldTmpl = t
+		}
+	}
+	if isStaticTmpl(fieldTmpl, loaded) {
+		return nil
+	}
+	return fieldTmpl
+}
+
+// sameTmplField reports whether the field template renders the same value at the path as the whole template.
+// Both are rendered with the functions changing on each call fixed, and false is returned when either fails.
+func sameTmplField(
+	tmplSet *TmplSet,
+	tmpl, fieldTmpl *template.Template,
+	replaceData map[string]any,
+	path []any,
+) bool {
+	field := func(t *template.Template) (any, bool) {
+		out, err := tmplSet.executeFixed(t, replaceData)
+		if err != nil {
+			return nil, false
+		}
+		var doc any
+		if err := yaml.Unmarshal(out, &doc); err != nil {
+			return nil, false
+		}
+		return lookupTmplField(doc, path)
+	}
+	want, ok := field(tmpl)
+	if !ok {
+		return false
+	}
+	got, ok := field(fieldTmpl)
+	return ok && reflect.DeepEqual(want, got)
+}
+
+// lookupTmplField returns the value at the path of the decoded runner file
+func lookupTmplField(doc any, path []any) (any, bool) {
+	for _, step := range path {
+		switch s := step.(type) {
+		case string:
+			m, ok := doc.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if doc, ok = m[s]; !ok {
+				return nil, false
+			}
+		case int:
+			l, ok := doc.([]any)
+			if !ok || s >= len(l) {
+				return nil, false
+			}
+			doc = l[s]
+		default:
+			return nil, false
+		}
+	}
+	return doc, true
+}
+
+// tmplLine represents the line of the template source
+type tmplLine struct {
+	start   int
+	end     int
+	indent  int
+	content string
+	// textLen is the length of the line before the first action
+	textLen int
+}
+
+// significant reports whether the line has the content other than the comment
+func (l tmplLine) significant() bool {
+	return l.content != "" && !strings.HasPrefix(l.content, "#")
+}
+
+// isItem reports whether the line starts the item of the sequence
+func (l tmplLine) isItem() bool {
+	return (l.content == "-" || strings.HasPrefix(l.content, "- ")) && l.textLen > l.indent
+}
+
+// isKey reports whether the line starts the key of the mapping
+func (l tmplLine) isKey(key string) bool {
+	rest, ok := strings.CutPrefix(l.content, key+":")
+	return ok && (rest == "" || rest[0] == ' ') && l.textLen >= l.indent+len(key)+1
+}
+
+// tmplSource returns the source of the top level nodes, and the ranges of the nodes other than the text
+func tmplSource(root *parse.ListNode) (string, [][2]int) {
+	var src strings.Builder
+	var actions [][2]int
+	for _, n := range root.Nodes {
+		start := src.Len()
+		src.WriteString(n.String())
+		if n.Type() != parse.NodeText {
+			actions = append(actions, [2]int{start, src.Len()})
+		}
+	}
+	return src.String(), actions
+}
+
+// splitTmplLines splits the source into the lines
+func splitTmplLines(src string, actions [][2]int) []tmplLine {
+	var lines []tmplLine
+	for start := 0; start < len(src); {
+		end := strings.IndexByte(src[start:], '\n') + start + 1
+		if end == start {
+			end = len(src)
+		}
+		raw := strings.TrimRight(src[start:end], "\r\n")
+		content := strings.TrimLeft(raw, " ")
+		line := tmplLine{
+			start:   start,
+			end:     end,
+			indent:  len(raw) - len(content),
+			content: strings.TrimRight(content, " "),
+			textLen: len(raw),
+		}
+		for _, a := range actions {
+			if a[0] < end && a[1] > start {
+				line.textLen = max(min(line.textLen, a[0]-start), 0)
+			}
+		}
+		lines = append(lines, line)
+		start = end
+	}
+	return lines
+}
+
+// extractTmplField extracts the source of the field at the path from the top level of the template,
+// the items of the sequences on the path are written in the block style, e.g. "scenarios", 0, "steps", 1.
+// The field is found by the indentation of the YAML, and false is returned when the field is not written
+// in the text of the top level, or it is cut through the action, e.g. it is inside of if or range.
+func extractTmplField(root *parse.ListNode, path []any) (string, bool) {
+	if len(path) == 0 {
+		return "", false
+	}
+	src, actions := tmplSource(root)
+	lines := splitTmplLines(src, actions)
+	cutsAction := func(offset int) bool {
+		for _, a := range actions {
+			if a[0] < offset && offset < a[1] {
+				return true
+			}
+		}
+		return false
+	}
+
+	var out strings.Builder
+	from, to, parentIndent := 0, len(lines), -1
+	for i, step := range path {
+		last := i == len(path)-1
+		switch s := step.(type) {
+		case string:
+			h, end, ok := findTmplKey(lines, from, to, parentIndent, s)
+			if !ok {
+				return "", false
+			}
+			if !last {
+				if lines[h].content != s+":" {
+					return "", false
+				}
+				fmt.Fprintf(&out, "%s%s:\n", strings.Repeat(" ", lines[h].indent), s)
+				from, to, parentIndent = h+1, end, lines[h].indent
+				continue
+			}
+			from, to = h, end
+		case int:
+			h, end, ok := findTmplItem(lines, from, to, parentIndent, s)
+			if !ok {
+				return "", false
+			}
+			for range s {
+				fmt.Fprintf(&out, "%s- {}\n", strings.Repeat(" ", lines[h].indent))
+			}
+			if !last {
+				// the item is opened on its own line, and its first key is read at the indentation after the dash
+				fmt.Fprintf(&out, "%s-\n", strings.Repeat(" ", lines[h].indent))
+				lines = slices.Clone(lines)
+				rest := strings.TrimLeft(strings.TrimPrefix(lines[h].content, "-"), " ")
+				parentIndent = lines[h].indent
+				lines[h].indent += len(lines[h].content) - len(rest)
+				lines[h].content = rest
+				from, to = h, end
+				continue
+			}
+			from, to = h, end
+		default:
+			return "", false
+		}
+	}
+
+	start, end := lines[from].start, len(src)
+	if to < len(lines) {
+		end = lines[to].start
+	}
+	if cutsAction(start) || cutsAction(end) {
+		return "", false
+	}
+	out.WriteString(src[start:end])
+	return out.String(), true
+}
+
+// findTmplKey finds the key in the child lines of the parent, the line of the key and the end of its block are returned
+func findTmplKey(lines []tmplLine, from, to, parentIndent int, key string) (int, int, bool) {
+	childIndent := -1
+	for j := from; j < to; j++ {
+		l := lines[j]
+		if !l.significant() {
+			continue
+		}
+		if childIndent < 0 {
+			childIndent = l.indent
+			if childIndent <= parentIndent {
+				return 0, 0, false
+			}
+		}
+		if l.indent < childIndent {
+			return 0, 0, false
+		}
+		if l.indent != childIndent || !l.isKey(key) {
+			continue
+		}
+		end := j + 1
+		for ; end < to; end++ {
+			next := lines[end]
+			if !next.significant() {
+				continue
+			}
+			// the sequence can be written at the same indentation as its key
+			if next.indent < childIndent || (next.indent == childIndent && !next.isItem()) {
+				break
+			}
+		}
+		return j, end, true
+	}
+	return 0, 0, false
+}
+
+// findTmplItem finds the item of the sequence in the child lines of the parent,
+// the first line of the item and the end of the item are returned
+func findTmplItem(lines []tmplLine, from, to, parentIndent, index int) (int, int, bool) {
+	itemIndent := -1
+	item := -1
+	start := -1
+	for j := from; j < to; j++ {
+		l := lines[j]
+		if !l.significant() {
+			continue
+		}
+		if itemIndent < 0 {
+			itemIndent = l.indent
+			if itemIndent < parentIndent || !l.isItem() {
+				return 0, 0, false
+			}
+		}
+		if l.indent < itemIndent || (l.indent == itemIndent && !l.isItem()) {
+			return 0, 0, false
+		}
+		if l.indent != itemIndent {
+			continue
+		}
+		if start >= 0 {
+			return start, j, true
+		}
+		item++
+		if item == index {
+			start = j
+		}
+	}
+	if start >= 0 {
+		return start, to, true
+	}
+	return 0, 0, false
+}
+
+// isStaticTmpl reports whether the template renders the same result for each request.
+// The template is static when it refers only to the dynamic values fixed on loading the runner file,
+// and it calls only the functions known to return the same result for the same arguments.
+func isStaticTmpl(tmpl *template.Template, loaded map[string]any) bool {
+	w := staticTmplWalker{
+		tmpl:    tmpl,
+		loaded:  loaded,
+		visited: map[string]struct{}{tmpl.Name(): {}},
+	}
+	return w.walk(tmpl.Tree.Root)
+}
+
+// staticTmplWalker walks the nodes of the template to find the values and the functions changing per request
+type staticTmplWalker struct {
+	tmpl    *template.Template
+	loaded  map[string]any
+	visited map[string]struct{}
+}
+
+// walkTmpl walks the template of the name, the template not parsed yet is resolved on execution, so it is not static
+func (w staticTmplWalker) walkTmpl(name string) bool {
+	if _, ok := w.visited[name]; ok {
+		return true
+	}
+	w.visited[name] = struct{}{}
+	t := w.tmpl.Lookup(name)
+	if t == nil || t.Tree == nil {
+		return false
+	}
+	return w.walk(t.Tree.Root)
+}
+
+// isDot reports whether the pipe passes only the dot
+func isDot(pipe *parse.PipeNode) bool {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	_, ok := pipe.Cmds[0].Args[0].(*parse.DotNode)
+	return ok
+}
+
+// staticFields reports whether the fields do not refer to the dynamic values changing per request
+func (w staticTmplWalker) staticFields(fields []string) bool {
+	if len(fields) == 0 || fields[0] != "Dynamic" {
+		return true
+	}
+	if len(fields) == 1 {
+		return false
+	}
+	_, ok := w.loaded[fields[1]]
+	return ok
+}
+
+func (w staticTmplWalker) walk(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return true
+		}
+		for _, c := range n.Nodes {
+			if !w.walk(c) {
+				return false
+			}
+		}
+	case *parse.ActionNode:
+		return w.walk(n.Pipe)
+	case *parse.IfNode:
+		return w.walkBranch(&n.BranchNode)
+	case *parse.RangeNode:
+		return w.walkBranch(&n.BranchNode)
+	case *parse.WithNode:
+		return w.walkBranch(&n.BranchNode)
+	case *parse.TemplateNode:
+		if n.Pipe != nil && !isDot(n.Pipe) && !w.walk(n.Pipe) {
+			return false
+		}
+		return w.walkTmpl(n.Name)
+	case *parse.PipeNode:
+		if n == nil {
+			return true
+		}
+		for _, cmd := range n.Cmds {
+			if !w.walk(cmd) {
+				return false
+			}
+		}
+	case *parse.CommandNode:
+		args := n.Args
+		if len(args) >= 2 {
+			ident, isIdent := args[0].(*parse.IdentifierNode)
+			name, isName := args[1].(*parse.StringNode)
+			if isIdent && isName && ident.Ident == TmplFuncInclude {
+				if !w.walkTmpl(name.Text) {
+					return false
+				}
+				// the included template is walked, so the dot can be passed to it
+				args = args[2:]
+				for _, arg := range args {
+					if _, ok := arg.(*parse.DotNode); !ok && !w.walk(arg) {
+						return false
+					}
+				}
+				return true
+			}
+		}
+		for _, arg := range args {
+			if !w.walk(arg) {
+				return false
+			}
+		}
+	case *parse.DotNode:
+		// the dot holds the dynamic values
+		return false
+	case *parse.IdentifierNode:
+		if isPerCallTmplFunc(n.Ident) {
+			return false
+		}
+	case *parse.FieldNode:
+		return w.staticFields(n.Ident)
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" {
+			return len(n.Ident) > 1 && w.staticFields(n.Ident[1:])
+		}
+	case *parse.ChainNode:
+		for _, f := range n.Field {
+			if f == "Dynamic" {
+				return false
+			}
+		}
+		return w.walk(n.Node)
+	}
+	return true
+}
+
+func (w staticTmplWalker) walkBranch(n *parse.BranchNode) bool {
+	return w.walk(n.Pipe) && w.walk(n.List) && w.walk(n.ElseList)
+}
@@ -0,0 +1,121 @@
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// scenarioSteps is the runner file whose steps are separated by the path of the scenario and the step
+const scenarioSteps = `kind: Scenario
+scenarios:
+  - name: a
+    weight: 1
+    steps:
+      - endpoint: /a/{{ .Dynamic.Iteration }}
+        method: GET
+  - steps:
+      - endpoint: /static
+        method: GET
+      - endpoint: /b/{{ .Dynamic.Extracted.id }}
+        method: POST
+    name: b
+    weight: 1
+`
+
+// TestFieldTmpl tests the templates of the fields at the paths of the runner file.
+func TestFieldTmpl(t *testing.T) {
+	cases := []struct {
+		name string
+		tmpl string
+		path []any
+		// want is the endpoint of the rendered step, it is empty when the step is static
+		want string
+		// whole reports whether the whole runner template is rendered
+		whole bool
+	}{
+		{name: "Step", tmpl: scenarioSteps, path: []any{"scenarios", 0, "steps", 0}, want: "/a/3"},
+		{name: "StaticStep", tmpl: scenarioSteps, path: []any{"scenarios", 1, "steps", 0}},
+		{name: "KeyOnDashLine", tmpl: scenarioSteps, path: []any{"scenarios", 1, "steps", 1}, want: "/b/x"},
+		{
+			name: "InsideRange",
+			tmpl: `scenarios:
+{{- range $i := until 2 }}
+  - name: s{{ $i }}
+    steps:
+      - endpoint: /r/{{ $.Dynamic.Iteration }}
+{{- end }}
+`,
+			path:  []any{"scenarios", 1, "steps", 0},
+			want:  "/r/3",
+			whole: true,
+		},
+		{
+			name:  "FlowStyle",
+			tmpl:  "scenarios: [{name: a, steps: [{endpoint: '/f/{{ .Dynamic.Iteration }}'}]}]\n",
+			path:  []any{"scenarios", 0, "steps", 0},
+			want:  "/f/3",
+			whole: true,
+		},
+		{
+			name:  "AnchorOutOfField",
+			tmpl:  "x-step: &step {endpoint: '/y/{{ .Dynamic.Iteration }}'}\nscenarios:\n  - steps:\n      - *step\n",
+			path:  []any{"scenarios", 0, "steps", 0},
+			want:  "/y/3",
+			whole: true,
+		},
+	}
+	ctx := context.Background()
+	data := map[string]any{"Dynamic": map[string]any{"LoopCount": 0}}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			tmplSet := runner.NewTmplSet(ctx, runner.NewLocalTmplFactor(tt.TempDir()), runner.NewTmplFuncMap(ctx, nil, nil))
+			tmpl, err := tmplSet.Parse("yaml", c.tmpl)
+			if err != nil {
+				tt.Fatalf("failed to parse template: %v", err)
+			}
+			fieldTmpl := runner.NewFieldTmpl(tmplSet, tmpl, data, c.path...)
+			if c.want == "" {
+				if fieldTmpl != nil {
+					tt.Fatalf("expected the static step, got %q", fieldTmpl.Tree.Root)
+				}
+				return
+			}
+			if fieldTmpl == nil {
+				tt.Fatalf("expected the step rendered per step")
+			}
+			if whole := fieldTmpl == tmpl; whole != c.whole {
+				tt.Fatalf("expected the whole template to be %t, got %q", c.whole, fieldTmpl.Tree.Root)
+			}
+			var buf bytes.Buffer
+			if err := fieldTmpl.Execute(&buf, map[string]any{"Dynamic": map[string]any{
+				"Iteration": 3,
+				"Extracted": map[string]any{"id": "x"},
+			}}); err != nil {
+				tt.Fatalf("failed to execute template: %v", err)
+			}
+			if !c.whole && strings.Contains(buf.String(), "/static") {
+				tt.Errorf("expected only the step to be rendered, got %s", buf.String())
+			}
+			var doc struct {
+				Scenarios []struct {
+					Steps []struct {
+						Endpoint string `yaml:"endpoint"`
+					} `yaml:"steps"`
+				} `yaml:"scenarios"`
+			}
+			if err := yaml.Unmarshal(buf.Bytes(), &doc); err != nil {
+				tt.Fatalf("failed to unmarshal yaml: %v\n%s", err, buf.String())
+			}
+			s, i := c.path[1].(int), c.path[3].(int)
+			if len(doc.Scenarios) <= s || len(doc.Scenarios[s].Steps) <= i || doc.Scenarios[s].Steps[i].Endpoint != c.want {
+				tt.Errorf("expected the endpoint %s, got %s", c.want, buf.String())
+			}
+		})
+	}
+}
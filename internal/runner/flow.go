@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	pb "github.com/cresplanex/bloader/gen/pb/cresplanex/bloader/v1"
 
@@ -127,6 +130,12 @@ type FlowStepFlow struct {
 	Flows            []FlowStepFlow          `yaml:"flows"`
 	Concurrency      *int                    `yaml:"concurrency"`
 	Executors        []FlowStepFlowExecutor  `yaml:"executors"`
+	Timeout          *string                 `yaml:"timeout"`
+	Retry            FlowStepFlowRetry       `yaml:"retry"`
+	OnError          *string                 `yaml:"on_error"`
+	If               *string                 `yaml:"if"`
+	ForEach          FlowStepFlowForEach     `yaml:"for_each"`
+	While            FlowStepFlowWhile       `yaml:"while"`
 }
 
 // ValidFlowStepFlow represents a valid flow step flow
@@ -142,9 +151,58 @@ type ValidFlowStepFlow struct {
 	Flows            []ValidFlowStepFlow
 	Concurrency      int
 	Executors        []ValidFlowStepFlowExecutor
+	Timeout          time.Duration
+	Retry            ValidFlowStepFlowRetry
+	OnError          FlowOnError
+	If               string
+	ForEach          ValidFlowStepFlowForEach
+	While            ValidFlowStepFlowWhile
 	waitFunc         func(ctx context.Context) error
 }
 
+// FlowOnError represents the behavior when the flow fails
+type FlowOnError string
+
+const (
+	// FlowOnErrorFail aborts the whole flow tree
+	FlowOnErrorFail FlowOnError = "fail"
+	// FlowOnErrorContinue records the failure and continues the other flows
+	FlowOnErrorContinue FlowOnError = "continue"
+	// FlowOnErrorSkipDependents records the failure and skips the flows depending on the failed flow
+	FlowOnErrorSkipDependents FlowOnError = "skip_dependents"
+)
+
+// FlowStepFlowRetry represents the retry policy of the flow step flow
+type FlowStepFlowRetry struct {
+	Attempts *int    `yaml:"attempts"`
+	Backoff  *string `yaml:"backoff"`
+}
+
+// ValidFlowStepFlowRetry represents a valid retry policy of the flow step flow
+type ValidFlowStepFlowRetry struct {
+	Attempts int
+	Backoff  time.Duration
+}
+
+// Validate validates a flow step flow retry
+func (r FlowStepFlowRetry) Validate() (ValidFlowStepFlowRetry, error) {
+	valid := ValidFlowStepFlowRetry{Attempts: 1}
+	if r.Attempts != nil {
+		if *r.Attempts < 1 {
+			return ValidFlowStepFlowRetry{}, fmt.Errorf("attempts must be greater than 0")
+		}
+		valid.Attempts = *r.Attempts
+	}
+	if r.Backoff != nil {
+		backoff, err := time.ParseDuration(*r.Backoff)
+		if err != nil {
+			return ValidFlowStepFlowRetry{}, fmt.Errorf("failed to parse backoff: %w", err)
+		}
+		valid.Backoff = backoff
+	}
+	return valid, nil
+}
+
 // FlowStepFlowExecutorOutput represents a flow step flow executor output
 type FlowStepFlowExecutorOutput struct {
 	Enabled  bool    `yaml:"enabled"`
@@ -272,6 +330,39 @@ func (f FlowStepFlow) Validate(valid *ValidFlowStepFlow, idSet map[string]struct
 		}
 		valid.ThreadOnlyValues = append(valid.ThreadOnlyValues, valValue)
 	}
+	if f.Timeout != nil {
+		timeout, err := time.ParseDuration(*f.Timeout)
+		if err != nil {
+			return fmt.Errorf("failed to parse timeout: %w", err)
+		}
+		valid.Timeout = timeout
+	}
+	validRetry, err := f.Retry.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate retry: %w", err)
+	}
+	valid.Retry = validRetry
+	valid.OnError = FlowOnErrorFail
+	if f.OnError != nil {
+		switch FlowOnError(*f.OnError) {
+		case FlowOnErrorFail, FlowOnErrorContinue, FlowOnErrorSkipDependents:
+			valid.OnError = FlowOnError(*f.OnError)
+		default:
+			return fmt.Errorf("invalid on_error value: %s", *f.OnError)
+		}
+	}
+	if f.If != nil {
+		valid.If = *f.If
+	}
+	if valid.ForEach, err = f.ForEach.Validate(); err != nil {
+		return fmt.Errorf("failed to validate for_each: %w", err)
+	}
+	if valid.While, err = f.While.Validate(); err != nil {
+		return fmt.Errorf("failed to validate while: %w", err)
+	}
+	if valid.ForEach.Enabled && valid.While.Enabled {
+		return fmt.Errorf("for_each and while cannot be used together")
+	}
 	if f.Type == nil {
 		return fmt.Errorf("type is required")
 	}
@@ -334,22 +425,93 @@ func (f FlowStepFlow) Validate(valid *ValidFlowStepFlow, idSet map[string]struct
 }
 
 type flowExecutor struct {
-	flowType        FlowStepFlowType
-	filename        string
+	flow            ValidFlowStepFlow
 	rootDir         string
-	rootStr         *sync.Map
 	threadOnlyStore *sync.Map
-	concurrency     int
-	flows           []ValidFlowStepFlow
-	executors       []ValidFlowStepFlowExecutor
 	loopCount       int
-	waitFunc        func(ctx context.Context) error
-	castFunc        func(ctx context.Context) error
+	castFunc        func(ctx context.Context, result flowResult) error
 	eventCaster     *utils.Broadcaster[Event]
+	checkpoint      *Checkpoint
+	results         *FlowResults
+	recorder        *recordingEventCaster
 }
 
 type closer func() error
 
+// FlowStatus represents the result status of the flow
+type FlowStatus string
+
+const (
+	// FlowStatusSucceeded represents the flow succeeded
+	FlowStatusSucceeded FlowStatus = "succeeded"
+	// FlowStatusFailed represents the flow failed
+	FlowStatusFailed FlowStatus = "failed"
+	// FlowStatusSkipped represents the flow skipped
+	FlowStatusSkipped FlowStatus = "skipped"
+	// FlowStatusInterrupted represents the flow interrupted before it finished, it is executed again on resume
+	FlowStatusInterrupted FlowStatus = "interrupted"
+)
+
+// flowResult represents the result of the flow which the dependents refer to
+type flowResult struct {
+	Status         FlowStatus
+	SkipDependents bool
+	// Events is the events cast by the flow, accumulated over the executors of the count
+	Events []Event
+	// Final reports whether all the executors of the flow are terminated,
+	// after which the events which are not cast yet are never cast
+	Final bool
+}
+
+var (
+	// errFlowSkipped is returned by the wait function when the flow must be skipped
+	errFlowSkipped = errors.New("flow skipped")
+	// errEventNeverCast is returned by the wait function when the depended flow succeeded without the event
+	errEventNeverCast = errors.New("depended flow terminated without casting the event")
+)
+
+// resolveWait resolves the pending events by the result of the depended flow,
+// and returns errFlowSkipped when the waiting flow must be skipped.
+// The result events are broadcast asynchronously, so the result is used instead of their order.
+// When the depended flow is terminated, the pending events which it has never cast cannot arrive any more,
+// the waiting flow is skipped if the depended flow failed or was skipped, and the wait fails otherwise.
+func resolveWait(waited, pending []Event, result flowResult) ([]Event, error) {
+	waitFailed := slices.Contains(waited, RunnerEventFailed)
+	waitSkipped := slices.Contains(waited, RunnerEventSkipped)
+	switch result.Status {
+	case FlowStatusSucceeded, FlowStatusInterrupted:
+		if waitFailed || waitSkipped {
+			return pending, errFlowSkipped
+		}
+	case FlowStatusFailed:
+		if waitSkipped || (result.SkipDependents && !waitFailed) {
+			return pending, errFlowSkipped
+		}
+		pending = utils.RemoveElement(pending, RunnerEventFailed)
+	case FlowStatusSkipped:
+		if waitFailed || !waitSkipped {
+			return pending, errFlowSkipped
+		}
+		pending = utils.RemoveElement(pending, RunnerEventSkipped)
+	}
+	if !result.Final {
+		return pending, nil
+	}
+	var never []Event
+	for _, event := range pending {
+		if event != RunnerEventTerminated && !slices.Contains(result.Events, event) {
+			never = append(never, event)
+		}
+	}
+	if len(never) == 0 {
+		return pending, nil
+	}
+	if result.Status != FlowStatusSucceeded {
+		return pending, errFlowSkipped
+	}
+	return pending, fmt.Errorf("%w: %v", errEventNeverCast, never)
+}
+
 func createBroadCastMap(
 	flows []ValidFlowStepFlow,
 	broadCastMap map[string]*utils.Broadcaster[Event],
@@ -374,10 +536,11 @@ func createBroadCastMap(
 func attachWaitChan(
 	flows []ValidFlowStepFlow,
 	broadCastMap map[string]*utils.Broadcaster[Event],
+	results *sync.Map,
 ) error {
 	for i, flow := range flows {
 		if len(flow.Flows) > 0 {
-			if err := attachWaitChan(flow.Flows, broadCastMap); err != nil {
+			if err := attachWaitChan(flow.Flows, broadCastMap, results); err != nil {
 				return err
 			}
 		}
@@ -395,13 +558,25 @@ func attachWaitChan(
 				return fmt.Errorf("failed to find depends_on %s", k)
 			}
 			waitChan := caster.Subscribe()
-			//nolint:unparam
 			flowWaitFuncMap[k] = func(ctx context.Context) error {
 				mustEvents := v
 				for len(mustEvents) > 0 {
 					select {
 					case event := <-waitChan:
 						mustEvents = utils.RemoveElement(mustEvents, event)
+						switch event {
+						case RunnerEventTerminated, RunnerEventFailed, RunnerEventSkipped:
+						default:
+							continue
+						}
+						result, ok := results.Load(k)
+						if !ok {
+							continue
+						}
+						var err error
+						if mustEvents, err = resolveWait(v, mustEvents, result.(flowResult)); err != nil {
+							return err
+						}
 					case <-ctx.Done():
 						return nil
 					}
@@ -436,6 +611,10 @@ func (f *ValidFlow) Run(
 	authFactor AuthenticatorFactor,
 	outFactor OutputFactor,
 	targetFactor TargetFactor,
+	barrier Barrier,
+	checkpoint *Checkpoint,
+	flowResults *FlowResults,
+	cookieJars *CookieJarContainer,
 	str *sync.Map,
 	outputRoot string,
 	callCount int,
@@ -454,7 +633,8 @@ func (f *ValidFlow) Run(
 			}
 		}
 	}()
-	if err := attachWaitChan(f.Step.Flows, broadCastMap); err != nil {
+	results := &sync.Map{}
+	if err := attachWaitChan(f.Step.Flows, broadCastMap, results); err != nil {
 		return err
 	}
 	return run(
@@ -468,6 +648,10 @@ func (f *ValidFlow) Run(
 		authFactor,
 		outFactor,
 		targetFactor,
+		barrier,
+		checkpoint,
+		flowResults,
+		cookieJars,
 		str,
 		outputRoot,
 		callCount,
@@ -475,6 +659,8 @@ func (f *ValidFlow) Run(
 		f.Step.Concurrency,
 		slaveValues,
 		broadCastMap,
+		results,
+		nil,
 	)
 }
 
@@ -489,6 +675,10 @@ func run(
 	authFactor AuthenticatorFactor,
 	outFactor OutputFactor,
 	targetFactor TargetFactor,
+	barrier Barrier,
+	checkpoint *Checkpoint,
+	flowResults *FlowResults,
+	cookieJars *CookieJarContainer,
 	str *sync.Map,
 	outputRoot string,
 	callCount int,
@@ -496,6 +686,8 @@ func run(
 	concurrency int,
 	slaveValues map[string]any,
 	broadCastMap map[string]*utils.Broadcaster[Event],
+	results *sync.Map,
+	threadOnlyValues map[string]any,
 ) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -508,24 +700,41 @@ func run(
 		sumCount += flow.Count
 	}
 
-	executors := make([]flowExecutor, sumCount)
+	executors := make([]flowExecutor, 0, sumCount)
 
-	var count int
 	for _, flow := range flows {
 		caster, ok := broadCastMap[flow.ID]
 		if !ok {
 			return fmt.Errorf("failed to find depends_on %s", flow.ID)
 		}
-		castFunc := func(_ context.Context) error {
+		var castMu sync.Mutex
+		var castEvents []Event
+		remaining := max(flow.Count, 1)
+		castFunc := func(_ context.Context, result flowResult) error {
+			castMu.Lock()
+			castEvents = append(castEvents, result.Events...)
+			remaining--
+			result.Events = slices.Clone(castEvents)
+			result.Final = remaining <= 0
+			// the result must be stored before the events, the waiting flows refer to it
+			results.Store(flow.ID, result)
+			castMu.Unlock()
+			switch result.Status {
+			case FlowStatusFailed:
+				caster.Broadcast(RunnerEventFailed)
+			case FlowStatusSkipped:
+				caster.Broadcast(RunnerEventSkipped)
+			}
 			caster.Broadcast(RunnerEventTerminated)
 			return nil
 		}
-		rootStr := &sync.Map{}
 		for _, v := range flow.Values {
 			str.Store(v.Key, v.Value)
-			rootStr.Store(v.Key, v.Value)
 		}
 		threadOnlyStore := &sync.Map{}
+		for k, v := range threadOnlyValues {
+			threadOnlyStore.Store(k, v)
+		}
 		for _, v := range flow.ThreadOnlyValues {
 			threadOnlyStore.Store(v.Key, v.Value)
 		}
@@ -538,21 +747,16 @@ func run(
 					rootDir = outputRoot
 				}
 
-				executors[count] = flowExecutor{
-					flowType:        flow.Type,
-					filename:        flow.File,
+				executors = append(executors, flowExecutor{
+					flow:            flow,
 					rootDir:         rootDir,
-					rootStr:         rootStr,
 					threadOnlyStore: threadOnlyStore,
-					concurrency:     flow.Concurrency,
-					flows:           flow.Flows,
-					executors:       flow.Executors,
 					loopCount:       j,
-					waitFunc:        flow.waitFunc,
 					castFunc:        castFunc,
 					eventCaster:     caster,
-				}
-				count++
+					checkpoint:      checkpoint.WithScope(fmt.Sprintf("%s#%d", flow.ID, j)),
+					results:         flowResults.WithScope(fmt.Sprintf("%s#%d", flow.ID, j)),
+				})
 			}
 		} else {
 			var rootDir string
@@ -562,242 +766,386 @@ func run(
 				rootDir = outputRoot
 			}
 
-			executors[count] = flowExecutor{
-				flowType:        flow.Type,
-				filename:        flow.File,
+			executors = append(executors, flowExecutor{
+				flow:            flow,
 				rootDir:         rootDir,
-				rootStr:         rootStr,
 				threadOnlyStore: threadOnlyStore,
-				concurrency:     flow.Concurrency,
-				flows:           flow.Flows,
-				executors:       flow.Executors,
 				loopCount:       0,
-				waitFunc:        flow.waitFunc,
 				castFunc:        castFunc,
 				eventCaster:     caster,
+				checkpoint:      checkpoint.WithScope(fmt.Sprintf("%s#%d", flow.ID, 0)),
+				results:         flowResults.WithScope(fmt.Sprintf("%s#%d", flow.ID, 0)),
+			})
+		}
+	}
+
+	// the cookies of the flow scope are released when the flows end
+	defer func() {
+		for _, executor := range executors {
+			cookieJars.Release(executor.threadOnlyStore)
+		}
+	}()
+
+	execOnce := func(ctx context.Context, executor flowExecutor) error {
+		switch executor.flow.Type {
+		case FlowStepFlowTypeFile:
+			var eventCaster EventCaster = NewDefaultEventCasterWithBroadcaster(executor.eventCaster)
+			if executor.recorder != nil {
+				eventCaster = executor.recorder
+			}
+			baseExecutor := BaseExecutor{
+				Env:                   env,
+				EncryptCtr:            encryptCtr,
+				Logger:                log,
+				SlaveConnectContainer: slaveConCtr,
+				TmplFactor:            tmplFactor,
+				Store:                 store,
+				AuthFactor:            authFactor,
+				OutputFactor:          outFactor,
+				TargetFactor:          targetFactor,
+				Barrier:               barrier,
+				Checkpoint:            executor.checkpoint,
+				Results:               executor.results,
+				CookieJars:            cookieJars,
+				FlowID:                executor.flow.ID,
 			}
-			count++
+			return baseExecutor.Execute(
+				ctx,
+				executor.flow.File,
+				str,
+				executor.threadOnlyStore,
+				executor.rootDir,
+				executor.loopCount,
+				callCount+1,
+				slaveValues,
+				eventCaster,
+			)
+		case FlowStepFlowTypeSlaveCmd:
+			return slaveCmdRun(
+				ctx,
+				log,
+				slaveConCtr,
+				outFactor,
+				str,
+				executor.rootDir,
+				executor.flow,
+			)
+		case FlowStepFlowTypeFlow:
+			return run(
+				ctx,
+				env,
+				log,
+				slaveConCtr,
+				encryptCtr,
+				tmplFactor,
+				store,
+				authFactor,
+				outFactor,
+				targetFactor,
+				barrier,
+				executor.checkpoint,
+				executor.results,
+				cookieJars,
+				str,
+				executor.rootDir,
+				callCount+1,
+				executor.flow.Flows,
+				executor.flow.Concurrency,
+				slaveValues,
+				broadCastMap,
+				results,
+				syncMapToMap(executor.threadOnlyStore),
+			)
 		}
+		return nil
 	}
 
-	var sequential bool
-	if concurrency < 0 {
-		concurrency = len(executors)
+	// finish reports the result of the executor and casts it to the dependents
+	finish := func(ctx context.Context, executor flowExecutor, result flowResult, reason string) error {
+		executor.checkpoint.Report(result.Status, reason, executor.rootDir)
+		return executor.castFunc(ctx, result)
 	}
-	if concurrency == 0 {
-		concurrency = 1
-		sequential = true
+
+	evaluator := newFlowExprEvaluator(ctx, store, encryptCtr, slaveValues, flowResults, callCount+1)
+
+	// wait waits for the depends_on and evaluates the if,
+	// and casts the skipped result when the flow must be skipped
+	wait := func(ctx context.Context, i int, executor flowExecutor) (bool, error) {
+		if err := executor.flow.waitFunc(ctx); err != nil {
+			if errors.Is(err, errFlowSkipped) {
+				log.Info(ctx, fmt.Sprintf("skipped flow[%d]", i),
+					logger.Value("id", executor.flow.ID))
+				if err := finish(ctx, executor, flowResult{Status: FlowStatusSkipped}, "skipped by depends_on"); err != nil {
+					return false, fmt.Errorf("failed to cast: %w", err)
+				}
+				return true, nil
+			}
+			log.Error(ctx, fmt.Sprintf("failed to wait[%d]", i),
+				logger.Value("error", err))
+			return false, fmt.Errorf("failed to wait: %w", err)
+		}
+		if executor.flow.If == "" {
+			return false, nil
+		}
+		ok, err := evaluator.Cond(
+			executor.flow.If,
+			evaluator.data(str, executor.threadOnlyStore, executor.loopCount, 0),
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to evaluate if of flow %s: %w", executor.flow.ID, err)
+		}
+		if !ok {
+			log.Info(ctx, fmt.Sprintf("skipped flow[%d] by if", i),
+				logger.Value("id", executor.flow.ID))
+			if err := finish(ctx, executor, flowResult{Status: FlowStatusSkipped}, "skipped by if"); err != nil {
+				return false, fmt.Errorf("failed to cast: %w", err)
+			}
+			return true, nil
+		}
+		return false, nil
 	}
 
-	if sequential {
-		for i, executor := range executors {
-			if err := executor.waitFunc(ctx); err != nil {
-				log.Error(ctx, fmt.Sprintf("failed to wait[%d]", i),
-					logger.Value("error", err))
-				return fmt.Errorf("failed to wait: %w", err)
+	// attempt executes the flow with the timeout and retry policy
+	attempt := func(ctx context.Context, i int, executor flowExecutor) error {
+		policy := executor.flow
+		var err error
+		for n := 1; n <= policy.Retry.Attempts; n++ {
+			err = func() error {
+				attemptCtx := ctx
+				if policy.Timeout > 0 {
+					var attemptCancel context.CancelFunc
+					attemptCtx, attemptCancel = context.WithTimeout(ctx, policy.Timeout)
+					defer attemptCancel()
+				}
+				err := execOnce(attemptCtx, executor)
+				if err == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+					err = fmt.Errorf("timed out after %s", policy.Timeout)
+				}
+				return err
+			}()
+			if err == nil || ctx.Err() != nil || n == policy.Retry.Attempts {
+				break
+			}
+			log.Warn(ctx, fmt.Sprintf("failed to execute flow[%d], retrying", i),
+				logger.Value("id", policy.ID),
+				logger.Value("attempt", n),
+				logger.Value("error", err))
+			select {
+			case <-time.After(policy.Retry.Backoff):
+			case <-ctx.Done():
 			}
-			switch executor.flowType {
-			case FlowStepFlowTypeFile:
-				baseExecutor := BaseExecutor{
-					Env:                   env,
-					EncryptCtr:            encryptCtr,
-					Logger:                log,
-					SlaveConnectContainer: slaveConCtr,
-					TmplFactor:            tmplFactor,
-					Store:                 store,
-					AuthFactor:            authFactor,
-					OutputFactor:          outFactor,
-					TargetFactor:          targetFactor,
+		}
+		return err
+	}
+
+	// iterate executes the flow for each iteration of for_each or while,
+	// the item and the index are bound into the thread only values.
+	iterate := func(ctx context.Context, i int, executor flowExecutor) error {
+		policy := executor.flow
+		bind := func(idx int, iterStore *sync.Map, bindings map[string]any) flowExecutor {
+			it := executor
+			it.threadOnlyStore = iterStore
+			it.checkpoint = executor.checkpoint.WithScope(strconv.Itoa(idx))
+			it.results = executor.results.WithScope(strconv.Itoa(idx))
+			it.flow.ThreadOnlyValues = slices.Clone(executor.flow.ThreadOnlyValues)
+			for k, v := range bindings {
+				iterStore.Store(k, v)
+				it.flow.ThreadOnlyValues = append(it.flow.ThreadOnlyValues, ValidFlowStepFlowValue{Key: k, Value: v})
+			}
+			return it
+		}
+		copyStore := func() *sync.Map {
+			iterStore := &sync.Map{}
+			executor.threadOnlyStore.Range(func(key, value any) bool {
+				iterStore.Store(key, value)
+				return true
+			})
+			return iterStore
+		}
+		switch {
+		case policy.ForEach.Enabled:
+			items, err := evaluator.Items(
+				policy.ForEach.Items,
+				evaluator.data(str, executor.threadOnlyStore, executor.loopCount, 0),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate for_each items: %w", err)
+			}
+			for idx, item := range items {
+				bindings := map[string]any{policy.ForEach.As: item}
+				if policy.ForEach.IndexAs != "" {
+					bindings[policy.ForEach.IndexAs] = idx
 				}
-				err := baseExecutor.Execute(
-					ctx,
-					executor.filename,
-					str,
-					executor.threadOnlyStore,
-					executor.rootDir,
-					executor.loopCount,
-					callCount+1,
-					slaveValues,
-					NewDefaultEventCasterWithBroadcaster(executor.eventCaster),
-				)
+				iterStore := copyStore()
+				err := attempt(ctx, i, bind(idx, iterStore, bindings))
+				// each iteration has its own cookies
+				cookieJars.Release(iterStore)
 				if err != nil {
-					log.Error(ctx, fmt.Sprintf("failed to execute flow[%d]", i),
-						logger.Value("error", err))
-					return fmt.Errorf("failed to execute flow: %w", err)
+					return fmt.Errorf("failed to execute for_each[%d]: %w", idx, err)
 				}
-			case FlowStepFlowTypeSlaveCmd:
-				err := slaveCmdRun(
-					ctx,
-					log,
-					slaveConCtr,
-					outFactor,
-					str,
-					executor.rootDir,
-					flows[i],
-				)
-				if err != nil {
-					log.Error(ctx, fmt.Sprintf("failed to execute flow[%d]", i),
-						logger.Value("error", err))
-					return fmt.Errorf("failed to execute flow: %w", err)
+			}
+			return nil
+		case policy.While.Enabled:
+			// the thread only values are shared between the iterations, so the condition can refer to the updated values
+			iterStore := copyStore()
+			defer cookieJars.Release(iterStore)
+			for idx := 0; ; idx++ {
+				if idx >= policy.While.MaxIterations {
+					// the loop which never converges must not be reported as succeeded
+					return fmt.Errorf("while condition still held after max_iterations %d", policy.While.MaxIterations)
 				}
-				log.Debug(ctx, "flow finished")
-			case FlowStepFlowTypeFlow:
-				err := run(
-					ctx,
-					env,
-					log,
-					slaveConCtr,
-					encryptCtr,
-					tmplFactor,
-					store,
-					authFactor,
-					outFactor,
-					targetFactor,
-					str,
-					executor.rootDir,
-					callCount+1,
-					executor.flows,
-					executor.concurrency,
-					slaveValues,
-					broadCastMap,
+				ok, err := evaluator.Cond(
+					policy.While.Condition,
+					evaluator.data(str, iterStore, executor.loopCount, idx),
 				)
 				if err != nil {
-					log.Error(ctx, fmt.Sprintf("failed to execute flow[%d]", i),
-						logger.Value("error", err))
-					return fmt.Errorf("failed to execute flow: %w", err)
+					return fmt.Errorf("failed to evaluate while condition: %w", err)
+				}
+				if !ok {
+					return nil
+				}
+				bindings := map[string]any{}
+				if policy.While.IndexAs != "" {
+					bindings[policy.While.IndexAs] = idx
+				}
+				if err := attempt(ctx, i, bind(idx, iterStore, bindings)); err != nil {
+					return fmt.Errorf("failed to execute while[%d]: %w", idx, err)
 				}
-				log.Debug(ctx, "flow finished")
 			}
+		default:
+			return attempt(ctx, i, executor)
+		}
+	}
 
-			if err := executor.castFunc(ctx); err != nil {
-				log.Error(ctx, fmt.Sprintf("failed to cast[%d]", i),
+	// execute executes the flow and casts the result.
+	// The returned error aborts the flow tree.
+	execute := func(ctx context.Context, i int, executor flowExecutor) error {
+		policy := executor.flow
+		// the nested flows are resumed by themselves, so the dependents on them are released
+		if policy.Type != FlowStepFlowTypeFlow {
+			if record, ok := executor.checkpoint.Completed(); ok {
+				log.Info(ctx, fmt.Sprintf("resumed completed flow[%d]", i),
+					logger.Value("id", policy.ID))
+				for _, event := range record.Events {
+					executor.eventCaster.Broadcast(event)
+				}
+				if err := finish(ctx, executor, flowResult{
+					Status: FlowStatusSucceeded,
+					Events: record.Events,
+				}, "resumed from the checkpoint"); err != nil {
+					return fmt.Errorf("failed to cast: %w", err)
+				}
+				return nil
+			}
+		}
+		// the cast events are recorded for the checkpoint and for the dependents waiting on them
+		executor.recorder = newRecordingEventCaster(NewDefaultEventCasterWithBroadcaster(executor.eventCaster))
+		err := iterate(ctx, i, executor)
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf("failed to execute flow[%d]", i),
+				logger.Value("id", policy.ID),
+				logger.Value("error", err))
+			if castErr := finish(ctx, executor, flowResult{
+				Status:         FlowStatusFailed,
+				SkipDependents: policy.OnError == FlowOnErrorSkipDependents,
+				Events:         executor.recorder.Events(),
+			}, err.Error()); castErr != nil {
+				return fmt.Errorf("failed to cast: %w", castErr)
+			}
+			if policy.OnError == FlowOnErrorFail || ctx.Err() != nil {
+				return fmt.Errorf("failed to execute flow: %w", err)
+			}
+			return nil
+		}
+		log.Debug(ctx, "flow finished")
+		// the interrupted flow returns without error, it must be executed again on resume
+		if executor.checkpoint != nil && ctx.Err() == nil {
+			if err := executor.checkpoint.Complete(executor.recorder.Events(), executor.rootDir); err != nil {
+				log.Warn(ctx, fmt.Sprintf("failed to save checkpoint[%d]", i),
+					logger.Value("id", policy.ID),
 					logger.Value("error", err))
-				return fmt.Errorf("failed to cast: %w", err)
 			}
 		}
-	} else {
-		var atomicErr atomic.Pointer[syncError]
-		var wg sync.WaitGroup
-		sem := make(chan struct{}, concurrency)
+		status := FlowStatusSucceeded
+		if ctx.Err() != nil {
+			status = FlowStatusInterrupted
+		}
+		if err := finish(ctx, executor, flowResult{
+			Status: status,
+			Events: executor.recorder.Events(),
+		}, ""); err != nil {
+			log.Error(ctx, fmt.Sprintf("failed to cast[%d]", i),
+				logger.Value("error", err))
+			return fmt.Errorf("failed to cast: %w", err)
+		}
+		return nil
+	}
+
+	var sequential bool
+	if concurrency < 0 {
+		concurrency = len(executors)
+	}
+	if concurrency == 0 {
+		concurrency = 1
+		sequential = true
+	}
+
+	if sequential {
 		for i, executor := range executors {
-			wg.Add(1)
+			skipped, err := wait(ctx, i, executor)
+			if err != nil {
+				return err
+			}
+			if skipped {
+				continue
+			}
+			if err := execute(ctx, i, executor); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 
-			go func(preExecutor flowExecutor) {
-				defer wg.Done()
+	var atomicErr atomic.Pointer[syncError]
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, executor := range executors {
+		wg.Add(1)
 
-				if err := executor.waitFunc(ctx); err != nil {
-					log.Error(ctx, fmt.Sprintf("failed to wait[%d]", i),
-						logger.Value("error", err))
-					atomicErr.Store(&syncError{Err: err})
-					cancel()
-				}
+		go func(i int, executor flowExecutor) {
+			defer wg.Done()
 
-				defer func() {
-					if err := preExecutor.castFunc(ctx); err != nil {
-						log.Error(ctx, fmt.Sprintf("failed to cast[%d]", i),
-							logger.Value("error", err))
-						atomicErr.Store(&syncError{Err: err})
-						cancel()
-					}
-				}()
-
-				sem <- struct{}{}
-
-				switch preExecutor.flowType {
-				case FlowStepFlowTypeFile:
-					baseExecutor := BaseExecutor{
-						Env:                   env,
-						Logger:                log,
-						SlaveConnectContainer: slaveConCtr,
-						EncryptCtr:            encryptCtr,
-						TmplFactor:            tmplFactor,
-						Store:                 store,
-						AuthFactor:            authFactor,
-						OutputFactor:          outFactor,
-						TargetFactor:          targetFactor,
-					}
-					err := baseExecutor.Execute(
-						ctx,
-						preExecutor.filename,
-						str,
-						preExecutor.threadOnlyStore,
-						preExecutor.rootDir,
-						preExecutor.loopCount,
-						callCount+1,
-						slaveValues,
-						NewDefaultEventCasterWithBroadcaster(preExecutor.eventCaster),
-					)
-					if err != nil {
-						atomicErr.Store(&syncError{Err: err})
-						log.Error(ctx, fmt.Sprintf("failed to execute flow[%d]", i),
-							logger.Value("error", err))
-						cancel()
-						return
-					}
-				case FlowStepFlowTypeSlaveCmd:
-					err := slaveCmdRun(
-						ctx,
-						log,
-						slaveConCtr,
-						outFactor,
-						str,
-						preExecutor.rootDir,
-						flows[i],
-					)
-					if err != nil {
-						fmt.Printf("type slCmd failed to execute flow[%d], %v(type: %T)\n", i, err, err)
-						atomicErr.Store(&syncError{Err: err})
-						log.Error(ctx, fmt.Sprintf("failed to execute flow[%d]", i),
-							logger.Value("error", err))
-						cancel()
-						return
-					}
-				case FlowStepFlowTypeFlow:
-					err := run(
-						ctx,
-						env,
-						log,
-						slaveConCtr,
-						encryptCtr,
-						tmplFactor,
-						store,
-						authFactor,
-						outFactor,
-						targetFactor,
-						str,
-						preExecutor.rootDir,
-						callCount+1,
-						preExecutor.flows,
-						preExecutor.concurrency,
-						slaveValues,
-						broadCastMap,
-					)
-					if err != nil {
-						fmt.Printf("type flow failed to execute flow[%d], %v(type: %T)\n", i, err, err)
-						atomicErr.Store(&syncError{Err: err})
-						log.Error(ctx, fmt.Sprintf("failed to execute flow[%d]", i),
-							logger.Value("error", err))
-						cancel()
-						return
-					}
-				}
-				log.Debug(ctx, "flow finished")
+			skipped, err := wait(ctx, i, executor)
+			if err != nil {
+				atomicErr.Store(&syncError{Err: err})
+				cancel()
+				return
+			}
+			if skipped {
+				return
+			}
 
+			sem <- struct{}{}
+			defer func() {
 				<-sem
-			}(executor)
-		}
+			}()
 
-		wg.Wait()
+			if err := execute(ctx, i, executor); err != nil {
+				atomicErr.Store(&syncError{Err: err})
+				cancel()
+				return
+			}
+		}(i, executor)
+	}
 
-		close(sem)
+	wg.Wait()
 
-		if syncErr := atomicErr.Load(); syncErr != nil {
-			log.Error(ctx, "failed to find error",
-				logger.Value("error", syncErr.Err))
-			return syncErr.Err
-		}
+	close(sem)
 
-		return nil
+	if syncErr := atomicErr.Load(); syncErr != nil {
+		log.Error(ctx, "failed to find error",
+			logger.Value("error", syncErr.Err))
+		return syncErr.Err
 	}
 
 	return nil
@@ -0,0 +1,561 @@
+package runner_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// flowEnv represents the loader directory and the containers shared by the runs of the flow files
+type flowEnv struct {
+	dir        string
+	targets    target.Container
+	out        *memoryOutput
+	results    *runner.FlowResults
+	jars       *runner.CookieJarContainer
+	checkpoint *runner.Checkpoint
+}
+
+// newFlowEnv writes the runner files into the temporary loader directory
+func newFlowEnv(t *testing.T, files map[string]string) *flowEnv {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return &flowEnv{
+		dir:     dir,
+		targets: target.Container{},
+		out:     &memoryOutput{rows: make(map[string][][]string)},
+		results: runner.NewFlowResults(),
+		jars:    runner.NewCookieJarContainer(),
+	}
+}
+
+// run runs the runner file with the values, the run is limited to 10 seconds so that a hang fails the test
+func (e *flowEnv) run(t *testing.T, file string, values *sync.Map) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	err := runner.BaseExecutor{
+		Env:          "test",
+		Logger:       logger.NewSlogLogger(),
+		TmplFactor:   runner.NewLocalTmplFactor(e.dir),
+		Store:        runner.NewLocalStore(nil, nil),
+		OutputFactor: outputFactor{out: e.out},
+		TargetFactor: runner.NewLocalTargetFactor(e.targets),
+		Checkpoint:   e.checkpoint,
+		Results:      e.results,
+		CookieJars:   e.jars,
+	}.Execute(ctx, file, values, &sync.Map{}, e.dir, 0, 0, map[string]any{}, runner.NewDefaultEventCaster())
+	if ctx.Err() != nil {
+		t.Fatalf("the run did not finish in time: %v", err)
+	}
+	return err
+}
+
+// setValue is the runner which stores the value into the memory values
+func setValue(key, value string) string {
+	return "kind: MemoryValue\ndata:\n  - key: " + key + "\n    value: " + value + "\n"
+}
+
+// failRunner is the runner which fails when it is rendered
+const failRunner = `{{ fail "boom" }}`
+
+// TestFlowPolicies tests the timeout, retry and on_error policies of the flows and the dependents of their results.
+func TestFlowPolicies(t *testing.T) {
+	var cancelled atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			select {
+			case <-r.Context().Done():
+				cancelled.Store(true)
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	common := map[string]string{
+		"fail.yaml":    failRunner,
+		"ok.yaml":      setValue("ok", "true"),
+		"cleanup.yaml": setValue("cleaned", "true"),
+		"next.yaml":    setValue("next", "true"),
+		"attempt.yaml": setValue("attempts", "{{ add1 (default 0 .Values.attempts) }}"),
+		// fails on the first attempt only
+		"flaky.yaml": `{{ if lt (int (default 0 .Values.attempts)) 2 }}{{ fail "flaky" }}{{ end }}` + setValue("flaky", "true"),
+		"slow.yaml": `kind: OneExecute
+type: http
+output:
+  enabled: false
+request:
+  target_id: server
+  endpoint: /slow
+  method: GET
+  response_type: json
+`,
+		"emit.yaml": `kind: OneExecute
+type: http
+output:
+  enabled: false
+request:
+  target_id: server
+  endpoint: /
+  method: GET
+  response_type: json
+emit:
+  - "seed:done"
+`,
+	}
+
+	cases := []struct {
+		name string
+		flow string
+		// wantErr is the substring of the error of the run, the run succeeds if empty
+		wantErr string
+		want    map[string]any
+		notSet  []string
+		check   func(t *testing.T)
+	}{
+		{
+			name: "RetryExhausted",
+			flow: `
+    - id: retried
+      type: flow
+      retry: {attempts: 3, backoff: 1ms}
+      on_error: continue
+      flows:
+        - id: attempt
+          type: file
+          file: attempt.yaml
+        - id: boom
+          type: file
+          file: fail.yaml
+    - id: cleanup
+      type: file
+      file: cleanup.yaml
+      depends_on: [{flow: retried, event: "sys:failed"}]
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: retried, event: "sys:terminated"}]`,
+			want: map[string]any{"attempts": 3, "cleaned": true, "next": true},
+		},
+		{
+			name: "RetrySucceeds",
+			flow: `
+    - id: retried
+      type: flow
+      retry: {attempts: 3, backoff: 1ms}
+      flows:
+        - id: attempt
+          type: file
+          file: attempt.yaml
+        - id: flaky
+          type: file
+          file: flaky.yaml
+          depends_on: [{flow: attempt, event: "sys:terminated"}]
+    - id: cleanup
+      type: file
+      file: cleanup.yaml
+      depends_on: [{flow: retried, event: "sys:failed"}]
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: retried, event: "sys:terminated"}]`,
+			want:   map[string]any{"attempts": 2, "flaky": true, "next": true},
+			notSet: []string{"cleaned"},
+		},
+		{
+			name: "OnErrorFail",
+			flow: `
+    - id: boom
+      type: file
+      file: fail.yaml`,
+			wantErr: "boom",
+		},
+		{
+			name: "OnErrorSkipDependents",
+			flow: `
+    - id: boom
+      type: file
+      file: fail.yaml
+      on_error: skip_dependents
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: boom, event: "sys:terminated"}]
+    - id: cleanup
+      type: file
+      file: cleanup.yaml
+      depends_on: [{flow: boom, event: "sys:failed"}]`,
+			want:   map[string]any{"cleaned": true},
+			notSet: []string{"next"},
+		},
+		{
+			name: "TimeoutCancelsChild",
+			flow: `
+    - id: slow
+      type: file
+      file: slow.yaml
+      timeout: 100ms
+      on_error: continue
+    - id: cleanup
+      type: file
+      file: cleanup.yaml
+      depends_on: [{flow: slow, event: "sys:failed"}]`,
+			want: map[string]any{"cleaned": true},
+			check: func(t *testing.T) {
+				// the server notices the closed connection asynchronously
+				deadline := time.Now().Add(time.Second)
+				for !cancelled.Load() && time.Now().Before(deadline) {
+					time.Sleep(10 * time.Millisecond)
+				}
+				if !cancelled.Load() {
+					t.Errorf("expected the request of the child to be cancelled")
+				}
+			},
+		},
+		{
+			name: "CustomEventCast",
+			flow: `
+    - id: seed
+      type: file
+      file: emit.yaml
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: seed, event: "seed:done"}]`,
+			want: map[string]any{"next": true},
+		},
+		{
+			// the failed flow never casts the event, so the dependent is skipped instead of waiting forever
+			name: "CustomEventOfFailedFlow",
+			flow: `
+    - id: seed
+      type: file
+      file: fail.yaml
+      on_error: continue
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: seed, event: "seed:done"}]
+    - id: cleanup
+      type: file
+      file: cleanup.yaml
+      depends_on: [{flow: next, event: "sys:skipped"}]`,
+			want:   map[string]any{"cleaned": true},
+			notSet: []string{"next"},
+		},
+		{
+			name: "CustomEventOfSucceededFlow",
+			flow: `
+    - id: seed
+      type: file
+      file: ok.yaml
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: seed, event: "seed:done"}]`,
+			wantErr: "without casting the event",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			files := map[string]string{
+				"main.yaml": "kind: Flow\nstep:\n  concurrency: -1\n  flows:" + c.flow + "\n",
+			}
+			for name, content := range common {
+				files[name] = content
+			}
+			env := newFlowEnv(tt, files)
+			env.targets["server"] = target.Target{Type: config.TargetTypeHTTP, URL: server.URL}
+			values := &sync.Map{}
+			err := env.run(tt, "main.yaml", values)
+			switch {
+			case c.wantErr == "" && err != nil:
+				tt.Fatalf("failed to run: %v", err)
+			case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+				tt.Fatalf("expected the error of %q, got %v", c.wantErr, err)
+			}
+			for key, want := range c.want {
+				if got, _ := values.Load(key); got != want {
+					tt.Errorf("expected %s to be %v, got %v", key, want, got)
+				}
+			}
+			for _, key := range c.notSet {
+				if got, ok := values.Load(key); ok {
+					tt.Errorf("expected %s not to be set, got %v", key, got)
+				}
+			}
+			if c.check != nil {
+				c.check(tt)
+			}
+		})
+	}
+}
+
+// TestFlowControl tests the if, for_each and while of the flows.
+func TestFlowControl(t *testing.T) {
+	common := map[string]string{
+		"next.yaml":    setValue("next", "true"),
+		"cleanup.yaml": setValue("cleaned", "true"),
+		"attempt.yaml": setValue("attempts", "{{ add1 (default 0 .Values.attempts) }}"),
+		"seen.yaml": setValue(
+			"seen_{{ .Dynamic.LoopCount }}_{{ .ThreadValues.idx }}",
+			`"{{ .ThreadValues.base }}-{{ .ThreadValues.item }}"`,
+		),
+	}
+
+	cases := []struct {
+		name string
+		flow string
+		// wantErr is the substring of the error of the run, the run succeeds if empty
+		wantErr string
+		want    map[string]any
+		notSet  []string
+	}{
+		{
+			name: "IfFalse",
+			flow: `
+    - id: next
+      type: file
+      file: next.yaml
+      if: "gt (len .Values.items) 5"
+    - id: cleanup
+      type: file
+      file: cleanup.yaml
+      depends_on: [{flow: next, event: "sys:skipped"}]`,
+			want:   map[string]any{"cleaned": true},
+			notSet: []string{"next"},
+		},
+		{
+			name: "IfTrue",
+			flow: `
+    - id: next
+      type: file
+      file: next.yaml
+      if: "gt (len .Values.items) 1"
+    - id: cleanup
+      type: file
+      file: cleanup.yaml
+      depends_on: [{flow: next, event: "sys:skipped"}]`,
+			want:   map[string]any{"next": true},
+			notSet: []string{"cleaned"},
+		},
+		{
+			name: "ForEach",
+			flow: `
+    - id: seen
+      type: file
+      file: seen.yaml
+      thread_only_values: [{key: base, value: x}]
+      for_each: {enabled: true, items: .Values.items, as: item, index_as: idx}`,
+			want: map[string]any{"seen_0_0": "x-a", "seen_0_1": "x-b"},
+		},
+		{
+			name: "ForEachEmpty",
+			flow: `
+    - id: seen
+      type: file
+      file: attempt.yaml
+      for_each: {enabled: true, items: .Values.empty, as: item}
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: seen, event: "sys:terminated"}]`,
+			want:   map[string]any{"next": true},
+			notSet: []string{"attempts"},
+		},
+		{
+			name: "ForEachNotList",
+			flow: `
+    - id: seen
+      type: file
+      file: attempt.yaml
+      for_each: {enabled: true, items: .Values.count, as: item}`,
+			wantErr: "items must be a list",
+		},
+		{
+			// the copies share the thread only values of the flow, the bindings of the iterations must not leak into them
+			name: "ForEachThreadOnlyCopy",
+			flow: `
+    - id: seen
+      type: file
+      file: seen.yaml
+      count: 2
+      thread_only_values: [{key: base, value: x}, {key: item, value: none}]
+      if: 'eq .ThreadValues.item "none"'
+      for_each: {enabled: true, items: .Values.items, as: item, index_as: idx}`,
+			want: map[string]any{"seen_0_0": "x-a", "seen_0_1": "x-b", "seen_1_0": "x-a", "seen_1_1": "x-b"},
+		},
+		{
+			name: "WhileMaxIterations",
+			flow: `
+    - id: loop
+      type: file
+      file: attempt.yaml
+      while: {enabled: true, condition: "true", max_iterations: 3, index_as: idx}`,
+			want:    map[string]any{"attempts": 3},
+			wantErr: "while condition still held after max_iterations 3",
+		},
+		{
+			name: "WhileMaxIterationsContinue",
+			flow: `
+    - id: loop
+      type: file
+      file: attempt.yaml
+      on_error: continue
+      while: {enabled: true, condition: "true", max_iterations: 3, index_as: idx}
+    - id: next
+      type: file
+      file: next.yaml
+      depends_on: [{flow: loop, event: "sys:failed"}]`,
+			want: map[string]any{"attempts": 3, "next": true},
+		},
+		{
+			name: "WhileCondition",
+			flow: `
+    - id: loop
+      type: file
+      file: attempt.yaml
+      while: {enabled: true, condition: "lt .Dynamic.Iteration 2"}`,
+			want: map[string]any{"attempts": 2},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			// the copies run one by one, so the later copy sees what the earlier one leaked
+			files := map[string]string{
+				"main.yaml": "kind: Flow\nstep:\n  concurrency: 1\n  flows:" + c.flow + "\n",
+			}
+			for name, content := range common {
+				files[name] = content
+			}
+			env := newFlowEnv(tt, files)
+			values := &sync.Map{}
+			values.Store("items", []any{"a", "b"})
+			values.Store("empty", []any{})
+			values.Store("count", 3)
+			err := env.run(tt, "main.yaml", values)
+			switch {
+			case c.wantErr == "" && err != nil:
+				tt.Fatalf("failed to run: %v", err)
+			case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+				tt.Fatalf("expected the error of %q, got %v", c.wantErr, err)
+			}
+			for key, want := range c.want {
+				if got, _ := values.Load(key); got != want {
+					tt.Errorf("expected %s to be %v, got %v", key, want, got)
+				}
+			}
+			for _, key := range c.notSet {
+				if got, ok := values.Load(key); ok {
+					tt.Errorf("expected %s not to be set, got %v", key, got)
+				}
+			}
+		})
+	}
+}
+
+// TestFlowResults tests that the results of the repeated flows are held by their scopes.
+func TestFlowResults(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer server.Close()
+
+	env := newFlowEnv(t, map[string]string{
+		"main.yaml": `kind: Flow
+step:
+  concurrency: 1
+  flows:
+    - id: mass
+      type: file
+      file: mass.yaml
+      count: 2
+    - id: each
+      type: file
+      file: mass.yaml
+      for_each: {enabled: true, items: .Values.items, as: item}
+    - id: nested
+      type: flow
+      concurrency: 1
+      flows:
+        - id: inner
+          type: file
+          file: mass.yaml
+          count: 2
+`,
+		// the endpoint tells the scope which sent the request
+		"mass.yaml": `kind: MassExecute
+type: http
+output:
+  enabled: false
+requests:
+  - target_id: server
+    endpoint: /{{ .Dynamic.LoopCount }}/{{ default "none" .ThreadValues.item }}
+    method: GET
+    response_type: json
+    interval: 1ms
+    success_break:
+      - count
+    break:
+      count: 1
+`,
+	})
+	env.targets["server"] = target.Target{Type: config.TargetTypeHTTP, URL: server.URL}
+	values := &sync.Map{}
+	values.Store("items", []any{3, 4})
+	if err := env.run(t, "main.yaml", values); err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+
+	results := env.results.Values()
+	for scope, want := range map[string]string{
+		"mass#0":                 "/0/none",
+		"mass#1":                 "/1/none",
+		"each#0/0":               "/0/3",
+		"each#0/1":               "/0/4",
+		"nested#0/inner#0":       "/0/none",
+		"nested#0/inner#1":       "/1/none",
+		"each":                   "",
+		"inner":                  "",
+		"mass":                   "",
+		"nested#0/inner#1/extra": "-",
+	} {
+		result, ok := results[scope].(map[string]any)
+		switch {
+		case want == "-":
+			if ok {
+				t.Errorf("expected no result of %s, got %v", scope, result)
+			}
+		case !ok:
+			t.Errorf("expected the result of %s, got %v", scope, results)
+		case want != "" && result["url"] != server.URL+want:
+			t.Errorf("expected the url of %s to be %s, got %v", scope, server.URL+want, result["url"])
+		}
+	}
+}
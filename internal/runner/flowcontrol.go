@@ -0,0 +1,166 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/cresplanex/bloader/internal/encrypt"
+)
+
+// FlowStepFlowForEach represents the for_each loop of the flow step flow.
+// The items is the template expression without the delimiters, such as ".Values.Tenants".
+type FlowStepFlowForEach struct {
+	Enabled bool    `yaml:"enabled"`
+	Items   *string `yaml:"items"`
+	As      *string `yaml:"as"`
+	IndexAs *string `yaml:"index_as"`
+}
+
+// ValidFlowStepFlowForEach represents a valid for_each loop of the flow step flow
+type ValidFlowStepFlowForEach struct {
+	Enabled bool
+	Items   string
+	As      string
+	IndexAs string
+}
+
+// Validate validates a flow step flow for_each
+func (r FlowStepFlowForEach) Validate() (ValidFlowStepFlowForEach, error) {
+	if !r.Enabled {
+		return ValidFlowStepFlowForEach{}, nil
+	}
+	var valid ValidFlowStepFlowForEach
+	valid.Enabled = r.Enabled
+	if r.Items == nil {
+		return ValidFlowStepFlowForEach{}, fmt.Errorf("items is required")
+	}
+	valid.Items = *r.Items
+	if r.As == nil {
+		return ValidFlowStepFlowForEach{}, fmt.Errorf("as is required")
+	}
+	valid.As = *r.As
+	if r.IndexAs != nil {
+		valid.IndexAs = *r.IndexAs
+	}
+	return valid, nil
+}
+
+// DefaultFlowWhileMaxIterations is the default max iterations of the while loop
+const DefaultFlowWhileMaxIterations = 1000
+
+// FlowStepFlowWhile represents the while loop of the flow step flow.
+// The condition is the template expression without the delimiters, such as "lt .ThreadValues.Page 10".
+type FlowStepFlowWhile struct {
+	Enabled       bool    `yaml:"enabled"`
+	Condition     *string `yaml:"condition"`
+	MaxIterations *int    `yaml:"max_iterations"`
+	IndexAs       *string `yaml:"index_as"`
+}
+
+// ValidFlowStepFlowWhile represents a valid while loop of the flow step flow
+type ValidFlowStepFlowWhile struct {
+	Enabled       bool
+	Condition     string
+	MaxIterations int
+	IndexAs       string
+}
+
+// Validate validates a flow step flow while
+func (r FlowStepFlowWhile) Validate() (ValidFlowStepFlowWhile, error) {
+	if !r.Enabled {
+		return ValidFlowStepFlowWhile{}, nil
+	}
+	var valid ValidFlowStepFlowWhile
+	valid.Enabled = r.Enabled
+	if r.Condition == nil {
+		return ValidFlowStepFlowWhile{}, fmt.Errorf("condition is required")
+	}
+	valid.Condition = *r.Condition
+	valid.MaxIterations = DefaultFlowWhileMaxIterations
+	if r.MaxIterations != nil {
+		if *r.MaxIterations < 1 {
+			return ValidFlowStepFlowWhile{}, fmt.Errorf("max_iterations must be greater than 0")
+		}
+		valid.MaxIterations = *r.MaxIterations
+	}
+	if r.IndexAs != nil {
+		valid.IndexAs = *r.IndexAs
+	}
+	return valid, nil
+}
+
+// flowExprEvaluator evaluates the template expressions of the flow control at start time
+type flowExprEvaluator struct {
+	funcMap     template.FuncMap
+	slaveValues map[string]any
+	results     *FlowResults
+	callCount   int
+}
+
+func newFlowExprEvaluator(
+	ctx context.Context,
+	store Store,
+	encryptCtr encrypt.Container,
+	slaveValues map[string]any,
+	results *FlowResults,
+	callCount int,
+) *flowExprEvaluator {
+	return &flowExprEvaluator{
+		funcMap:     NewTmplFuncMap(ctx, store, encryptCtr),
+		slaveValues: slaveValues,
+		results:     results,
+		callCount:   callCount,
+	}
+}
+
+func (e *flowExprEvaluator) data(str, threadOnlyStr *sync.Map, loopCount, iteration int) map[string]any {
+	return map[string]any{
+		"SlaveValues":  e.slaveValues,
+		"Values":       syncMapToMap(str),
+		"ThreadValues": syncMapToMap(threadOnlyStr),
+		"Results":      e.results.Values(),
+		"Dynamic": map[string]any{
+			"LoopCount": loopCount,
+			"CallCount": e.callCount,
+			"Iteration": iteration,
+		},
+	}
+}
+
+func (e *flowExprEvaluator) execute(text string, data map[string]any) (string, error) {
+	tmpl, err := template.New("expr").Funcs(e.funcMap).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse expression: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute expression: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// Cond evaluates the condition with the truth of the template
+func (e *flowExprEvaluator) Cond(expr string, data map[string]any) (bool, error) {
+	res, err := e.execute(fmt.Sprintf("{{ if %s }}true{{ end }}", expr), data)
+	if err != nil {
+		return false, err
+	}
+	return res == "true", nil
+}
+
+// Items evaluates the expression which must result in a list
+func (e *flowExprEvaluator) Items(expr string, data map[string]any) ([]any, error) {
+	res, err := e.execute(fmt.Sprintf("{{ toJson (%s) }}", expr), data)
+	if err != nil {
+		return nil, err
+	}
+	var items []any
+	if err := json.Unmarshal([]byte(res), &items); err != nil {
+		return nil, fmt.Errorf("items must be a list: %w", err)
+	}
+	return items, nil
+}
@@ -0,0 +1,382 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/container"
+)
+
+// FlowGraphFormat represents the format of the flow graph
+type FlowGraphFormat string
+
+const (
+	// FlowGraphFormatMermaid represents the mermaid format
+	FlowGraphFormatMermaid FlowGraphFormat = "mermaid"
+	// FlowGraphFormatDOT represents the graphviz dot format
+	FlowGraphFormatDOT FlowGraphFormat = "dot"
+)
+
+// flowEmittableEvents returns the events which the flow can emit
+func flowEmittableEvents(flow ValidFlowStepFlow) []Event {
+	if flow.Type == FlowStepFlowTypeFile {
+		return knownEvents
+	}
+	// flow and slaveCmd only cast the result events
+	return []Event{RunnerEventTerminated, RunnerEventFailed, RunnerEventSkipped}
+}
+
+// flowCanEmit returns true if the flow can emit the event,
+// the user-defined events are emitted by the runner files, so they are only known at run time
+func flowCanEmit(flow ValidFlowStepFlow, event Event) bool {
+	if flow.Type == FlowStepFlowTypeFile && event.IsUserDefined() {
+		return true
+	}
+	return slices.Contains(flowEmittableEvents(flow), event)
+}
+
+// FlowGraph represents the dependency graph of the flow
+type FlowGraph struct {
+	Concurrency int
+	Flows       []ValidFlowStepFlow
+	nodeIDs     map[string]string
+	flowMap     map[string]ValidFlowStepFlow
+}
+
+// NewFlowGraph creates a new flow graph from the valid flow
+func NewFlowGraph(flow ValidFlow) *FlowGraph {
+	g := &FlowGraph{
+		Concurrency: flow.Step.Concurrency,
+		Flows:       flow.Step.Flows,
+		nodeIDs:     make(map[string]string),
+		flowMap:     make(map[string]ValidFlowStepFlow),
+	}
+	var walk func(flows []ValidFlowStepFlow)
+	walk = func(flows []ValidFlowStepFlow) {
+		for _, f := range flows {
+			g.nodeIDs[f.ID] = fmt.Sprintf("n%d", len(g.nodeIDs))
+			g.flowMap[f.ID] = f
+			walk(f.Flows)
+		}
+	}
+	walk(flow.Step.Flows)
+	return g
+}
+
+func (g *FlowGraph) descendants(id string) []string {
+	var res []string
+	for _, c := range g.flowMap[id].Flows {
+		res = append(res, c.ID)
+		res = append(res, g.descendants(c.ID)...)
+	}
+	return res
+}
+
+// startDeps returns the flows which must have been started (or terminated) before the flow starts
+func (g *FlowGraph) startDeps() map[string][]string {
+	deps := make(map[string][]string)
+	addTerminated := func(from, to string) {
+		deps[from] = append(deps[from], to)
+		deps[from] = append(deps[from], g.descendants(to)...)
+	}
+	var walk func(flows []ValidFlowStepFlow, parent string, concurrency int)
+	walk = func(flows []ValidFlowStepFlow, parent string, concurrency int) {
+		for i, f := range flows {
+			if parent != "" {
+				deps[f.ID] = append(deps[f.ID], parent)
+			}
+			if concurrency == 0 && i > 0 {
+				addTerminated(f.ID, flows[i-1].ID)
+			}
+			for _, dep := range f.DependsOn {
+				if _, ok := g.flowMap[dep.Flow]; !ok {
+					continue
+				}
+				if dep.Event == RunnerEventTerminated || dep.Event == RunnerEventFailed || dep.Event == RunnerEventSkipped {
+					addTerminated(f.ID, dep.Flow)
+				} else {
+					deps[f.ID] = append(deps[f.ID], dep.Flow)
+				}
+			}
+			walk(f.Flows, f.ID, f.Concurrency)
+		}
+	}
+	walk(g.Flows, "", g.Concurrency)
+	return deps
+}
+
+// Check detects the dependency cycles and the waits which are never satisfied
+func (g *FlowGraph) Check() []string {
+	var problems []string
+	var walk func(flows []ValidFlowStepFlow)
+	walk = func(flows []ValidFlowStepFlow) {
+		for _, f := range flows {
+			for _, dep := range f.DependsOn {
+				target, ok := g.flowMap[dep.Flow]
+				if !ok {
+					problems = append(problems, fmt.Sprintf("flow %s depends on unknown flow %s", f.ID, dep.Flow))
+					continue
+				}
+				if !flowCanEmit(target, dep.Event) {
+					problems = append(problems, fmt.Sprintf(
+						"flow %s waits for %s of flow %s which never emits it (type %s)",
+						f.ID, dep.Event, dep.Flow, target.Type,
+					))
+				}
+			}
+			walk(f.Flows)
+		}
+	}
+	walk(g.Flows)
+
+	deps := g.startDeps()
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+	reported := make(map[string]struct{})
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, d := range deps[id] {
+			switch state[d] {
+			case visiting:
+				idx := slices.Index(stack, d)
+				cycle := append(slices.Clone(stack[idx:]), d)
+				key := strings.Join(cycle, ",")
+				if _, ok := reported[key]; !ok {
+					reported[key] = struct{}{}
+					problems = append(problems, fmt.Sprintf("dependency cycle detected: %s", strings.Join(cycle, " -> ")))
+				}
+			case unvisited:
+				visit(d)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = visited
+	}
+	var ids []string
+	for id := range g.flowMap {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return problems
+}
+
+func flowNodeLabel(f ValidFlowStepFlow) []string {
+	lines := []string{f.ID}
+	switch f.Type {
+	case FlowStepFlowTypeFile:
+		lines = append(lines, "file: "+f.File)
+		if f.Count > 1 {
+			lines = append(lines, fmt.Sprintf("count: %d", f.Count))
+		}
+	case FlowStepFlowTypeSlaveCmd:
+		lines = append(lines, "slaveCmd: "+f.File)
+		var slaves []string
+		for _, e := range f.Executors {
+			slaves = append(slaves, e.SlaveID)
+		}
+		lines = append(lines, fmt.Sprintf("executors(%d): %s", len(f.Executors), strings.Join(slaves, ", ")))
+	case FlowStepFlowTypeFlow:
+		lines = append(lines, fmt.Sprintf("concurrency: %d", f.Concurrency))
+	}
+	if f.If != "" {
+		lines = append(lines, "if: "+f.If)
+	}
+	if f.ForEach.Enabled {
+		lines = append(lines, fmt.Sprintf("for_each: %s as %s", f.ForEach.Items, f.ForEach.As))
+	}
+	if f.While.Enabled {
+		lines = append(lines, fmt.Sprintf("while: %s (max %d)", f.While.Condition, f.While.MaxIterations))
+	}
+	return lines
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
+
+// Mermaid returns the mermaid flowchart of the flow
+func (g *FlowGraph) Mermaid() string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "flowchart TD")
+	fmt.Fprintf(&b, "  %%%% step concurrency: %d\n", g.Concurrency)
+	var writeNodes func(flows []ValidFlowStepFlow, indent string)
+	writeNodes = func(flows []ValidFlowStepFlow, indent string) {
+		for _, f := range flows {
+			label := mermaidEscape(strings.Join(flowNodeLabel(f), "<br/>"))
+			if f.Type == FlowStepFlowTypeFlow {
+				fmt.Fprintf(&b, "%ssubgraph %s[\"%s\"]\n", indent, g.nodeIDs[f.ID], label)
+				writeNodes(f.Flows, indent+"  ")
+				fmt.Fprintf(&b, "%send\n", indent)
+				continue
+			}
+			shape := "[\"%s\"]"
+			if f.Type == FlowStepFlowTypeSlaveCmd {
+				shape = "[[\"%s\"]]"
+			}
+			fmt.Fprintf(&b, "%s%s"+shape+"\n", indent, g.nodeIDs[f.ID], label)
+		}
+	}
+	writeNodes(g.Flows, "  ")
+	g.walkEdges(func(from, to, label string, order bool) {
+		if order {
+			fmt.Fprintf(&b, "  %s -.-> %s\n", from, to)
+			return
+		}
+		fmt.Fprintf(&b, "  %s -- \"%s\" --> %s\n", from, mermaidEscape(label), to)
+	})
+	return b.String()
+}
+
+func dotEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+// DOT returns the graphviz dot graph of the flow
+func (g *FlowGraph) DOT() string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "digraph flow {")
+	fmt.Fprintln(&b, "  compound=true;")
+	fmt.Fprintf(&b, "  label=\"step concurrency: %d\";\n", g.Concurrency)
+	fmt.Fprintln(&b, "  node [shape=box];")
+	var writeNodes func(flows []ValidFlowStepFlow, indent string)
+	writeNodes = func(flows []ValidFlowStepFlow, indent string) {
+		for _, f := range flows {
+			label := dotEscape(strings.Join(flowNodeLabel(f), `\n`))
+			if f.Type == FlowStepFlowTypeFlow {
+				id := g.nodeIDs[f.ID]
+				fmt.Fprintf(&b, "%ssubgraph cluster_%s {\n", indent, id)
+				fmt.Fprintf(&b, "%s  label=\"%s\";\n", indent, label)
+				fmt.Fprintf(&b, "%s  style=dashed;\n", indent)
+				// the anchor node represents the group in the edges
+				fmt.Fprintf(&b, "%s  %s [label=\"%s\", shape=point];\n", indent, id, dotEscape(f.ID))
+				writeNodes(f.Flows, indent+"  ")
+				fmt.Fprintf(&b, "%s}\n", indent)
+				continue
+			}
+			shape := "box"
+			if f.Type == FlowStepFlowTypeSlaveCmd {
+				shape = "box3d"
+			}
+			fmt.Fprintf(&b, "%s%s [label=\"%s\", shape=%s];\n", indent, g.nodeIDs[f.ID], label, shape)
+		}
+	}
+	writeNodes(g.Flows, "  ")
+	g.walkEdges(func(from, to, label string, order bool) {
+		if order {
+			fmt.Fprintf(&b, "  %s -> %s [style=dotted];\n", from, to)
+			return
+		}
+		fmt.Fprintf(&b, "  %s -> %s [label=\"%s\"];\n", from, to, dotEscape(label))
+	})
+	fmt.Fprintln(&b, "}")
+	return b.String()
+}
+
+// walkEdges walks the depends_on edges and the sequential order edges
+func (g *FlowGraph) walkEdges(fn func(from, to, label string, order bool)) {
+	var walk func(flows []ValidFlowStepFlow, concurrency int)
+	walk = func(flows []ValidFlowStepFlow, concurrency int) {
+		for i, f := range flows {
+			if concurrency == 0 && i > 0 {
+				fn(g.nodeIDs[flows[i-1].ID], g.nodeIDs[f.ID], "", true)
+			}
+			for _, dep := range f.DependsOn {
+				from, ok := g.nodeIDs[dep.Flow]
+				if !ok {
+					continue
+				}
+				fn(from, g.nodeIDs[f.ID], string(dep.Event), false)
+			}
+			walk(f.Flows, f.Concurrency)
+		}
+	}
+	walk(g.Flows, g.Concurrency)
+}
+
+// GraphFlow renders the flow file and writes its dependency graph
+func GraphFlow(
+	ctr *container.Container,
+	filename string,
+	data map[string]any,
+	format FlowGraphFormat,
+	w io.Writer,
+) error {
+	ctx, cancel := context.WithCancel(ctr.Ctx)
+	defer cancel()
+
+	tmplFactor := NewLocalTmplFactor(ctr.Config.Loader.BasePath)
+	tmplStr, err := tmplFactor.TmplFactorize(ctx, filename)
+	if err != nil {
+		return fmt.Errorf("failed to factorize template: %w", err)
+	}
+	str := NewLocalStore(ctr.EncypterContainer, ctr.Store)
+	tmpl, err := NewTmplSet(ctx, tmplFactor, NewTmplFuncMap(ctx, str, ctr.EncypterContainer)).Parse("yaml", tmplStr)
+	if err != nil {
+		return fmt.Errorf("failed to parse yaml: %w", err)
+	}
+	values := &sync.Map{}
+	for k, v := range data {
+		values.Store(k, v)
+	}
+	runner, rawData, err := renderRunner(tmpl, map[string]any{
+		"SlaveValues":  map[string]any{},
+		"Values":       syncMapToMap(values),
+		"ThreadValues": map[string]any{},
+		"Dynamic": map[string]any{
+			"OutputRoot": LintOutputRoot,
+			"LoopCount":  0,
+			"CallCount":  0,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	validRunner, err := runner.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate runner: %w", err)
+	}
+	if validRunner.Kind != RunnerKindFlow {
+		return fmt.Errorf("%s is not a flow runner: %s", filename, validRunner.Kind)
+	}
+	var flow Flow
+	if err := yaml.NewDecoder(rawData).Decode(&flow); err != nil {
+		return fmt.Errorf("failed to decode yaml: %w", err)
+	}
+	validFlow, err := flow.Validate()
+	if err != nil {
+		return fmt.Errorf("failed to validate flow: %w", err)
+	}
+
+	g := NewFlowGraph(validFlow)
+	switch format {
+	case FlowGraphFormatMermaid:
+		fmt.Fprint(w, g.Mermaid())
+	case FlowGraphFormatDOT:
+		fmt.Fprint(w, g.DOT())
+	default:
+		return fmt.Errorf("invalid format: %s", format)
+	}
+
+	if problems := g.Check(); len(problems) > 0 {
+		return fmt.Errorf("found %d problem(s):\n  - %s", len(problems), strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package runner_test
+
+import (
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// newFlowGraph decodes and validates the flows of the Flow runner file, and creates its graph
+func newFlowGraph(t *testing.T, concurrency, flows string) *runner.FlowGraph {
+	t.Helper()
+	var f runner.Flow
+	if err := yaml.Unmarshal([]byte("step:\n  concurrency: "+concurrency+"\n  flows:\n"+flows), &f); err != nil {
+		t.Fatalf("failed to decode flow: %v", err)
+	}
+	valid, err := f.Validate()
+	if err != nil {
+		t.Fatalf("failed to validate flow: %v", err)
+	}
+	return runner.NewFlowGraph(valid)
+}
+
+// graphFlows has the nested flow, the slaveCmd, the sequential order and the depends_on edges
+const graphFlows = `    - id: prepare
+      type: file
+      file: prepare.yaml
+    - id: group
+      type: flow
+      concurrency: -1
+      flows:
+        - id: load
+          type: file
+          file: load.yaml
+          count: 2
+        - id: remote
+          type: slaveCmd
+          file: remote.yaml
+          executors:
+            - slave_id: s1
+            - slave_id: s2
+          depends_on:
+            - {flow: load, event: "sys:validated"}
+    - id: report
+      type: file
+      file: "report \"final\".yaml"
+      depends_on:
+        - {flow: remote, event: "sys:failed"}
+`
+
+// TestFlowGraphOutput tests the mermaid and dot graphs of the flow.
+func TestFlowGraphOutput(t *testing.T) {
+	cases := []struct {
+		name   string
+		render func(g *runner.FlowGraph) string
+		want   string
+	}{
+		{
+			name:   "Mermaid",
+			render: (*runner.FlowGraph).Mermaid,
+			want: `flowchart TD
+  %% step concurrency: 0
+  n0["prepare<br/>file: prepare.yaml"]
+  subgraph n1["group<br/>concurrency: -1"]
+    n2["load<br/>file: load.yaml<br/>count: 2"]
+    n3[["remote<br/>slaveCmd: remote.yaml<br/>executors(2): s1, s2"]]
+  end
+  n4["report<br/>file: report #quot;final#quot;.yaml"]
+  n0 -.-> n1
+  n2 -- "sys:validated" --> n3
+  n1 -.-> n4
+  n3 -- "sys:failed" --> n4
+`,
+		},
+		{
+			name:   "DOT",
+			render: (*runner.FlowGraph).DOT,
+			want: `digraph flow {
+  compound=true;
+  label="step concurrency: 0";
+  node [shape=box];
+  n0 [label="prepare\nfile: prepare.yaml", shape=box];
+  subgraph cluster_n1 {
+    label="group\nconcurrency: -1";
+    style=dashed;
+    n1 [label="group", shape=point];
+    n2 [label="load\nfile: load.yaml\ncount: 2", shape=box];
+    n3 [label="remote\nslaveCmd: remote.yaml\nexecutors(2): s1, s2", shape=box3d];
+  }
+  n4 [label="report\nfile: report \"final\".yaml", shape=box];
+  n0 -> n1 [style=dotted];
+  n2 -> n3 [label="sys:validated"];
+  n1 -> n4 [style=dotted];
+  n3 -> n4 [label="sys:failed"];
+}
+`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			if got := c.render(newFlowGraph(tt, "0", graphFlows)); got != c.want {
+				tt.Errorf("expected the graph\n%s\ngot\n%s", c.want, got)
+			}
+		})
+	}
+}
+
+// TestFlowGraphCheck tests the dependency cycles and the waits which are never satisfied.
+func TestFlowGraphCheck(t *testing.T) {
+	cases := []struct {
+		name        string
+		concurrency string
+		flows       string
+		want        []string
+	}{
+		{
+			name:        "Valid",
+			concurrency: "0",
+			flows:       graphFlows,
+		},
+		{
+			name:        "Cycle",
+			concurrency: "-1",
+			flows: `    - id: a
+      type: file
+      file: a.yaml
+      depends_on:
+        - {flow: b, event: "sys:terminated"}
+    - id: b
+      type: file
+      file: b.yaml
+      depends_on:
+        - {flow: a, event: "sys:validated"}
+`,
+			want: []string{"dependency cycle detected: a -> b -> a"},
+		},
+		{
+			name:        "CycleThroughSubgraph",
+			concurrency: "-1",
+			flows: `    - id: group
+      type: flow
+      concurrency: -1
+      flows:
+        - id: child
+          type: file
+          file: child.yaml
+          depends_on:
+            - {flow: after, event: "sys:start"}
+    - id: after
+      type: file
+      file: after.yaml
+      depends_on:
+        - {flow: group, event: "sys:terminated"}
+`,
+			want: []string{"dependency cycle detected: after -> child -> after"},
+		},
+		{
+			name:        "SequentialCycle",
+			concurrency: "0",
+			flows: `    - id: a
+      type: file
+      file: a.yaml
+      depends_on:
+        - {flow: b, event: "sys:start"}
+    - id: b
+      type: file
+      file: b.yaml
+`,
+			want: []string{"dependency cycle detected: a -> b -> a"},
+		},
+		{
+			name:        "NeverCast",
+			concurrency: "-1",
+			flows: `    - id: group
+      type: flow
+      flows:
+        - id: child
+          type: file
+          file: child.yaml
+    - id: remote
+      type: slaveCmd
+      file: remote.yaml
+      executors:
+        - slave_id: s1
+    - id: waiter
+      type: file
+      file: waiter.yaml
+      depends_on:
+        - {flow: group, event: "sys:validated"}
+        - {flow: remote, event: "sys:store:imported"}
+        - {flow: remote, event: "ready"}
+        - {flow: child, event: "ready"}
+        - {flow: missing, event: "sys:terminated"}
+`,
+			want: []string{
+				"flow waiter waits for sys:validated of flow group which never emits it (type flow)",
+				"flow waiter waits for sys:store:imported of flow remote which never emits it (type slaveCmd)",
+				"flow waiter waits for ready of flow remote which never emits it (type slaveCmd)",
+				"flow waiter depends on unknown flow missing",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			if got := newFlowGraph(tt, c.concurrency, c.flows).Check(); !slices.Equal(got, c.want) {
+				tt.Errorf("expected the problems %q, got %q", c.want, got)
+			}
+		})
+	}
+}
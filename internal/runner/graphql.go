@@ -0,0 +1,102 @@
+package runner
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// graphQLOperationPattern matches the first named operation of the GraphQL document
+var graphQLOperationPattern = regexp.MustCompile(`(?m)^\s*(?:query|mutation|subscription)\s+([_A-Za-z][_0-9A-Za-z]*)`)
+
+// graphQLPayload converts the body of the graphql body type to the GraphQL request payload.
+// The body has query, variables and operation_name.
+func graphQLPayload(body any) (map[string]any, error) {
+	fields, ok := body.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("graphql body must be a map")
+	}
+	query, ok := fields["query"].(string)
+	if !ok || query == "" {
+		return nil, fmt.Errorf("graphql body requires query")
+	}
+	payload := map[string]any{
+		"query": query,
+	}
+	if variables, ok := fields["variables"]; ok && variables != nil {
+		if _, ok := variables.(map[string]any); !ok {
+			return nil, fmt.Errorf("graphql variables must be a map")
+		}
+		payload["variables"] = variables
+	}
+	if operationName, ok := fields["operation_name"]; ok && operationName != nil {
+		name, ok := operationName.(string)
+		if !ok {
+			return nil, fmt.Errorf("graphql operation_name must be string")
+		}
+		payload["operationName"] = name
+	}
+	return payload, nil
+}
+
+// validateGraphQLBody validates the body of the graphql body type,
+// the body may be nil when it is given by the request template
+func validateGraphQLBody(body any) error {
+	if body == nil {
+		return nil
+	}
+	_, err := graphQLPayload(body)
+	return err
+}
+
+// graphQLOperationName returns the operation name of the body of the graphql body type,
+// the name of the first operation of the query is used when operation_name is not given
+func graphQLOperationName(body any) string {
+	fields, ok := body.(map[string]any)
+	if !ok {
+		return ""
+	}
+	if name, ok := fields["operation_name"].(string); ok && name != "" {
+		return name
+	}
+	query, _ := fields["query"].(string)
+	if m := graphQLOperationPattern.FindStringSubmatch(query); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// graphQLErrors returns the errors[].extensions.code of the GraphQL response,
+// and whether the response has the non-empty errors
+func graphQLErrors(response any) ([]string, bool) {
+	fields, ok := response.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	errs, ok := fields["errors"].([]any)
+	if !ok || len(errs) == 0 {
+		return nil, false
+	}
+	var codes []string
+	for _, e := range errs {
+		errFields, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		extensions, ok := errFields["extensions"].(map[string]any)
+		if !ok {
+			continue
+		}
+		if code, ok := extensions["code"]; ok && code != nil {
+			codes = append(codes, fmt.Sprint(code))
+		}
+	}
+	return codes, true
+}
+
+// graphQLFailed reports whether the GraphQL response is the failure,
+// the response with the 200 status but the non-empty errors fails
+func graphQLFailed(statusCode int, response any) bool {
+	_, hasErrors := graphQLErrors(response)
+	return statusCode == http.StatusOK && hasErrors
+}
@@ -0,0 +1,123 @@
+package runner_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// newGraphQLServer starts the GraphQL server which answers the user query,
+// the unknown user is reported in the errors with the 200 status
+func newGraphQLServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Query         string         `json:"query"`
+			Variables     map[string]any `json:"variables"`
+			OperationName string         `json:"operationName"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if payload.Variables["id"] != "1" {
+			_, _ = w.Write([]byte(`{"data":{"user":null},"errors":[{"message":"not found","extensions":{"code":"NOT_FOUND"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"user":{"id":"1","name":"alice"}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestMassExecGraphQL tests the graphql body type against the in-process GraphQL server.
+func TestMassExecGraphQL(t *testing.T) {
+	srv := newGraphQLServer(t)
+
+	out, results := runMassExec(t, target.Container{
+		"api": {Type: config.TargetTypeHTTP, URL: srv.URL},
+	}, "graphql", `
+type: http
+output:
+  enabled: true
+  ids: ["memory"]
+requests:
+  - target_id: api
+    endpoint: /graphql
+    method: POST
+    body_type: graphql
+    body:
+      query: |
+        query GetUser($id: ID!) { user(id: $id) { id name } }
+      variables:
+        id: "1"
+    response_type: json
+    data:
+      - key: Name
+        extractor:
+          type: jmesPath
+          jmes_path: data.user.name
+    interval: 1ms
+    await_prev_response: true
+    success_break:
+      - count
+    break:
+      count: 2
+  - target_id: api
+    endpoint: /graphql
+    method: POST
+    body_type: graphql
+    body:
+      query: |
+        query GetUser($id: ID!) { user(id: $id) { id } }
+      variables:
+        id: "2"
+      operation_name: MissingUser
+    response_type: json
+    interval: 1ms
+    await_prev_response: true
+    success_break:
+      - errorCode/notFound
+    break:
+      error_code:
+        - id: notFound
+          op: eq
+          value: NOT_FOUND
+`)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if !r.Success {
+			t.Errorf("expected success, got %+v", r)
+		}
+	}
+	if results[0].Operation != "GetUser" || results[1].Operation != "MissingUser" {
+		t.Errorf("expected the operation names, got %q and %q", results[0].Operation, results[1].Operation)
+	}
+	if results[1].MatchedID != "notFound" || results[1].Counts.FailureCount != 1 {
+		t.Errorf("expected the error code to break as the failure, got %+v", results[1])
+	}
+
+	rows := out.bySuffix("_0")
+	if len(rows) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d", len(rows))
+	}
+	for _, row := range rows[1:] {
+		if row[0] != "true" || row[6] != "GetUser" || row[7] != "" || row[8] != "alice" {
+			t.Errorf("expected the successful operation, got %v", row)
+		}
+	}
+	rows = out.bySuffix("_1")
+	if len(rows) != 2 {
+		t.Fatalf("expected header and 1 row, got %d", len(rows))
+	}
+	if row := rows[1]; row[0] != "false" || row[5] != "200" || row[6] != "MissingUser" || row[7] != "NOT_FOUND" {
+		t.Errorf("expected the failed operation with the error code, got %v", row)
+	}
+}
@@ -0,0 +1,368 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/auth"
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/grpcexec"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// GRPCMethod is the method recorded in the results of the gRPC requests
+const GRPCMethod = "GRPC"
+
+// MassExecGRPC represents the gRPC configuration for the MassExec runner.
+// The descriptors are loaded from the .proto files, or fetched by the server reflection when no file is given.
+type MassExecGRPC struct {
+	Proto      MassExecGRPCProto     `yaml:"proto"`
+	Reflection bool                  `yaml:"reflection"`
+	TLS        MassExecGRPCTLS       `yaml:"tls"`
+	Requests   []MassExecGRPCRequest `yaml:"requests"`
+}
+
+// MassExecGRPCProto represents the .proto files, they are read through the loader relative to the import paths
+type MassExecGRPCProto struct {
+	ImportPaths []string `yaml:"import_paths"`
+	Files       []string `yaml:"files"`
+}
+
+// MassExecGRPCTLS represents the TLS configuration of the gRPC connection
+type MassExecGRPCTLS struct {
+	Enabled            bool    `yaml:"enabled"`
+	InsecureSkipVerify bool    `yaml:"insecure_skip_verify"`
+	ServerName         *string `yaml:"server_name"`
+}
+
+// Validate validates the MassExecGRPCTLS
+func (t MassExecGRPCTLS) Validate() grpcexec.TLSConfig {
+	if !t.Enabled {
+		return grpcexec.TLSConfig{}
+	}
+	valid := grpcexec.TLSConfig{
+		Enabled:            true,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if t.ServerName != nil {
+		valid.ServerName = *t.ServerName
+	}
+	return valid
+}
+
+// MassExecGRPCRequest represents the gRPC request configuration for the MassExec runner
+type MassExecGRPCRequest struct {
+	TargetID            *string                            `yaml:"target_id"`
+	Method              *string                            `yaml:"method"`
+	Metadata            map[string]any                     `yaml:"metadata"`
+	Body                any                                `yaml:"body"`
+	RequestTemplate     MassExecRequestTemplate            `yaml:"request_template"`
+	Data                []ExecRequestData                  `yaml:"data"`
+	Interval            *string                            `yaml:"interval"`
+	AwaitPrevResp       bool                               `yaml:"await_prev_response"`
+	SuccessBreak        []string                           `yaml:"success_break"`
+	Break               MassExecRequestBreak               `yaml:"break"`
+	RecordExcludeFilter MassExecRequestRecordExcludeFilter `yaml:"record_exclude_filter"`
+	Emit                []MassExecRequestEmit              `yaml:"emit"`
+}
+
+// MassExecGRPCRequestTemplateFields represents the fields which can be overridden by the per-request template
+type MassExecGRPCRequestTemplateFields struct {
+	Metadata map[string]any `yaml:"metadata"`
+	Body     any            `yaml:"body"`
+}
+
+// ValidMassExecGRPC represents the valid gRPC configuration for the MassExec runner
+type ValidMassExecGRPC struct {
+	Reflection bool
+	TLS        grpcexec.TLSConfig
+	Requests   []ValidMassExecGRPCRequest
+}
+
+// ValidMassExecGRPCRequest represents the valid gRPC request configuration for the MassExec runner.
+// The metadata is held as the headers, and the responses are handled as the JSON responses.
+type ValidMassExecGRPCRequest struct {
+	ValidMassExecRequest
+	Target string
+	RPC    string
+	// Descriptor is nil until it is fetched by the server reflection
+	Descriptor protoreflect.MethodDescriptor
+}
+
+// Validate validates the MassExecGRPC
+func (g MassExecGRPC) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecGRPC, error) {
+	var resolver grpcexec.DescriptorResolver
+	switch {
+	case len(g.Proto.Files) > 0:
+		var err error
+		if resolver, err = grpcexec.ParseProtoFiles(ctx, func(path string) (io.ReadCloser, error) {
+			content, err := tmplSet.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			return io.NopCloser(strings.NewReader(content)), nil
+		}, g.Proto.ImportPaths, g.Proto.Files); err != nil {
+			return ValidMassExecGRPC{}, fmt.Errorf("failed to parse proto: %w", err)
+		}
+	case !g.Reflection:
+		return ValidMassExecGRPC{}, fmt.Errorf("proto.files or reflection is required")
+	}
+
+	valid := ValidMassExecGRPC{
+		Reflection: resolver == nil,
+		TLS:        g.TLS.Validate(),
+	}
+	for i, req := range g.Requests {
+		reqTmpl := newFieldTmpl(tmplSet, tmpl, replaceData, "grpc", "requests", i)
+		validRequest, err := req.Validate(ctx, log, targetFactor, resolver, tmplSet, reqTmpl, replaceData)
+		if err != nil {
+			return ValidMassExecGRPC{}, fmt.Errorf("failed to validate request[%d]: %w", i, err)
+		}
+		valid.Requests = append(valid.Requests, validRequest)
+	}
+	return valid, nil
+}
+
+// Validate validates the MassExecGRPCRequest, the method is resolved later if the resolver is nil
+func (r MassExecGRPCRequest) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	resolver grpcexec.DescriptorResolver,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecGRPCRequest, error) {
+	var valid ValidMassExecGRPCRequest
+	if r.TargetID == nil {
+		return ValidMassExecGRPCRequest{}, fmt.Errorf("target_id is required")
+	}
+	if r.Method == nil {
+		return ValidMassExecGRPCRequest{}, fmt.Errorf("method is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *r.TargetID)
+	if err != nil {
+		return ValidMassExecGRPCRequest{}, fmt.Errorf("failed to factorize target: %w", err)
+	}
+	if tg.Type != config.TargetTypeGRPC {
+		return ValidMassExecGRPCRequest{}, fmt.Errorf("target %s is not a grpc target", *r.TargetID)
+	}
+	valid.Target = tg.URL
+	service, method, err := grpcexec.SplitMethod(*r.Method)
+	if err != nil {
+		return ValidMassExecGRPCRequest{}, err
+	}
+	valid.RPC = fmt.Sprintf("%s/%s", service, method)
+	if resolver != nil {
+		if valid.Descriptor, err = grpcexec.FindMethod(resolver, valid.RPC); err != nil {
+			return ValidMassExecGRPCRequest{}, fmt.Errorf("failed to find method: %w", err)
+		}
+	}
+
+	valid.URL = fmt.Sprintf("%s/%s", valid.Target, valid.RPC)
+	valid.Method = GRPCMethod
+	valid.Headers = r.Metadata
+	valid.Body = r.Body
+	valid.ResponseType = string(httpexec.ResponseTypeJSON)
+	handling := MassExecRequest{
+		Data:                r.Data,
+		Interval:            r.Interval,
+		AwaitPrevResp:       r.AwaitPrevResp,
+		SuccessBreak:        r.SuccessBreak,
+		Break:               r.Break,
+		RecordExcludeFilter: r.RecordExcludeFilter,
+		Emit:                r.Emit,
+	}
+	if err := handling.validateHandling(ctx, log, &valid.ValidMassExecRequest); err != nil {
+		return ValidMassExecGRPCRequest{}, err
+	}
+	if valid.RequestTmpl, err = r.RequestTemplate.Validate(tmplSet); err != nil {
+		return ValidMassExecGRPCRequest{}, fmt.Errorf("failed to validate request template: %w", err)
+	}
+	valid.Tmpl = tmpl
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// GRPCRequest represents the gRPC request of the MassExec runner, it is rendered for each count
+type GRPCRequest struct {
+	Descriptor  protoreflect.MethodDescriptor
+	Metadata    map[string]any // map[string]any or map[string][]any
+	Body        any
+	Auth        auth.SetAuthor
+	Tmpl        *template.Template
+	RequestTmpl *template.Template
+	ReplaceData map[string]any
+	ReqIndex    int
+}
+
+// render renders the dynamic part of the request for the given count
+func (r GRPCRequest) render(count int) (GRPCRequest, error) {
+	if r.RequestTmpl == nil && r.Tmpl == nil {
+		return r, nil
+	}
+	replaceData := massReplaceData(r.ReplaceData, map[string]any{
+		"RequestLoopCount": count,
+	})
+	var buffer bytes.Buffer
+	if r.RequestTmpl != nil {
+		if err := r.RequestTmpl.Execute(&buffer, replaceData); err != nil {
+			return GRPCRequest{}, fmt.Errorf("failed to execute request template: %w", err)
+		}
+		var fields MassExecGRPCRequestTemplateFields
+		if err := yaml.Unmarshal(buffer.Bytes(), &fields); err != nil {
+			return GRPCRequest{}, fmt.Errorf("failed to unmarshal request template: %w", err)
+		}
+		if fields.Metadata != nil {
+			r.Metadata = fields.Metadata
+		}
+		if fields.Body != nil {
+			r.Body = fields.Body
+		}
+		return r, nil
+	}
+	if err := r.Tmpl.Execute(&buffer, replaceData); err != nil {
+		return GRPCRequest{}, fmt.Errorf("failed to execute template: %w", err)
+	}
+	var massExec MassExec
+	if err := yaml.Unmarshal(buffer.Bytes(), &massExec); err != nil {
+		return GRPCRequest{}, fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if massExec.GRPC == nil || r.ReqIndex >= len(massExec.GRPC.Requests) {
+		return GRPCRequest{}, fmt.Errorf("grpc request[%d] not found in rendered template", r.ReqIndex)
+	}
+	r.Metadata = massExec.GRPC.Requests[r.ReqIndex].Metadata
+	r.Body = massExec.GRPC.Requests[r.ReqIndex].Body
+	return r, nil
+}
+
+// CreateRequest creates the gRPC request, the auth is set on the metadata
+func (r GRPCRequest) CreateRequest(ctx context.Context, _ logger.Logger, count int) (grpcexec.Request, error) {
+	r, err := r.render(count)
+	if err != nil {
+		return grpcexec.Request{}, err
+	}
+	md := metadata.MD{}
+	for key, value := range r.Metadata {
+		key = strings.ToLower(key)
+		if array, ok := value.([]any); ok {
+			for _, v := range array {
+				md.Append(key, fmt.Sprint(v))
+			}
+			continue
+		}
+		md.Set(key, fmt.Sprint(value))
+	}
+	if r.Auth != nil {
+		// the auth is set on the dummy request, and its headers are mapped to the metadata
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost", nil)
+		if err != nil {
+			return grpcexec.Request{}, fmt.Errorf("failed to create request: %w", err)
+		}
+		r.Auth.SetOnRequest(ctx, req)
+		md = metadata.Join(md, grpcexec.MetadataFromHeader(req.Header))
+	}
+	var body []byte
+	if r.Body != nil {
+		if body, err = json.Marshal(r.Body); err != nil {
+			return grpcexec.Request{}, fmt.Errorf("failed to marshal body: %w", err)
+		}
+	}
+	return grpcexec.NewRequest(r.Descriptor, body, md)
+}
+
+var _ grpcexec.ExecReq = GRPCRequest{}
+
+func (r ValidMassExec) runGRPC(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+) ([]MassExecResult, error) {
+	conns := make(map[string]*grpc.ClientConn)
+	defer func() {
+		for target, conn := range conns {
+			if err := conn.Close(); err != nil {
+				log.Error(ctx, "failed to close connection",
+					logger.Value("error", err), logger.Value("target", target))
+			}
+		}
+	}()
+	resolvers := make(map[string]grpcexec.DescriptorResolver)
+
+	threads := make([]massExecThread, len(r.GRPC.Requests))
+	for i, request := range r.GRPC.Requests {
+		conn, ok := conns[request.Target]
+		if !ok {
+			var err error
+			if conn, err = grpcexec.Dial(request.Target, r.GRPC.TLS); err != nil {
+				return nil, fmt.Errorf("failed to dial %s: %w", request.Target, err)
+			}
+			conns[request.Target] = conn
+		}
+		descriptor := request.Descriptor
+		if descriptor == nil {
+			service, _, err := grpcexec.SplitMethod(request.RPC)
+			if err != nil {
+				return nil, err
+			}
+			key := fmt.Sprintf("%s/%s", request.Target, service)
+			resolver, ok := resolvers[key]
+			if !ok {
+				if resolver, err = grpcexec.ResolveByReflection(ctx, conn, service); err != nil {
+					return nil, fmt.Errorf("failed to resolve %s by reflection: %w", request.RPC, err)
+				}
+				resolvers[key] = resolver
+			}
+			if descriptor, err = grpcexec.FindMethod(resolver, request.RPC); err != nil {
+				return nil, fmt.Errorf("failed to find method: %w", err)
+			}
+		}
+
+		resChan := make(chan httpexec.ResponseContent)
+		threads[i] = massExecThread{
+			request: request.ValidMassExecRequest,
+			result: MassExecResult{
+				RequestIndex: i,
+				Method:       request.Method,
+				URL:          request.URL,
+			},
+			executor: grpcexec.MassRequestContent[GRPCRequest]{
+				MassDriver: request.massDriver(resChan),
+				Conn:       conn,
+				Req: GRPCRequest{
+					Descriptor:  descriptor,
+					Metadata:    request.Headers,
+					Body:        request.Body,
+					Auth:        r.Auth,
+					Tmpl:        request.Tmpl,
+					RequestTmpl: request.RequestTmpl,
+					ReplaceData: request.ReplaceData,
+					ReqIndex:    i,
+				},
+				SkipParse: !request.NeedsBody(),
+			},
+			resChan: resChan,
+		}
+	}
+	return r.runThreads(ctx, log, outputRoot, eventCaster, threads)
+}
@@ -0,0 +1,285 @@
+package runner_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/grpcexec"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+const echoProto = `syntax = "proto3";
+
+package bloader.test;
+
+import "google/protobuf/timestamp.proto";
+
+message SayRequest {
+  string text = 1;
+  int32 count = 2;
+}
+
+message SayResponse {
+  string text = 1;
+  string user = 2;
+  int32 index = 3;
+  google.protobuf.Timestamp at = 4;
+}
+
+service Echo {
+  rpc Say(SayRequest) returns (SayResponse);
+  rpc Repeat(SayRequest) returns (stream SayResponse);
+}
+`
+
+// newEchoResponse creates the response of the echo service from the request
+func newEchoResponse(desc protoreflect.MethodDescriptor, req *dynamicpb.Message, user string, index int) *dynamicpb.Message {
+	res := dynamicpb.NewMessage(desc.Output())
+	fields := desc.Output().Fields()
+	res.Set(fields.ByName("text"), req.Get(desc.Input().Fields().ByName("text")))
+	res.Set(fields.ByName("user"), protoreflect.ValueOfString(user))
+	res.Set(fields.ByName("index"), protoreflect.ValueOfInt32(int32(index)))
+	return res
+}
+
+// newGRPCServer starts the gRPC server with the echo service built from the proto file,
+// the health service and the server reflection
+func newGRPCServer(t *testing.T, dir string) string {
+	t.Helper()
+	resolver, err := grpcexec.ParseProtoFiles(context.Background(), func(path string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, path))
+	}, nil, []string{"echo.proto"})
+	if err != nil {
+		t.Fatalf("failed to parse proto: %v", err)
+	}
+	say, err := grpcexec.FindMethod(resolver, "bloader.test.Echo/Say")
+	if err != nil {
+		t.Fatalf("failed to find method: %v", err)
+	}
+	repeat, err := grpcexec.FindMethod(resolver, "bloader.test.Echo/Repeat")
+	if err != nil {
+		t.Fatalf("failed to find method: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "bloader.test.Echo",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Say",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := dynamicpb.NewMessage(say.Input())
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				if req.Get(say.Input().Fields().ByName("text")).String() == "fail" {
+					return nil, status.Error(codes.InvalidArgument, "fail")
+				}
+				var user string
+				if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-user")) > 0 {
+					user = md.Get("x-user")[0]
+				}
+				return newEchoResponse(say, req, user, 0), nil
+			},
+		}},
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Repeat",
+			ServerStreams: true,
+			Handler: func(_ any, stream grpc.ServerStream) error {
+				req := dynamicpb.NewMessage(repeat.Input())
+				if err := stream.RecvMsg(req); err != nil {
+					return err
+				}
+				count := int(req.Get(repeat.Input().Fields().ByName("count")).Int())
+				for i := 0; i < count; i++ {
+					if err := stream.SendMsg(newEchoResponse(repeat, req, "", i)); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		}},
+	}, struct{}{})
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	reflection.Register(srv)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+// TestMassExecGRPC tests the gRPC MassExec against the in-process gRPC server.
+func TestMassExecGRPC(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "proto"), 0o755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "proto", "echo.proto"), []byte(echoProto), 0o600); err != nil {
+		t.Fatalf("failed to write proto: %v", err)
+	}
+	addr := newGRPCServer(t, filepath.Join(dir, "proto"))
+	targets := target.Container{
+		"echo": {Type: config.TargetTypeGRPC, URL: addr},
+	}
+
+	t.Run("ProtoFiles", func(tt *testing.T) {
+		out, results, err := execMassExec(tt, dir, targets, "grpc", `
+type: grpc
+output:
+  enabled: true
+  ids: ["memory"]
+grpc:
+  proto:
+    import_paths: ["proto"]
+    files: ["echo.proto"]
+  requests:
+    - target_id: echo
+      method: bloader.test.Echo/Say
+      metadata:
+        X-User: alice
+      body:
+        text: hello
+      data:
+        - key: User
+          extractor:
+            type: jmesPath
+            jmes_path: user
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 3
+    - target_id: echo
+      method: bloader.test.Echo/Repeat
+      body:
+        text: hi
+        count: 3
+      data:
+        - key: Size
+          extractor:
+            type: jmesPath
+            jmes_path: length(@)
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 2
+    - target_id: echo
+      method: /bloader.test.Echo/Say
+      body:
+        text: fail
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - statusCode/invalid
+      break:
+        status_code:
+          - id: invalid
+            op: eq
+            value: 3
+`)
+		if err != nil {
+			tt.Fatalf("failed to run: %v", err)
+		}
+		if len(results) != 3 {
+			tt.Fatalf("expected 3 results, got %d", len(results))
+		}
+		for _, r := range results {
+			if !r.Success {
+				tt.Errorf("expected success, got %+v", r)
+			}
+		}
+		if results[2].MatchedID != "invalid" {
+			tt.Errorf("expected the invalid argument status to match, got %+v", results[2])
+		}
+		rows := out.bySuffix("_0")
+		if len(rows) != 4 {
+			tt.Fatalf("expected header and 3 rows, got %d", len(rows))
+		}
+		for _, row := range rows[1:] {
+			// the status code is recorded in place of the HTTP status code
+			if row[5] != "0" || row[6] != "alice" {
+				tt.Errorf("expected the OK status with the metadata, got %v", row)
+			}
+		}
+		rows = out.bySuffix("_1")
+		if len(rows) != 3 {
+			tt.Fatalf("expected header and 2 rows, got %d", len(rows))
+		}
+		for _, row := range rows[1:] {
+			if row[6] != "3" {
+				tt.Errorf("expected 3 streamed messages, got %v", row)
+			}
+		}
+	})
+
+	t.Run("Reflection", func(tt *testing.T) {
+		out, results, err := execMassExec(tt, dir, targets, "grpc", `
+type: grpc
+output:
+  enabled: true
+  ids: ["memory"]
+grpc:
+  reflection: true
+  requests:
+    - target_id: echo
+      method: grpc.health.v1.Health/Check
+      body: {}
+      data:
+        - key: Status
+          extractor:
+            type: jmesPath
+            jmes_path: status
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 1
+    - target_id: echo
+      method: grpc.health.v1.Health/Check
+      body:
+        service: missing
+      interval: 1ms
+      success_break:
+        - statusCode/notFound
+      break:
+        status_code:
+          - id: notFound
+            op: eq
+            value: 5
+`)
+		if err != nil {
+			tt.Fatalf("failed to run: %v", err)
+		}
+		if len(results) != 2 || !results[0].Success || !results[1].Success {
+			tt.Fatalf("expected success, got %+v", results)
+		}
+		if rows := out.bySuffix("_0"); len(rows) != 2 || rows[1][6] != "SERVING" {
+			tt.Errorf("expected the serving status, got %v", rows)
+		}
+	})
+}
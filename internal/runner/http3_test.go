@@ -0,0 +1,126 @@
+package runner_test
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// newHTTP3Server starts the HTTP/3 server with the self-signed certificate,
+// and the TLS server over TCP which has no QUIC listener on its port
+func newHTTP3Server(t *testing.T) (h3URL string, tcpURL string) {
+	t.Helper()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"proto":%q}`, r.Proto)
+	})
+	tcp := httptest.NewTLSServer(handler)
+	t.Cleanup(tcp.Close)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := &http3.Server{
+		Handler:   handler,
+		TLSConfig: http3.ConfigureTLSConfig(&tls.Config{Certificates: tcp.TLS.Certificates}), //nolint:gosec
+	}
+	go func() {
+		_ = srv.Serve(conn)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+		_ = conn.Close()
+	})
+	return "https://" + conn.LocalAddr().String(), tcp.URL
+}
+
+const http3MassExec = `
+type: http
+output:
+  enabled: true
+  ids: ["memory"]
+requests:
+  - target_id: api
+    endpoint: /proto
+    method: GET
+    response_type: json
+    data:
+      - key: Proto
+        extractor:
+          type: jmesPath
+          jmes_path: proto
+    interval: 1ms
+    await_prev_response: true
+    success_break:
+      - count
+    break:
+      count: 2
+`
+
+// TestMassExecHTTP3 tests the requests to the h3 target against the in-process QUIC server.
+func TestMassExecHTTP3(t *testing.T) {
+	h3URL, tcpURL := newHTTP3Server(t)
+	h3 := func(url string, cfg config.ValidTargetHTTP3Config) target.Container {
+		cfg.InsecureSkipVerify = true
+		return target.Container{
+			"api": {Type: config.TargetTypeHTTP, URL: url, Protocol: config.TargetProtocolH3, HTTP3: cfg},
+		}
+	}
+
+	t.Run("ZeroRTT", func(tt *testing.T) {
+		// the second run resumes the session of the first one
+		for i, resumed := range []bool{false, true} {
+			out, _ := runMassExec(tt, h3(h3URL, config.ValidTargetHTTP3Config{ZeroRTT: true}), "http3", http3MassExec)
+			rows := out.bySuffix("_0")
+			if len(rows) != 3 || rows[0][6] != "Protocol" || rows[0][8] != "ZeroRTT" {
+				tt.Fatalf("expected header and 2 rows, got %v", rows)
+			}
+			for j, row := range rows[1:] {
+				if row[0] != "true" || row[6] != "HTTP/3.0" || row[9] != "HTTP/3.0" {
+					tt.Errorf("expected the HTTP/3 response, got %v", row)
+				}
+				// only the first request opens the connection
+				if expected := j == 0 && resumed; row[8] != strconv.FormatBool(expected) {
+					tt.Errorf("run %d: expected ZeroRTT %t, got %v", i, expected, row)
+				}
+			}
+		}
+	})
+
+	t.Run("Fallback", func(tt *testing.T) {
+		out, _ := runMassExec(tt, h3(tcpURL, config.ValidTargetHTTP3Config{Fallback: true, HandshakeTimeout: 300 * time.Millisecond}), "http3", http3MassExec)
+		rows := out.bySuffix("_0")
+		if len(rows) != 3 {
+			tt.Fatalf("expected header and 2 rows, got %v", rows)
+		}
+		for _, row := range rows[1:] {
+			if row[0] != "true" || row[6] != "HTTP/1.1" || row[9] != "HTTP/1.1" {
+				tt.Errorf("expected the response over TCP, got %v", row)
+			}
+		}
+	})
+
+	t.Run("NoFallback", func(tt *testing.T) {
+		out, _ := runMassExec(tt, h3(tcpURL, config.ValidTargetHTTP3Config{HandshakeTimeout: 300 * time.Millisecond}), "http3", http3MassExec)
+		rows := out.bySuffix("_0")
+		if len(rows) != 3 {
+			tt.Fatalf("expected header and 2 rows, got %v", rows)
+		}
+		for _, row := range rows[1:] {
+			if row[0] != "false" || row[6] != "" {
+				tt.Errorf("expected the failure without h3, got %v", row)
+			}
+		}
+	})
+}
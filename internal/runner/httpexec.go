@@ -21,6 +21,8 @@ type WriteData struct {
 	Count            int
 	ResponseTime     int
 	StatusCode       string
+	ErrorCodes       []string
+	Columns          []string
 	RawData          any
 }
 
@@ -60,6 +62,7 @@ func runResponseHandler(
 	uidChan <-chan uuid.UUID,
 	resChan <-chan httpexec.ResponseContent,
 	writeChan chan<- writeSendData,
+	emitter *RequestEventEmitter,
 ) {
 	defer close(termChan)
 	var timeout <-chan time.Time
@@ -67,6 +70,9 @@ func runResponseHandler(
 		timeout = time.After(request.Break.Time.Time)
 	}
 	sentUID := make(map[uuid.UUID]struct{})
+	var counts TerminateCounts
+	// the body is not parsed when nothing refers to it
+	needsBody := request.NeedsBody()
 	for {
 		select {
 		case uid := <-uidChan:
@@ -83,7 +89,7 @@ func runResponseHandler(
 				}
 			}
 			select {
-			case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+			case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 			case <-reqTermChan:
 				return
 			}
@@ -108,7 +114,7 @@ func runResponseHandler(
 				log.Warn(ctx, "Term Condition: Write Error",
 					logger.Value("id", id))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -117,7 +123,7 @@ func runResponseHandler(
 			log.Info(ctx, "Term Condition: Time",
 				logger.Value("id", id))
 			select {
-			case termChan <- NewTermChanType(matcher.TerminateTypeByTimeout, ""):
+			case termChan <- NewTermChanType(matcher.TerminateTypeByTimeout, "").WithCounts(counts):
 			case <-reqTermChan:
 				return
 			}
@@ -142,7 +148,7 @@ func runResponseHandler(
 				log.Warn(ctx, "Term Condition: Write Error",
 					logger.Value("id", id))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -151,7 +157,7 @@ func runResponseHandler(
 			log.Info(ctx, "Term Condition: Context Done",
 				logger.Value("id", id))
 			select {
-			case termChan <- NewTermChanType(matcher.TerminateTypeByContext, ""):
+			case termChan <- NewTermChanType(matcher.TerminateTypeByContext, "").WithCounts(counts):
 			case <-reqTermChan:
 				return
 			}
@@ -177,7 +183,7 @@ func runResponseHandler(
 					log.Warn(ctx, "Term Condition: Write Error",
 						logger.Value("id", id), logger.Value("count", v.Count))
 					select {
-					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 					case <-reqTermChan:
 						return
 					}
@@ -187,7 +193,7 @@ func runResponseHandler(
 				log.Info(ctx, "Term Condition: Count Limit",
 					logger.Value("id", id), logger.Value("count", v.Count))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByCount, ""):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByCount, "").WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -195,11 +201,34 @@ func runResponseHandler(
 			}
 			mustWrite := true
 			var response any
-			err := json.Unmarshal(v.ByteResponse, &response)
-			if err != nil {
-				log.Error(ctx, "The response is not a valid JSON",
-					logger.Value("error", err), logger.Value("count", v.Count))
+			var err error
+			if needsBody {
+				switch httpexec.ResponseType(request.ResponseType) {
+				case httpexec.ResponseTypeJSON, httpexec.ResponseTypeStream:
+					// the executor has already decoded the body
+					response = v.Res
+				default:
+					if err = json.Unmarshal(v.ByteResponse, &response); err != nil {
+						log.Error(ctx, "The response is not a valid JSON",
+							logger.Value("error", err), logger.Value("count", v.Count))
+					}
+				}
+			}
+			var errorCodes []string
+			if request.IsGraphQL() {
+				errorCodes, _ = graphQLErrors(response)
+				if graphQLFailed(v.StatusCode, response) {
+					v.Success = false
+				}
 			}
+			counts.Count++
+			counts.BodyBytes += v.BodyBytes
+			if v.Success {
+				counts.SuccessCount++
+			} else {
+				counts.FailureCount++
+			}
+			emitter.OnResponse(ctx, log, v.Count, response)
 			_, isMatch := request.RecordExcludeFilter.CountFilter(v.Count)
 			if isMatch {
 				log.Debug(ctx, "Count output filter found",
@@ -212,6 +241,18 @@ func runResponseHandler(
 					logger.Value("id", id), logger.Value("count", v.Count))
 				mustWrite = false
 			}
+			_, isMatch = request.RecordExcludeFilter.ErrorCodeFilter(errorCodes)
+			if isMatch {
+				log.Debug(ctx, "Error code output filter found",
+					logger.Value("id", id), logger.Value("count", v.Count))
+				mustWrite = false
+			}
+			_, isMatch = request.RecordExcludeFilter.ResponseBytesFilter(v.ByteResponse)
+			if isMatch {
+				log.Debug(ctx, "Response bytes output filter found",
+					logger.Value("id", id), logger.Value("count", v.Count))
+				mustWrite = false
+			}
 			var matchID string
 			matchID, isMatch, err = request.RecordExcludeFilter.ResponseBodyFilter(response)
 			if err != nil {
@@ -236,7 +277,7 @@ func runResponseHandler(
 					log.Warn(ctx, "Term Condition: Write Error",
 						logger.Value("id", id), logger.Value("count", v.Count))
 					select {
-					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 					case <-reqTermChan:
 						return
 					}
@@ -245,7 +286,7 @@ func runResponseHandler(
 				log.Info(ctx, "Term Condition: Response Body Write Filter Error",
 					logger.Value("id", id), logger.Value("count", v.Count))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByResponseBodyWriteFilterError, matchID):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByResponseBodyWriteFilterError, matchID).WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -266,6 +307,8 @@ func runResponseHandler(
 					Count:            v.Count,
 					ResponseTime:     int(v.ResponseTime),
 					StatusCode:       strconv.Itoa(v.StatusCode),
+					ErrorCodes:       errorCodes,
+					Columns:          v.Columns,
 					RawData:          response,
 				}
 				sentUID[uid] = struct{}{}
@@ -298,7 +341,7 @@ func runResponseHandler(
 				log.Warn(ctx, "Term Condition: Request Creation Error",
 					logger.Value("id", id), logger.Value("count", v.Count))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByCreateRequestError, ""):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByCreateRequestError, "").WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -322,7 +365,7 @@ func runResponseHandler(
 					log.Warn(ctx, "Term Condition: System Error",
 						logger.Value("id", id), logger.Value("count", v.Count))
 					select {
-					case termChan <- NewTermChanType(matcher.TerminateTypeBySystemError, ""):
+					case termChan <- NewTermChanType(matcher.TerminateTypeBySystemError, "").WithCounts(counts):
 					case <-reqTermChan:
 						return
 					}
@@ -349,7 +392,7 @@ func runResponseHandler(
 					log.Warn(ctx, "Term Condition: Response Parse Error",
 						logger.Value("id", id), logger.Value("count", v.Count))
 					select {
-					case termChan <- NewTermChanType(matcher.TerminateTypeByParseResponseError, ""):
+					case termChan <- NewTermChanType(matcher.TerminateTypeByParseResponseError, "").WithCounts(counts):
 					case <-reqTermChan:
 						return
 					}
@@ -381,7 +424,7 @@ func runResponseHandler(
 					log.Warn(ctx, "Term Condition: Write Error",
 						logger.Value("id", id), logger.Value("count", v.Count))
 					select {
-					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 					case <-reqTermChan:
 						return
 					}
@@ -391,7 +434,7 @@ func runResponseHandler(
 				log.Info(ctx, "Term Condition: Response Body Break Filter Error",
 					logger.Value("id", id), logger.Value("count", v.Count))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByResponseBodyBreakFilterError, matchID):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByResponseBodyBreakFilterError, matchID).WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -417,7 +460,7 @@ func runResponseHandler(
 					log.Warn(ctx, "Term Condition: Write Error",
 						logger.Value("id", id), logger.Value("count", v.Count))
 					select {
-					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 					case <-reqTermChan:
 						return
 					}
@@ -427,7 +470,7 @@ func runResponseHandler(
 				log.Info(ctx, "Term Condition: Response Body",
 					logger.Value("id", id), logger.Value("count", v.Count))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByResponseBody, matchID):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByResponseBody, matchID).WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -454,7 +497,7 @@ func runResponseHandler(
 					log.Warn(ctx, "Term Condition: Write Error",
 						logger.Value("id", id), logger.Value("count", v.Count))
 					select {
-					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, ""):
+					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
 					case <-reqTermChan:
 						return
 					}
@@ -464,7 +507,81 @@ func runResponseHandler(
 				log.Info(ctx, "Term Condition: Status Code",
 					logger.Value("id", id), logger.Value("count", v.Count))
 				select {
-				case termChan <- NewTermChanType(matcher.TerminateTypeByStatusCode, matchID):
+				case termChan <- NewTermChanType(matcher.TerminateTypeByStatusCode, matchID).WithCounts(counts):
+				case <-reqTermChan:
+					return
+				}
+				return
+			}
+			matchID, isMatch = request.Break.ErrorCodeMatcher(errorCodes)
+			if isMatch {
+				sentLen := len(sentUID)
+				writeErr := false
+				for sentLen > 0 {
+					select {
+					case <-reqTermChan:
+						return
+					case uid := <-uidChan:
+						delete(sentUID, uid)
+						sentLen--
+					case <-writeErrChan:
+						log.Warn(ctx, "write error occurred",
+							logger.Value("id", id), logger.Value("count", v.Count))
+						writeErr = true
+					}
+				}
+				if writeErr {
+					log.Warn(ctx, "Term Condition: Write Error",
+						logger.Value("id", id), logger.Value("count", v.Count))
+					select {
+					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
+					case <-reqTermChan:
+						return
+					}
+					return
+				}
+
+				log.Info(ctx, "Term Condition: Error Code",
+					logger.Value("id", id), logger.Value("count", v.Count))
+				select {
+				case termChan <- NewTermChanType(matcher.TerminateTypeByErrorCode, matchID).WithCounts(counts):
+				case <-reqTermChan:
+					return
+				}
+				return
+			}
+			matchID, isMatch = request.Break.ResponseBytesMatcher(v.ByteResponse)
+			if isMatch {
+				sentLen := len(sentUID)
+				writeErr := false
+				for sentLen > 0 {
+					select {
+					case <-reqTermChan:
+						return
+					case uid := <-uidChan:
+						delete(sentUID, uid)
+						sentLen--
+					case <-writeErrChan:
+						log.Warn(ctx, "write error occurred",
+							logger.Value("id", id), logger.Value("count", v.Count))
+						writeErr = true
+					}
+				}
+				if writeErr {
+					log.Warn(ctx, "Term Condition: Write Error",
+						logger.Value("id", id), logger.Value("count", v.Count))
+					select {
+					case termChan <- NewTermChanType(matcher.TerminateTypeByWriteError, "").WithCounts(counts):
+					case <-reqTermChan:
+						return
+					}
+					return
+				}
+
+				log.Info(ctx, "Term Condition: Response Bytes",
+					logger.Value("id", id), logger.Value("count", v.Count))
+				select {
+				case termChan <- NewTermChanType(matcher.TerminateTypeByResponseBytes, matchID).WithCounts(counts):
 				case <-reqTermChan:
 					return
 				}
@@ -484,12 +601,13 @@ func RunAsyncProcessing(
 	termChan chan<- TermChanType,
 	resChan <-chan httpexec.ResponseContent,
 	consumer ResponseDataConsumer,
+	emitter *RequestEventEmitter,
 ) {
 	writeChan := make(chan writeSendData)
 	wroteUIDChan := make(chan uuid.UUID)
 	writeErrChan := make(chan struct{})
 	go func() {
-		runResponseHandler(ctx, reqTermChan, log, id, request, termChan, writeErrChan, wroteUIDChan, resChan, writeChan)
+		runResponseHandler(ctx, reqTermChan, log, id, request, termChan, writeErrChan, wroteUIDChan, resChan, writeChan, emitter)
 	}()
 
 	go func() {
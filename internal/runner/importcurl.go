@@ -0,0 +1,365 @@
+package runner
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// curlValueShortFlags is the short options of curl which take the value
+const curlValueShortFlags = "XHdubAeFomwTxEKCrcDUyYzQt"
+
+// curlValueLongFlags is the long options of curl which take the value and are not imported
+var curlValueLongFlags = map[string]bool{
+	"--output": true, "--max-time": true, "--connect-timeout": true, "--write-out": true,
+	"--upload-file": true, "--proxy": true, "--proxy-user": true, "--cert": true, "--key": true,
+	"--cacert": true, "--capath": true, "--config": true, "--range": true, "--cookie-jar": true,
+	"--dump-header": true, "--retry": true, "--retry-delay": true, "--retry-max-time": true,
+	"--resolve": true, "--connect-to": true, "--limit-rate": true, "--max-redirs": true,
+	"--interface": true, "--unix-socket": true, "--trace": true, "--trace-ascii": true,
+	"--stderr": true, "--cert-type": true, "--key-type": true, "--ciphers": true,
+	"--speed-limit": true, "--speed-time": true, "--continue-at": true, "--quote": true,
+	"--time-cond": true, "--expect100-timeout": true, "--keepalive-time": true, "--aws-sigv4": true,
+}
+
+// curlLongFlags maps the long options of curl which are imported to their short options
+var curlLongFlags = map[string]string{
+	"--request":    "X",
+	"--header":     "H",
+	"--data":       "d",
+	"--data-ascii": "d",
+	"--user":       "u",
+	"--cookie":     "b",
+	"--user-agent": "A",
+	"--referer":    "e",
+	"--form":       "F",
+	"--get":        "G",
+	"--head":       "I",
+}
+
+// splitShell splits the shell script into the commands and their words,
+// the commands are separated by the newlines, ;, &&, || and |
+func splitShell(script string) ([][]string, error) {
+	var commands [][]string
+	var words []string
+	var word strings.Builder
+	inWord := false
+	endWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	endCommand := func() {
+		endWord()
+		if len(words) > 0 {
+			commands = append(commands, words)
+			words = nil
+		}
+	}
+	runes := []rune(script)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\':
+			if i+1 < len(runes) {
+				i++
+				if runes[i] == '\n' {
+					continue
+				}
+				if runes[i] == '\r' && i+1 < len(runes) && runes[i+1] == '\n' {
+					i++
+					continue
+				}
+				word.WriteRune(runes[i])
+				inWord = true
+			}
+		case r == '\'':
+			i++
+			for ; i < len(runes) && runes[i] != '\''; i++ {
+				word.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			inWord = true
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			i += 2
+			for ; i < len(runes) && runes[i] != '\''; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					switch runes[i] {
+					case 'n':
+						word.WriteRune('\n')
+					case 't':
+						word.WriteRune('\t')
+					case 'r':
+						word.WriteRune('\r')
+					default:
+						word.WriteRune(runes[i])
+					}
+					continue
+				}
+				word.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			inWord = true
+		case r == '"':
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`\n", runes[i+1]) {
+					i++
+					if runes[i] == '\n' {
+						continue
+					}
+				}
+				word.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case r == '#' && !inWord:
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			endCommand()
+		case r == '\n' || r == ';' || r == '|' || r == '&':
+			endCommand()
+		case r == ' ' || r == '\t' || r == '\r':
+			endWord()
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	endCommand()
+	return commands, nil
+}
+
+// ParseCurl parses the curl commands, such as the ones copied from the browser, into the requests.
+// The commands other than curl in the script are ignored.
+func ParseCurl(script string) ([]ImportedRequest, error) {
+	commands, err := splitShell(script)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split command: %w", err)
+	}
+	var requests []ImportedRequest
+	for _, words := range commands {
+		if path.Base(words[0]) != "curl" {
+			continue
+		}
+		parsed, err := parseCurlCommand(words[1:])
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, parsed...)
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no curl command is found")
+	}
+	return requests, nil
+}
+
+// parseCurlCommand parses the arguments of the curl command, a request is returned for each URL
+func parseCurlCommand(args []string) ([]ImportedRequest, error) {
+	var urls []string
+	var method string
+	var headers []ImportedHeader
+	var data []string
+	var form []ImportedHeader
+	var formFiles, notes []string
+	var get, head bool
+	var mimeType string
+
+	apply := func(flag, value string) {
+		switch flag {
+		case "X":
+			method = strings.ToUpper(value)
+		case "H":
+			name, headerValue, ok := strings.Cut(value, ":")
+			headerValue = strings.TrimSpace(headerValue)
+			if !ok || headerValue == "" {
+				return
+			}
+			name = strings.TrimSpace(name)
+			if strings.EqualFold(name, "content-type") {
+				mimeType = headerValue
+			}
+			headers = append(headers, ImportedHeader{Name: name, Value: headerValue})
+		case "d", "data-raw", "data-binary":
+			if flag != "data-raw" && strings.HasPrefix(value, "@") {
+				notes = append(notes, fmt.Sprintf("the data of the file %s is not imported", value[1:]))
+				return
+			}
+			data = append(data, value)
+		case "data-urlencode":
+			name, content, ok := strings.Cut(value, "=")
+			switch {
+			case !ok:
+				data = append(data, url.QueryEscape(value))
+			case name == "":
+				data = append(data, url.QueryEscape(content))
+			default:
+				data = append(data, name+"="+url.QueryEscape(content))
+			}
+		case "json":
+			data = append(data, value)
+			mimeType = "application/json"
+		case "u":
+			headers = append(headers, ImportedHeader{
+				Name:  "Authorization",
+				Value: "Basic " + base64.StdEncoding.EncodeToString([]byte(value)),
+			})
+		case "oauth2-bearer":
+			headers = append(headers, ImportedHeader{Name: "Authorization", Value: "Bearer " + value})
+		case "b":
+			if strings.Contains(value, "=") {
+				headers = append(headers, ImportedHeader{Name: "Cookie", Value: value})
+			}
+		case "A":
+			headers = append(headers, ImportedHeader{Name: "User-Agent", Value: value})
+		case "e":
+			headers = append(headers, ImportedHeader{Name: "Referer", Value: value})
+		case "F", "form-string":
+			name, content, _ := strings.Cut(value, "=")
+			if flag == "F" && (strings.HasPrefix(content, "@") || strings.HasPrefix(content, "<")) {
+				formFiles = append(formFiles, name)
+				return
+			}
+			form = append(form, ImportedHeader{Name: name, Value: content})
+		case "G":
+			get = true
+		case "I":
+			head = true
+		case "url":
+			urls = append(urls, value)
+		}
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		next := func() (string, error) {
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("option %s requires a value", arg)
+			}
+			i++
+			return args[i], nil
+		}
+		switch {
+		case strings.HasPrefix(arg, "--"):
+			flag, ok := curlLongFlags[arg]
+			if !ok {
+				switch arg {
+				case "--data-raw", "--data-binary", "--data-urlencode", "--json", "--oauth2-bearer",
+					"--form-string", "--url":
+					flag = strings.TrimPrefix(arg, "--")
+				default:
+					if curlValueLongFlags[arg] {
+						if _, err := next(); err != nil {
+							return nil, err
+						}
+					}
+					continue
+				}
+			}
+			if flag == "G" || flag == "I" {
+				apply(flag, "")
+				continue
+			}
+			value, err := next()
+			if err != nil {
+				return nil, err
+			}
+			apply(flag, value)
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// the short options may be combined, and the value may follow the option without the space
+			for j := 1; j < len(arg); j++ {
+				flag := string(arg[j])
+				if !strings.Contains(curlValueShortFlags, flag) {
+					apply(flag, "")
+					continue
+				}
+				value := arg[j+1:]
+				if value == "" {
+					var err error
+					if value, err = next(); err != nil {
+						return nil, err
+					}
+				}
+				apply(flag, value)
+				break
+			}
+		default:
+			urls = append(urls, arg)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no url is found in the curl command")
+	}
+
+	body := strings.Join(data, "&")
+	if mimeType == "application/json" {
+		body = strings.Join(data, "")
+		if !hasHeader(headers, "content-type") {
+			headers = append(headers, ImportedHeader{Name: "Content-Type", Value: mimeType})
+		}
+	}
+	if method == "" {
+		switch {
+		case head:
+			method = "HEAD"
+		case (len(data) > 0 && !get) || len(form) > 0 || len(formFiles) > 0:
+			method = "POST"
+		default:
+			method = "GET"
+		}
+	}
+	if len(form) > 0 || len(formFiles) > 0 {
+		mimeType = "multipart/form-data"
+	} else if body != "" && mimeType == "" && !get {
+		mimeType = "application/x-www-form-urlencoded"
+	}
+
+	requests := make([]ImportedRequest, 0, len(urls))
+	for _, rawURL := range urls {
+		if !strings.Contains(rawURL, "://") {
+			rawURL = "http://" + rawURL
+		}
+		req := ImportedRequest{
+			Method:    method,
+			URL:       rawURL,
+			Headers:   headers,
+			MimeType:  mimeType,
+			Form:      form,
+			FormFiles: formFiles,
+			Notes:     notes,
+		}
+		if get && body != "" {
+			separator := "?"
+			if strings.Contains(rawURL, "?") {
+				separator = "&"
+			}
+			req.URL = rawURL + separator + body
+		} else {
+			req.Body = body
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// hasHeader reports whether the header of the name is in the headers
+func hasHeader(headers []ImportedHeader, name string) bool {
+	for _, header := range headers {
+		if strings.EqualFold(header.Name, name) {
+			return true
+		}
+	}
+	return false
+}
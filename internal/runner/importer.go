@@ -0,0 +1,1040 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/container"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// ImportKind represents the kind of the runner files generated by the import
+type ImportKind string
+
+const (
+	// ImportKindOne generates the OneExecute runner for each request
+	ImportKindOne ImportKind = "one"
+	// ImportKindMass generates the MassExecute runner for each request,
+	// the requests which produce the memory data are still generated as OneExecute
+	ImportKindMass ImportKind = "mass"
+)
+
+const (
+	// DefaultImportMassCount is the default count of the requests of the MassExecute runner
+	DefaultImportMassCount = 10
+	// DefaultImportMassInterval is the default interval of the requests of the MassExecute runner
+	DefaultImportMassInterval = "100ms"
+	// ImportFlowFile is the name of the flow file which runs the imported files in order
+	ImportFlowFile = "flow.yaml"
+)
+
+// ImportedHeader represents the name and the value of the header, query parameter or form field
+type ImportedHeader struct {
+	Name  string
+	Value string
+}
+
+// ImportedResponse represents the recorded response of the imported request
+type ImportedResponse struct {
+	Status   int
+	MimeType string
+	Body     string
+}
+
+// ImportedRequest represents the request parsed from the HAR file or the curl command
+type ImportedRequest struct {
+	Method   string
+	URL      string
+	Headers  []ImportedHeader
+	MimeType string
+	Body     string
+	// Form is the multipart form fields, which is used instead of Body
+	Form []ImportedHeader
+	// FormFiles is the names of the multipart file fields, which are not imported
+	FormFiles []string
+	// ResourceType is the type of the resource recorded by the browser, such as xhr, fetch or image
+	ResourceType string
+	// Notes is what the parser could not import, it is reported with the generated file
+	Notes []string
+	// Response is nil when it is not recorded
+	Response *ImportedResponse
+}
+
+// ImportOptions represents the options of the import
+type ImportOptions struct {
+	Kind ImportKind
+	// FilePrefix is the path of the output directory relative to the loader base path,
+	// which is used for the files of the flow
+	FilePrefix string
+	// OutputIDs enables the output of the generated runners
+	OutputIDs []string
+	// IncludeStatic imports the static assets such as images, stylesheets and scripts
+	IncludeStatic bool
+	Count         int
+	Interval      string
+	// Force overwrites the existing files in the output directory
+	Force bool
+}
+
+// ImportedFile represents the generated runner file
+type ImportedFile struct {
+	Name    string
+	Content []byte
+}
+
+// ImportResult represents the result of the import
+type ImportResult struct {
+	Files []ImportedFile
+	// Notes reports what the user should review, such as the unmapped hosts and the dynamic values
+	Notes []string
+}
+
+// importRunner represents the OneExecute or MassExecute runner file written by the import
+type importRunner struct {
+	Kind     string          `yaml:"kind"`
+	Type     string          `yaml:"type"`
+	Output   importOutput    `yaml:"output"`
+	Auth     *importAuth     `yaml:"auth,omitempty"`
+	Request  *importRequest  `yaml:"request,omitempty"`
+	Requests []importRequest `yaml:"requests,omitempty"`
+}
+
+// importOutput represents the output of the runner file written by the import
+type importOutput struct {
+	Enabled bool     `yaml:"enabled"`
+	IDs     []string `yaml:"ids,omitempty"`
+}
+
+// importAuth represents the auth of the runner file written by the import
+type importAuth struct {
+	Enabled bool   `yaml:"enabled"`
+	AuthID  string `yaml:"auth_id"`
+}
+
+// importRequest represents the request of the runner file written by the import
+type importRequest struct {
+	TargetID      string            `yaml:"target_id"`
+	Endpoint      string            `yaml:"endpoint"`
+	Method        string            `yaml:"method"`
+	QueryParam    map[string]any    `yaml:"query_param,omitempty"`
+	Headers       map[string]any    `yaml:"headers,omitempty"`
+	BodyType      string            `yaml:"body_type,omitempty"`
+	Body          any               `yaml:"body,omitempty"`
+	ResponseType  string            `yaml:"response_type"`
+	MemoryData    []importData      `yaml:"memory_data,omitempty"`
+	Interval      string            `yaml:"interval,omitempty"`
+	AwaitPrevResp bool              `yaml:"await_prev_response,omitempty"`
+	SuccessBreak  []string          `yaml:"success_break,omitempty"`
+	Break         *importBreak      `yaml:"break,omitempty"`
+	statusCode    int               `yaml:"-"`
+	rawValues     map[string]string `yaml:"-"`
+}
+
+// importData represents the memory data of the request written by the import
+type importData struct {
+	Key       string          `yaml:"key"`
+	Extractor importExtractor `yaml:"extractor"`
+}
+
+// importExtractor represents the extractor of the memory data written by the import
+type importExtractor struct {
+	Type     string `yaml:"type"`
+	JMESPath string `yaml:"jmes_path"`
+	OnNil    string `yaml:"on_nil"`
+}
+
+// importBreak represents the break of the MassExecute request written by the import
+type importBreak struct {
+	Count      int                     `yaml:"count"`
+	SysError   bool                    `yaml:"sys_error"`
+	StatusCode []importStatusCondition `yaml:"status_code,omitempty"`
+}
+
+// importStatusCondition represents the status code condition written by the import
+type importStatusCondition struct {
+	ID    string `yaml:"id"`
+	Op    string `yaml:"op"`
+	Value int    `yaml:"value"`
+}
+
+// importFlow represents the flow file written by the import
+type importFlow struct {
+	Kind string         `yaml:"kind"`
+	Step importFlowStep `yaml:"step"`
+}
+
+// importFlowStep represents the step of the flow file written by the import
+type importFlowStep struct {
+	Concurrency int              `yaml:"concurrency"`
+	Flows       []importFlowFlow `yaml:"flows"`
+}
+
+// importFlowFlow represents the flow of the flow file written by the import
+type importFlowFlow struct {
+	ID        string                `yaml:"id"`
+	DependsOn []importFlowDependsOn `yaml:"depends_on,omitempty"`
+	Type      string                `yaml:"type"`
+	File      string                `yaml:"file"`
+	Mkdir     bool                  `yaml:"mkdir"`
+}
+
+// importFlowDependsOn represents the depends_on of the flow written by the import
+type importFlowDependsOn struct {
+	Flow  string `yaml:"flow"`
+	Event string `yaml:"event"`
+}
+
+// importEntry represents the request being imported with its runner
+type importEntry struct {
+	name    string
+	path    string
+	runner  importRunner
+	request *importRequest
+}
+
+// importProducer represents the response value which the later requests may refer,
+// key is assigned when a later request refers it
+type importProducer struct {
+	entry    *importEntry
+	jmesPath string
+	jsonKey  string
+	key      string
+}
+
+// importer holds the state of the import across the requests
+type importer struct {
+	targets   target.Container
+	targetIDs []string
+	auths     config.ValidAuthConfig
+	opts      ImportOptions
+	// producers is keyed by the value, the latest response wins
+	producers map[string]*importProducer
+	// newTargets is keyed by the origin of the hosts which are not in the config
+	newTargets map[string]string
+	keys       map[string]bool
+	notes      []string
+	rawCount   int
+}
+
+// browserHeaders is the headers set by the browser or the HTTP client, which are not imported
+var browserHeaders = map[string]bool{
+	"host":              true,
+	"content-length":    true,
+	"connection":        true,
+	"keep-alive":        true,
+	"accept-encoding":   true,
+	"cookie":            true,
+	"te":                true,
+	"upgrade":           true,
+	"transfer-encoding": true,
+	"pragma":            true,
+	"cache-control":     true,
+	"if-none-match":     true,
+	"if-modified-since": true,
+}
+
+// staticResourceTypes is the resource types of the static assets recorded by the browser
+var staticResourceTypes = map[string]bool{
+	"image":      true,
+	"stylesheet": true,
+	"script":     true,
+	"font":       true,
+	"media":      true,
+	"manifest":   true,
+	"texttrack":  true,
+}
+
+var (
+	importUUIDPattern  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	importJWTPattern   = regexp.MustCompile(`^[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]*$`)
+	importHexPattern   = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+	importTokenPattern = regexp.MustCompile(`^[A-Za-z0-9_\-.~+/=]{20,}$`)
+	importIdentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// looksDynamic reports whether the value looks generated by the server, such as the UUID, JWT or the random token
+func looksDynamic(value string) bool {
+	if importUUIDPattern.MatchString(value) || importJWTPattern.MatchString(value) || importHexPattern.MatchString(value) {
+		return true
+	}
+	if !importTokenPattern.MatchString(value) {
+		return false
+	}
+	hasLetter := strings.IndexFunc(value, unicode.IsLetter) >= 0
+	hasDigit := strings.IndexFunc(value, unicode.IsDigit) >= 0
+	return hasLetter && hasDigit
+}
+
+// isDynamicKey reports whether the key names the identifier or the credential
+func isDynamicKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, word := range []string{"token", "session", "nonce", "csrf", "xsrf", "uuid", "guid"} {
+		if strings.Contains(lower, word) {
+			return true
+		}
+	}
+	return lower == "id" || strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "ID") ||
+		strings.HasSuffix(lower, "_id") || strings.HasSuffix(lower, "-id")
+}
+
+// isStaticAsset reports whether the request fetches the static asset
+func isStaticAsset(req ImportedRequest) bool {
+	if staticResourceTypes[strings.ToLower(req.ResourceType)] {
+		return true
+	}
+	if req.Response == nil {
+		return false
+	}
+	mimeType, _, _ := mime.ParseMediaType(req.Response.MimeType)
+	switch {
+	case strings.HasPrefix(mimeType, "image/"), strings.HasPrefix(mimeType, "font/"),
+		strings.HasPrefix(mimeType, "audio/"), strings.HasPrefix(mimeType, "video/"):
+		return true
+	case mimeType == "text/css", mimeType == "text/javascript", mimeType == "application/javascript":
+		return true
+	}
+	return false
+}
+
+// isMultipart reports whether the MIME type is the multipart form
+func isMultipart(mimeType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(mimeType)
+	return mediaType == "multipart/form-data"
+}
+
+// camelCase joins the words of the name in the camel case, which is used for the IDs and the memory keys
+func camelCase(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	for i, word := range words {
+		runes := []rune(word)
+		if i == 0 {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteString(string(runes))
+	}
+	s := b.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "value" + s
+	}
+	return s
+}
+
+// escapeTemplate escapes the template delimiters of the recorded value
+func escapeTemplate(s string) string {
+	return strings.ReplaceAll(s, "{{", `{{ "{{" }}`)
+}
+
+// valueRef returns the template which refers the memory value
+func valueRef(key string) string {
+	return fmt.Sprintf("{{ .Values.%s }}", key)
+}
+
+// jmesPathKey returns the key of the JMESPath expression, quoting it if it is not an identifier
+func jmesPathKey(key string) string {
+	if importIdentPattern.MatchString(key) {
+		return key
+	}
+	quoted, _ := json.Marshal(key)
+	return string(quoted)
+}
+
+// normalizeHost returns the host with the default port of the scheme
+func normalizeHost(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		default:
+			port = "80"
+		}
+	}
+	return strings.ToLower(u.Hostname()) + ":" + port
+}
+
+// BuildImport converts the imported requests into the runner files and the flow which runs them in order.
+// The hosts are mapped to the http targets, the auth headers are replaced by the auth of the config,
+// and the values returned by a response and sent by a later request are extracted by memory_data.
+func BuildImport(
+	requests []ImportedRequest,
+	targets target.Container,
+	auths config.ValidAuthConfig,
+	opts ImportOptions,
+) (ImportResult, error) {
+	if opts.Kind == "" {
+		opts.Kind = ImportKindOne
+	}
+	if opts.Kind != ImportKindOne && opts.Kind != ImportKindMass {
+		return ImportResult{}, fmt.Errorf("invalid import kind: %s", opts.Kind)
+	}
+	if opts.Count <= 0 {
+		opts.Count = DefaultImportMassCount
+	}
+	if opts.Interval == "" {
+		opts.Interval = DefaultImportMassInterval
+	}
+	im := &importer{
+		targets:    targets,
+		auths:      auths,
+		opts:       opts,
+		producers:  make(map[string]*importProducer),
+		newTargets: make(map[string]string),
+		keys:       make(map[string]bool),
+	}
+	for id, t := range targets {
+		if t.Type == config.TargetTypeHTTP {
+			im.targetIDs = append(im.targetIDs, id)
+		}
+	}
+	slices.Sort(im.targetIDs)
+
+	var entries []*importEntry
+	var skipped int
+	for _, req := range requests {
+		if !opts.IncludeStatic && isStaticAsset(req) {
+			skipped++
+			continue
+		}
+		entry, err := im.importRequest(len(entries)+1, req)
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("failed to import %s %s: %w", req.Method, req.URL, err)
+		}
+		entries = append(entries, entry)
+	}
+	if skipped > 0 {
+		im.notef("%d static asset requests are skipped, use --include-static to import them", skipped)
+	}
+	if len(entries) == 0 {
+		return ImportResult{}, fmt.Errorf("no request to import")
+	}
+
+	var result ImportResult
+	flow := importFlow{
+		Kind: string(RunnerKindFlow),
+		Step: importFlowStep{Concurrency: -1},
+	}
+	for i, entry := range entries {
+		content, err := im.marshalEntry(entry)
+		if err != nil {
+			return ImportResult{}, fmt.Errorf("failed to marshal %s: %w", entry.path, err)
+		}
+		result.Files = append(result.Files, ImportedFile{Name: entry.path, Content: content})
+		flowFlow := importFlowFlow{
+			ID:   entry.name,
+			Type: string(FlowStepFlowTypeFile),
+			File: path.Join(filepath.ToSlash(opts.FilePrefix), entry.path),
+		}
+		if i > 0 {
+			flowFlow.DependsOn = []importFlowDependsOn{{Flow: entries[i-1].name, Event: string(RunnerEventTerminated)}}
+		}
+		flow.Step.Flows = append(flow.Step.Flows, flowFlow)
+	}
+	content, err := marshalImport(flow)
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("failed to marshal flow: %w", err)
+	}
+	result.Files = append(result.Files, ImportedFile{Name: ImportFlowFile, Content: content})
+	result.Notes = im.notes
+	return result, nil
+}
+
+// notef records the note of the import
+func (im *importer) notef(format string, args ...any) {
+	im.notes = append(im.notes, fmt.Sprintf(format, args...))
+}
+
+// importRequest converts the request into the runner
+func (im *importer) importRequest(index int, req ImportedRequest) (*importEntry, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = "GET"
+	}
+	targetID, endpoint := im.mapTarget(u)
+	slug := strings.ToLower(staticSegment(u.Path, "root"))
+	name := fmt.Sprintf("%03d_%s_%s", index, strings.ToLower(method), slug)
+	entry := &importEntry{name: name, path: name + ".yaml"}
+	request := &importRequest{
+		TargetID:     targetID,
+		Method:       method,
+		ResponseType: string(httpexec.ResponseTypeJSON),
+		rawValues:    make(map[string]string),
+	}
+	if req.Response != nil {
+		request.ResponseType = string(responseTypeOf(req.Response.MimeType))
+		request.statusCode = req.Response.Status
+	}
+
+	// the path segments
+	segments := strings.Split(endpoint, "/")
+	for i, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		value, err := url.PathUnescape(segment)
+		if err != nil {
+			value = segment
+		}
+		if ref, ok := im.refer(entry, name, "path", value, true); ok {
+			segments[i] = ref
+			continue
+		}
+		segments[i] = escapeTemplate(segment)
+	}
+	request.Endpoint = strings.Join(segments, "/")
+
+	// the query parameters
+	if query := u.Query(); len(query) > 0 {
+		request.QueryParam = make(map[string]any, len(query))
+		for key, values := range query {
+			refs := make([]any, 0, len(values))
+			for _, value := range values {
+				if ref, ok := im.refer(entry, name, "query_param."+key, value, isDynamicKey(key)); ok {
+					refs = append(refs, ref)
+					continue
+				}
+				refs = append(refs, escapeTemplate(value))
+			}
+			if len(refs) == 1 {
+				request.QueryParam[escapeTemplate(key)] = refs[0]
+			} else {
+				request.QueryParam[escapeTemplate(key)] = refs
+			}
+		}
+	}
+
+	// the body
+	if err := im.importBody(entry, name, request, u, req); err != nil {
+		return nil, err
+	}
+
+	// the headers
+	var auth *importAuth
+	var cookies bool
+	for _, header := range req.Headers {
+		lower := strings.ToLower(header.Name)
+		switch {
+		case lower == "cookie":
+			cookies = true
+			continue
+		case browserHeaders[lower], strings.HasPrefix(lower, ":"), strings.HasPrefix(lower, "sec-"),
+			strings.HasPrefix(lower, "proxy-"):
+			continue
+		case lower == "content-type" && request.BodyType != "":
+			continue
+		}
+		value, handled := im.importCredential(entry, name, header, &auth)
+		if handled && value == "" {
+			continue
+		}
+		if !handled {
+			if ref, ok := im.refer(entry, name, "headers."+header.Name, header.Value, isDynamicKey(header.Name)); ok {
+				value = ref
+			} else {
+				value = escapeTemplate(header.Value)
+			}
+		}
+		if request.Headers == nil {
+			request.Headers = make(map[string]any)
+		}
+		request.Headers[header.Name] = value
+	}
+	if cookies {
+		im.notef("%s: the cookie header is dropped, the cookies of the config or the cookies field are used instead", name)
+	}
+	for _, note := range req.Notes {
+		im.notef("%s: %s", name, note)
+	}
+	if len(req.FormFiles) > 0 {
+		im.notef("%s: the multipart file fields %s are not imported, add them to the body", name,
+			strings.Join(req.FormFiles, ", "))
+	}
+
+	entry.request = request
+	entry.runner = importRunner{
+		Type:   string(OneExecTypeHTTP),
+		Output: importOutput{Enabled: len(im.opts.OutputIDs) > 0, IDs: im.opts.OutputIDs},
+		Auth:   auth,
+	}
+
+	// the values of the response which the later requests may refer
+	if req.Response != nil && request.ResponseType == string(httpexec.ResponseTypeJSON) && req.Response.Body != "" {
+		var body any
+		if err := json.Unmarshal([]byte(req.Response.Body), &body); err == nil {
+			collectProducers(body, "", "", 0, func(jmesPath, jsonKey, value string) {
+				im.producers[value] = &importProducer{entry: entry, jmesPath: jmesPath, jsonKey: jsonKey}
+			})
+		}
+	}
+	return entry, nil
+}
+
+// responseTypeOf returns the response type of the MIME type
+func responseTypeOf(mimeType string) httpexec.ResponseType {
+	mediaType, _, _ := mime.ParseMediaType(mimeType)
+	switch {
+	case mediaType == "", strings.Contains(mediaType, "json"):
+		return httpexec.ResponseTypeJSON
+	case strings.Contains(mediaType, "xml"):
+		return httpexec.ResponseTypeXML
+	case strings.Contains(mediaType, "yaml"):
+		return httpexec.ResponseTypeYAML
+	case mediaType == "text/html":
+		return httpexec.ResponseTypeHTML
+	case strings.HasPrefix(mediaType, "text/"):
+		return httpexec.ResponseTypeText
+	}
+	return httpexec.ResponseTypeDiscard
+}
+
+// mapTarget returns the ID of the http target whose URL is the prefix of the URL and the rest of the path.
+// The host is recorded as the new target if no target matches.
+func (im *importer) mapTarget(u *url.URL) (string, string) {
+	var matchedID, matchedPath string
+	for _, id := range im.targetIDs {
+		t, err := url.Parse(im.targets[id].URL)
+		if err != nil || t.Scheme != u.Scheme || normalizeHost(t) != normalizeHost(u) {
+			continue
+		}
+		prefix := strings.TrimSuffix(t.Path, "/")
+		if prefix != "" && u.Path != prefix && !strings.HasPrefix(u.Path, prefix+"/") {
+			continue
+		}
+		if matchedID == "" || len(prefix) > len(matchedPath) {
+			matchedID, matchedPath = id, prefix
+		}
+	}
+	if matchedID != "" {
+		return matchedID, importEndpoint(u, matchedPath)
+	}
+	origin := u.Scheme + "://" + u.Host
+	id, ok := im.newTargets[origin]
+	if !ok {
+		id = camelCase(u.Hostname())
+		for i := 2; im.targets[id].URL != "" || slices.Contains(mapValues(im.newTargets), id); i++ {
+			id = fmt.Sprintf("%s%d", camelCase(u.Hostname()), i)
+		}
+		im.newTargets[origin] = id
+		im.notef("the target %s is not in the config, add the http target with the url %s", id, origin)
+	}
+	return id, importEndpoint(u, "")
+}
+
+// mapValues returns the values of the map
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// importEndpoint returns the escaped path of the URL after the prefix
+func importEndpoint(u *url.URL, prefix string) string {
+	endpoint := strings.TrimPrefix(u.EscapedPath(), prefix)
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	return endpoint
+}
+
+// refer returns the reference to the memory value if the value is returned by an earlier response.
+// The candidate is reported if the value looks dynamic but no response returns it.
+func (im *importer) refer(entry *importEntry, name, field, value string, dynamicField bool) (string, bool) {
+	if value == "" {
+		return "", false
+	}
+	producer, ok := im.producers[value]
+	if ok && producer.entry != entry && (dynamicField || looksDynamic(value)) {
+		return valueRef(im.assignKey(producer)), true
+	}
+	if looksDynamic(value) {
+		im.notef("%s: %s looks dynamic (%s), consider extracting it with memory_data", name, field, abbreviate(value))
+	}
+	return "", false
+}
+
+// staticSegment returns the last path segment which names the resource in the camel case,
+// the segments of the IDs and the templates are skipped
+func staticSegment(endpoint, fallback string) string {
+	for _, segment := range slices.Backward(strings.Split(endpoint, "/")) {
+		if segment != "" && !strings.Contains(segment, "{{") && !looksDynamic(segment) &&
+			strings.IndexFunc(segment, unicode.IsLetter) >= 0 {
+			return camelCase(segment)
+		}
+	}
+	return fallback
+}
+
+// abbreviate shortens the value for the notes
+func abbreviate(value string) string {
+	if len(value) <= 16 {
+		return value
+	}
+	return value[:12] + "..."
+}
+
+// assignKey returns the memory key of the producer, adding memory_data to the producing request on the first use
+func (im *importer) assignKey(producer *importProducer) string {
+	if producer.key != "" {
+		return producer.key
+	}
+	base := camelCase(producer.jsonKey)
+	switch strings.ToLower(base) {
+	case "id", "token", "uuid", "guid", "key", "value", "code":
+		resource := staticSegment(producer.entry.request.Endpoint, "value")
+		base = resource + strings.ToUpper(base[:1]) + base[1:]
+	}
+	key := base
+	for i := 2; im.keys[key]; i++ {
+		key = fmt.Sprintf("%s%d", base, i)
+	}
+	im.keys[key] = true
+	producer.key = key
+	producer.entry.request.MemoryData = append(producer.entry.request.MemoryData, importData{
+		Key: key,
+		Extractor: importExtractor{
+			Type:     "jmesPath",
+			JMESPath: producer.jmesPath,
+			OnNil:    "error",
+		},
+	})
+	return key
+}
+
+// collectProducers walks the JSON response and calls found for the leaves which the later requests may refer
+func collectProducers(v any, jmesPath, jsonKey string, depth int, found func(jmesPath, jsonKey, value string)) {
+	const maxDepth, maxItems = 10, 50
+	if depth > maxDepth {
+		return
+	}
+	join := func(key string) string {
+		if jmesPath == "" {
+			return jmesPathKey(key)
+		}
+		return jmesPath + "." + jmesPathKey(key)
+	}
+	switch value := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			collectProducers(value[key], join(key), key, depth+1, found)
+		}
+	case []any:
+		for i, item := range value[:min(len(value), maxItems)] {
+			collectProducers(item, fmt.Sprintf("%s[%d]", jmesPath, i), jsonKey, depth+1, found)
+		}
+	case string:
+		if jmesPath != "" && (isDynamicKey(jsonKey) || looksDynamic(value)) {
+			found(jmesPath, jsonKey, value)
+		}
+	case float64:
+		if jmesPath != "" && isDynamicKey(jsonKey) {
+			found(jmesPath, jsonKey, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+}
+
+// importBody converts the body of the request by its MIME type
+func (im *importer) importBody(entry *importEntry, name string, request *importRequest, u *url.URL, req ImportedRequest) error {
+	if len(req.Form) > 0 || len(req.FormFiles) > 0 {
+		request.BodyType = string(HTTPRequestBodyTypeMultipart)
+		body := make(map[string]any, len(req.Form))
+		for _, field := range req.Form {
+			body[escapeTemplate(field.Name)] = im.referString(entry, name, "body."+field.Name, field.Name, field.Value)
+		}
+		request.Body = body
+		return nil
+	}
+	if req.Body == "" {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(req.MimeType)
+	trimmed := strings.TrimSpace(req.Body)
+	switch {
+	case strings.Contains(mediaType, "json") ||
+		(mediaType == "" && (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["))):
+		var body any
+		if err := json.Unmarshal([]byte(req.Body), &body); err != nil {
+			im.notef("%s: the body is not valid JSON and is not imported: %v", name, err)
+			return nil
+		}
+		fields, ok := body.(map[string]any)
+		if query, isString := fields["query"].(string); ok && isString && query != "" &&
+			strings.Contains(strings.ToLower(u.Path), "graphql") {
+			request.BodyType = string(HTTPRequestBodyTypeGraphQL)
+			graphql := map[string]any{"query": escapeTemplate(query)}
+			if variables, ok := fields["variables"].(map[string]any); ok && len(variables) > 0 {
+				graphql["variables"] = im.referBody(entry, name, request, "body.variables", "", variables)
+			}
+			if operationName, ok := fields["operationName"].(string); ok && operationName != "" {
+				graphql["operation_name"] = escapeTemplate(operationName)
+			}
+			request.Body = graphql
+			return nil
+		}
+		request.BodyType = string(HTTPRequestBodyTypeJSON)
+		request.Body = im.referBody(entry, name, request, "body", "", body)
+	case mediaType == "application/x-www-form-urlencoded" || mediaType == "":
+		values, err := url.ParseQuery(req.Body)
+		if err != nil {
+			im.notef("%s: the form body is not valid and is not imported: %v", name, err)
+			return nil
+		}
+		request.BodyType = string(HTTPRequestBodyTypeForm)
+		body := make(map[string]any, len(values))
+		for key, value := range values {
+			if len(value) > 1 {
+				im.notef("%s: the form field %s has %d values, only the first one is imported", name, key, len(value))
+			}
+			body[escapeTemplate(key)] = im.referString(entry, name, "body."+key, key, value[0])
+		}
+		request.Body = body
+	default:
+		im.notef("%s: the body of %s is not supported and is not imported", name, mediaType)
+	}
+	return nil
+}
+
+// referString returns the reference or the escaped value of the string field
+func (im *importer) referString(entry *importEntry, name, field, key, value string) string {
+	if ref, ok := im.refer(entry, name, field, value, isDynamicKey(key)); ok {
+		return ref
+	}
+	return escapeTemplate(value)
+}
+
+// referBody walks the JSON body and replaces the leaves returned by the earlier responses.
+// The numbers are replaced by the placeholders which are written as the bare template after marshalling,
+// so that they are still the numbers after rendering.
+func (im *importer) referBody(entry *importEntry, name string, request *importRequest, field, key string, v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		replaced := make(map[string]any, len(value))
+		for k, item := range value {
+			replaced[escapeTemplate(k)] = im.referBody(entry, name, request, field+"."+k, k, item)
+		}
+		return replaced
+	case []any:
+		replaced := make([]any, len(value))
+		for i, item := range value {
+			replaced[i] = im.referBody(entry, name, request, fmt.Sprintf("%s[%d]", field, i), key, item)
+		}
+		return replaced
+	case string:
+		return im.referString(entry, name, field, key, value)
+	case float64:
+		ref, ok := im.refer(entry, name, field, strconv.FormatFloat(value, 'f', -1, 64), isDynamicKey(key))
+		if !ok {
+			return value
+		}
+		placeholder := fmt.Sprintf("__bloader_import_raw_%d__", im.rawCount)
+		im.rawCount++
+		request.rawValues[placeholder] = ref
+		return placeholder
+	}
+	return v
+}
+
+// importCredential replaces the credential header by the auth of the config.
+// It returns the value of the header and true if the header is the credential,
+// the value is empty if the header is dropped.
+func (im *importer) importCredential(
+	entry *importEntry,
+	name string,
+	header ImportedHeader,
+	auth **importAuth,
+) (string, bool) {
+	lower := strings.ToLower(header.Name)
+	var types []config.AuthType
+	var prefix, credential string
+	switch {
+	case lower == "authorization":
+		scheme, value, _ := strings.Cut(strings.TrimSpace(header.Value), " ")
+		switch strings.ToLower(scheme) {
+		case "bearer":
+			types = []config.AuthType{config.AuthTypeOAuth2, config.AuthTypeJWT}
+		case "basic":
+			types = []config.AuthType{config.AuthTypeBasic}
+		}
+		prefix, credential = scheme+" ", strings.TrimSpace(value)
+		if types == nil {
+			prefix, credential = "", header.Value
+		}
+	case lower == "x-api-key" || lower == "api-key" || lower == "apikey" || im.isAPIKeyHeader(header.Name):
+		types = []config.AuthType{config.AuthTypeAPIKey}
+		credential = header.Value
+	default:
+		return "", false
+	}
+
+	// the token returned by an earlier response, such as the login, is referred instead of the auth
+	if _, ok := im.producers[credential]; ok {
+		if ref, ok := im.refer(entry, name, "headers."+header.Name, credential, true); ok {
+			return prefix + ref, true
+		}
+	}
+	for _, t := range types {
+		if t == config.AuthTypeAPIKey && !im.isAPIKeyHeader(header.Name) {
+			continue
+		}
+		if id, ok := im.findAuth(t); ok {
+			if *auth == nil {
+				*auth = &importAuth{Enabled: true, AuthID: id}
+			}
+			return "", true
+		}
+	}
+	key := camelCase(header.Name)
+	im.notef("%s: no auth of the config matches the %s header, its credential is replaced by .Values.%s",
+		name, header.Name, key)
+	return prefix + valueRef(key), true
+}
+
+// isAPIKeyHeader reports whether the header is the header of the apiKey auth of the config
+func (im *importer) isAPIKeyHeader(name string) bool {
+	for _, a := range im.auths {
+		if a.Type == config.AuthTypeAPIKey && strings.EqualFold(a.APIKey.HeaderName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// findAuth returns the ID of the auth of the type, the default auth is preferred
+func (im *importer) findAuth(authType config.AuthType) (string, bool) {
+	var found string
+	for _, a := range im.auths {
+		if a.Type != authType {
+			continue
+		}
+		if a.Default {
+			return a.ID, true
+		}
+		if found == "" {
+			found = a.ID
+		}
+	}
+	return found, found != ""
+}
+
+// marshalEntry marshals the runner of the entry,
+// the requests which produce the memory data are OneExecute even if the kind is mass
+func (im *importer) marshalEntry(entry *importEntry) ([]byte, error) {
+	runner := entry.runner
+	request := *entry.request
+	if im.opts.Kind == ImportKindMass && len(request.MemoryData) == 0 {
+		runner.Kind = string(RunnerKindMassExecute)
+		request.Interval = im.opts.Interval
+		request.AwaitPrevResp = true
+		request.SuccessBreak = []string{"count"}
+		request.Break = &importBreak{Count: im.opts.Count, SysError: true}
+		if request.statusCode > 0 {
+			request.Break.StatusCode = []importStatusCondition{{ID: "unexpected", Op: "ne", Value: request.statusCode}}
+		}
+		runner.Requests = []importRequest{request}
+	} else {
+		runner.Kind = string(RunnerKindOneExecute)
+		runner.Request = &request
+	}
+	content, err := marshalImport(runner)
+	if err != nil {
+		return nil, err
+	}
+	for placeholder, ref := range request.rawValues {
+		content = []byte(strings.ReplaceAll(string(content), placeholder, ref))
+	}
+	return content, nil
+}
+
+// marshalImport marshals the generated file with the indent of the examples
+func marshalImport(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Import writes the runner files of the imported requests and the flow into the output directory,
+// and reports the written files and the notes to w.
+// Nothing is written if any of the files exists, unless the Force option is given.
+func Import(ctr *container.Container, requests []ImportedRequest, opts ImportOptions, outDir string, w io.Writer) error {
+	if opts.FilePrefix == "" {
+		rel, err := filepath.Rel(ctr.Config.Loader.BasePath, outDir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("output directory %s must be under the loader base path %s", outDir, ctr.Config.Loader.BasePath)
+		}
+		opts.FilePrefix = rel
+	}
+	result, err := BuildImport(requests, ctr.TargetContainer, ctr.Config.Auth, opts)
+	if err != nil {
+		return err
+	}
+	if !opts.Force {
+		var existing []string
+		for _, file := range result.Files {
+			if _, err := os.Lstat(filepath.Join(outDir, file.Name)); err == nil {
+				existing = append(existing, file.Name)
+			} else if !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to stat %s: %w", file.Name, err)
+			}
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("files already exist in %s: %s, use --force to overwrite them",
+				outDir, strings.Join(existing, ", "))
+		}
+	}
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	for _, file := range result.Files {
+		if err := os.WriteFile(filepath.Join(outDir, file.Name), file.Content, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Name, err)
+		}
+	}
+	fmt.Fprintf(w, "Imported %d requests into %s, run %s with the flow runner\n",
+		len(result.Files)-1, outDir, path.Join(filepath.ToSlash(opts.FilePrefix), ImportFlowFile))
+	for _, note := range result.Notes {
+		fmt.Fprintf(w, "- %s\n", note)
+	}
+	return nil
+}
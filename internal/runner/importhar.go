@@ -0,0 +1,99 @@
+package runner
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// harFile represents the HTTP Archive recorded by the browser
+type harFile struct {
+	Log struct {
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+// harEntry represents the request and the response of the HTTP Archive
+type harEntry struct {
+	ResourceType string `json:"_resourceType"`
+	Request      struct {
+		Method   string         `json:"method"`
+		URL      string         `json:"url"`
+		Headers  []harNameValue `json:"headers"`
+		PostData *struct {
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+			Params   []struct {
+				Name     string `json:"name"`
+				Value    string `json:"value"`
+				FileName string `json:"fileName"`
+			} `json:"params"`
+		} `json:"postData"`
+	} `json:"request"`
+	Response struct {
+		Status  int `json:"status"`
+		Content struct {
+			MimeType string `json:"mimeType"`
+			Text     string `json:"text"`
+			Encoding string `json:"encoding"`
+		} `json:"content"`
+	} `json:"response"`
+}
+
+// harNameValue represents the name and the value of the HTTP Archive
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ParseHAR parses the HTTP Archive into the requests in the recorded order
+func ParseHAR(r io.Reader) ([]ImportedRequest, error) {
+	var har harFile
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, fmt.Errorf("failed to decode har: %w", err)
+	}
+	requests := make([]ImportedRequest, 0, len(har.Log.Entries))
+	for _, entry := range har.Log.Entries {
+		req := ImportedRequest{
+			Method:       entry.Request.Method,
+			URL:          entry.Request.URL,
+			ResourceType: entry.ResourceType,
+		}
+		for _, header := range entry.Request.Headers {
+			req.Headers = append(req.Headers, ImportedHeader(header))
+		}
+		if postData := entry.Request.PostData; postData != nil {
+			req.MimeType = postData.MimeType
+			req.Body = postData.Text
+			// the multipart body is recorded by the params, the text is often omitted
+			if isMultipart(postData.MimeType) {
+				req.Body = ""
+				for _, param := range postData.Params {
+					if param.FileName != "" {
+						req.FormFiles = append(req.FormFiles, param.Name)
+						continue
+					}
+					req.Form = append(req.Form, ImportedHeader{Name: param.Name, Value: param.Value})
+				}
+			}
+		}
+		if entry.Response.Status > 0 {
+			body := entry.Response.Content.Text
+			if entry.Response.Content.Encoding == "base64" {
+				decoded, err := base64.StdEncoding.DecodeString(body)
+				if err != nil {
+					return nil, fmt.Errorf("failed to decode response of %s: %w", entry.Request.URL, err)
+				}
+				body = string(decoded)
+			}
+			req.Response = &ImportedResponse{
+				Status:   entry.Response.Status,
+				MimeType: entry.Response.Content.MimeType,
+				Body:     body,
+			}
+		}
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
@@ -0,0 +1,716 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/container"
+	"github.com/cresplanex/bloader/internal/encrypt"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+)
+
+// LintOutputRoot is the mock output root used while linting
+const LintOutputRoot = "dry-run"
+
+// LintIssue represents the problem found while linting
+type LintIssue struct {
+	File    string
+	Message string
+}
+
+// String returns the string representation of the issue
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.File, i.Message)
+}
+
+// knownEvents are the events which can be referred by depends_on
+var knownEvents = []Event{
+	RunnerEventStart,
+	RunnerEventStoreImporting,
+	RunnerEventStoreImported,
+	RunnerEventValidating,
+	RunnerEventValidated,
+	RunnerEventTerminated,
+	RunnerEventFailed,
+	RunnerEventSkipped,
+	SlaveConnectRunnerEventConnecting,
+	SlaveConnectRunnerEventConnected,
+}
+
+// Linter renders and validates the runner files recursively without sending any traffic
+type Linter struct {
+	tmplFactor   TmplFactor
+	store        Store
+	encryptCtr   encrypt.Container
+	log          logger.Logger
+	authFactor   AuthenticatorFactor
+	outFactor    OutputFactor
+	targetFactor TargetFactor
+	buckets      []string
+	slaveIDs     map[string]struct{}
+	stored       map[string]any
+	results      *FlowResults
+	flowID       string
+	visiting     []string
+	issues       []LintIssue
+	plan         strings.Builder
+}
+
+// Lint lints the runner file and its flow tree, prints the execution plan and the issues
+func Lint(ctr *container.Container, filename string, data map[string]any, w io.Writer) error {
+	if filename == "" {
+		return fmt.Errorf("file is required")
+	}
+	ctx, cancel := context.WithCancel(ctr.Ctx)
+	defer cancel()
+
+	l := &Linter{
+		tmplFactor:   NewLocalTmplFactor(ctr.Config.Loader.BasePath),
+		store:        NewLocalStore(ctr.EncypterContainer, ctr.Store),
+		encryptCtr:   ctr.EncypterContainer,
+		log:          ctr.Logger,
+		authFactor:   NewLocalAuthenticatorFactor(ctr.AuthenticatorContainer),
+		outFactor:    NewLocalOutputFactor(output.NewContainer(ctr.Config.Env, ctr.Config.Outputs)),
+		targetFactor: NewLocalTargetFactor(ctr.TargetContainer),
+		buckets:      ctr.Config.Store.Buckets,
+		slaveIDs:     make(map[string]struct{}),
+		stored:       make(map[string]any),
+		results:      NewFlowResults(),
+	}
+
+	str := &sync.Map{}
+	for k, v := range data {
+		str.Store(k, v)
+	}
+	l.lintFile(ctx, filename, str, &sync.Map{}, 0, 0, 0)
+
+	fmt.Fprintln(w, "Execution plan:")
+	fmt.Fprint(w, l.plan.String())
+	if len(l.issues) == 0 {
+		fmt.Fprintln(w, "No problems found")
+		return nil
+	}
+	fmt.Fprintf(w, "Found %d problem(s):\n", len(l.issues))
+	for _, issue := range l.issues {
+		fmt.Fprintf(w, "  - %s\n", issue)
+	}
+	return fmt.Errorf("lint failed with %d problem(s)", len(l.issues))
+}
+
+func (l *Linter) addIssue(file, format string, args ...any) {
+	l.issues = append(l.issues, LintIssue{File: file, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *Linter) addPlan(depth int, format string, args ...any) {
+	fmt.Fprintf(&l.plan, "%s%s\n", strings.Repeat("  ", depth+1), fmt.Sprintf(format, args...))
+}
+
+func (l *Linter) checkBucket(file, bucketID string) {
+	if !slices.Contains(l.buckets, bucketID) {
+		l.addIssue(file, "bucket %s is not defined in the config", bucketID)
+	}
+}
+
+func syncMapToMap(m *sync.Map) map[string]any {
+	res := make(map[string]any)
+	m.Range(func(key, value any) bool {
+		if keyStr, ok := key.(string); ok {
+			res[keyStr] = value
+		}
+		return true
+	})
+	return res
+}
+
+// renderRunner renders the runner template and decodes the runner header
+func renderRunner(tmpl *template.Template, data map[string]any) (Runner, *bytes.Buffer, error) {
+	yamlBuf := &bytes.Buffer{}
+	if err := tmpl.Execute(yamlBuf, data); err != nil {
+		return Runner{}, nil, fmt.Errorf("failed to execute yaml: %w", err)
+	}
+	var runner Runner
+	if err := yaml.NewDecoder(bytes.NewReader(yamlBuf.Bytes())).Decode(&runner); err != nil {
+		return Runner{}, nil, fmt.Errorf("failed to decode yaml: %w", err)
+	}
+	return runner, yamlBuf, nil
+}
+
+// importValues imports the values from the store,
+// the values stored by the preceding runners in this lint are used when the store doesn't have them yet.
+func (l *Linter) importValues(
+	ctx context.Context,
+	filename string,
+	data []ValidStoreImportData,
+	fn func(d ValidStoreImportData, val any),
+) {
+	for _, d := range data {
+		l.checkBucket(filename, d.BucketID)
+		if err := l.store.Import(ctx, []ValidStoreImportData{d}, func(_ context.Context, d ValidStoreImportData, val any, _ []byte) error {
+			fn(d, val)
+			return nil
+		}); err != nil {
+			if val, ok := l.stored[d.BucketID+"/"+d.StoreKey]; ok {
+				fn(d, val)
+				continue
+			}
+			l.addIssue(filename, "failed to import %s from bucket %s: %v", d.StoreKey, d.BucketID, err)
+		}
+	}
+}
+
+func (l *Linter) lintFile(
+	ctx context.Context,
+	filename string,
+	str *sync.Map,
+	threadOnlyStr *sync.Map,
+	loopCount int,
+	callCount int,
+	depth int,
+) {
+	if slices.Contains(l.visiting, filename) {
+		l.addIssue(filename, "recursive reference: %s", strings.Join(append(l.visiting, filename), " -> "))
+		return
+	}
+	l.visiting = append(l.visiting, filename)
+	defer func() {
+		l.visiting = l.visiting[:len(l.visiting)-1]
+	}()
+
+	tmplStr, err := l.tmplFactor.TmplFactorize(ctx, filename)
+	if err != nil {
+		l.addIssue(filename, "failed to factorize template: %v", err)
+		return
+	}
+	tmplSet := NewTmplSet(ctx, l.tmplFactor, NewTmplFuncMap(ctx, l.store, l.encryptCtr))
+	tmpl, err := tmplSet.Parse("yaml", tmplStr)
+	if err != nil {
+		l.addIssue(filename, "failed to parse yaml: %v", err)
+		return
+	}
+
+	values := syncMapToMap(str)
+	threadValues := syncMapToMap(threadOnlyStr)
+	data := map[string]any{
+		"SlaveValues":  map[string]any{},
+		"Values":       values,
+		"ThreadValues": threadValues,
+		"Results":      l.results.Values(),
+		"Dynamic": map[string]any{
+			"OutputRoot": LintOutputRoot,
+			"LoopCount":  loopCount,
+			"CallCount":  callCount,
+		},
+	}
+
+	runner, rawData, err := renderRunner(tmpl, data)
+	if err != nil {
+		l.addIssue(filename, "%v", err)
+		return
+	}
+	validRunner, err := runner.Validate()
+	if err != nil {
+		l.addIssue(filename, "failed to validate runner: %v", err)
+		return
+	}
+	if validRunner.StoreImport.Enabled {
+		l.importValues(ctx, filename, validRunner.StoreImport.Data, func(d ValidStoreImportData, val any) {
+			if d.ThreadOnly {
+				threadOnlyStr.Store(d.Key, val)
+				threadValues[d.Key] = val
+			} else {
+				str.Store(d.Key, val)
+				values[d.Key] = val
+			}
+		})
+		if runner, rawData, err = renderRunner(tmpl, data); err != nil {
+			l.addIssue(filename, "%v", err)
+			return
+		}
+		if validRunner, err = runner.Validate(); err != nil {
+			l.addIssue(filename, "failed to validate runner: %v", err)
+			return
+		}
+	}
+
+	l.addPlan(depth, "%s [%s]", filename, validRunner.Kind)
+
+	switch validRunner.Kind {
+	case RunnerKindStoreValue:
+		var storeValue StoreValue
+		if err := yaml.NewDecoder(rawData).Decode(&storeValue); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validStoreValue, err := storeValue.Validate()
+		if err != nil {
+			l.addIssue(filename, "failed to validate store value: %v", err)
+			return
+		}
+		for _, d := range validStoreValue.Data {
+			l.checkBucket(filename, d.BucketID)
+			l.checkEncrypt(filename, d.Encrypt)
+			l.stored[d.BucketID+"/"+d.Key] = d.Value
+			l.addPlan(depth+1, "store %s/%s", d.BucketID, d.Key)
+		}
+	case RunnerKindMemoryValue:
+		var memoryValue MemoryValue
+		if err := yaml.NewDecoder(rawData).Decode(&memoryValue); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validMemoryValue, err := memoryValue.Validate()
+		if err != nil {
+			l.addIssue(filename, "failed to validate memory store value: %v", err)
+			return
+		}
+		// the memory store is only in memory, so it is applied for the following runners
+		if err := validMemoryValue.Run(ctx, str); err != nil {
+			l.addIssue(filename, "failed to execute memory store value: %v", err)
+			return
+		}
+		for _, d := range validMemoryValue.Data {
+			l.addPlan(depth+1, "memory %s", d.Key)
+		}
+	case RunnerKindStoreImport:
+		var storeImport StoreImport
+		if err := yaml.NewDecoder(rawData).Decode(&storeImport); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validStoreImport, err := storeImport.Validate()
+		if err != nil {
+			l.addIssue(filename, "failed to validate store import: %v", err)
+			return
+		}
+		l.importValues(ctx, filename, validStoreImport.Data, func(d ValidStoreImportData, val any) {
+			str.Store(d.Key, val)
+		})
+		for _, d := range validStoreImport.Data {
+			l.checkEncrypt(filename, d.Encrypt)
+			l.addPlan(depth+1, "import %s/%s as %s", d.BucketID, d.StoreKey, d.Key)
+		}
+	case RunnerKindOneExecute:
+		var oneExec OneExec
+		if err := yaml.NewDecoder(rawData).Decode(&oneExec); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validOneExec, err := oneExec.Validate(ctx, l.authFactor, l.outFactor, l.targetFactor)
+		if err != nil {
+			l.addIssue(filename, "failed to validate one exec: %v", err)
+			return
+		}
+		for _, d := range validOneExec.Request.StoreData {
+			l.checkBucket(filename, d.BucketID)
+			l.checkEncrypt(filename, d.Encrypt)
+			l.stored[d.BucketID+"/"+d.StoreKey] = nil
+		}
+		for _, d := range validOneExec.Request.MemoryData {
+			// the value is not known until the request is sent
+			str.Store(d.Key, nil)
+		}
+		l.addPlan(depth+1, "%s %s", validOneExec.Request.Method, validOneExec.Request.URL)
+		for _, e := range validOneExec.Emit {
+			l.addPlan(depth+2, "emit %s", e)
+		}
+	case RunnerKindMassExecute:
+		var massExec MassExec
+		if err := yaml.NewDecoder(rawData).Decode(&massExec); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validMassExec, err := massExec.Validate(
+			ctx,
+			l.log,
+			l.authFactor,
+			l.outFactor,
+			l.targetFactor,
+			tmplSet,
+			tmpl,
+			data,
+		)
+		if err != nil {
+			l.addIssue(filename, "failed to validate mass exec: %v", err)
+			return
+		}
+		// the results are mocked so that the later flows referring them can be rendered
+		mockResults := make([]MassExecResult, 0, len(validMassExec.Requests))
+		for i, req := range validMassExec.Requests {
+			mockResults = append(mockResults, MassExecResult{
+				RequestIndex:  i,
+				Method:        req.Method,
+				URL:           req.URL,
+				Operation:     req.Operation,
+				TerminateType: matcher.TerminateTypeByCount,
+				Success:       true,
+			})
+			if req.RequestTmpl != nil {
+				replaceData := make(map[string]any, len(data))
+				for k, v := range data {
+					replaceData[k] = v
+				}
+				replaceData["Dynamic"] = map[string]any{
+					"OutputRoot":       LintOutputRoot,
+					"LoopCount":        loopCount,
+					"CallCount":        callCount,
+					"RequestLoopCount": 0,
+				}
+				var buf bytes.Buffer
+				if err := req.RequestTmpl.Execute(&buf, replaceData); err != nil {
+					l.addIssue(filename, "failed to execute request template[%d]: %v", i, err)
+				} else {
+					var fields MassExecRequestTemplateFields
+					if err := yaml.Unmarshal(buf.Bytes(), &fields); err != nil {
+						l.addIssue(filename, "failed to decode request template[%d]: %v", i, err)
+					}
+				}
+			}
+			if req.IsGraphQL() {
+				l.addPlan(depth+1, "%s %s (interval=%s, graphql=%s)", req.Method, req.URL, req.Interval, req.Operation)
+			} else {
+				l.addPlan(depth+1, "%s %s (interval=%s)", req.Method, req.URL, req.Interval)
+			}
+			for _, e := range req.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		for i, req := range validMassExec.GRPC.Requests {
+			mockResults = append(mockResults, MassExecResult{
+				RequestIndex:  i,
+				Method:        req.Method,
+				URL:           req.URL,
+				TerminateType: matcher.TerminateTypeByCount,
+				Success:       true,
+			})
+			resolved := "reflection"
+			if req.Descriptor != nil {
+				resolved = "proto"
+			}
+			l.addPlan(depth+1, "GRPC %s (interval=%s, descriptor=%s)", req.URL, req.Interval, resolved)
+			for _, e := range req.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		for i, req := range validMassExec.Socket.Requests {
+			mockResults = append(mockResults, MassExecResult{
+				RequestIndex:  i,
+				Method:        req.Method,
+				URL:           req.URL,
+				TerminateType: matcher.TerminateTypeByCount,
+				Success:       true,
+			})
+			l.addPlan(depth+1, "%s %s (interval=%s, read=%s, keep_alive=%t)",
+				req.Method, req.URL, req.Interval, req.Config.Read.Mode, req.Config.KeepAlive)
+			for _, e := range req.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		for i, req := range validMassExec.SQL.Requests {
+			mockResults = append(mockResults, MassExecResult{
+				RequestIndex:  i,
+				Method:        req.Method,
+				URL:           req.URL,
+				TerminateType: matcher.TerminateTypeByCount,
+				Success:       true,
+			})
+			l.addPlan(depth+1, "%s %s (interval=%s, max_open_conns=%d)",
+				req.Method, req.URL, req.Interval, req.Config.MaxOpenConns)
+			for _, e := range req.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		for i, req := range validMassExec.Redis.Requests {
+			mockResults = append(mockResults, MassExecResult{
+				RequestIndex:  i,
+				Method:        req.Method,
+				URL:           req.URL,
+				TerminateType: matcher.TerminateTypeByCount,
+				Success:       true,
+			})
+			l.addPlan(depth+1, "%s %s %s (interval=%s, pool_size=%d)",
+				req.Method, req.URL, strings.ToUpper(req.Command[0]), req.Interval, req.Config.PoolSize)
+			for _, e := range req.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		for i, pub := range validMassExec.MQTT.Publishers {
+			mockResults = append(mockResults, MassExecResult{
+				RequestIndex:  i,
+				Method:        pub.Method,
+				URL:           pub.URL,
+				TerminateType: matcher.TerminateTypeByCount,
+				Success:       true,
+			})
+			l.addPlan(depth+1, "%s %s %s (interval=%s, qos=%d)",
+				pub.Method, pub.URL, pub.Topic, pub.Interval, pub.QoS)
+			for _, e := range pub.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		if sub := validMassExec.MQTT.Subscribers; sub != nil {
+			for i := 0; i < sub.Connections; i++ {
+				mockResults = append(mockResults, MassExecResult{
+					RequestIndex:  i,
+					Method:        MQTTMethodSubscribe,
+					URL:           sub.URL,
+					TerminateType: matcher.TerminateTypeByCount,
+					Success:       true,
+				})
+			}
+			l.addPlan(depth+1, "%s %s %s (connections=%d, qos=%d)",
+				MQTTMethodSubscribe, sub.URL, strings.Join(sub.Topics, ","), sub.Connections, sub.QoS)
+			for _, e := range sub.ReadyEmit {
+				l.addPlan(depth+2, "emit %s", e)
+			}
+			for _, e := range sub.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		if validMassExec.Type == MassExecTypeWebSocket {
+			ws := validMassExec.WebSocket
+			for i := 0; i < ws.Connections; i++ {
+				mockResults = append(mockResults, MassExecResult{
+					RequestIndex:  i,
+					Method:        http.MethodGet,
+					URL:           ws.URL,
+					TerminateType: matcher.TerminateTypeByCount,
+					Success:       true,
+				})
+			}
+			if ws.hasMessage() {
+				if _, err := ws.renderMessage(0, 0, nil); err != nil {
+					l.addIssue(filename, "failed to render websocket message: %v", err)
+				}
+			}
+			l.addPlan(depth+1, "WEBSOCKET %s (connections=%d, interval=%s, reply=%t)",
+				ws.URL, ws.Connections, ws.Interval, ws.Reply.Enabled)
+			for _, e := range ws.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		if validMassExec.Type == MassExecTypeSSE {
+			sse := validMassExec.SSE
+			for i := 0; i < sse.Connections; i++ {
+				mockResults = append(mockResults, MassExecResult{
+					RequestIndex:  i,
+					Method:        http.MethodGet,
+					URL:           sse.URL,
+					TerminateType: matcher.TerminateTypeByCount,
+					Success:       true,
+				})
+			}
+			l.addPlan(depth+1, "SSE %s (connections=%d, timestamp=%t)",
+				sse.URL, sse.Connections, sse.Timestamp != nil)
+			for _, e := range sse.Emit {
+				l.addPlan(depth+2, "emit %s", e.Event)
+			}
+		}
+		l.results.Set(l.flowID, MassExecResultsToValue(mockResults))
+	case RunnerKindScenario:
+		var scenario Scenario
+		if err := yaml.NewDecoder(rawData).Decode(&scenario); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validScenario, err := scenario.Validate(
+			ctx,
+			l.authFactor,
+			l.outFactor,
+			l.targetFactor,
+			tmplSet,
+			tmpl,
+			data,
+		)
+		if err != nil {
+			l.addIssue(filename, "failed to validate scenario: %v", err)
+			return
+		}
+		var total int
+		for _, s := range validScenario.Scenarios {
+			total += s.Weight
+		}
+		for _, s := range validScenario.Scenarios {
+			l.addPlan(depth+1, "scenario %s (weight=%d, %.1f%%)", s.Name, s.Weight, float64(s.Weight)*100/float64(total))
+			for _, step := range s.Steps {
+				l.addPlan(depth+2, "%s %s", step.Method, step.URL)
+			}
+		}
+	case RunnerKindSlaveConnect:
+		var slaveConnect SlaveConnect
+		if err := yaml.NewDecoder(rawData).Decode(&slaveConnect); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validSlaveConnect, err := slaveConnect.Validate()
+		if err != nil {
+			l.addIssue(filename, "failed to validate slave connect: %v", err)
+			return
+		}
+		for _, s := range validSlaveConnect.Slaves {
+			l.checkEncrypt(filename, s.Encrypt)
+			l.slaveIDs[s.ID] = struct{}{}
+			l.addPlan(depth+1, "connect %s (%s)", s.ID, s.URI)
+		}
+	case RunnerKindBarrier:
+		var barrierWait BarrierWait
+		if err := yaml.NewDecoder(rawData).Decode(&barrierWait); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validBarrierWait, err := barrierWait.Validate()
+		if err != nil {
+			l.addIssue(filename, "failed to validate barrier: %v", err)
+			return
+		}
+		l.addPlan(depth+1, "barrier %s (participants=%d, timeout=%s)",
+			validBarrierWait.ID, validBarrierWait.Participants, validBarrierWait.Timeout)
+	case RunnerKindFlow:
+		var flow Flow
+		if err := yaml.NewDecoder(rawData).Decode(&flow); err != nil {
+			l.addIssue(filename, "failed to decode yaml: %v", err)
+			return
+		}
+		validFlow, err := flow.Validate()
+		if err != nil {
+			l.addIssue(filename, "failed to validate flow: %v", err)
+			return
+		}
+		for _, problem := range NewFlowGraph(validFlow).Check() {
+			l.addIssue(filename, "%s", problem)
+		}
+		l.lintFlows(ctx, filename, validFlow.Step.Flows, str, &sync.Map{}, callCount, depth+1)
+	default:
+		l.addIssue(filename, "invalid runner kind: %s", validRunner.Kind)
+	}
+}
+
+func (l *Linter) checkEncrypt(filename string, enc ValidCredentialEncryptConfig) {
+	if !enc.Enabled {
+		return
+	}
+	if _, ok := l.encryptCtr[enc.EncryptID]; !ok {
+		l.addIssue(filename, "encrypt %s is not defined in the config", enc.EncryptID)
+	}
+}
+
+func (l *Linter) lintFlows(
+	ctx context.Context,
+	filename string,
+	flows []ValidFlowStepFlow,
+	str *sync.Map,
+	inheritedThreadOnlyStr *sync.Map,
+	callCount int,
+	depth int,
+) {
+	evaluator := newFlowExprEvaluator(ctx, l.store, l.encryptCtr, map[string]any{}, l.results, callCount+1)
+	for _, flow := range flows {
+		var deps []string
+		for _, dep := range flow.DependsOn {
+			deps = append(deps, fmt.Sprintf("%s@%s", dep.Flow, dep.Event))
+		}
+		depStr := ""
+		if len(deps) > 0 {
+			depStr = fmt.Sprintf(" depends_on=[%s]", strings.Join(deps, ", "))
+		}
+		threadOnlyStr := &sync.Map{}
+		inheritedThreadOnlyStr.Range(func(key, value any) bool {
+			threadOnlyStr.Store(key, value)
+			return true
+		})
+		for _, v := range flow.ThreadOnlyValues {
+			threadOnlyStr.Store(v.Key, v.Value)
+		}
+		for _, v := range flow.Values {
+			str.Store(v.Key, v.Value)
+		}
+		depStr += l.lintFlowControl(filename, evaluator, flow, str, threadOnlyStr)
+		switch flow.Type {
+		case FlowStepFlowTypeFile:
+			l.addPlan(depth, "flow %s (file, count=%d)%s", flow.ID, flow.Count, depStr)
+			l.flowID = flow.ID
+			l.lintFile(ctx, flow.File, str, threadOnlyStr, 0, callCount+1, depth+1)
+		case FlowStepFlowTypeFlow:
+			l.addPlan(depth, "flow %s (flow, concurrency=%d)%s", flow.ID, flow.Concurrency, depStr)
+			l.lintFlows(ctx, filename, flow.Flows, str, threadOnlyStr, callCount, depth+1)
+		case FlowStepFlowTypeSlaveCmd:
+			l.addPlan(depth, "flow %s (slaveCmd, executors=%d)%s", flow.ID, len(flow.Executors), depStr)
+			for _, e := range flow.Executors {
+				if _, ok := l.slaveIDs[e.SlaveID]; !ok {
+					l.addIssue(filename, "flow %s refers to slave %s which is not connected", flow.ID, e.SlaveID)
+				}
+				slaveStr := &sync.Map{}
+				if e.InheritValues {
+					str.Range(func(key, value any) bool {
+						slaveStr.Store(key, value)
+						return true
+					})
+				}
+				for _, v := range e.AdditionalValues {
+					slaveStr.Store(v.Key, v.Value)
+				}
+				slaveThreadOnlyStr := &sync.Map{}
+				for _, v := range e.AdditionalThreadOnlyValues {
+					slaveThreadOnlyStr.Store(v.Key, v.Value)
+				}
+				l.addPlan(depth+1, "executor %s", e.SlaveID)
+				// the results of the slaves are not shared with the master
+				l.flowID = ""
+				l.lintFile(ctx, flow.File, slaveStr, slaveThreadOnlyStr, 0, 0, depth+2)
+			}
+		}
+	}
+}
+
+// lintFlowControl evaluates the if, for_each and while with the current values,
+// and binds the first item of for_each so that the file can be rendered.
+func (l *Linter) lintFlowControl(
+	filename string,
+	evaluator *flowExprEvaluator,
+	flow ValidFlowStepFlow,
+	str *sync.Map,
+	threadOnlyStr *sync.Map,
+) string {
+	var desc string
+	if flow.If != "" {
+		desc += fmt.Sprintf(" if=%q", flow.If)
+		if _, err := evaluator.Cond(flow.If, evaluator.data(str, threadOnlyStr, 0, 0)); err != nil {
+			l.addIssue(filename, "flow %s: failed to evaluate if: %v", flow.ID, err)
+		}
+	}
+	if flow.ForEach.Enabled {
+		items, err := evaluator.Items(flow.ForEach.Items, evaluator.data(str, threadOnlyStr, 0, 0))
+		if err != nil {
+			l.addIssue(filename, "flow %s: failed to evaluate for_each items: %v", flow.ID, err)
+		}
+		desc += fmt.Sprintf(" for_each=%s(%d items)", flow.ForEach.Items, len(items))
+		var item any
+		if len(items) > 0 {
+			item = items[0]
+		}
+		threadOnlyStr.Store(flow.ForEach.As, item)
+		if flow.ForEach.IndexAs != "" {
+			threadOnlyStr.Store(flow.ForEach.IndexAs, 0)
+		}
+	}
+	if flow.While.Enabled {
+		desc += fmt.Sprintf(" while=%q(max=%d)", flow.While.Condition, flow.While.MaxIterations)
+		if flow.While.IndexAs != "" {
+			threadOnlyStr.Store(flow.While.IndexAs, 0)
+		}
+		if _, err := evaluator.Cond(flow.While.Condition, evaluator.data(str, threadOnlyStr, 0, 0)); err != nil {
+			l.addIssue(filename, "flow %s: failed to evaluate while condition: %v", flow.ID, err)
+		}
+	}
+	return desc
+}
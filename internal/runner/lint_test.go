@@ -0,0 +1,211 @@
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/container"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// lintRequest returns the OneExecute runner file with the target, the output and the auth
+func lintRequest(targetID, outputID, auth string) string {
+	return `kind: OneExecute
+type: http
+output:
+  enabled: true
+  ids: ["` + outputID + `"]
+auth:
+  enabled: ` + auth + `
+request:
+  target_id: ` + targetID + `
+  endpoint: /
+  method: GET
+  response_type: json
+`
+}
+
+// lintFlow returns the Flow runner file with the flows
+func lintFlow(flows string) string {
+	return "kind: Flow\nstep:\n  concurrency: -1\n  flows:\n" + flows
+}
+
+// TestLint tests the problems found by the lint and the execution plan.
+func TestLint(t *testing.T) {
+	cases := []struct {
+		name string
+		// files has main.yaml as the entry
+		files    map[string]string
+		want     []string
+		wantPlan []string
+	}{
+		{
+			name: "Valid",
+			files: map[string]string{
+				"main.yaml": lintFlow(`    - id: first
+      type: file
+      file: ok.yaml
+    - id: second
+      type: file
+      file: ok.yaml
+      depends_on:
+        - {flow: first, event: "sys:terminated"}
+`),
+				"ok.yaml": lintRequest("api", "local", "false"),
+			},
+			wantPlan: []string{"flow first (file, count=1)", "flow second (file, count=1) depends_on=[first@sys:terminated]"},
+		},
+		{
+			name: "UnknownDependsOnFlow",
+			files: map[string]string{
+				"main.yaml": lintFlow(`    - id: first
+      type: file
+      file: ok.yaml
+      depends_on:
+        - {flow: missing, event: "sys:terminated"}
+`),
+				"ok.yaml": lintRequest("api", "local", "false"),
+			},
+			want: []string{"main.yaml", "missing"},
+		},
+		{
+			name: "UnknownEvent",
+			files: map[string]string{
+				"main.yaml": lintFlow(`    - id: first
+      type: file
+      file: ok.yaml
+    - id: second
+      type: file
+      file: ok.yaml
+      depends_on:
+        - {flow: first, event: "sys:never"}
+    - id: group
+      type: flow
+      flows:
+        - id: inner
+          type: file
+          file: ok.yaml
+    - id: third
+      type: file
+      file: ok.yaml
+      depends_on:
+        - {flow: group, event: "sys:validated"}
+`),
+				"ok.yaml": lintRequest("api", "local", "false"),
+			},
+			want: []string{"sys:never of flow first", "sys:validated of flow group"},
+		},
+		{
+			name: "MissingTarget",
+			files: map[string]string{
+				"main.yaml": lintRequest("nope", "local", "false"),
+			},
+			want: []string{"main.yaml", "nope"},
+		},
+		{
+			name: "MissingOutput",
+			files: map[string]string{
+				"main.yaml": lintRequest("api", "nope", "false"),
+			},
+			want: []string{"main.yaml", "nope"},
+		},
+		{
+			name: "MissingAuth",
+			files: map[string]string{
+				"main.yaml": lintRequest("api", "local", "true\n  auth_id: nope"),
+			},
+			want: []string{"main.yaml", "auth"},
+		},
+		{
+			name: "DiscardedBody",
+			files: map[string]string{
+				"main.yaml": `kind: MassExecute
+type: http
+output:
+  enabled: false
+requests:
+  - target_id: api
+    endpoint: /
+    method: GET
+    response_type: discard
+    interval: 1ms
+    break:
+      response_body:
+        - {id: done, extractor: {type: jmesPath, jmes_path: done}}
+`,
+			},
+			want: []string{"main.yaml", "response_type discard"},
+		},
+		{
+			name: "NestedFlowFile",
+			files: map[string]string{
+				"main.yaml": lintFlow(`    - id: outer
+      type: file
+      file: sub/flow.yaml
+`),
+				"sub/flow.yaml": lintFlow(`    - id: inner
+      type: file
+      file: sub/bad.yaml
+`),
+				"sub/bad.yaml": lintRequest("nope", "local", "false"),
+			},
+			want:     []string{"sub/bad.yaml", "nope"},
+			wantPlan: []string{"flow outer (file, count=1)", "    flow inner (file, count=1)"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			dir := tt.TempDir()
+			for name, content := range c.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					tt.Fatalf("failed to create dir: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+					tt.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+			ctr := &container.Container{
+				Ctx: context.Background(),
+				Config: config.ValidConfig{
+					Env:    "test",
+					Loader: config.ValidLoaderConfig{BasePath: dir},
+					Outputs: config.ValidOutputConfig{{ID: "local", Values: []config.ValidOutputRespectiveValueConfig{
+						{Env: "test", Type: config.OutputTypeLocal, Format: config.OutputFormatCSV, BasePath: tt.TempDir()},
+					}}},
+				},
+				Logger:          logger.NewSlogLogger(),
+				TargetContainer: target.Container{"api": {Type: config.TargetTypeHTTP, URL: "http://api.test"}},
+			}
+			var out bytes.Buffer
+			err := runner.Lint(ctr, "main.yaml", map[string]any{}, &out)
+			switch {
+			case len(c.want) == 0 && err != nil:
+				tt.Fatalf("expected no problems, got %v\n%s", err, out.String())
+			case len(c.want) > 0 && err == nil:
+				tt.Fatalf("expected the problems, got none\n%s", out.String())
+			}
+			problems := out.String()
+			if i := strings.Index(problems, "problem(s):"); i >= 0 {
+				problems = problems[i:]
+			}
+			for _, want := range c.want {
+				if !strings.Contains(problems, want) {
+					tt.Errorf("expected the problem of %s, got\n%s", want, out.String())
+				}
+			}
+			for _, want := range c.wantPlan {
+				if !strings.Contains(out.String(), want) {
+					tt.Errorf("expected the plan of %q, got\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
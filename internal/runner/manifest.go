@@ -0,0 +1,396 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/store"
+	"github.com/cresplanex/bloader/internal/utils"
+)
+
+// ManifestFileName is the name of the manifest file written in the output root of the run
+const ManifestFileName = "manifest.json"
+
+// RunStatus represents the status of the run
+type RunStatus string
+
+const (
+	// RunStatusRunning represents the run is running, or the process exited unexpectedly
+	RunStatusRunning RunStatus = "running"
+	// RunStatusSucceeded represents the run succeeded
+	RunStatusSucceeded RunStatus = "succeeded"
+	// RunStatusFailed represents the run failed
+	RunStatusFailed RunStatus = "failed"
+	// RunStatusInterrupted represents the run is interrupted, it can be resumed
+	RunStatusInterrupted RunStatus = "interrupted"
+)
+
+// RunManifest represents the metadata of the run
+type RunManifest struct {
+	RunID     string                  `json:"run_id"`
+	File      string                  `json:"file"`
+	Data      map[string]any          `json:"data"`
+	Env       string                  `json:"env"`
+	Version   string                  `json:"version"`
+	Status    RunStatus               `json:"status"`
+	Error     string                  `json:"error,omitempty"`
+	StartedAt time.Time               `json:"started_at"`
+	EndedAt   *time.Time              `json:"ended_at,omitempty"`
+	ResumedAt []time.Time             `json:"resumed_at,omitempty"`
+	Flows     map[string]ManifestFlow `json:"flows"`
+	Slaves    []string                `json:"slaves"`
+	Outputs   []ManifestOutput        `json:"outputs"`
+	mu        *sync.Mutex
+}
+
+// ManifestFlow represents the result of the flow in the manifest
+type ManifestFlow struct {
+	Status     FlowStatus        `json:"status"`
+	Reason     string            `json:"reason,omitempty"`
+	OutputRoot string            `json:"output_root"`
+	EndedAt    time.Time         `json:"ended_at"`
+	Requests   []ManifestRequest `json:"requests,omitempty"`
+}
+
+// ManifestRequest represents how the request of the MassExec flow was terminated
+type ManifestRequest struct {
+	RequestIndex  int    `json:"request_index"`
+	TerminateType string `json:"terminate_type"`
+	MatchedID     string `json:"matched_id,omitempty"`
+}
+
+// ManifestOutput represents the output file written by the run
+type ManifestOutput struct {
+	OutputID string `json:"output_id"`
+	Path     string `json:"path"`
+}
+
+// buildVersion returns the version of the binary, with the vcs revision if it is embedded
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	var revision, modified string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			modified = s.Value
+		}
+	}
+	if revision != "" {
+		if len(revision) > 12 {
+			revision = revision[:12]
+		}
+		version = fmt.Sprintf("%s (%s)", version, revision)
+		if modified == "true" && !strings.Contains(version, "dirty") {
+			version += " dirty"
+		}
+	}
+	return version
+}
+
+// NewRunManifest creates a new RunManifest
+func NewRunManifest(runID, file string, data map[string]any, env string) *RunManifest {
+	return &RunManifest{
+		RunID:     runID,
+		File:      file,
+		Data:      data,
+		Env:       env,
+		Version:   buildVersion(),
+		Status:    RunStatusRunning,
+		StartedAt: time.Now(),
+		Flows:     make(map[string]ManifestFlow),
+		Slaves:    []string{},
+		Outputs:   []ManifestOutput{},
+		mu:        &sync.Mutex{},
+	}
+}
+
+// Resume marks the manifest as resumed
+func (m *RunManifest) Resume() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Status = RunStatusRunning
+	m.Error = ""
+	m.EndedAt = nil
+	m.ResumedAt = append(m.ResumedAt, time.Now())
+	if m.Flows == nil {
+		m.Flows = make(map[string]ManifestFlow)
+	}
+}
+
+// SetFlow records the result of the flow, the requests recorded before are kept
+func (m *RunManifest) SetFlow(scope string, status FlowStatus, reason string, outputRoot string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Flows[scope] = ManifestFlow{
+		Status:     status,
+		Reason:     reason,
+		OutputRoot: outputRoot,
+		EndedAt:    time.Now(),
+		Requests:   m.Flows[scope].Requests,
+	}
+}
+
+// SetFlowRequests records how the requests of the flow were terminated
+func (m *RunManifest) SetFlowRequests(scope string, results []MassExecResult) {
+	if m == nil {
+		return
+	}
+	requests := make([]ManifestRequest, 0, len(results))
+	for _, r := range results {
+		requests = append(requests, ManifestRequest{
+			RequestIndex:  r.RequestIndex,
+			TerminateType: r.TerminateType.String(),
+			MatchedID:     r.MatchedID,
+		})
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f := m.Flows[scope]
+	f.Requests = requests
+	m.Flows[scope] = f
+}
+
+// AddOutput records the output file
+func (m *RunManifest) AddOutput(outputID, path string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	o := ManifestOutput{OutputID: outputID, Path: path}
+	if !slices.Contains(m.Outputs, o) {
+		m.Outputs = append(m.Outputs, o)
+	}
+}
+
+// AddSlave records the slave connected in the run
+func (m *RunManifest) AddSlave(slaveID string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !slices.Contains(m.Slaves, slaveID) {
+		m.Slaves = append(m.Slaves, slaveID)
+	}
+}
+
+// Finish records the end of the run
+func (m *RunManifest) Finish(status RunStatus, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	m.Status = status
+	m.EndedAt = &now
+	if err != nil {
+		m.Error = err.Error()
+	}
+}
+
+// Save writes the manifest into the directories of the run
+func (m *RunManifest) Save(dirs []string) error {
+	m.mu.Lock()
+	data, err := json.MarshalIndent(m, "", "  ")
+	m.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, m.RunID, ManifestFileName)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewRunID returns the ID of the run started at the time, which is also its output root.
+// The ID is the start time as the output root has always been,
+// the suffix is added only when a run started in the same second already exists.
+func NewRunID(str store.Store, dirs []string, startedAt time.Time) string {
+	runID := startedAt.Format("20060102_150405")
+	exists := func(id string) bool {
+		for _, dir := range dirs {
+			if _, err := os.Stat(filepath.Join(dir, id)); err == nil {
+				return true
+			}
+		}
+		if str != nil {
+			if data, err := str.GetObject(CheckpointBucketID, id); err == nil && data != nil {
+				return true
+			}
+		}
+		return false
+	}
+	for exists(runID) {
+		runID = fmt.Sprintf("%s_%s", startedAt.Format("20060102_150405"), utils.GenerateUniqueID()[:8])
+	}
+	return runID
+}
+
+// ManifestDirs returns the base paths of the local outputs of the env, the manifests are written under them
+func ManifestDirs(env string, cfg config.ValidOutputConfig) []string {
+	var dirs []string
+	for _, o := range cfg {
+		for _, v := range o.Values {
+			if v.Env == env && v.Type == config.OutputTypeLocal && !slices.Contains(dirs, v.BasePath) {
+				dirs = append(dirs, v.BasePath)
+			}
+		}
+	}
+	return dirs
+}
+
+func readManifest(path string) (*RunManifest, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m := &RunManifest{mu: &sync.Mutex{}}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+	}
+	if m.Flows == nil {
+		m.Flows = make(map[string]ManifestFlow)
+	}
+	return m, nil
+}
+
+// ListManifests returns the manifests of the runs in the directories, sorted by the start time
+func ListManifests(dirs []string) ([]*RunManifest, error) {
+	seen := make(map[string]struct{})
+	var manifests []*RunManifest
+	for _, dir := range dirs {
+		paths, err := filepath.Glob(filepath.Join(dir, "*", ManifestFileName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find manifests: %w", err)
+		}
+		for _, path := range paths {
+			m, err := readManifest(path)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			if _, ok := seen[m.RunID]; ok {
+				continue
+			}
+			seen[m.RunID] = struct{}{}
+			manifests = append(manifests, m)
+		}
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].StartedAt.Before(manifests[j].StartedAt)
+	})
+	return manifests, nil
+}
+
+// FindManifest returns the manifest of the run
+func FindManifest(dirs []string, runID string) (*RunManifest, error) {
+	for _, dir := range dirs {
+		m, err := readManifest(filepath.Join(dir, runID, ManifestFileName))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("manifest of run %s not found", runID)
+}
+
+// ClearRunOutputs removes the output files recorded in the manifest
+func ClearRunOutputs(m *RunManifest) error {
+	for _, o := range m.Outputs {
+		if err := os.Remove(o.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove output: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteRun removes the outputs, the manifests and the checkpoint of the run
+func DeleteRun(str store.Store, dirs []string, m *RunManifest) error {
+	if err := ClearRunOutputs(m); err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(filepath.Join(dir, m.RunID)); err != nil {
+			return fmt.Errorf("failed to remove run directory: %w", err)
+		}
+	}
+	if _, err := str.GetObject(CheckpointBucketID, m.RunID); err == nil {
+		if err := str.DeleteObject(CheckpointBucketID, m.RunID); err != nil {
+			return fmt.Errorf("failed to delete checkpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+// manifestOutputFactor records the files written through the outputs into the manifest
+type manifestOutputFactor struct {
+	OutputFactor
+	manifest *RunManifest
+}
+
+// Factorize returns the factorized output
+func (f manifestOutputFactor) Factorize(ctx context.Context, outputID string) (output.Output, error) {
+	o, err := f.OutputFactor.Factorize(ctx, outputID)
+	if err != nil {
+		return nil, err
+	}
+	return manifestOutput{Output: o, outputID: outputID, manifest: f.manifest}, nil
+}
+
+// manifestOutput records the file written through the output into the manifest
+type manifestOutput struct {
+	output.Output
+	outputID string
+	manifest *RunManifest
+}
+
+// HTTPDataWriteFactory returns the HTTPDataWrite function
+func (o manifestOutput) HTTPDataWriteFactory(
+	ctx context.Context,
+	log logger.Logger,
+	enabled bool,
+	uniqueName string,
+	header []string,
+) (output.HTTPDataWrite, output.Close, error) {
+	write, closer, err := o.Output.HTTPDataWriteFactory(ctx, log, enabled, uniqueName, header)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := uniqueName
+	if fo, ok := o.Output.(output.FileOutput); ok {
+		if path, err = fo.FilePath(uniqueName); err != nil {
+			return nil, nil, err
+		}
+	}
+	o.manifest.AddOutput(o.outputID, path)
+	return write, closer, nil
+}
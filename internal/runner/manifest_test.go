@@ -0,0 +1,294 @@
+package runner_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+	"github.com/cresplanex/bloader/internal/store"
+)
+
+// memoryStore is the store holding the buckets in memory, as the bolt store does on the file.
+// The bolt store is not used by the tests, since it does not pass the checkptr checks of -race.
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]map[string][]byte
+}
+
+// newMemoryStore returns the empty store
+func newMemoryStore() store.Store {
+	return &memoryStore{buckets: make(map[string]map[string][]byte)}
+}
+
+func (s *memoryStore) SetupStore(_ string, conf config.ValidStoreConfig) error {
+	return s.CreateBuckets(conf)
+}
+
+func (s *memoryStore) CreateBuckets(conf config.ValidStoreConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, bucket := range conf.Buckets {
+		if _, ok := s.buckets[bucket]; !ok {
+			s.buckets[bucket] = make(map[string][]byte)
+		}
+	}
+	return nil
+}
+
+// bucket returns the bucket, the lock must be held
+func (s *memoryStore) bucket(bucket string) (map[string][]byte, error) {
+	b, ok := s.buckets[bucket]
+	if !ok {
+		return nil, fmt.Errorf("bucket %s not found", bucket)
+	}
+	return b, nil
+}
+
+func (s *memoryStore) PutObject(bucket, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucket(bucket)
+	if err != nil {
+		return err
+	}
+	b[key] = bytes.Clone(data)
+	return nil
+}
+
+func (s *memoryStore) GetObject(bucket, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Clone(b[key]), nil
+}
+
+func (s *memoryStore) PutObjectReader(bucket, key string, reader io.Reader) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read data: %w", err)
+	}
+	return s.PutObject(bucket, key, data)
+}
+
+func (s *memoryStore) GetObjectReader(bucket, key string) (io.Reader, error) {
+	data, err := s.GetObject(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+func (s *memoryStore) DeleteObject(bucket, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucket(bucket)
+	if err != nil {
+		return err
+	}
+	delete(b, key)
+	return nil
+}
+
+func (s *memoryStore) ListObjects(bucket string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, err := s.bucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+	keys := slices.Collect(maps.Keys(b))
+	slices.Sort(keys)
+	return keys, nil
+}
+
+func (s *memoryStore) ListBuckets() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buckets := slices.Collect(maps.Keys(s.buckets))
+	slices.Sort(buckets)
+	return buckets, nil
+}
+
+func (s *memoryStore) Backup(_ io.Writer) (int, error) {
+	return 0, nil
+}
+
+func (s *memoryStore) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.buckets)
+	return nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+// TestRunManifest tests the round trip of the manifests of the runs.
+func TestRunManifest(t *testing.T) {
+	t.Run("ManifestDirs", func(tt *testing.T) {
+		dirs := runner.ManifestDirs("test", config.ValidOutputConfig{
+			{ID: "a", Values: []config.ValidOutputRespectiveValueConfig{
+				{Env: "test", Type: config.OutputTypeLocal, BasePath: "out"},
+				{Env: "prod", Type: config.OutputTypeLocal, BasePath: "prod"},
+			}},
+			{ID: "b", Values: []config.ValidOutputRespectiveValueConfig{
+				{Env: "test", Type: config.OutputTypeLocal, BasePath: "out"},
+				{Env: "test", Type: config.OutputTypeLocal, BasePath: "other"},
+			}},
+		})
+		if strings.Join(dirs, ",") != "out,other" {
+			tt.Errorf("expected the local base paths of the env, got %v", dirs)
+		}
+	})
+
+	t.Run("SaveAndFind", func(tt *testing.T) {
+		dirs := []string{tt.TempDir(), tt.TempDir()}
+		m := runner.NewRunManifest("run1", "main.yaml", map[string]any{"count": 3}, "test")
+		m.SetFlowRequests("main.yaml/seed#0", []runner.MassExecResult{
+			{RequestIndex: 0, TerminateType: matcher.TerminateTypeByStatusCode, MatchedID: "notFound"},
+		})
+		m.SetFlow("main.yaml/seed#0", runner.FlowStatusSucceeded, "", "run1")
+		m.AddOutput("local", "run1/a.csv")
+		m.AddOutput("local", "run1/a.csv")
+		m.AddSlave("slave1")
+		m.Finish(runner.RunStatusInterrupted, os.ErrDeadlineExceeded)
+		if err := m.Save(dirs); err != nil {
+			tt.Fatalf("failed to save: %v", err)
+		}
+		for _, dir := range dirs {
+			if _, err := os.Stat(filepath.Join(dir, "run1", runner.ManifestFileName)); err != nil {
+				tt.Errorf("expected the manifest in %s: %v", dir, err)
+			}
+		}
+
+		found, err := runner.FindManifest(dirs, "run1")
+		if err != nil {
+			tt.Fatalf("failed to find: %v", err)
+		}
+		if found.File != "main.yaml" || found.Env != "test" || found.Data["count"] != float64(3) ||
+			found.Status != runner.RunStatusInterrupted || found.Error == "" || found.EndedAt == nil {
+			tt.Errorf("unexpected manifest: %+v", found)
+		}
+		if f := found.Flows["main.yaml/seed#0"]; f.Status != runner.FlowStatusSucceeded || f.OutputRoot != "run1" {
+			tt.Errorf("unexpected flow: %+v", found.Flows)
+		}
+		want := []runner.ManifestRequest{{RequestIndex: 0, TerminateType: "statusCode", MatchedID: "notFound"}}
+		if f := found.Flows["main.yaml/seed#0"]; !slices.Equal(f.Requests, want) {
+			tt.Errorf("expected the requests %+v, got %+v", want, f.Requests)
+		}
+		if len(found.Outputs) != 1 || len(found.Slaves) != 1 || found.Slaves[0] != "slave1" {
+			tt.Errorf("unexpected outputs or slaves: %+v %+v", found.Outputs, found.Slaves)
+		}
+
+		found.Resume()
+		if found.Status != runner.RunStatusRunning || found.Error != "" || found.EndedAt != nil || len(found.ResumedAt) != 1 {
+			tt.Errorf("expected the resumed manifest to be running, got %+v", found)
+		}
+		found.SetFlow("main.yaml/next#0", runner.FlowStatusFailed, "boom", "run1")
+		found.Finish(runner.RunStatusFailed, os.ErrClosed)
+		if err := found.Save(dirs); err != nil {
+			tt.Fatalf("failed to save: %v", err)
+		}
+		found, err = runner.FindManifest(dirs[1:], "run1")
+		if err != nil {
+			tt.Fatalf("failed to find: %v", err)
+		}
+		if found.Status != runner.RunStatusFailed || len(found.Flows) != 2 || found.Flows["main.yaml/next#0"].Reason != "boom" {
+			tt.Errorf("expected the resumed run to be saved, got %+v", found)
+		}
+
+		if _, err := runner.FindManifest(dirs, "missing"); err == nil {
+			tt.Errorf("expected the error of the missing run")
+		}
+	})
+
+	t.Run("List", func(tt *testing.T) {
+		dirs := []string{tt.TempDir(), tt.TempDir()}
+		second := runner.NewRunManifest("run2", "b.yaml", nil, "test")
+		first := runner.NewRunManifest("run1", "a.yaml", nil, "test")
+		first.StartedAt = second.StartedAt.Add(-time.Minute)
+		if err := second.Save(dirs); err != nil {
+			tt.Fatalf("failed to save: %v", err)
+		}
+		if err := first.Save(dirs[:1]); err != nil {
+			tt.Fatalf("failed to save: %v", err)
+		}
+		manifests, err := runner.ListManifests(dirs)
+		if err != nil {
+			tt.Fatalf("failed to list: %v", err)
+		}
+		if len(manifests) != 2 || manifests[0].RunID != "run1" || manifests[1].RunID != "run2" {
+			tt.Errorf("expected the runs once each in the start order, got %+v", manifests)
+		}
+	})
+
+	t.Run("DeleteRun", func(tt *testing.T) {
+		str := newMemoryStore()
+		dirs := []string{tt.TempDir()}
+		output := filepath.Join(tt.TempDir(), "result.csv")
+		if err := os.WriteFile(output, []byte("a,b\n"), 0o600); err != nil {
+			tt.Fatalf("failed to write output: %v", err)
+		}
+		m := runner.NewRunManifest("run1", "main.yaml", nil, "test")
+		m.AddOutput("local", output)
+		if err := m.Save(dirs); err != nil {
+			tt.Fatalf("failed to save: %v", err)
+		}
+		if _, err := runner.NewCheckpoint(str, runner.CheckpointRecord{RunID: "run1"}, &sync.Map{}, runner.NewFlowResults(), m); err != nil {
+			tt.Fatalf("failed to create checkpoint: %v", err)
+		}
+
+		if err := runner.DeleteRun(str, dirs, m); err != nil {
+			tt.Fatalf("failed to delete: %v", err)
+		}
+		if _, err := os.Stat(output); !os.IsNotExist(err) {
+			tt.Errorf("expected the output to be removed, got %v", err)
+		}
+		if _, err := runner.FindManifest(dirs, "run1"); err == nil {
+			tt.Errorf("expected the manifest to be removed")
+		}
+		if _, err := runner.LoadCheckpointRecord(str, "run1"); err == nil {
+			tt.Errorf("expected the checkpoint to be removed")
+		}
+	})
+
+	t.Run("NewRunID", func(tt *testing.T) {
+		str := newMemoryStore()
+		dirs := []string{tt.TempDir()}
+		startedAt := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+		if id := runner.NewRunID(str, dirs, startedAt); id != "20240506_070809" {
+			tt.Errorf("expected the start time as the output root, got %s", id)
+		}
+
+		if err := os.MkdirAll(filepath.Join(dirs[0], "20240506_070809"), 0o755); err != nil {
+			tt.Fatalf("failed to create dir: %v", err)
+		}
+		id := runner.NewRunID(str, dirs, startedAt)
+		if !strings.HasPrefix(id, "20240506_070809_") {
+			tt.Errorf("expected the suffix on the collision, got %s", id)
+		}
+
+		startedAt = startedAt.Add(time.Second)
+		if _, err := runner.NewCheckpoint(str, runner.CheckpointRecord{RunID: "20240506_070810"}, &sync.Map{}, runner.NewFlowResults(), nil); err != nil {
+			tt.Fatalf("failed to create checkpoint: %v", err)
+		}
+		if id := runner.NewRunID(str, dirs, startedAt); id == "20240506_070810" {
+			tt.Errorf("expected the suffix on the collision with the checkpoint, got %s", id)
+		}
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"text/template"
 	"time"
 
 	"github.com/cresplanex/bloader/internal/auth"
@@ -22,22 +24,52 @@ type MassExecType string
 const (
 	// MassExecTypeHTTP represents the HTTP type
 	MassExecTypeHTTP MassExecType = "http"
+	// MassExecTypeWebSocket represents the WebSocket type
+	MassExecTypeWebSocket MassExecType = "websocket"
+	// MassExecTypeGRPC represents the gRPC type
+	MassExecTypeGRPC MassExecType = "grpc"
+	// MassExecTypeSSE represents the Server-Sent Events type
+	MassExecTypeSSE MassExecType = "sse"
+	// MassExecTypeSocket represents the raw TCP or UDP socket type
+	MassExecTypeSocket MassExecType = "socket"
+	// MassExecTypeSQL represents the SQL database type
+	MassExecTypeSQL MassExecType = "sql"
+	// MassExecTypeRedis represents the Redis type
+	MassExecTypeRedis MassExecType = "redis"
+	// MassExecTypeMQTT represents the MQTT publish and subscribe type
+	MassExecTypeMQTT MassExecType = "mqtt"
 )
 
 // MassExec represents the MassExec runner
 type MassExec struct {
-	Type     *string           `yaml:"type"`
-	Output   MassExecOutput    `yaml:"output"`
-	Auth     MassExecAuth      `yaml:"auth"`
-	Requests []MassExecRequest `yaml:"requests"`
+	Type      *string            `yaml:"type"`
+	Output    MassExecOutput     `yaml:"output"`
+	Auth      MassExecAuth       `yaml:"auth"`
+	Requests  []MassExecRequest  `yaml:"requests"`
+	WebSocket *MassExecWebSocket `yaml:"websocket"`
+	GRPC      *MassExecGRPC      `yaml:"grpc"`
+	SSE       *MassExecSSE       `yaml:"sse"`
+	Socket    *MassExecSocket    `yaml:"socket"`
+	SQL       *MassExecSQL       `yaml:"sql"`
+	Redis     *MassExecRedis     `yaml:"redis"`
+	MQTT      *MassExecMQTT      `yaml:"mqtt"`
+	Cookies   Cookies            `yaml:"cookies"`
 }
 
 // ValidMassExec represents the valid MassExec runner
 type ValidMassExec struct {
-	Type     MassExecType
-	Output   []output.Output
-	Auth     auth.SetAuthor
-	Requests []ValidMassExecRequest
+	Type      MassExecType
+	Output    []output.Output
+	Auth      auth.SetAuthor
+	Requests  []ValidMassExecRequest
+	WebSocket ValidMassExecWebSocket
+	GRPC      ValidMassExecGRPC
+	SSE       ValidMassExecSSE
+	Socket    ValidMassExecSocket
+	SQL       ValidMassExecSQL
+	Redis     ValidMassExecRedis
+	MQTT      ValidMassExecMQTT
+	Cookies   ValidCookies
 }
 
 // Validate validates the MassExec
@@ -47,7 +79,8 @@ func (r MassExec) Validate(
 	authFactor AuthenticatorFactor,
 	outFactor OutputFactor,
 	targetFactor TargetFactor,
-	tmplStr string,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
 	replaceData map[string]any,
 ) (ValidMassExec, error) {
 	var massExecType MassExecType
@@ -55,7 +88,8 @@ func (r MassExec) Validate(
 		return ValidMassExec{}, fmt.Errorf("type is required")
 	}
 	switch MassExecType(*r.Type) {
-	case MassExecTypeHTTP:
+	case MassExecTypeHTTP, MassExecTypeWebSocket, MassExecTypeGRPC, MassExecTypeSSE, MassExecTypeSocket, MassExecTypeSQL, MassExecTypeRedis,
+		MassExecTypeMQTT:
 		massExecType = MassExecType(*r.Type)
 	default:
 		return ValidMassExec{}, fmt.Errorf("invalid type value: %s", *r.Type)
@@ -76,7 +110,8 @@ func (r MassExec) Validate(
 			ctx,
 			log,
 			targetFactor,
-			tmplStr,
+			tmplSet,
+			newFieldTmpl(tmplSet, tmpl, replaceData, "requests", i),
 			replaceData,
 		)
 		if err != nil {
@@ -84,11 +119,128 @@ func (r MassExec) Validate(
 		}
 		validRequests = append(validRequests, validRequest)
 	}
+	var validWebSocket ValidMassExecWebSocket
+	if massExecType == MassExecTypeWebSocket {
+		if r.WebSocket == nil {
+			return ValidMassExec{}, fmt.Errorf("websocket is required")
+		}
+		if validWebSocket, err = r.WebSocket.Validate(
+			ctx,
+			log,
+			targetFactor,
+			tmplSet,
+			tmpl,
+			replaceData,
+		); err != nil {
+			return ValidMassExec{}, fmt.Errorf("failed to validate websocket: %w", err)
+		}
+	}
+	var validGRPC ValidMassExecGRPC
+	if massExecType == MassExecTypeGRPC {
+		if r.GRPC == nil {
+			return ValidMassExec{}, fmt.Errorf("grpc is required")
+		}
+		if validGRPC, err = r.GRPC.Validate(
+			ctx,
+			log,
+			targetFactor,
+			tmplSet,
+			tmpl,
+			replaceData,
+		); err != nil {
+			return ValidMassExec{}, fmt.Errorf("failed to validate grpc: %w", err)
+		}
+	}
+	var validSSE ValidMassExecSSE
+	if massExecType == MassExecTypeSSE {
+		if r.SSE == nil {
+			return ValidMassExec{}, fmt.Errorf("sse is required")
+		}
+		if validSSE, err = r.SSE.Validate(ctx, log, targetFactor); err != nil {
+			return ValidMassExec{}, fmt.Errorf("failed to validate sse: %w", err)
+		}
+	}
+	var validSocket ValidMassExecSocket
+	if massExecType == MassExecTypeSocket {
+		if r.Socket == nil {
+			return ValidMassExec{}, fmt.Errorf("socket is required")
+		}
+		if validSocket, err = r.Socket.Validate(
+			ctx,
+			log,
+			targetFactor,
+			tmplSet,
+			tmpl,
+			replaceData,
+		); err != nil {
+			return ValidMassExec{}, fmt.Errorf("failed to validate socket: %w", err)
+		}
+	}
+	var validSQL ValidMassExecSQL
+	if massExecType == MassExecTypeSQL {
+		if r.SQL == nil {
+			return ValidMassExec{}, fmt.Errorf("sql is required")
+		}
+		if validSQL, err = r.SQL.Validate(
+			ctx,
+			log,
+			targetFactor,
+			tmplSet,
+			tmpl,
+			replaceData,
+		); err != nil {
+			return ValidMassExec{}, fmt.Errorf("failed to validate sql: %w", err)
+		}
+	}
+	var validRedis ValidMassExecRedis
+	if massExecType == MassExecTypeRedis {
+		if r.Redis == nil {
+			return ValidMassExec{}, fmt.Errorf("redis is required")
+		}
+		if validRedis, err = r.Redis.Validate(
+			ctx,
+			log,
+			targetFactor,
+			tmplSet,
+			tmpl,
+			replaceData,
+		); err != nil {
+			return ValidMassExec{}, fmt.Errorf("failed to validate redis: %w", err)
+		}
+	}
+	var validMQTT ValidMassExecMQTT
+	if massExecType == MassExecTypeMQTT {
+		if r.MQTT == nil {
+			return ValidMassExec{}, fmt.Errorf("mqtt is required")
+		}
+		if validMQTT, err = r.MQTT.Validate(
+			ctx,
+			log,
+			targetFactor,
+			tmplSet,
+			tmpl,
+			replaceData,
+		); err != nil {
+			return ValidMassExec{}, fmt.Errorf("failed to validate mqtt: %w", err)
+		}
+	}
+	validCookies, err := r.Cookies.Validate()
+	if err != nil {
+		return ValidMassExec{}, fmt.Errorf("failed to validate cookies: %w", err)
+	}
 	return ValidMassExec{
-		Type:     massExecType,
-		Output:   validOutput,
-		Auth:     validAuth,
-		Requests: validRequests,
+		Type:      massExecType,
+		Output:    validOutput,
+		Auth:      validAuth,
+		Requests:  validRequests,
+		WebSocket: validWebSocket,
+		GRPC:      validGRPC,
+		SSE:       validSSE,
+		Socket:    validSocket,
+		SQL:       validSQL,
+		Redis:     validRedis,
+		MQTT:      validMQTT,
+		Cookies:   validCookies,
 	}, nil
 }
 
@@ -146,13 +298,15 @@ func (a MassExecAuth) Validate(ctx context.Context, authFactor AuthenticatorFact
 
 // MassExecRequestBreak represents the break configuration for the MassExec runner
 type MassExecRequestBreak struct {
-	Time         *string                      `yaml:"time"`
-	Count        *int                         `yaml:"count"`
-	SysError     bool                         `yaml:"sys_error"`
-	ParseError   bool                         `yaml:"parse_error"`
-	WriteError   bool                         `yaml:"write_error"`
-	StatusCode   matcher.StatusCodeConditions `yaml:"status_code"`
-	ResponseBody matcher.BodyConditions       `yaml:"response_body"`
+	Time          *string                      `yaml:"time"`
+	Count         *int                         `yaml:"count"`
+	SysError      bool                         `yaml:"sys_error"`
+	ParseError    bool                         `yaml:"parse_error"`
+	WriteError    bool                         `yaml:"write_error"`
+	StatusCode    matcher.StatusCodeConditions `yaml:"status_code"`
+	ErrorCode     matcher.ErrorCodeConditions  `yaml:"error_code"`
+	ResponseBody  matcher.BodyConditions       `yaml:"response_body"`
+	ResponseBytes matcher.BytesConditions      `yaml:"response_bytes"`
 }
 
 // ValidMassExecRequestBreak represents the valid break configuration for the MassExec runner
@@ -161,12 +315,16 @@ type ValidMassExecRequestBreak struct {
 		Enabled bool
 		Time    time.Duration
 	}
-	Count               httpexec.RequestCountLimit
-	SysError            bool
-	ParseError          bool
-	WriteError          bool
-	StatusCodeMatcher   matcher.StatusCodeConditionsMatcher
-	ResponseBodyMatcher matcher.BodyConditionsMatcher
+	Count                httpexec.RequestCountLimit
+	SysError             bool
+	ParseError           bool
+	WriteError           bool
+	StatusCodeMatcher    matcher.StatusCodeConditionsMatcher
+	ErrorCodeMatcher     matcher.ErrorCodeConditionsMatcher
+	ResponseBodyEnabled  bool
+	ResponseBodyMatcher  matcher.BodyConditionsMatcher
+	ResponseBytesEnabled bool
+	ResponseBytesMatcher matcher.BytesConditionsMatcher
 }
 
 // Validate validates the MassExecRequestBreak
@@ -191,25 +349,39 @@ func (b MassExecRequestBreak) Validate(ctx context.Context, log logger.Logger) (
 	if valid.StatusCodeMatcher, err = b.StatusCode.MatcherGenerate(ctx, log); err != nil {
 		return ValidMassExecRequestBreak{}, fmt.Errorf("failed to generate status code matcher: %w", err)
 	}
+	if valid.ErrorCodeMatcher, err = b.ErrorCode.MatcherGenerate(ctx, log); err != nil {
+		return ValidMassExecRequestBreak{}, fmt.Errorf("failed to generate error code matcher: %w", err)
+	}
+	valid.ResponseBodyEnabled = len(b.ResponseBody) > 0
 	if valid.ResponseBodyMatcher, err = b.ResponseBody.MatcherGenerate(ctx, log); err != nil {
 		return ValidMassExecRequestBreak{}, fmt.Errorf("failed to generate response body matcher: %w", err)
 	}
+	valid.ResponseBytesEnabled = len(b.ResponseBytes) > 0
+	if valid.ResponseBytesMatcher, err = b.ResponseBytes.MatcherGenerate(ctx, log); err != nil {
+		return ValidMassExecRequestBreak{}, fmt.Errorf("failed to generate response bytes matcher: %w", err)
+	}
 	return valid, nil
 }
 
 // MassExecRequestRecordExcludeFilter represents the record exclude filter configuration for the MassExec runner
 type MassExecRequestRecordExcludeFilter struct {
-	Count        matcher.CountConditions      `yaml:"count"`
-	StatusCode   matcher.StatusCodeConditions `yaml:"status_code"`
-	ResponseBody matcher.BodyConditions       `yaml:"response_body"`
+	Count         matcher.CountConditions      `yaml:"count"`
+	StatusCode    matcher.StatusCodeConditions `yaml:"status_code"`
+	ErrorCode     matcher.ErrorCodeConditions  `yaml:"error_code"`
+	ResponseBody  matcher.BodyConditions       `yaml:"response_body"`
+	ResponseBytes matcher.BytesConditions      `yaml:"response_bytes"`
 }
 
 // ValidMassExecRequestRecordExcludeFilter represents the valid record exclude
 // filter configuration for the MassExec runner
 type ValidMassExecRequestRecordExcludeFilter struct {
-	CountFilter        matcher.CountConditionsMatcher
-	StatusCodeFilter   matcher.StatusCodeConditionsMatcher
-	ResponseBodyFilter matcher.BodyConditionsMatcher
+	CountFilter          matcher.CountConditionsMatcher
+	StatusCodeFilter     matcher.StatusCodeConditionsMatcher
+	ErrorCodeFilter      matcher.ErrorCodeConditionsMatcher
+	ResponseBodyEnabled  bool
+	ResponseBodyFilter   matcher.BodyConditionsMatcher
+	ResponseBytesEnabled bool
+	ResponseBytesFilter  matcher.BytesConditionsMatcher
 }
 
 // Validate validates the MassExecRequestRecordExcludeFilter
@@ -225,9 +397,17 @@ func (f MassExecRequestRecordExcludeFilter) Validate(
 	if valid.StatusCodeFilter, err = f.StatusCode.MatcherGenerate(ctx, log); err != nil {
 		return ValidMassExecRequestRecordExcludeFilter{}, fmt.Errorf("failed to generate status code filter: %w", err)
 	}
+	if valid.ErrorCodeFilter, err = f.ErrorCode.MatcherGenerate(ctx, log); err != nil {
+		return ValidMassExecRequestRecordExcludeFilter{}, fmt.Errorf("failed to generate error code filter: %w", err)
+	}
+	valid.ResponseBodyEnabled = len(f.ResponseBody) > 0
 	if valid.ResponseBodyFilter, err = f.ResponseBody.MatcherGenerate(ctx, log); err != nil {
 		return ValidMassExecRequestRecordExcludeFilter{}, fmt.Errorf("failed to generate response body filter: %w", err)
 	}
+	valid.ResponseBytesEnabled = len(f.ResponseBytes) > 0
+	if valid.ResponseBytesFilter, err = f.ResponseBytes.MatcherGenerate(ctx, log); err != nil {
+		return ValidMassExecRequestRecordExcludeFilter{}, fmt.Errorf("failed to generate response bytes filter: %w", err)
+	}
 	return valid, nil
 }
 
@@ -242,57 +422,63 @@ type MassExecRequest struct {
 	BodyType            *string                            `yaml:"body_type"`
 	Body                any                                `yaml:"body"`
 	ResponseType        *string                            `yaml:"response_type"`
+	MaxBodyBytes        *int64                             `yaml:"max_body_bytes"`
+	RequestTemplate     MassExecRequestTemplate            `yaml:"request_template"`
 	Data                []ExecRequestData                  `yaml:"data"`
 	Interval            *string                            `yaml:"interval"`
 	AwaitPrevResp       bool                               `yaml:"await_prev_response"`
 	SuccessBreak        []string                           `yaml:"success_break"`
 	Break               MassExecRequestBreak               `yaml:"break"`
 	RecordExcludeFilter MassExecRequestRecordExcludeFilter `yaml:"record_exclude_filter"`
+	Emit                []MassExecRequestEmit              `yaml:"emit"`
 }
 
 // ValidMassExecRequest represents the valid request configuration for the MassExec runner
 type ValidMassExecRequest struct {
 	URL                 string
 	Method              string
+	TargetURL           string
 	QueryParams         map[string]any
 	PathVariables       map[string]string
 	Headers             map[string]any
 	BodyType            HTTPRequestBodyType
 	Body                any
+	Operation           string
 	ResponseType        string
+	MaxBodyBytes        int64
+	Transport           httpexec.TransportConfig
 	Data                ValidExecRequestDataSlice
 	Interval            time.Duration
 	AwaitPrevResp       bool
 	SuccessBreak        matcher.TerminateTypeAndParamsSlice
 	Break               ValidMassExecRequestBreak
 	RecordExcludeFilter ValidMassExecRequestRecordExcludeFilter
-	TmplStr             string
-	ReplaceData         *sync.Map
+	Emit                []ValidMassExecRequestEmit
+	BodyFields          matcher.BodyFields
+	Tmpl                *template.Template
+	RequestTmpl         *template.Template
+	ReplaceData         map[string]any
 }
 
-// Validate validates the MassExecRequest
-func (r MassExecRequest) Validate(
+// ValidateRequestFields validates only the fields used to build the HTTP request
+func (r MassExecRequest) ValidateRequestFields(
 	ctx context.Context,
-	log logger.Logger,
 	targetFactor TargetFactor,
-	tmplStr string,
-	replaceData map[string]any,
 ) (ValidMassExecRequest, error) {
 	var valid ValidMassExecRequest
-	var err error
 	if r.TargetID == nil {
 		return ValidMassExecRequest{}, fmt.Errorf("target_id is required")
 	}
 	if r.Endpoint == nil {
 		return ValidMassExecRequest{}, fmt.Errorf("endpoint is required")
 	}
-	var urlRoot string
 	tg, err := targetFactor.Factorize(ctx, *r.TargetID)
 	if err != nil {
 		return ValidMassExecRequest{}, fmt.Errorf("failed to factorize target: %w", err)
 	}
-	urlRoot = tg.URL
-	valid.URL = fmt.Sprintf("%s%s", urlRoot, *r.Endpoint)
+	valid.TargetURL = tg.URL
+	valid.URL = fmt.Sprintf("%s%s", tg.URL, *r.Endpoint)
+	valid.Transport = httpTransport(tg)
 	if r.Method == nil {
 		return ValidMassExecRequest{}, fmt.Errorf("method is required")
 	}
@@ -307,43 +493,162 @@ func (r MassExecRequest) Validate(
 		switch HTTPRequestBodyType(*r.BodyType) {
 		case HTTPRequestBodyTypeJSON, HTTPRequestBodyTypeForm, HTTPRequestBodyTypeMultipart:
 			valid.BodyType = HTTPRequestBodyTypeJSON
+		case HTTPRequestBodyTypeGraphQL:
+			if err := validateGraphQLBody(r.Body); err != nil {
+				return ValidMassExecRequest{}, err
+			}
+			valid.BodyType = HTTPRequestBodyTypeGraphQL
+			valid.Operation = graphQLOperationName(r.Body)
 		default:
 			return ValidMassExecRequest{}, fmt.Errorf("invalid body_type value: %s", *r.BodyType)
 		}
 	}
+	return valid, nil
+}
+
+// Validate validates the MassExecRequest,
+// the tmpl renders the fields of the request for each request and it is nil when they are static
+func (r MassExecRequest) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecRequest, error) {
+	valid, err := r.ValidateRequestFields(ctx, targetFactor)
+	if err != nil {
+		return ValidMassExecRequest{}, err
+	}
 	if r.ResponseType == nil {
 		return ValidMassExecRequest{}, fmt.Errorf("response_type is required")
 	}
 	valid.ResponseType = *r.ResponseType
+	if valid.MaxBodyBytes, err = validateMaxBodyBytes(r.MaxBodyBytes); err != nil {
+		return ValidMassExecRequest{}, err
+	}
+	if err := r.validateHandling(ctx, log, &valid); err != nil {
+		return ValidMassExecRequest{}, err
+	}
+	if httpexec.ResponseType(valid.ResponseType) == httpexec.ResponseTypeDiscard && valid.ReferBody() {
+		return ValidMassExecRequest{}, fmt.Errorf(
+			"response_type discard does not read the body, so it cannot be used with data or response_body",
+		)
+	}
+	if valid.IsGraphQL() {
+		// the errors are read from the GraphQL response
+		valid.BodyFields.Add("data")
+		valid.BodyFields.Add("errors")
+	}
+	if valid.RequestTmpl, err = r.RequestTemplate.Validate(tmplSet); err != nil {
+		return ValidMassExecRequest{}, fmt.Errorf("failed to validate request template: %w", err)
+	}
+	valid.Tmpl = tmpl
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// validateHandling validates the fields which handle the responses, they are shared by the request types
+func (r MassExecRequest) validateHandling(ctx context.Context, log logger.Logger, valid *ValidMassExecRequest) error {
+	var err error
 	for i, d := range r.Data {
 		validData, err := d.Validate()
 		if err != nil {
-			return ValidMassExecRequest{}, fmt.Errorf("failed to validate data[%d]: %w", i, err)
+			return fmt.Errorf("failed to validate data[%d]: %w", i, err)
 		}
 		valid.Data = append(valid.Data, validData)
 	}
 	if r.Interval == nil {
-		return ValidMassExecRequest{}, fmt.Errorf("interval is required")
+		return fmt.Errorf("interval is required")
 	}
 	if valid.Interval, err = time.ParseDuration(*r.Interval); err != nil {
-		return ValidMassExecRequest{}, fmt.Errorf("failed to parse interval: %w", err)
+		return fmt.Errorf("failed to parse interval: %w", err)
 	}
 	valid.AwaitPrevResp = r.AwaitPrevResp
 	if valid.SuccessBreak, err = matcher.NewTerminateTypeAndParamsSliceFromStringSlice(r.SuccessBreak); err != nil {
-		return ValidMassExecRequest{}, fmt.Errorf("failed to parse success break: %w", err)
+		return fmt.Errorf("failed to parse success break: %w", err)
 	}
 	if valid.Break, err = r.Break.Validate(ctx, log); err != nil {
-		return ValidMassExecRequest{}, fmt.Errorf("failed to validate break: %w", err)
+		return fmt.Errorf("failed to validate break: %w", err)
 	}
 	if valid.RecordExcludeFilter, err = r.RecordExcludeFilter.Validate(ctx, log); err != nil {
-		return ValidMassExecRequest{}, fmt.Errorf("failed to validate record exclude filter: %w", err)
+		return fmt.Errorf("failed to validate record exclude filter: %w", err)
 	}
-	valid.TmplStr = tmplStr
-	valid.ReplaceData = utils.NewSyncMapFromMap(replaceData)
-	return valid, nil
+	for i, e := range r.Emit {
+		validEmit, err := e.Validate(ctx, log)
+		if err != nil {
+			return fmt.Errorf("failed to validate emit[%d]: %w", i, err)
+		}
+		valid.Emit = append(valid.Emit, validEmit)
+		e.ResponseBody.AddFields(&valid.BodyFields)
+	}
+	for _, d := range valid.Data {
+		valid.BodyFields.Add(d.Extractor.Field)
+	}
+	r.Break.ResponseBody.AddFields(&valid.BodyFields)
+	r.RecordExcludeFilter.ResponseBody.AddFields(&valid.BodyFields)
+	return nil
+}
+
+// ReferBody reports whether the parsed response body is referred by the data, the filters, the breaks or the emits
+func (r ValidMassExecRequest) ReferBody() bool {
+	if len(r.Data) > 0 || r.Break.ResponseBodyEnabled || r.RecordExcludeFilter.ResponseBodyEnabled {
+		return true
+	}
+	for _, e := range r.Emit {
+		if e.ResponseBodyEnabled {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsBody reports whether the response body is referred by the data, the filters, the breaks or the emits.
+// The body is not parsed if it is not referred, the GraphQL response is always parsed for its errors.
+func (r ValidMassExecRequest) NeedsBody() bool {
+	return r.IsGraphQL() || r.ReferBody() || r.Break.ParseError ||
+		r.Break.ResponseBytesEnabled || r.RecordExcludeFilter.ResponseBytesEnabled
+}
+
+// IsGraphQL reports whether the request is the GraphQL request,
+// its records are tagged by the operation name and the error codes
+func (r ValidMassExecRequest) IsGraphQL() bool {
+	return r.BodyType == HTTPRequestBodyTypeGraphQL
+}
+
+// MassExecRequestTemplate represents the per-request template configuration for the MassExec runner.
+// The file is rendered for each request and only the fields it declares are overridden,
+// so the whole runner file does not need to be rendered and validated again.
+type MassExecRequestTemplate struct {
+	Enabled bool    `yaml:"enabled"`
+	File    *string `yaml:"file"`
+}
+
+// Validate validates the MassExecRequestTemplate and parses the template once,
+// the template shares the functions, the includes and the defines of the runner template.
+func (t MassExecRequestTemplate) Validate(tmplSet *TmplSet) (*template.Template, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+	if t.File == nil {
+		return nil, fmt.Errorf("file is required")
+	}
+	tmpl, err := tmplSet.ParseFile(*t.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// MassExecRequestTemplateFields represents the fields which can be overridden by the per-request template
+type MassExecRequestTemplateFields struct {
+	QueryParam    map[string]any    `yaml:"query_param"`
+	PathVariables map[string]string `yaml:"path_variables"`
+	Headers       map[string]any    `yaml:"headers"`
+	Body          any               `yaml:"body"`
 }
 
-// Run runs the MassExec runner
+// Run runs the MassExec runner, and returns the results of the requests with their terminate reasons
 func (r ValidMassExec) Run(
 	ctx context.Context,
 	log logger.Logger,
@@ -351,12 +656,28 @@ func (r ValidMassExec) Run(
 	authFactor AuthenticatorFactor,
 	outFactor OutputFactor,
 	targetFactor TargetFactor,
-) error {
+	eventCaster EventCaster,
+	cookieJar CookieJarFactory,
+) ([]MassExecResult, error) {
 	switch r.Type {
 	case MassExecTypeHTTP:
-		return r.runHTTP(ctx, log, outputRoot, authFactor, outFactor, targetFactor)
+		return r.runHTTP(ctx, log, outputRoot, authFactor, outFactor, targetFactor, eventCaster, cookieJar)
+	case MassExecTypeWebSocket:
+		return r.runWebSocket(ctx, log, outputRoot, eventCaster)
+	case MassExecTypeGRPC:
+		return r.runGRPC(ctx, log, outputRoot, eventCaster)
+	case MassExecTypeSSE:
+		return r.runSSE(ctx, log, outputRoot, eventCaster)
+	case MassExecTypeSocket:
+		return r.runSocket(ctx, log, outputRoot, eventCaster)
+	case MassExecTypeSQL:
+		return r.runSQL(ctx, log, outputRoot, eventCaster)
+	case MassExecTypeRedis:
+		return r.runRedis(ctx, log, outputRoot, eventCaster)
+	case MassExecTypeMQTT:
+		return r.runMQTT(ctx, log, outputRoot, eventCaster)
 	}
-	return nil
+	return nil, nil
 }
 
 func (r ValidMassExec) runHTTP(
@@ -366,19 +687,11 @@ func (r ValidMassExec) runHTTP(
 	authFactor AuthenticatorFactor,
 	outFactor OutputFactor,
 	targetFactor TargetFactor,
-) error {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-	concurrentCount := len(r.Requests)
-	threadExecutors := make([]*MassiveExecThreadExecutor, concurrentCount)
-	uniqueName := fmt.Sprintf("%s/%s", outputRoot, utils.GenerateUniqueID())
-
-	for i := 0; i < concurrentCount; i++ {
-		request := r.Requests[i]
-		threadExecutors[i] = &MassiveExecThreadExecutor{
-			ID: i,
-		}
-
+	eventCaster EventCaster,
+	cookieJar CookieJarFactory,
+) ([]MassExecResult, error) {
+	threads := make([]massExecThread, len(r.Requests))
+	for i, request := range r.Requests {
 		req := HTTPRequest{
 			Method:        request.Method,
 			URL:           request.URL,
@@ -387,55 +700,119 @@ func (r ValidMassExec) runHTTP(
 			PathVariables: request.PathVariables,
 			BodyType:      request.BodyType,
 			Body:          request.Body,
-			AttachRequestInfo: func(ctx context.Context, req *http.Request) error {
-				if r.Auth == nil {
-					return nil
-				}
+			IsMass:        true,
+			Tmpl:          request.Tmpl,
+			RequestTmpl:   request.RequestTmpl,
+			ReplaceData:   request.ReplaceData,
+			TargetURL:     request.TargetURL,
+			OutputFactor:  outFactor,
+			AuthFactor:    authFactor,
+			ReqIndex:      i,
+		}
+		// the request is sent without the auth when it is disabled, as the one exec request is
+		if r.Auth != nil {
+			req.AttachRequestInfo = func(ctx context.Context, req *http.Request) error {
 				r.Auth.SetOnRequest(ctx, req)
 				return nil
-			},
-			IsMass:       true,
-			TmplStr:      request.TmplStr,
-			ReplaceData:  request.ReplaceData,
-			OutputFactor: outFactor,
-			AuthFactor:   authFactor,
-			TargetFactor: targetFactor,
-			ReqIndex:     i,
+			}
 		}
 		resChan := make(chan httpexec.ResponseContent)
-		exe := httpexec.MassRequestContent[HTTPRequest]{
-			Req:          req,
-			Interval:     request.Interval,
-			ResponseWait: request.AwaitPrevResp,
-			ResChan:      resChan,
-			CountLimit:   request.Break.Count,
-			ResponseType: httpexec.ResponseType(request.ResponseType),
+		threads[i] = massExecThread{
+			request: request,
+			result: MassExecResult{
+				RequestIndex: i,
+				Method:       request.Method,
+				URL:          request.URL,
+				Operation:    request.Operation,
+			},
+			executor: httpexec.MassRequestContent[HTTPRequest]{
+				MassDriver:   request.massDriver(resChan),
+				Req:          req,
+				ResponseType: httpexec.ResponseType(request.ResponseType),
+				MaxBodyBytes: request.MaxBodyBytes,
+				SkipParse:    !request.NeedsBody(),
+				BodyFields:   request.BodyFields.Fields(),
+				CookieJar:    cookieJar,
+				Transport:    request.Transport,
+			},
+			resChan: resChan,
+		}
+		if request.Transport.HTTP3 {
+			threads[i].columns = httpexec.HTTP3ColumnHeader
+		}
+	}
+	return r.runThreads(ctx, log, outputRoot, eventCaster, threads)
+}
+
+// massDriver returns the driver which sends the request by its interval and its count break to the resChan
+func (r ValidMassExecRequest) massDriver(resChan chan<- httpexec.ResponseContent) httpexec.MassDriver {
+	return httpexec.MassDriver{
+		Interval:     r.Interval,
+		ResponseWait: r.AwaitPrevResp,
+		ResChan:      resChan,
+		CountLimit:   r.Break.Count,
+	}
+}
+
+// massExecThread represents the request of the MassExec runner with its executor,
+// the executor sends the responses to the resChan regardless of the protocol
+type massExecThread struct {
+	request  ValidMassExecRequest
+	result   MassExecResult
+	executor httpexec.MassRequestExecutor
+	resChan  chan httpexec.ResponseContent
+	// columns is the header of the extra columns of the protocol, the responses have their values
+	columns []string
+}
+
+// runThreads runs the requests concurrently and handles their responses until they terminate by the breaks
+func (r ValidMassExec) runThreads(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+	threads []massExecThread,
+) ([]MassExecResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	concurrentCount := len(threads)
+	threadExecutors := make([]*MassiveExecThreadExecutor, concurrentCount)
+	uniqueName := fmt.Sprintf("%s/%s", outputRoot, utils.GenerateUniqueID())
+
+	for i := 0; i < concurrentCount; i++ {
+		request := threads[i].request
+		threadExecutors[i] = &MassiveExecThreadExecutor{
+			ID:     i,
+			Result: threads[i].result,
 		}
 
 		reqTermChan := make(chan struct{})
 		writers := make([]output.HTTPDataWrite, 0)
 		uName := fmt.Sprintf("%s_%d", uniqueName, i)
 		var writeCloser []output.Close
+		header := []string{
+			"Success",
+			"SendDatetime",
+			"ReceivedDatetime",
+			"Count",
+			"ResponseTime",
+			"StatusCode",
+		}
+		if request.IsGraphQL() {
+			header = append(header, "Operation", "ErrorCodes")
+		}
+		columns := threads[i].columns
+		header = append(header, columns...)
 		for _, o := range r.Output {
 			writer, closer, err := o.HTTPDataWriteFactory(
 				ctx,
 				log,
 				true,
 				uName,
-				append(
-					[]string{
-						"Success",
-						"SendDatetime",
-						"ReceivedDatetime",
-						"Count",
-						"ResponseTime",
-						"StatusCode",
-					},
-					request.Data.ExtractHeader()...,
-				),
+				append(header, request.Data.ExtractHeader()...),
 			)
 			if err != nil {
-				return fmt.Errorf("failed to create writer: %w", err)
+				return nil, fmt.Errorf("failed to create writer: %w", err)
 			}
 			writeCloser = append(writeCloser, closer)
 			writers = append(writers, writer)
@@ -451,12 +828,14 @@ func (r ValidMassExec) runHTTP(
 		}
 
 		termChan := make(chan TermChanType)
+		emitter := NewRequestEventEmitter(eventCaster, request.Emit)
 
 		threadExecutors[i].closer = closer
-		threadExecutors[i].RequestExecutor = exe
+		threadExecutors[i].RequestExecutor = threads[i].executor
 		threadExecutors[i].TermChan = termChan
 		threadExecutors[i].successBreak = request.SuccessBreak
 		threadExecutors[i].ReqTermChan = reqTermChan
+		threadExecutors[i].emitter = emitter
 
 		consumer := func(
 			ctx context.Context,
@@ -465,6 +844,13 @@ func (r ValidMassExec) runHTTP(
 			data WriteData,
 		) error {
 			var additionalData []string
+			if request.IsGraphQL() {
+				additionalData = append(additionalData, request.Operation, strings.Join(data.ErrorCodes, ","))
+			}
+			// the columns are padded, so the response without them keeps the row aligned with the header
+			values := make([]string, len(columns))
+			copy(values, data.Columns)
+			additionalData = append(additionalData, values...)
 			for _, d := range request.Data {
 				result, err := d.Extractor.Extract(data.RawData)
 				if err != nil {
@@ -487,10 +873,11 @@ func (r ValidMassExec) runHTTP(
 			reqTermChan,
 			log,
 			threadExecutors[i].ID,
-			r.Requests[i],
+			request,
 			termChan,
-			resChan,
+			threads[i].resChan,
 			consumer,
+			emitter,
 		)
 	}
 
@@ -519,12 +906,52 @@ func (r ValidMassExec) runHTTP(
 	close(startChan)
 	wg.Wait()
 
+	results := make([]MassExecResult, 0, len(threadExecutors))
+	for _, executor := range threadExecutors {
+		results = append(results, executor.Result)
+	}
+	// the context is canceled by the failed request, so the results are written with the parent context
+	if err := writeMassExecResults(context.WithoutCancel(ctx), log, r.Output, uniqueName, results); err != nil {
+		return results, err
+	}
+
 	if syncErr := atomicErr.Load(); syncErr != nil {
 		log.Error(ctx, "failed to find error",
 			logger.Value("error", syncErr.Err))
-		return syncErr.Err
+		return results, syncErr.Err
 	}
 
+	return results, nil
+}
+
+// writeMassExecResults writes the results of the requests to the outputs
+func writeMassExecResults(
+	ctx context.Context,
+	log logger.Logger,
+	outputs []output.Output,
+	uniqueName string,
+	results []MassExecResult,
+) error {
+	for _, o := range outputs {
+		writer, closer, err := o.HTTPDataWriteFactory(
+			ctx,
+			log,
+			true,
+			fmt.Sprintf("%s_results", uniqueName),
+			MassExecResultHeader,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create results writer: %w", err)
+		}
+		for _, result := range results {
+			if err := writer(ctx, log, result.ToSlice()); err != nil {
+				return fmt.Errorf("failed to write result: %w", err)
+			}
+		}
+		if err := closer(); err != nil {
+			return fmt.Errorf("failed to close results writer: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -532,6 +959,7 @@ func (r ValidMassExec) runHTTP(
 type TermChanType struct {
 	termType matcher.TerminateType
 	param    string
+	counts   TerminateCounts
 }
 
 // NewTermChanType creates a new termChanType
@@ -542,6 +970,12 @@ func NewTermChanType(termType matcher.TerminateType, param string) TermChanType
 	}
 }
 
+// WithCounts returns the termChanType with the counts at the termination
+func (t TermChanType) WithCounts(counts TerminateCounts) TermChanType {
+	t.counts = counts
+	return t
+}
+
 // MassiveExecThreadExecutor represents the thread executor for the MassExec runner
 type MassiveExecThreadExecutor struct {
 	ID              int
@@ -549,7 +983,10 @@ type MassiveExecThreadExecutor struct {
 	TermChan        chan TermChanType
 	ReqTermChan     chan<- struct{}
 	successBreak    matcher.TerminateTypeAndParamsSlice
+	emitter         *RequestEventEmitter
 	closer          func() error
+	// Result is the result of the request, it is filled when the request terminates
+	Result MassExecResult
 }
 
 // Execute executes the MassiveExecThreadExecutor
@@ -577,7 +1014,13 @@ func (e *MassiveExecThreadExecutor) Execute(
 	termType := <-e.TermChan
 	log.Info(ctx, "Execute End For Break",
 		logger.Value("ExecuteID", e.ID))
-	if e.successBreak.Match(termType.termType, termType.param) {
+	e.emitter.OnBreak(ctx, log, termType.termType, termType.param)
+	e.Result.TerminateType = termType.termType
+	e.Result.MatchedID = termType.param
+	e.Result.Counts = termType.counts
+	e.Result.Success = e.successBreak.Match(termType.termType, termType.param)
+	e.Result.EndedAt = time.Now()
+	if e.Result.Success {
 		fmt.Println("Execute End For Success Break", termType.termType.String())
 		log.Info(ctx, "Execute End For Success Break", logger.Value("ExecuteID", e.ID))
 		return nil
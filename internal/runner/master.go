@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"sync"
 
 	"google.golang.org/grpc"
@@ -73,6 +74,20 @@ func (c *ConnectionContainer) Find(slaveID string) (*ConnectionMapData, bool) {
 	return conn, true
 }
 
+// IDs returns the IDs of the connected slaves.
+func (c *ConnectionContainer) IDs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := make([]string, 0, len(c.conMap))
+	for id := range c.conMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
 // Connect adds a connection to the map.
 func (c *ConnectionContainer) Connect(
 	ctx context.Context,
@@ -74,3 +74,14 @@ func (bcs BodyConditions) MatcherGenerate(ctx context.Context, log logger.Logger
 		return "", false, nil
 	}, nil
 }
+
+// AddFields adds the top level fields referred by the conditions
+func (bcs BodyConditions) AddFields(fields *BodyFields) {
+	for _, bc := range bcs {
+		if bc.Extractor == nil {
+			fields.Add("")
+			continue
+		}
+		fields.Add(bc.Extractor.Field())
+	}
+}
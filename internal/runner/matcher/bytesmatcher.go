@@ -0,0 +1,135 @@
+package matcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// BytesOperator represents the bytes operator
+type BytesOperator string
+
+const (
+	// BytesOperatorNone represents the none operator
+	BytesOperatorNone BytesOperator = "none"
+	// BytesOperatorRegex represents the regex operator, the bytes are matched as they are
+	BytesOperatorRegex BytesOperator = "regex"
+	// BytesOperatorContains represents the contains operator
+	BytesOperatorContains BytesOperator = "contains"
+	// BytesOperatorHex represents the hex operator, the bytes equal to the hex value
+	BytesOperatorHex BytesOperator = "hex"
+	// BytesOperatorHexPrefix represents the hex prefix operator, the bytes start with the hex value
+	BytesOperatorHexPrefix BytesOperator = "hexPrefix"
+)
+
+// BytesCondition represents the condition on the raw bytes of the response, such as the reply of the socket
+type BytesCondition struct {
+	ID    *string `yaml:"id"`
+	Op    *string `yaml:"op"`
+	Value *string `yaml:"value"`
+}
+
+// BytesConditionMatcher represents the bytes matcher
+type BytesConditionMatcher func(b []byte) bool
+
+// decodeHexValue decodes the hex value, the spaces and the 0x prefix are ignored
+func decodeHexValue(value string) ([]byte, error) {
+	value = strings.TrimPrefix(strings.ToLower(value), "0x")
+	value = strings.NewReplacer(" ", "", "\t", "", "\n", "").Replace(value)
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode hex value: %w", err)
+	}
+	return b, nil
+}
+
+// MatcherGenerate generates the bytes matcher
+func (bc BytesCondition) MatcherGenerate(
+	ctx context.Context,
+	log logger.Logger,
+) (BytesConditionMatcher, error) {
+	if bc.ID == nil {
+		return nil, fmt.Errorf("id is required")
+	}
+	if bc.Op == nil {
+		return nil, fmt.Errorf("operator is required")
+	}
+	if (BytesOperator)(*bc.Op) == BytesOperatorNone {
+		return func(_ []byte) bool {
+			return false
+		}, nil
+	}
+	if bc.Value == nil {
+		return nil, fmt.Errorf("value is required")
+	}
+	switch (BytesOperator)(*bc.Op) {
+	case BytesOperatorRegex:
+		re, err := regexp.Compile(*bc.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile regex: %w", err)
+		}
+		return re.Match, nil
+	case BytesOperatorContains:
+		value := []byte(*bc.Value)
+		return func(b []byte) bool {
+			return bytes.Contains(b, value)
+		}, nil
+	case BytesOperatorHex:
+		value, err := decodeHexValue(*bc.Value)
+		if err != nil {
+			return nil, err
+		}
+		return func(b []byte) bool {
+			return bytes.Equal(b, value)
+		}, nil
+	case BytesOperatorHexPrefix:
+		value, err := decodeHexValue(*bc.Value)
+		if err != nil {
+			return nil, err
+		}
+		return func(b []byte) bool {
+			return bytes.HasPrefix(b, value)
+		}, nil
+	default:
+		log.Error(ctx, "unknown operator",
+			logger.Value("operator", bc.Op))
+		return nil, fmt.Errorf("unknown operator: %s", *bc.Op)
+	}
+}
+
+// BytesConditions represents the bytes conditions
+type BytesConditions []BytesCondition
+
+// BytesConditionsMatcher represents the bytes conditions matcher
+type BytesConditionsMatcher func(b []byte) (string, bool)
+
+// MatcherGenerate generates the bytes conditions matcher
+func (bcs BytesConditions) MatcherGenerate(
+	ctx context.Context,
+	log logger.Logger,
+) (BytesConditionsMatcher, error) {
+	matchers := make([]BytesConditionMatcher, 0, len(bcs))
+	for _, bc := range bcs {
+		matcher, err := bc.MatcherGenerate(ctx, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate matcher: %w", err)
+		}
+		matchers = append(matchers, matcher)
+	}
+	return func(b []byte) (string, bool) {
+		for i, matcher := range matchers {
+			if matcher(b) {
+				if bcs[i].ID != nil {
+					return *bcs[i].ID, true
+				}
+				return "", true
+			}
+		}
+		return "", false
+	}, nil
+}
@@ -0,0 +1,154 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// ErrorCodeOperator represents the error code operator
+type ErrorCodeOperator string
+
+const (
+	// ErrorCodeOperatorNone represents the none operator
+	ErrorCodeOperatorNone ErrorCodeOperator = "none"
+	// ErrorCodeOperatorEqual represents the equal operator
+	ErrorCodeOperatorEqual ErrorCodeOperator = "eq"
+	// ErrorCodeOperatorNotEqual represents the not equal operator
+	ErrorCodeOperatorNotEqual ErrorCodeOperator = "ne"
+	// ErrorCodeOperatorIn represents the in operator
+	ErrorCodeOperatorIn ErrorCodeOperator = "in"
+	// ErrorCodeOperatorNotIn represents the not in operator
+	ErrorCodeOperatorNotIn ErrorCodeOperator = "nin"
+	// ErrorCodeOperatorRegex represents the regex operator
+	ErrorCodeOperatorRegex ErrorCodeOperator = "regex"
+)
+
+// ErrorCodeCondition represents the error code condition,
+// the error codes are the string codes reported in the response, such as errors[].extensions.code of GraphQL
+type ErrorCodeCondition struct {
+	ID    *string `yaml:"id"`
+	Op    *string `yaml:"op"`
+	Value *any    `yaml:"value"`
+}
+
+// ErrorCodeConditionMatcher represents the error code matcher
+type ErrorCodeConditionMatcher func(code string) bool
+
+// MatcherGenerate generates the error code matcher
+func (ecc ErrorCodeCondition) MatcherGenerate(
+	ctx context.Context,
+	log logger.Logger,
+) (ErrorCodeConditionMatcher, error) {
+	if ecc.ID == nil {
+		return nil, fmt.Errorf("id is required")
+	}
+	if ecc.Op == nil {
+		return nil, fmt.Errorf("operator is required")
+	}
+	switch (ErrorCodeOperator)(*ecc.Op) {
+	case ErrorCodeOperatorNone:
+		return func(_ string) bool {
+			return false
+		}, nil
+	case ErrorCodeOperatorEqual:
+		if ecc.Value == nil {
+			return nil, fmt.Errorf("value is required")
+		}
+		codeStr, ok := (*ecc.Value).(string)
+		if !ok {
+			return nil, fmt.Errorf("value must be string")
+		}
+		return func(code string) bool {
+			return code == codeStr
+		}, nil
+	case ErrorCodeOperatorNotEqual:
+		if ecc.Value == nil {
+			return nil, fmt.Errorf("value is required")
+		}
+		codeStr, ok := (*ecc.Value).(string)
+		if !ok {
+			return nil, fmt.Errorf("value must be string")
+		}
+		return func(code string) bool {
+			return code != codeStr
+		}, nil
+	case ErrorCodeOperatorIn, ErrorCodeOperatorNotIn:
+		if ecc.Value == nil {
+			return nil, fmt.Errorf("value is required")
+		}
+		rawCodes, ok := (*ecc.Value).([]any)
+		if !ok {
+			return nil, fmt.Errorf("value must be []string")
+		}
+		codes := make(map[string]struct{}, len(rawCodes))
+		for _, rawCode := range rawCodes {
+			code, ok := rawCode.(string)
+			if !ok {
+				return nil, fmt.Errorf("value must be []string")
+			}
+			codes[code] = struct{}{}
+		}
+		in := (ErrorCodeOperator)(*ecc.Op) == ErrorCodeOperatorIn
+		return func(code string) bool {
+			_, ok := codes[code]
+			return ok == in
+		}, nil
+	case ErrorCodeOperatorRegex:
+		if ecc.Value == nil {
+			return nil, fmt.Errorf("value is required")
+		}
+		strV, ok := (*ecc.Value).(string)
+		if !ok {
+			return nil, fmt.Errorf("value must be string")
+		}
+		re, err := regexp.Compile(strV)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile regex: %w", err)
+		}
+		return func(code string) bool {
+			return re.MatchString(code)
+		}, nil
+	default:
+		log.Error(ctx, "unknown operator",
+			logger.Value("operator", ecc.Op))
+		return nil, fmt.Errorf("unknown operator: %s", *ecc.Op)
+	}
+}
+
+// ErrorCodeConditions represents the error code conditions
+type ErrorCodeConditions []ErrorCodeCondition
+
+// ErrorCodeConditionsMatcher represents the error code conditions matcher,
+// a condition matches when any of the error codes of the response matches it
+type ErrorCodeConditionsMatcher func(codes []string) (string, bool)
+
+// MatcherGenerate generates the error code conditions matcher
+func (eccs ErrorCodeConditions) MatcherGenerate(
+	ctx context.Context,
+	log logger.Logger,
+) (ErrorCodeConditionsMatcher, error) {
+	matchers := make([]ErrorCodeConditionMatcher, 0, len(eccs))
+	for _, ecc := range eccs {
+		matcher, err := ecc.MatcherGenerate(ctx, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate matcher: %w", err)
+		}
+		matchers = append(matchers, matcher)
+	}
+	return func(codes []string) (string, bool) {
+		for i, matcher := range matchers {
+			for _, code := range codes {
+				if matcher(code) {
+					if eccs[i].ID != nil {
+						return *eccs[i].ID, true
+					}
+					return "", true
+				}
+			}
+		}
+		return "", false
+	}, nil
+}
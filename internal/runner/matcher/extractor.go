@@ -2,6 +2,8 @@ package matcher
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 
 	"github.com/jmespath/go-jmespath"
 )
@@ -41,6 +43,56 @@ type ValidDataExtractor struct {
 	Type     DataExtractorType
 	JMESPath *jmespath.JMESPath
 	OnNil    DataExtractorOnNilType
+	// Field is the top level field referred by the extractor, it is empty when the whole body may be referred
+	Field string
+}
+
+// simpleJMESPathRe matches the JMESPath which only walks down from the top level field,
+// e.g. "data.items[0].id" or "items[*].name"
+var simpleJMESPathRe = regexp.MustCompile(
+	`^\s*([A-Za-z_][A-Za-z0-9_]*)(\.[A-Za-z_][A-Za-z0-9_]*|\[-?[0-9]+\]|\[\*\]|\.\*|\[\])*\s*$`,
+)
+
+// Field returns the top level field of the JSON object referred by the extractor,
+// it is empty when the extractor may refer to the whole body, e.g. the comparison or the function
+func (d DataExtractor) Field() string {
+	if d.JMESPath == nil {
+		return ""
+	}
+	m := simpleJMESPathRe.FindStringSubmatch(*d.JMESPath)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// BodyFields represents the top level fields of the JSON object body referred by the extractors
+type BodyFields struct {
+	// All is true when the whole body may be referred
+	All   bool
+	Names []string
+}
+
+// Add adds the top level field, the empty field refers to the whole body
+func (f *BodyFields) Add(field string) {
+	if field == "" {
+		f.All = true
+		return
+	}
+	if !slices.Contains(f.Names, field) {
+		f.Names = append(f.Names, field)
+	}
+}
+
+// Fields returns the fields to decode, nil is returned when the whole body is decoded
+func (f BodyFields) Fields() []string {
+	if f.All {
+		return nil
+	}
+	if f.Names == nil {
+		return []string{}
+	}
+	return f.Names
 }
 
 // Validate validates the data extractor
@@ -60,6 +112,7 @@ func (d DataExtractor) Validate() (ValidDataExtractor, error) {
 			return ValidDataExtractor{}, fmt.Errorf("failed to compile jmesPath: %w", err)
 		}
 		valid.JMESPath = jPath
+		valid.Field = d.Field()
 		if d.OnNil == nil {
 			valid.OnNil = DefaultDataExtractorOnNilType
 		} else {
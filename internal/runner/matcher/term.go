@@ -33,6 +33,10 @@ const (
 	TerminateTypeByResponseBody TerminateType = "responseBody"
 	// TerminateTypeByStatusCode represents the status code type
 	TerminateTypeByStatusCode TerminateType = "statusCode"
+	// TerminateTypeByErrorCode represents the error code type
+	TerminateTypeByErrorCode TerminateType = "errorCode"
+	// TerminateTypeByResponseBytes represents the response bytes type
+	TerminateTypeByResponseBytes TerminateType = "responseBytes"
 )
 
 // String returns the string representation of the terminate type
@@ -85,6 +89,10 @@ func NewTerminateTypeFromString(s string) (TerminateTypeAndParams, error) {
 		return NewTerminateTypeAndParams(TerminateTypeByResponseBody, params), nil
 	case TerminateTypeByStatusCode:
 		return NewTerminateTypeAndParams(TerminateTypeByStatusCode, params), nil
+	case TerminateTypeByErrorCode:
+		return NewTerminateTypeAndParams(TerminateTypeByErrorCode, params), nil
+	case TerminateTypeByResponseBytes:
+		return NewTerminateTypeAndParams(TerminateTypeByResponseBytes, params), nil
 	case TerminateTypeByResponseBodyWriteFilterError:
 		return NewTerminateTypeAndParams(TerminateTypeByResponseBodyWriteFilterError, params), nil
 	case TerminateTypeByResponseBodyDataExtractorError:
@@ -0,0 +1,971 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/executor/mqttexec"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+	"github.com/cresplanex/bloader/internal/utils"
+)
+
+const (
+	// MQTTMethodPublish is the method recorded in the results of the publishers
+	MQTTMethodPublish = "PUBLISH"
+	// MQTTMethodSubscribe is the method recorded in the results of the subscribers
+	MQTTMethodSubscribe = "SUBSCRIBE"
+)
+
+// DefaultMQTTConnections is the default number of the MQTT subscriber connections
+const DefaultMQTTConnections = 1
+
+// MassExecMQTT represents the MQTT configuration for the MassExec runner.
+// The subscribers are subscribed before the publishers start, and they run until their breaks.
+type MassExecMQTT struct {
+	Subscribers *MassExecMQTTSubscribers `yaml:"subscribers"`
+	Publishers  []MassExecMQTTPublisher  `yaml:"publishers"`
+}
+
+// MassExecMQTTPublisher represents the publisher configuration for the MassExec runner,
+// each count publishes the message and its acknowledgement is recorded like the response of the HTTP request
+type MassExecMQTTPublisher struct {
+	TargetID            *string                            `yaml:"target_id"`
+	ClientID            *string                            `yaml:"client_id"`
+	Topic               *string                            `yaml:"topic"`
+	QoS                 *int                               `yaml:"qos"`
+	Retain              bool                               `yaml:"retain"`
+	Payload             any                                `yaml:"payload"`
+	CorrelationID       *matcher.DataExtractor             `yaml:"correlation_id"`
+	EmbedPublishTime    *bool                              `yaml:"embed_publish_time"`
+	RequestTemplate     MassExecRequestTemplate            `yaml:"request_template"`
+	ConnectTimeout      *string                            `yaml:"connect_timeout"`
+	Timeout             *string                            `yaml:"timeout"`
+	Data                []ExecRequestData                  `yaml:"data"`
+	Interval            *string                            `yaml:"interval"`
+	AwaitPrevResp       bool                               `yaml:"await_prev_response"`
+	SuccessBreak        []string                           `yaml:"success_break"`
+	Break               MassExecRequestBreak               `yaml:"break"`
+	RecordExcludeFilter MassExecRequestRecordExcludeFilter `yaml:"record_exclude_filter"`
+	Emit                []MassExecRequestEmit              `yaml:"emit"`
+}
+
+// MassExecMQTTPublisherTemplateFields represents the fields which can be overridden by the per-request template
+type MassExecMQTTPublisherTemplateFields struct {
+	ClientID *string `yaml:"client_id"`
+	Topic    *string `yaml:"topic"`
+	Payload  any     `yaml:"payload"`
+}
+
+// MassExecMQTTSubscribers represents the subscriber configuration for the MassExec runner.
+// Each connection subscribes to the topics, and the received messages are recorded with their latency,
+// which is measured from the publish time of the correlation ID recorded by the publishers of the same runner,
+// the publish time embedded in the message by the publisher, or the timestamp of the message.
+type MassExecMQTTSubscribers struct {
+	TargetID       *string                `yaml:"target_id"`
+	Connections    *int                   `yaml:"connections"`
+	ClientID       *string                `yaml:"client_id"`
+	Topics         []string               `yaml:"topics"`
+	QoS            *int                   `yaml:"qos"`
+	ConnectTimeout *string                `yaml:"connect_timeout"`
+	Timeout        *string                `yaml:"timeout"`
+	ResponseType   *string                `yaml:"response_type"`
+	CorrelationID  *matcher.DataExtractor `yaml:"correlation_id"`
+	Timestamp      *MassExecSSETimestamp  `yaml:"timestamp"`
+	ReadyEmit      []string               `yaml:"ready_emit"`
+	Data           []ExecRequestData      `yaml:"data"`
+	SuccessBreak   []string               `yaml:"success_break"`
+	Break          MassExecRequestBreak   `yaml:"break"`
+	Emit           []MassExecRequestEmit  `yaml:"emit"`
+}
+
+// ValidMassExecMQTT represents the valid MQTT configuration for the MassExec runner
+type ValidMassExecMQTT struct {
+	Subscribers *ValidMassExecMQTTSubscribers
+	Publishers  []ValidMassExecMQTTPublisher
+}
+
+// ValidMassExecMQTTPublisher represents the valid publisher configuration for the MassExec runner.
+// The acknowledgements are handled as the JSON responses with the client_id, topic, correlation_id and payload fields.
+// The publish time is embedded in the payload with the correlation ID unless embed_publish_time is false.
+type ValidMassExecMQTTPublisher struct {
+	ValidMassExecRequest
+	Config           mqttexec.Config
+	ClientID         string
+	Topic            string
+	QoS              mqttexec.QoS
+	Retain           bool
+	Payload          any
+	CorrelationID    *matcher.ValidDataExtractor
+	EmbedPublishTime bool
+}
+
+// ValidMassExecMQTTSubscribers represents the valid subscriber configuration for the MassExec runner
+type ValidMassExecMQTTSubscribers struct {
+	URL           string
+	Config        mqttexec.Config
+	Connections   int
+	ClientID      string
+	Topics        []string
+	QoS           mqttexec.QoS
+	ResponseType  httpexec.ResponseType
+	CorrelationID *matcher.ValidDataExtractor
+	Timestamp     *ValidMassExecSSETimestamp
+	ReadyEmit     []Event
+	Data          ValidExecRequestDataSlice
+	SuccessBreak  matcher.TerminateTypeAndParamsSlice
+	Break         ValidMassExecRequestBreak
+	Emit          []ValidMassExecRequestEmit
+	Tmpl          *template.Template
+	ReplaceData   map[string]any
+}
+
+// mqttTarget returns the client configuration of the mqtt target
+func mqttTarget(ctx context.Context, targetFactor TargetFactor, targetID *string) (mqttexec.Config, string, error) {
+	if targetID == nil {
+		return mqttexec.Config{}, "", fmt.Errorf("target_id is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *targetID)
+	if err != nil {
+		return mqttexec.Config{}, "", fmt.Errorf("failed to factorize target: %w", err)
+	}
+	if tg.Type != config.TargetTypeMQTT {
+		return mqttexec.Config{}, "", fmt.Errorf("target %s is not a mqtt target", *targetID)
+	}
+	cfg, err := mqttexec.ParseURL(tg.URL)
+	if err != nil {
+		return mqttexec.Config{}, "", fmt.Errorf("invalid url of target %s: %w", *targetID, err)
+	}
+	return cfg, mqttexec.RedactURL(tg.URL), nil
+}
+
+// validateMQTTQoS validates the QoS level, it is 0 if not set
+func validateMQTTQoS(qos *int) (mqttexec.QoS, error) {
+	if qos == nil {
+		return mqttexec.QoSAtMostOnce, nil
+	}
+	if *qos < int(mqttexec.QoSAtMostOnce) || *qos > int(mqttexec.QoSExactlyOnce) {
+		return 0, fmt.Errorf("invalid qos value: %d", *qos)
+	}
+	return mqttexec.QoS(*qos), nil
+}
+
+// validateMQTTTimeouts validates the connect_timeout and the timeout of the client
+func validateMQTTTimeouts(cfg *mqttexec.Config, connectTimeout, timeout *string) error {
+	var err error
+	if connectTimeout != nil {
+		if cfg.ConnectTimeout, err = time.ParseDuration(*connectTimeout); err != nil {
+			return fmt.Errorf("failed to parse connect_timeout: %w", err)
+		}
+	}
+	if timeout != nil {
+		if cfg.Timeout, err = time.ParseDuration(*timeout); err != nil {
+			return fmt.Errorf("failed to parse timeout: %w", err)
+		}
+	}
+	return nil
+}
+
+// mqttPayload converts the payload written in the runner file to the bytes,
+// the strings are sent as they are and the others are encoded in JSON
+func mqttPayload(payload any) ([]byte, error) {
+	switch p := payload.(type) {
+	case nil:
+		return nil, fmt.Errorf("payload is empty")
+	case string:
+		return []byte(p), nil
+	case []byte:
+		return p, nil
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	return b, nil
+}
+
+// mqttCorrelationID extracts the correlation ID from the message, false is returned if it is not found
+func mqttCorrelationID(extractor *matcher.ValidDataExtractor, message any) (string, bool) {
+	if extractor == nil || message == nil {
+		return "", false
+	}
+	id, err := extractor.Extract(message)
+	if err != nil || id == nil {
+		return "", false
+	}
+	return fmt.Sprint(id), true
+}
+
+// Validate validates the MassExecMQTT
+func (m MassExecMQTT) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecMQTT, error) {
+	var valid ValidMassExecMQTT
+	if m.Subscribers == nil && len(m.Publishers) == 0 {
+		return ValidMassExecMQTT{}, fmt.Errorf("subscribers or publishers is required")
+	}
+	if m.Subscribers != nil {
+		subscribers, err := m.Subscribers.Validate(
+			ctx,
+			log,
+			targetFactor,
+			newFieldTmpl(tmplSet, tmpl, replaceData, "mqtt", "subscribers", "client_id"),
+			replaceData,
+		)
+		if err != nil {
+			return ValidMassExecMQTT{}, fmt.Errorf("failed to validate subscribers: %w", err)
+		}
+		valid.Subscribers = &subscribers
+	}
+	for i, pub := range m.Publishers {
+		validPublisher, err := pub.Validate(
+			ctx,
+			log,
+			targetFactor,
+			tmplSet,
+			newFieldTmpl(tmplSet, tmpl, replaceData, "mqtt", "publishers", i),
+			replaceData,
+			i,
+		)
+		if err != nil {
+			return ValidMassExecMQTT{}, fmt.Errorf("failed to validate publishers[%d]: %w", i, err)
+		}
+		valid.Publishers = append(valid.Publishers, validPublisher)
+	}
+	return valid, nil
+}
+
+// Validate validates the MassExecMQTTPublisher,
+// the client ID is unique to the publisher if it is not set
+func (p MassExecMQTTPublisher) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+	index int,
+) (ValidMassExecMQTTPublisher, error) {
+	var valid ValidMassExecMQTTPublisher
+	var err error
+	if valid.Config, valid.URL, err = mqttTarget(ctx, targetFactor, p.TargetID); err != nil {
+		return ValidMassExecMQTTPublisher{}, err
+	}
+	if err := validateMQTTTimeouts(&valid.Config, p.ConnectTimeout, p.Timeout); err != nil {
+		return ValidMassExecMQTTPublisher{}, err
+	}
+	valid.Method = MQTTMethodPublish
+	if p.Topic == nil || *p.Topic == "" {
+		return ValidMassExecMQTTPublisher{}, fmt.Errorf("topic is required")
+	}
+	valid.Topic = *p.Topic
+	valid.ClientID = fmt.Sprintf("bloader-pub-%s-%d", utils.GenerateUniqueID(), index)
+	if p.ClientID != nil {
+		if *p.ClientID == "" {
+			return ValidMassExecMQTTPublisher{}, fmt.Errorf("client_id must not be empty")
+		}
+		valid.ClientID = *p.ClientID
+	}
+	if valid.QoS, err = validateMQTTQoS(p.QoS); err != nil {
+		return ValidMassExecMQTTPublisher{}, err
+	}
+	valid.Retain = p.Retain
+	valid.Payload = p.Payload
+	if valid.RequestTmpl, err = p.RequestTemplate.Validate(tmplSet); err != nil {
+		return ValidMassExecMQTTPublisher{}, fmt.Errorf("failed to validate request template: %w", err)
+	}
+	if valid.Payload == nil && valid.RequestTmpl == nil {
+		return ValidMassExecMQTTPublisher{}, fmt.Errorf("payload or request_template is required")
+	}
+	if p.CorrelationID != nil {
+		correlationID, err := p.CorrelationID.Validate()
+		if err != nil {
+			return ValidMassExecMQTTPublisher{}, fmt.Errorf("failed to validate correlation_id: %w", err)
+		}
+		valid.CorrelationID = &correlationID
+		valid.EmbedPublishTime = p.EmbedPublishTime == nil || *p.EmbedPublishTime
+	} else if p.EmbedPublishTime != nil && *p.EmbedPublishTime {
+		return ValidMassExecMQTTPublisher{}, fmt.Errorf("embed_publish_time requires correlation_id")
+	}
+	valid.Body = valid.Topic
+
+	valid.ResponseType = string(httpexec.ResponseTypeJSON)
+	handling := MassExecRequest{
+		Data:                p.Data,
+		Interval:            p.Interval,
+		AwaitPrevResp:       p.AwaitPrevResp,
+		SuccessBreak:        p.SuccessBreak,
+		Break:               p.Break,
+		RecordExcludeFilter: p.RecordExcludeFilter,
+		Emit:                p.Emit,
+	}
+	if err := handling.validateHandling(ctx, log, &valid.ValidMassExecRequest); err != nil {
+		return ValidMassExecMQTTPublisher{}, err
+	}
+	valid.Tmpl = tmpl
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// Validate validates the MassExecMQTTSubscribers,
+// the client IDs are unique to the connections if they are not set
+func (s MassExecMQTTSubscribers) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecMQTTSubscribers, error) {
+	var valid ValidMassExecMQTTSubscribers
+	var err error
+	if valid.Config, valid.URL, err = mqttTarget(ctx, targetFactor, s.TargetID); err != nil {
+		return ValidMassExecMQTTSubscribers{}, err
+	}
+	if err := validateMQTTTimeouts(&valid.Config, s.ConnectTimeout, s.Timeout); err != nil {
+		return ValidMassExecMQTTSubscribers{}, err
+	}
+	valid.Connections = DefaultMQTTConnections
+	if s.Connections != nil {
+		if *s.Connections <= 0 {
+			return ValidMassExecMQTTSubscribers{}, fmt.Errorf("connections must be greater than 0")
+		}
+		valid.Connections = *s.Connections
+	}
+	if s.ClientID != nil {
+		if *s.ClientID == "" {
+			return ValidMassExecMQTTSubscribers{}, fmt.Errorf("client_id must not be empty")
+		}
+		valid.ClientID = *s.ClientID
+	}
+	if len(s.Topics) == 0 {
+		return ValidMassExecMQTTSubscribers{}, fmt.Errorf("topics is required")
+	}
+	for i, topic := range s.Topics {
+		if topic == "" {
+			return ValidMassExecMQTTSubscribers{}, fmt.Errorf("topics[%d] must not be empty", i)
+		}
+	}
+	valid.Topics = s.Topics
+	if valid.QoS, err = validateMQTTQoS(s.QoS); err != nil {
+		return ValidMassExecMQTTSubscribers{}, err
+	}
+	if s.ResponseType == nil {
+		return ValidMassExecMQTTSubscribers{}, fmt.Errorf("response_type is required")
+	}
+	switch httpexec.ResponseType(*s.ResponseType) {
+	case httpexec.ResponseTypeJSON, httpexec.ResponseTypeXML, httpexec.ResponseTypeYAML,
+		httpexec.ResponseTypeText, httpexec.ResponseTypeHTML, httpexec.ResponseTypeDiscard:
+		valid.ResponseType = httpexec.ResponseType(*s.ResponseType)
+	default:
+		return ValidMassExecMQTTSubscribers{}, fmt.Errorf("invalid response_type value: %s", *s.ResponseType)
+	}
+	if s.CorrelationID != nil {
+		correlationID, err := s.CorrelationID.Validate()
+		if err != nil {
+			return ValidMassExecMQTTSubscribers{}, fmt.Errorf("failed to validate correlation_id: %w", err)
+		}
+		valid.CorrelationID = &correlationID
+	}
+	if s.Timestamp != nil {
+		timestamp, err := s.Timestamp.Validate()
+		if err != nil {
+			return ValidMassExecMQTTSubscribers{}, fmt.Errorf("failed to validate timestamp: %w", err)
+		}
+		valid.Timestamp = &timestamp
+	}
+	if valid.ReadyEmit, err = ValidateEmitEvents(s.ReadyEmit); err != nil {
+		return ValidMassExecMQTTSubscribers{}, fmt.Errorf("failed to validate ready_emit: %w", err)
+	}
+	for i, d := range s.Data {
+		validData, err := d.Validate()
+		if err != nil {
+			return ValidMassExecMQTTSubscribers{}, fmt.Errorf("failed to validate data[%d]: %w", i, err)
+		}
+		valid.Data = append(valid.Data, validData)
+	}
+	if valid.SuccessBreak, err = matcher.NewTerminateTypeAndParamsSliceFromStringSlice(s.SuccessBreak); err != nil {
+		return ValidMassExecMQTTSubscribers{}, fmt.Errorf("failed to parse success break: %w", err)
+	}
+	if valid.Break, err = s.Break.Validate(ctx, log); err != nil {
+		return ValidMassExecMQTTSubscribers{}, fmt.Errorf("failed to validate break: %w", err)
+	}
+	for i, e := range s.Emit {
+		validEmit, err := e.Validate(ctx, log)
+		if err != nil {
+			return ValidMassExecMQTTSubscribers{}, fmt.Errorf("failed to validate emit[%d]: %w", i, err)
+		}
+		valid.Emit = append(valid.Emit, validEmit)
+	}
+	valid.Tmpl = tmpl
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// renderClientID renders the client ID of the connection, it is available as .Dynamic.Connection
+func (s ValidMassExecMQTTSubscribers) renderClientID(prefix string, connection int) (string, error) {
+	if s.ClientID == "" {
+		return fmt.Sprintf("bloader-sub-%s-%d", prefix, connection), nil
+	}
+	if s.Tmpl == nil {
+		return s.ClientID, nil
+	}
+	replaceData := massReplaceData(s.ReplaceData, map[string]any{
+		"Connection": connection,
+	})
+	var buffer bytes.Buffer
+	if err := s.Tmpl.Execute(&buffer, replaceData); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	var massExec MassExec
+	if err := yaml.Unmarshal(buffer.Bytes(), &massExec); err != nil {
+		return "", fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if massExec.MQTT == nil || massExec.MQTT.Subscribers == nil || massExec.MQTT.Subscribers.ClientID == nil {
+		return "", fmt.Errorf("subscribers not found in rendered template")
+	}
+	return *massExec.MQTT.Subscribers.ClientID, nil
+}
+
+// MQTTPublishRequest represents the message of the publisher, it is rendered for each count.
+// The publish time is available as .Dynamic.PublishTime, so that it can be embedded in the payload.
+type MQTTPublishRequest struct {
+	ClientID         string
+	Topic            string
+	QoS              mqttexec.QoS
+	Retain           bool
+	Payload          any
+	CorrelationID    *matcher.ValidDataExtractor
+	EmbedPublishTime bool
+	Tmpl             *template.Template
+	RequestTmpl      *template.Template
+	ReplaceData      map[string]any
+	ReqIndex         int
+}
+
+// CreateMessage renders the message for the given count
+func (r MQTTPublishRequest) CreateMessage(_ context.Context, _ logger.Logger, count int) (mqttexec.PublishMessage, error) {
+	msg := mqttexec.PublishMessage{
+		ClientID: r.ClientID,
+		Topic:    r.Topic,
+		QoS:      r.QoS,
+		Retain:   r.Retain,
+	}
+	payload := r.Payload
+	if r.RequestTmpl != nil || r.Tmpl != nil {
+		replaceData := massReplaceData(r.ReplaceData, map[string]any{
+			"RequestLoopCount": count,
+			"PublishTime":      time.Now(),
+		})
+		var buffer bytes.Buffer
+		if r.RequestTmpl != nil {
+			if err := r.RequestTmpl.Execute(&buffer, replaceData); err != nil {
+				return mqttexec.PublishMessage{}, fmt.Errorf("failed to execute request template: %w", err)
+			}
+			var fields MassExecMQTTPublisherTemplateFields
+			if err := yaml.Unmarshal(buffer.Bytes(), &fields); err != nil {
+				return mqttexec.PublishMessage{}, fmt.Errorf("failed to unmarshal request template: %w", err)
+			}
+			if fields.ClientID != nil {
+				msg.ClientID = *fields.ClientID
+			}
+			if fields.Topic != nil {
+				msg.Topic = *fields.Topic
+			}
+			if fields.Payload != nil {
+				payload = fields.Payload
+			}
+		} else {
+			if err := r.Tmpl.Execute(&buffer, replaceData); err != nil {
+				return mqttexec.PublishMessage{}, fmt.Errorf("failed to execute template: %w", err)
+			}
+			var massExec MassExec
+			if err := yaml.Unmarshal(buffer.Bytes(), &massExec); err != nil {
+				return mqttexec.PublishMessage{}, fmt.Errorf("failed to unmarshal yaml: %w", err)
+			}
+			if massExec.MQTT == nil || r.ReqIndex >= len(massExec.MQTT.Publishers) {
+				return mqttexec.PublishMessage{}, fmt.Errorf("mqtt publisher[%d] not found in rendered template", r.ReqIndex)
+			}
+			pub := massExec.MQTT.Publishers[r.ReqIndex]
+			if pub.ClientID != nil {
+				msg.ClientID = *pub.ClientID
+			}
+			if pub.Topic != nil {
+				msg.Topic = *pub.Topic
+			}
+			payload = pub.Payload
+		}
+	}
+	if msg.ClientID == "" || msg.Topic == "" {
+		return mqttexec.PublishMessage{}, fmt.Errorf("client_id and topic must not be empty")
+	}
+	var err error
+	if msg.Payload, err = mqttPayload(payload); err != nil {
+		return mqttexec.PublishMessage{}, err
+	}
+	if r.CorrelationID != nil {
+		var message any
+		if err := json.Unmarshal(msg.Payload, &message); err == nil {
+			msg.CorrelationID, _ = mqttCorrelationID(r.CorrelationID, message)
+		}
+		msg.EmbedPublishTime = r.EmbedPublishTime
+	}
+	return msg, nil
+}
+
+var _ mqttexec.ExecReq = MQTTPublishRequest{}
+
+// MQTTMessageHeader is the header of the received messages file of the MQTT subscriber
+var MQTTMessageHeader = []string{
+	"Success",
+	"PublishDatetime",
+	"ReceivedDatetime",
+	"Count",
+	"Latency",
+	"Topic",
+	"CorrelationID",
+}
+
+// MQTTConnectionHeader is the header of the connections file of the MQTT subscribers
+var MQTTConnectionHeader = []string{
+	"Connection",
+	"ClientID",
+	"Success",
+	"ConnectDatetime",
+	"SubscribedDatetime",
+	"ConnectTime",
+}
+
+func (r ValidMassExec) runMQTT(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+) ([]MassExecResult, error) {
+	// the publish times of the correlation IDs are shared only in the runner
+	correlations := mqttexec.NewCorrelations()
+	var subResults []MassExecResult
+	var subErr error
+	var subWg sync.WaitGroup
+	if r.MQTT.Subscribers != nil {
+		ready := make(chan struct{})
+		subWg.Add(1)
+		go func() {
+			defer subWg.Done()
+			subResults, subErr = r.runMQTTSubscribers(ctx, log, outputRoot, eventCaster, correlations, ready)
+		}()
+		// the publishers start after the subscriptions, so that the first messages are received
+		select {
+		case <-ctx.Done():
+		case <-ready:
+		}
+	}
+
+	var pubResults []MassExecResult
+	var pubErr error
+	if len(r.MQTT.Publishers) > 0 {
+		pools := make(map[mqttexec.Config]*mqttexec.Pool)
+		threads := make([]massExecThread, len(r.MQTT.Publishers))
+		for i, pub := range r.MQTT.Publishers {
+			pool, ok := pools[pub.Config]
+			if !ok {
+				pool = mqttexec.NewPool(pub.Config)
+				pools[pub.Config] = pool
+			}
+			resChan := make(chan httpexec.ResponseContent)
+			threads[i] = massExecThread{
+				request: pub.ValidMassExecRequest,
+				result: MassExecResult{
+					RequestIndex: i,
+					Method:       pub.Method,
+					URL:          pub.URL,
+				},
+				executor: mqttexec.MassRequestContent[MQTTPublishRequest]{
+					MassDriver: pub.massDriver(resChan),
+					Pool:       pool,
+					Req: MQTTPublishRequest{
+						ClientID:         pub.ClientID,
+						Topic:            pub.Topic,
+						QoS:              pub.QoS,
+						Retain:           pub.Retain,
+						Payload:          pub.Payload,
+						CorrelationID:    pub.CorrelationID,
+						EmbedPublishTime: pub.EmbedPublishTime,
+						Tmpl:             pub.Tmpl,
+						RequestTmpl:      pub.RequestTmpl,
+						ReplaceData:      pub.ReplaceData,
+						ReqIndex:         i,
+					},
+					Correlations: correlations,
+				},
+				resChan: resChan,
+				columns: mqttexec.ColumnHeader,
+			}
+		}
+		pubResults, pubErr = r.runThreads(ctx, log, outputRoot, eventCaster, threads)
+		for _, pool := range pools {
+			pool.Close()
+		}
+	}
+
+	subWg.Wait()
+	results := append(pubResults, subResults...)
+	if pubErr != nil {
+		return results, pubErr
+	}
+	return results, subErr
+}
+
+// runMQTTSubscribers runs the subscriber connections until they terminate by the breaks,
+// ready is closed when all the connections are subscribed or failed
+func (r ValidMassExec) runMQTTSubscribers(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+	correlations *mqttexec.Correlations,
+	ready chan<- struct{},
+) ([]MassExecResult, error) {
+	sub := r.MQTT.Subscribers
+	uniqueID := utils.GenerateUniqueID()
+	uniqueName := fmt.Sprintf("%s/%s", outputRoot, uniqueID)
+	var connMu sync.Mutex
+	connWriters := make([]output.HTTPDataWrite, 0, len(r.Output))
+	for _, o := range r.Output {
+		writer, closer, err := o.HTTPDataWriteFactory(
+			ctx,
+			log,
+			true,
+			fmt.Sprintf("%s_connections", uniqueName),
+			MQTTConnectionHeader,
+		)
+		if err != nil {
+			close(ready)
+			return nil, fmt.Errorf("failed to create writer: %w", err)
+		}
+		defer func() {
+			if err := closer(); err != nil {
+				log.Error(ctx, "failed to close writer",
+					logger.Value("error", err))
+			}
+		}()
+		connWriters = append(connWriters, writer)
+	}
+	// the writers of the outputs are not safe for the concurrent use
+	writeConnection := func(ctx context.Context, log logger.Logger, data []string) error {
+		connMu.Lock()
+		defer connMu.Unlock()
+		for _, w := range connWriters {
+			if err := w(ctx, log, data); err != nil {
+				return fmt.Errorf("failed to write data: %w", err)
+			}
+		}
+		return nil
+	}
+
+	var readyWg sync.WaitGroup
+	readyWg.Add(sub.Connections)
+	go func() {
+		readyWg.Wait()
+		for _, event := range sub.ReadyEmit {
+			if eventCaster == nil {
+				break
+			}
+			log.Debug(ctx, "emitting event",
+				logger.Value("event", event))
+			if err := eventCaster.CastEvent(ctx, event); err != nil {
+				log.Error(ctx, "failed to cast event",
+					logger.Value("event", event), logger.Value("error", err))
+			}
+		}
+		close(ready)
+	}()
+
+	results := make([]MassExecResult, sub.Connections)
+	stats := make([]sseStats, sub.Connections)
+	var wg sync.WaitGroup
+	var atomicErr atomic.Pointer[syncError]
+	startTime := time.Now()
+	for i := 0; i < sub.Connections; i++ {
+		writers := make([]output.HTTPDataWrite, 0, len(r.Output))
+		var writeCloser []output.Close
+		for _, o := range r.Output {
+			writer, closer, err := o.HTTPDataWriteFactory(
+				ctx,
+				log,
+				true,
+				fmt.Sprintf("%s_%d", uniqueName, i),
+				append(MQTTMessageHeader, sub.Data.ExtractHeader()...),
+			)
+			if err != nil {
+				// the connections not started are regarded as ready
+				for j := i; j < sub.Connections; j++ {
+					readyWg.Done()
+				}
+				return nil, fmt.Errorf("failed to create writer: %w", err)
+			}
+			writeCloser = append(writeCloser, closer)
+			writers = append(writers, writer)
+		}
+		emitter := NewRequestEventEmitter(eventCaster, sub.Emit)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				for _, c := range writeCloser {
+					if err := c(); err != nil {
+						log.Error(ctx, "failed to close writer",
+							logger.Value("error", err), logger.Value("id", i))
+					}
+				}
+			}()
+			var readyOnce sync.Once
+			onReady := func() {
+				readyOnce.Do(readyWg.Done)
+			}
+			defer onReady()
+			result, err := sub.runConnection(
+				ctx, log, i, uniqueID, &stats[i], correlations, writers, writeConnection, emitter, onReady)
+			results[i] = result
+			if err != nil {
+				atomicErr.Store(&syncError{Err: err})
+				log.Error(ctx, "failed to execute",
+					logger.Value("error", err), logger.Value("id", i))
+			}
+		}(i)
+	}
+	wg.Wait()
+	duration := time.Since(startTime)
+
+	// the context may be canceled, so the results are written with the parent context
+	writeCtx := context.WithoutCancel(ctx)
+	if err := writeSSESummary(writeCtx, log, r.Output, uniqueName, stats, duration); err != nil {
+		return results, err
+	}
+	if err := writeMassExecResults(writeCtx, log, r.Output, uniqueName, results); err != nil {
+		return results, err
+	}
+
+	if syncErr := atomicErr.Load(); syncErr != nil {
+		log.Error(ctx, "failed to find error",
+			logger.Value("error", syncErr.Err))
+		return results, syncErr.Err
+	}
+	return results, nil
+}
+
+// runConnection runs the subscriber connection until it terminates by the break
+func (s ValidMassExecMQTTSubscribers) runConnection(
+	ctx context.Context,
+	log logger.Logger,
+	id int,
+	uniqueID string,
+	stats *sseStats,
+	correlations *mqttexec.Correlations,
+	writers []output.HTTPDataWrite,
+	writeConnection func(ctx context.Context, log logger.Logger, data []string) error,
+	emitter *RequestEventEmitter,
+	onReady func(),
+) (MassExecResult, error) {
+	result := MassExecResult{
+		RequestIndex: id,
+		Method:       MQTTMethodSubscribe,
+		URL:          s.URL,
+	}
+	var counts TerminateCounts
+	finish := func(termType matcher.TerminateType, param string) (MassExecResult, error) {
+		log.Info(ctx, "Execute End For Break",
+			logger.Value("ExecuteID", id))
+		emitter.OnBreak(ctx, log, termType, param)
+		result.TerminateType = termType
+		result.MatchedID = param
+		result.Counts = counts
+		result.Success = s.SuccessBreak.Match(termType, param)
+		result.EndedAt = time.Now()
+		if result.Success {
+			log.Info(ctx, "Execute End For Success Break", logger.Value("ExecuteID", id))
+			return result, nil
+		}
+		if termType == matcher.TerminateTypeByContext {
+			log.Debug(ctx, "execute End For Context", logger.Value("ExecuteID", id))
+			return result, nil
+		}
+		return result, fmt.Errorf("execute End For Fail Break: %v(%v)", termType, param)
+	}
+
+	log.Info(ctx, "Execute Start",
+		logger.Value("ExecutorID", id))
+	clientID, err := s.renderClientID(uniqueID, id)
+	if err != nil {
+		log.Error(ctx, "failed to render client_id",
+			logger.Value("error", err), logger.Value("id", id))
+		return finish(matcher.TerminateTypeByCreateRequestError, "")
+	}
+	connectTime := time.Now()
+	client, connectDuration, err := mqttexec.Connect(ctx, s.Config, clientID)
+	var messages <-chan mqttexec.Message
+	if err == nil {
+		defer client.Close()
+		messages, err = client.Subscribe(ctx, s.Topics, s.QoS)
+	}
+	subscribedTime := time.Now()
+	onReady()
+	stats.connected = err == nil
+	stats.connectTime = connectDuration.Milliseconds()
+	if writeErr := writeConnection(ctx, log, []string{
+		strconv.Itoa(id),
+		clientID,
+		strconv.FormatBool(err == nil),
+		connectTime.Format(time.RFC3339Nano),
+		subscribedTime.Format(time.RFC3339Nano),
+		strconv.FormatInt(connectDuration.Milliseconds(), 10),
+	}); writeErr != nil {
+		log.Error(ctx, "failed to write connection",
+			logger.Value("error", writeErr), logger.Value("id", id))
+		if s.Break.WriteError {
+			return finish(matcher.TerminateTypeByWriteError, "")
+		}
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return finish(matcher.TerminateTypeByContext, "")
+		}
+		log.Error(ctx, "failed to subscribe",
+			logger.Value("error", err), logger.Value("id", id))
+		return finish(matcher.TerminateTypeBySystemError, "")
+	}
+
+	var timeout <-chan time.Time
+	if s.Break.Time.Enabled {
+		timer := time.NewTimer(s.Break.Time.Time)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	needsBody := len(s.Data) > 0 || s.CorrelationID != nil || s.Timestamp != nil || s.Break.ParseError ||
+		s.Break.ResponseBodyEnabled || len(s.Emit) > 0
+	for {
+		select {
+		case <-ctx.Done():
+			return finish(matcher.TerminateTypeByContext, "")
+		case <-timeout:
+			return finish(matcher.TerminateTypeByTimeout, "")
+		case err := <-client.Lost():
+			if ctx.Err() != nil {
+				return finish(matcher.TerminateTypeByContext, "")
+			}
+			log.Error(ctx, "connection lost",
+				logger.Value("error", err), logger.Value("id", id))
+			return finish(matcher.TerminateTypeBySystemError, "")
+		case msg := <-messages:
+			count := int(stats.received)
+			stats.received++
+			counts.Count++
+			body, parseErr := httpexec.ReadResponseBody(bytes.NewReader(msg.Payload), s.ResponseType, 0, needsBody)
+			counts.BodyBytes += body.BodyBytes
+			if parseErr != nil {
+				counts.FailureCount++
+				log.Error(ctx, "failed to parse message",
+					logger.Value("error", parseErr), logger.Value("id", id))
+			} else {
+				counts.SuccessCount++
+			}
+
+			// the publish time is found if the publisher runs in the same runner,
+			// otherwise the publish time embedded with the correlation ID or the timestamp of the message is used
+			var publishDatetime, latency, correlationID string
+			var publishTime time.Time
+			var found bool
+			if cid, ok := mqttCorrelationID(s.CorrelationID, body.Res); ok {
+				correlationID = cid
+				if publishTime, found = correlations.Lookup(cid); !found {
+					publishTime, found = mqttexec.EmbeddedPublishTime(body.Res)
+				}
+			}
+			if !found && s.Timestamp != nil && parseErr == nil {
+				ts, err := s.Timestamp.Extract(body.Res)
+				if err != nil {
+					log.Warn(ctx, "failed to extract timestamp",
+						logger.Value("error", err), logger.Value("id", id))
+				} else {
+					publishTime, found = ts, true
+				}
+			}
+			if found {
+				l := msg.ReceivedTime.Sub(publishTime)
+				stats.latencySum += l
+				stats.latencySize++
+				stats.latencyMax = max(stats.latencyMax, l)
+				publishDatetime = publishTime.Format(time.RFC3339Nano)
+				latency = strconv.FormatInt(l.Milliseconds(), 10)
+			}
+			emitter.OnResponse(ctx, log, count, body.Res)
+
+			row := []string{
+				strconv.FormatBool(parseErr == nil),
+				publishDatetime,
+				msg.ReceivedTime.Format(time.RFC3339Nano),
+				strconv.Itoa(count),
+				latency,
+				msg.Topic,
+				correlationID,
+			}
+			writeErr := func() error {
+				for _, d := range s.Data {
+					result, err := d.Extractor.Extract(body.Res)
+					if err != nil {
+						return fmt.Errorf("failed to extract data: %w", err)
+					}
+					row = append(row, fmt.Sprint(result))
+				}
+				for _, writer := range writers {
+					if err := writer(ctx, log, row); err != nil {
+						return fmt.Errorf("failed to write data: %w", err)
+					}
+				}
+				return nil
+			}()
+			if writeErr != nil {
+				log.Error(ctx, "failed to write data",
+					logger.Value("error", writeErr), logger.Value("id", id))
+				if s.Break.WriteError {
+					return finish(matcher.TerminateTypeByWriteError, "")
+				}
+			}
+
+			if parseErr != nil && s.Break.ParseError {
+				return finish(matcher.TerminateTypeByParseResponseError, "")
+			}
+			if s.Break.ResponseBodyEnabled {
+				matchID, isMatch, err := s.Break.ResponseBodyMatcher(body.Res)
+				if err != nil {
+					log.Error(ctx, "failed to match response body",
+						logger.Value("error", err), logger.Value("id", id))
+					return finish(matcher.TerminateTypeByResponseBodyBreakFilterError, matchID)
+				}
+				if isMatch {
+					return finish(matcher.TerminateTypeByResponseBody, matchID)
+				}
+			}
+			if s.Break.Count.Enabled && int(stats.received) >= s.Break.Count.Count {
+				return finish(matcher.TerminateTypeByCount, "")
+			}
+		}
+	}
+}
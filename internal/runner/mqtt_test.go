@@ -0,0 +1,443 @@
+package runner_test
+
+import (
+	"encoding/json"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.mqtt.golang/packets"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/mqttexec"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// mqttBrokerConn represents the client connection of the embedded broker
+type mqttBrokerConn struct {
+	conn    net.Conn
+	mu      sync.Mutex
+	nextID  uint16
+	filters map[string]byte
+}
+
+func (c *mqttBrokerConn) write(p packets.ControlPacket) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = p.Write(c.conn)
+}
+
+// mqttTopicMatch reports whether the topic matches the filter with the + and # wildcards
+func mqttTopicMatch(filter, topic string) bool {
+	f := strings.Split(filter, "/")
+	t := strings.Split(topic, "/")
+	for i, level := range f {
+		if level == "#" {
+			return true
+		}
+		if i >= len(t) || (level != "+" && level != t[i]) {
+			return false
+		}
+	}
+	return len(f) == len(t)
+}
+
+// newMQTTBroker starts the embedded MQTT 3.1.1 broker, which acknowledges the QoS of the publishers
+// and delivers the messages to the subscribers with QoS 0 or 1
+func newMQTTBroker(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	var mu sync.Mutex
+	conns := make(map[*mqttBrokerConn]struct{})
+	route := func(p *packets.PublishPacket) {
+		mu.Lock()
+		defer mu.Unlock()
+		for c := range conns {
+			for filter, qos := range c.filters {
+				if !mqttTopicMatch(filter, p.TopicName) {
+					continue
+				}
+				out := packets.NewControlPacket(packets.Publish).(*packets.PublishPacket)
+				out.TopicName = p.TopicName
+				out.Payload = p.Payload
+				out.Qos = min(p.Qos, qos, 1)
+				if out.Qos > 0 {
+					c.nextID++
+					out.MessageID = c.nextID
+				}
+				c.write(out)
+				break
+			}
+		}
+	}
+	serve := func(c *mqttBrokerConn) {
+		defer func() {
+			mu.Lock()
+			delete(conns, c)
+			mu.Unlock()
+			_ = c.conn.Close()
+		}()
+		for {
+			cp, err := packets.ReadPacket(c.conn)
+			if err != nil {
+				return
+			}
+			switch p := cp.(type) {
+			case *packets.ConnectPacket:
+				c.write(packets.NewControlPacket(packets.Connack))
+			case *packets.SubscribePacket:
+				mu.Lock()
+				for i, filter := range p.Topics {
+					c.filters[filter] = p.Qoss[i]
+				}
+				mu.Unlock()
+				ack := packets.NewControlPacket(packets.Suback).(*packets.SubackPacket)
+				ack.MessageID = p.MessageID
+				ack.ReturnCodes = p.Qoss
+				c.write(ack)
+			case *packets.PublishPacket:
+				switch p.Qos {
+				case 1:
+					ack := packets.NewControlPacket(packets.Puback).(*packets.PubackPacket)
+					ack.MessageID = p.MessageID
+					c.write(ack)
+				case 2:
+					rec := packets.NewControlPacket(packets.Pubrec).(*packets.PubrecPacket)
+					rec.MessageID = p.MessageID
+					c.write(rec)
+				}
+				route(p)
+			case *packets.PubrelPacket:
+				comp := packets.NewControlPacket(packets.Pubcomp).(*packets.PubcompPacket)
+				comp.MessageID = p.MessageID
+				c.write(comp)
+			case *packets.PingreqPacket:
+				c.write(packets.NewControlPacket(packets.Pingresp))
+			case *packets.DisconnectPacket:
+				return
+			}
+		}
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			c := &mqttBrokerConn{conn: conn, filters: make(map[string]byte)}
+			mu.Lock()
+			conns[c] = struct{}{}
+			mu.Unlock()
+			go serve(c)
+		}
+	}()
+	return ln
+}
+
+// rowsWithColumn returns the rows of the outputs whose header has the column
+func rowsWithColumn(out *memoryOutput, column string) [][][]string {
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	var found [][][]string
+	for _, rows := range out.rows {
+		if len(rows) > 0 && slices.Contains(rows[0], column) {
+			found = append(found, rows)
+		}
+	}
+	return found
+}
+
+// TestMassExecMQTT tests the MQTT publishers and subscribers against the embedded broker.
+func TestMassExecMQTT(t *testing.T) {
+	broker := newMQTTBroker(t)
+	targets := target.Container{
+		"broker": {Type: config.TargetTypeMQTT, URL: "mqtt://" + broker.Addr().String()},
+	}
+
+	t.Run("Correlation", func(tt *testing.T) {
+		out, results := runMassExec(tt, targets, "mqtt", `
+type: mqtt
+output:
+  enabled: true
+  ids: ["memory"]
+mqtt:
+  subscribers:
+    target_id: broker
+    connections: 2
+    client_id: "sub-{{ .Dynamic.Connection }}"
+    topics: ["devices/+/telemetry"]
+    qos: 1
+    response_type: json
+    correlation_id:
+      type: jmesPath
+      jmes_path: id
+    data:
+      - key: Device
+        extractor:
+          type: jmesPath
+          jmes_path: device
+    success_break:
+      - count
+    break:
+      count: 3
+      time: 5s
+  publishers:
+    - target_id: broker
+      client_id: "dev-{{ .Dynamic.RequestLoopCount }}"
+      topic: "devices/d{{ .Dynamic.RequestLoopCount }}/telemetry"
+      qos: 1
+      payload: '{"id": "m{{ .Dynamic.RequestLoopCount }}", "device": "d{{ .Dynamic.RequestLoopCount }}"}'
+      correlation_id:
+        type: jmesPath
+        jmes_path: id
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 3
+`)
+		if len(results) != 3 {
+			tt.Fatalf("expected 1 publisher and 2 subscriber results, got %v", results)
+		}
+		for _, result := range results {
+			if !result.Success {
+				tt.Errorf("expected success, got %+v", result)
+			}
+		}
+		published := rowsWithColumn(out, "ClientID")
+		if len(published) != 2 {
+			tt.Fatalf("expected the publisher and the connections files, got %d", len(published))
+		}
+		for _, rows := range published {
+			if rows[0][0] != "Success" {
+				continue
+			}
+			if len(rows) != 4 {
+				tt.Fatalf("expected header and 3 rows, got %v", rows)
+			}
+			for _, row := range rows[1:] {
+				if row[0] != "true" || row[6] != "dev-"+row[3] || row[8] != "m"+row[3] {
+					tt.Errorf("expected acknowledged publish, got %v", row)
+				}
+			}
+		}
+		received := rowsWithColumn(out, "Latency")
+		if len(received) != 2 {
+			tt.Fatalf("expected 2 subscriber files, got %d", len(received))
+		}
+		for _, rows := range received {
+			if len(rows) != 4 {
+				tt.Fatalf("expected header and 3 rows, got %v", rows)
+			}
+			for _, row := range rows[1:] {
+				if row[0] != "true" || row[1] == "" || row[4] == "" || row[6] != "m"+row[7][1:] {
+					tt.Errorf("expected correlated row, got %v", row)
+				}
+			}
+		}
+	})
+
+	t.Run("EmbeddedPublishTime", func(tt *testing.T) {
+		// the subscriber runs in another runner, so the publish time is read from the message
+		type subscribed struct {
+			out     *memoryOutput
+			results []runner.MassExecResult
+			err     error
+		}
+		done := make(chan subscribed, 1)
+		go func() {
+			out, results, err := execMassExec(tt, tt.TempDir(), targets, "mqtt", `
+type: mqtt
+output:
+  enabled: true
+  ids: ["memory"]
+mqtt:
+  subscribers:
+    target_id: broker
+    topics: ["orders/#"]
+    qos: 1
+    response_type: json
+    correlation_id:
+      type: jmesPath
+      jmes_path: id
+    success_break:
+      - count
+    break:
+      count: 2
+      time: 5s
+`)
+			done <- subscribed{out: out, results: results, err: err}
+		}()
+		// the messages are published until the subscriber is subscribed
+		_, results := runMassExec(tt, targets, "mqtt", `
+type: mqtt
+output:
+  enabled: false
+mqtt:
+  publishers:
+    - target_id: broker
+      topic: "orders/o{{ .Dynamic.RequestLoopCount }}"
+      qos: 1
+      payload: '{"id": "o{{ .Dynamic.RequestLoopCount }}"}'
+      correlation_id:
+        type: jmesPath
+        jmes_path: id
+      interval: 100ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 10
+`)
+		if len(results) != 1 || !results[0].Success {
+			tt.Fatalf("expected the publisher to succeed, got %v", results)
+		}
+		sub := <-done
+		if sub.err != nil {
+			tt.Fatalf("failed to run the subscriber: %v", sub.err)
+		}
+		if len(sub.results) != 1 || !sub.results[0].Success {
+			tt.Fatalf("expected the subscriber to succeed, got %v", sub.results)
+		}
+		received := rowsWithColumn(sub.out, "Latency")
+		if len(received) != 1 || len(received[0]) != 3 {
+			tt.Fatalf("expected header and 2 rows, got %v", received)
+		}
+		for _, row := range received[0][1:] {
+			if row[1] == "" || row[4] == "" || row[6] != "o"+strings.TrimPrefix(row[5], "orders/o") {
+				tt.Errorf("expected latency from the embedded publish time, got %v", row)
+			}
+		}
+	})
+
+	t.Run("Timestamp", func(tt *testing.T) {
+		// the publisher has no correlation ID, as if it ran on another slave
+		out, results := runMassExec(tt, targets, "mqtt", `
+type: mqtt
+output:
+  enabled: true
+  ids: ["memory"]
+mqtt:
+  subscribers:
+    target_id: broker
+    topics: ["metrics/#"]
+    qos: 2
+    response_type: json
+    timestamp:
+      extractor:
+        type: jmesPath
+        jmes_path: ts
+      format: unixNano
+    success_break:
+      - count
+    break:
+      count: 2
+      time: 5s
+  publishers:
+    - target_id: broker
+      topic: "metrics/cpu"
+      qos: 2
+      payload: '{"ts": {{ if .Dynamic.PublishTime }}{{ .Dynamic.PublishTime.UnixNano }}{{ else }}0{{ end }}}'
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 2
+`)
+		if len(results) != 2 || !results[0].Success || !results[1].Success {
+			tt.Fatalf("expected success, got %v", results)
+		}
+		if results[0].Method != runner.MQTTMethodPublish || results[1].Method != runner.MQTTMethodSubscribe {
+			tt.Errorf("expected the publisher then the subscriber, got %v", results)
+		}
+		received := rowsWithColumn(out, "Latency")
+		if len(received) != 1 || len(received[0]) != 3 {
+			tt.Fatalf("expected header and 2 rows, got %v", received)
+		}
+		for _, row := range received[0][1:] {
+			if row[1] == "" || row[4] == "" || row[5] != "metrics/cpu" || row[6] != "" {
+				tt.Errorf("expected latency from timestamp, got %v", row)
+			}
+		}
+	})
+}
+
+// TestMQTTCorrelations tests that the publish times are held only in their scopes and they are bounded.
+func TestMQTTCorrelations(t *testing.T) {
+	start := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	t.Run("Scopes", func(tt *testing.T) {
+		first := mqttexec.NewCorrelations()
+		second := mqttexec.NewCorrelations()
+		first.Record("m1", start)
+		second.Record("m2", start.Add(time.Second))
+		if got, ok := first.Lookup("m1"); !ok || !got.Equal(start) {
+			tt.Errorf("expected m1 in the first scope, got %v %v", got, ok)
+		}
+		if _, ok := first.Lookup("m2"); ok {
+			tt.Errorf("expected m2 not to be in the first scope")
+		}
+		if _, ok := second.Lookup("m1"); ok {
+			tt.Errorf("expected m1 not to be in the second scope")
+		}
+		// the entry is kept for the other subscribers
+		if _, ok := second.Lookup("m2"); !ok {
+			tt.Errorf("expected m2 in the second scope")
+		}
+		if _, ok := second.Lookup("m2"); !ok {
+			tt.Errorf("expected m2 to be kept in the second scope")
+		}
+	})
+
+	t.Run("TTL", func(tt *testing.T) {
+		c := mqttexec.NewCorrelations()
+		c.Record("old", start)
+		c.Record("new", start.Add(mqttexec.CorrelationTTL+time.Second))
+		if _, ok := c.Lookup("old"); ok {
+			tt.Errorf("expected the expired entry to be dropped")
+		}
+		if _, ok := c.Lookup("new"); !ok {
+			tt.Errorf("expected the new entry to be kept")
+		}
+	})
+
+	t.Run("Limit", func(tt *testing.T) {
+		c := mqttexec.NewCorrelations()
+		for i := range mqttexec.CorrelationLimit + 1 {
+			c.Record(strconv.Itoa(i), start)
+		}
+		if _, ok := c.Lookup("0"); ok {
+			tt.Errorf("expected the oldest entry to be dropped")
+		}
+		for _, id := range []string{"1", strconv.Itoa(mqttexec.CorrelationLimit)} {
+			if _, ok := c.Lookup(id); !ok {
+				tt.Errorf("expected %s to be kept", id)
+			}
+		}
+	})
+
+	t.Run("Embed", func(tt *testing.T) {
+		payload := mqttexec.EmbedPublishTime([]byte(`{"id": "m1"}`), start)
+		var message any
+		if err := json.Unmarshal(payload, &message); err != nil {
+			tt.Fatalf("failed to unmarshal %s: %v", payload, err)
+		}
+		if got, ok := mqttexec.EmbeddedPublishTime(message); !ok || !got.Equal(start) {
+			tt.Errorf("expected the embedded publish time, got %v %v in %s", got, ok, payload)
+		}
+		if got := mqttexec.EmbedPublishTime([]byte("plain"), start); string(got) != "plain" {
+			tt.Errorf("expected the payload other than the object to be kept, got %s", got)
+		}
+	})
+}
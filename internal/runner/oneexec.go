@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/cresplanex/bloader/internal/auth"
 	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/executor/sqlexec"
 	"github.com/cresplanex/bloader/internal/logger"
 	"github.com/cresplanex/bloader/internal/output"
 	"github.com/cresplanex/bloader/internal/utils"
@@ -19,6 +21,8 @@ type OneExecType string
 const (
 	// OneExecTypeHTTP represents the HTTP type
 	OneExecTypeHTTP OneExecType = "http"
+	// OneExecTypeSQL represents the SQL database type
+	OneExecTypeSQL OneExecType = "sql"
 )
 
 // OneExec represents the OneExec runner
@@ -27,6 +31,8 @@ type OneExec struct {
 	Output  OneExecOutput   `yaml:"output"`
 	Auth    OneExecAuth     `yaml:"auth"`
 	Request *OneExecRequest `yaml:"request"`
+	Cookies Cookies         `yaml:"cookies"`
+	Emit    []string        `yaml:"emit"`
 }
 
 // ValidOneExec represents the valid OneExec runner
@@ -35,6 +41,8 @@ type ValidOneExec struct {
 	Output  []output.Output
 	Auth    auth.SetAuthor
 	Request ValidOneExecRequest
+	Cookies ValidCookies
+	Emit    []Event
 }
 
 // Validate validates the OneExec
@@ -49,7 +57,7 @@ func (r OneExec) Validate(
 		return ValidOneExec{}, fmt.Errorf("type is required")
 	}
 	switch OneExecType(*r.Type) {
-	case OneExecTypeHTTP:
+	case OneExecTypeHTTP, OneExecTypeSQL:
 		oneExecType = OneExecType(*r.Type)
 	default:
 		return ValidOneExec{}, fmt.Errorf("invalid type value: %s", *r.Type)
@@ -67,15 +75,30 @@ func (r OneExec) Validate(
 	if r.Request == nil {
 		return ValidOneExec{}, fmt.Errorf("request is required")
 	}
-	validRequest, err := r.Request.Validate(ctx, targetFactor)
+	var validRequest ValidOneExecRequest
+	if oneExecType == OneExecTypeSQL {
+		validRequest, err = r.Request.ValidateSQL(ctx, targetFactor)
+	} else {
+		validRequest, err = r.Request.Validate(ctx, targetFactor)
+	}
 	if err != nil {
 		return ValidOneExec{}, fmt.Errorf("failed to validate request: %w", err)
 	}
+	validCookies, err := r.Cookies.Validate()
+	if err != nil {
+		return ValidOneExec{}, fmt.Errorf("failed to validate cookies: %w", err)
+	}
+	validEmit, err := ValidateEmitEvents(r.Emit)
+	if err != nil {
+		return ValidOneExec{}, err
+	}
 	return ValidOneExec{
 		Type:    oneExecType,
 		Output:  validOutput,
 		Auth:    validAuth,
 		Request: validRequest,
+		Cookies: validCookies,
+		Emit:    validEmit,
 	}, nil
 }
 
@@ -142,6 +165,11 @@ type OneExecRequest struct {
 	BodyType      *string                `yaml:"body_type"`
 	Body          any                    `yaml:"body"`
 	ResponseType  *string                `yaml:"response_type"`
+	MaxBodyBytes  *int64                 `yaml:"max_body_bytes"`
+	Mode          *string                `yaml:"mode"`
+	Query         *string                `yaml:"query"`
+	Args          []any                  `yaml:"args"`
+	Timeout       *string                `yaml:"timeout"`
 	Data          []ExecRequestData      `yaml:"data"`
 	MemoryData    []ExecRequestData      `yaml:"memory_data"`
 	StoreData     []ExecRequestStoreData `yaml:"store_data"`
@@ -157,9 +185,13 @@ type ValidOneExecRequest struct {
 	BodyType      HTTPRequestBodyType
 	Body          any
 	ResponseType  string
+	MaxBodyBytes  int64
 	Data          ValidExecRequestDataSlice
 	MemoryData    ValidExecRequestDataSlice
 	StoreData     []ValidExecRequestStoreData
+	Transport     httpexec.TransportConfig
+	SQL           sqlexec.Config
+	Query         sqlexec.Query
 }
 
 // Validate validates the OneExecRequest
@@ -179,6 +211,7 @@ func (r OneExecRequest) Validate(ctx context.Context, targetFactor TargetFactor)
 	}
 	urlRoot = tg.URL
 	valid.URL = fmt.Sprintf("%s%s", urlRoot, *r.Endpoint)
+	valid.Transport = httpTransport(tg)
 	if r.Method == nil {
 		return ValidOneExecRequest{}, fmt.Errorf("method is required")
 	}
@@ -194,6 +227,11 @@ func (r OneExecRequest) Validate(ctx context.Context, targetFactor TargetFactor)
 		switch HTTPRequestBodyType(*r.BodyType) {
 		case HTTPRequestBodyTypeJSON, HTTPRequestBodyTypeForm, HTTPRequestBodyTypeMultipart:
 			valid.BodyType = HTTPRequestBodyTypeJSON
+		case HTTPRequestBodyTypeGraphQL:
+			if err := validateGraphQLBody(r.Body); err != nil {
+				return ValidOneExecRequest{}, err
+			}
+			valid.BodyType = HTTPRequestBodyTypeGraphQL
 		default:
 			return ValidOneExecRequest{}, fmt.Errorf("invalid body_type value: %s", *r.BodyType)
 		}
@@ -202,28 +240,61 @@ func (r OneExecRequest) Validate(ctx context.Context, targetFactor TargetFactor)
 		return ValidOneExecRequest{}, fmt.Errorf("response_type is required")
 	}
 	valid.ResponseType = *r.ResponseType
+	if valid.MaxBodyBytes, err = validateMaxBodyBytes(r.MaxBodyBytes); err != nil {
+		return ValidOneExecRequest{}, err
+	}
+	if err := r.validateExtraction(&valid); err != nil {
+		return ValidOneExecRequest{}, err
+	}
+	return valid, nil
+}
+
+// ValidateSQL validates the OneExecRequest of the sql type,
+// the extractors refer the returned rows as the JSON array of the objects keyed by the column names
+func (r OneExecRequest) ValidateSQL(ctx context.Context, targetFactor TargetFactor) (ValidOneExecRequest, error) {
+	var valid ValidOneExecRequest
+	var err error
+	if valid.SQL, err = sqlTarget(ctx, targetFactor, r.TargetID); err != nil {
+		return ValidOneExecRequest{}, err
+	}
+	if valid.Query, err = validateSQLStatement(r.Mode, r.Query, r.Args); err != nil {
+		return ValidOneExecRequest{}, err
+	}
+	if valid.SQL.Timeout, err = validateSQLTimeout(r.Timeout); err != nil {
+		return ValidOneExecRequest{}, err
+	}
+	valid.Method = sqlMethods[valid.Query.Mode]
+	valid.URL = sqlexec.RedactURL(valid.SQL.URL)
+	if err := r.validateExtraction(&valid); err != nil {
+		return ValidOneExecRequest{}, err
+	}
+	return valid, nil
+}
+
+// validateExtraction validates the data, the memory data and the store data
+func (r OneExecRequest) validateExtraction(valid *ValidOneExecRequest) error {
 	for _, d := range r.Data {
 		validData, err := d.Validate()
 		if err != nil {
-			return ValidOneExecRequest{}, fmt.Errorf("failed to validate data: %w", err)
+			return fmt.Errorf("failed to validate data: %w", err)
 		}
 		valid.Data = append(valid.Data, validData)
 	}
 	for _, d := range r.MemoryData {
 		validData, err := d.Validate()
 		if err != nil {
-			return ValidOneExecRequest{}, fmt.Errorf("failed to validate memory data: %w", err)
+			return fmt.Errorf("failed to validate memory data: %w", err)
 		}
 		valid.MemoryData = append(valid.MemoryData, validData)
 	}
 	for _, d := range r.StoreData {
 		validData, err := d.Validate()
 		if err != nil {
-			return ValidOneExecRequest{}, fmt.Errorf("failed to validate store data: %w", err)
+			return fmt.Errorf("failed to validate store data: %w", err)
 		}
 		valid.StoreData = append(valid.StoreData, validData)
 	}
-	return valid, nil
+	return nil
 }
 
 // Run runs the OneExec runner
@@ -233,10 +304,13 @@ func (r ValidOneExec) Run(
 	str *sync.Map,
 	log logger.Logger,
 	store Store,
+	cookieJar CookieJarFactory,
 ) error {
 	switch r.Type {
 	case OneExecTypeHTTP:
-		return r.runHTTP(ctx, outputRoot, str, log, store)
+		return r.runHTTP(ctx, outputRoot, str, log, store, cookieJar)
+	case OneExecTypeSQL:
+		return r.runSQL(ctx, outputRoot, str, log, store)
 	}
 	return nil
 }
@@ -247,6 +321,7 @@ func (r ValidOneExec) runHTTP(
 	str *sync.Map,
 	log logger.Logger,
 	store Store,
+	cookieJar CookieJarFactory,
 ) error {
 	req := HTTPRequest{
 		Method:        r.Request.Method,
@@ -256,35 +331,46 @@ func (r ValidOneExec) runHTTP(
 		PathVariables: r.Request.PathVariables,
 		BodyType:      r.Request.BodyType,
 		Body:          r.Request.Body,
-		AttachRequestInfo: func(ctx context.Context, req *http.Request) error {
+	}
+	// the request is sent without the auth when it is disabled
+	if r.Auth != nil {
+		req.AttachRequestInfo = func(ctx context.Context, req *http.Request) error {
 			r.Auth.SetOnRequest(ctx, req)
 			return nil
-		},
+		}
 	}
 	exe := httpexec.RequestContent[HTTPRequest]{
 		Req:          req,
 		ResponseType: httpexec.ResponseType(r.Request.ResponseType),
+		MaxBodyBytes: r.Request.MaxBodyBytes,
+		CookieJar:    cookieJar,
+		Transport:    r.Request.Transport,
 	}
 
+	isGraphQL := r.Request.BodyType == HTTPRequestBodyTypeGraphQL
 	writers := make([]output.HTTPDataWrite, 0)
 	uniqueName := fmt.Sprintf("%s/%s", outputRoot, utils.GenerateUniqueID())
+	header := []string{
+		"Success",
+		"SendDatetime",
+		"ReceivedDatetime",
+		"Count",
+		"ResponseTime",
+		"StatusCode",
+	}
+	if isGraphQL {
+		header = append(header, "Operation", "ErrorCodes")
+	}
+	if r.Request.Transport.HTTP3 {
+		header = append(header, httpexec.HTTP3ColumnHeader...)
+	}
 	for _, o := range r.Output {
 		writer, closer, err := o.HTTPDataWriteFactory(
 			ctx,
 			log,
 			true,
 			uniqueName,
-			append(
-				[]string{
-					"Success",
-					"SendDatetime",
-					"ReceivedDatetime",
-					"Count",
-					"ResponseTime",
-					"StatusCode",
-				},
-				r.Request.Data.ExtractHeader()...,
-			),
+			append(header, r.Request.Data.ExtractHeader()...),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to create writer: %w", err)
@@ -298,6 +384,16 @@ func (r ValidOneExec) runHTTP(
 		return fmt.Errorf("failed to execute request: %w", err)
 	}
 	var data []string
+	if isGraphQL {
+		if graphQLFailed(resp.StatusCode, resp.Res) {
+			resp.Success = false
+		}
+		codes, _ := graphQLErrors(resp.Res)
+		data = append(data, graphQLOperationName(r.Request.Body), strings.Join(codes, ","))
+	}
+	if r.Request.Transport.HTTP3 {
+		data = append(data, resp.HTTP3Columns()...)
+	}
 	for _, d := range r.Request.Data {
 		result, err := d.Extractor.Extract(resp.Res)
 		if err != nil {
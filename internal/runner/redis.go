@@ -0,0 +1,253 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/executor/redisexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+// RedisMethod is the method recorded in the results of the Redis commands
+const RedisMethod = "REDIS"
+
+// MassExecRedis represents the Redis configuration for the MassExec runner
+type MassExecRedis struct {
+	Requests []MassExecRedisRequest `yaml:"requests"`
+}
+
+// MassExecRedisRequest represents the Redis command configuration for the MassExec runner
+type MassExecRedisRequest struct {
+	TargetID            *string                            `yaml:"target_id"`
+	Command             []any                              `yaml:"command"`
+	RequestTemplate     MassExecRequestTemplate            `yaml:"request_template"`
+	PoolSize            *int                               `yaml:"pool_size"`
+	DialTimeout         *string                            `yaml:"dial_timeout"`
+	Timeout             *string                            `yaml:"timeout"`
+	Data                []ExecRequestData                  `yaml:"data"`
+	Interval            *string                            `yaml:"interval"`
+	AwaitPrevResp       bool                               `yaml:"await_prev_response"`
+	SuccessBreak        []string                           `yaml:"success_break"`
+	Break               MassExecRequestBreak               `yaml:"break"`
+	RecordExcludeFilter MassExecRequestRecordExcludeFilter `yaml:"record_exclude_filter"`
+	Emit                []MassExecRequestEmit              `yaml:"emit"`
+}
+
+// MassExecRedisRequestTemplateFields represents the fields which can be overridden by the per-request template
+type MassExecRedisRequestTemplateFields struct {
+	Command []any `yaml:"command"`
+}
+
+// ValidMassExecRedis represents the valid Redis configuration for the MassExec runner
+type ValidMassExecRedis struct {
+	Requests []ValidMassExecRedisRequest
+}
+
+// ValidMassExecRedisRequest represents the valid Redis command configuration for the MassExec runner.
+// The replies are handled as the JSON responses with the type, value and json fields.
+type ValidMassExecRedisRequest struct {
+	ValidMassExecRequest
+	Command []string
+	Config  redisexec.Config
+}
+
+// redisCommand converts the command written in the runner file to the arguments,
+// the numbers and the booleans are sent as their strings
+func redisCommand(command []any) ([]string, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("command is required")
+	}
+	args := make([]string, len(command))
+	for i, arg := range command {
+		switch v := arg.(type) {
+		case nil, map[string]any, []any:
+			return nil, fmt.Errorf("command[%d] must be a scalar value", i)
+		case string:
+			args[i] = v
+		default:
+			args[i] = fmt.Sprint(v)
+		}
+	}
+	if strings.TrimSpace(args[0]) == "" {
+		return nil, fmt.Errorf("command name is required")
+	}
+	return args, nil
+}
+
+// Validate validates the MassExecRedis
+func (s MassExecRedis) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecRedis, error) {
+	var valid ValidMassExecRedis
+	for i, req := range s.Requests {
+		reqTmpl := newFieldTmpl(tmplSet, tmpl, replaceData, "redis", "requests", i)
+		validRequest, err := req.Validate(ctx, log, targetFactor, tmplSet, reqTmpl, replaceData)
+		if err != nil {
+			return ValidMassExecRedis{}, fmt.Errorf("failed to validate request[%d]: %w", i, err)
+		}
+		valid.Requests = append(valid.Requests, validRequest)
+	}
+	return valid, nil
+}
+
+// Validate validates the MassExecRedisRequest
+func (r MassExecRedisRequest) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecRedisRequest, error) {
+	var valid ValidMassExecRedisRequest
+	if r.TargetID == nil {
+		return ValidMassExecRedisRequest{}, fmt.Errorf("target_id is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *r.TargetID)
+	if err != nil {
+		return ValidMassExecRedisRequest{}, fmt.Errorf("failed to factorize target: %w", err)
+	}
+	if tg.Type != config.TargetTypeRedis {
+		return ValidMassExecRedisRequest{}, fmt.Errorf("target %s is not a redis target", *r.TargetID)
+	}
+	if valid.Config, err = redisexec.ParseURL(tg.URL); err != nil {
+		return ValidMassExecRedisRequest{}, fmt.Errorf("invalid url of target %s: %w", *r.TargetID, err)
+	}
+	valid.Method = RedisMethod
+	valid.URL = redisexec.RedactURL(tg.URL)
+
+	if valid.Command, err = redisCommand(r.Command); err != nil {
+		return ValidMassExecRedisRequest{}, err
+	}
+	valid.Body = strings.Join(valid.Command, " ")
+	valid.Config.PoolSize = redisexec.DefaultPoolSize
+	if r.PoolSize != nil {
+		if *r.PoolSize <= 0 {
+			return ValidMassExecRedisRequest{}, fmt.Errorf("pool_size must be positive")
+		}
+		valid.Config.PoolSize = *r.PoolSize
+	}
+	if r.DialTimeout != nil {
+		if valid.Config.DialTimeout, err = time.ParseDuration(*r.DialTimeout); err != nil {
+			return ValidMassExecRedisRequest{}, fmt.Errorf("failed to parse dial_timeout: %w", err)
+		}
+	}
+	if r.Timeout != nil {
+		if valid.Config.Timeout, err = time.ParseDuration(*r.Timeout); err != nil {
+			return ValidMassExecRedisRequest{}, fmt.Errorf("failed to parse timeout: %w", err)
+		}
+	}
+
+	valid.ResponseType = string(httpexec.ResponseTypeJSON)
+	handling := MassExecRequest{
+		Data:                r.Data,
+		Interval:            r.Interval,
+		AwaitPrevResp:       r.AwaitPrevResp,
+		SuccessBreak:        r.SuccessBreak,
+		Break:               r.Break,
+		RecordExcludeFilter: r.RecordExcludeFilter,
+		Emit:                r.Emit,
+	}
+	if err := handling.validateHandling(ctx, log, &valid.ValidMassExecRequest); err != nil {
+		return ValidMassExecRedisRequest{}, err
+	}
+	if valid.RequestTmpl, err = r.RequestTemplate.Validate(tmplSet); err != nil {
+		return ValidMassExecRedisRequest{}, fmt.Errorf("failed to validate request template: %w", err)
+	}
+	valid.Tmpl = tmpl
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// RedisRequest represents the Redis command of the MassExec runner, it is rendered for each count
+type RedisRequest struct {
+	Command     []string
+	Tmpl        *template.Template
+	RequestTmpl *template.Template
+	ReplaceData map[string]any
+	ReqIndex    int
+}
+
+// CreateCommand renders the command for the given count
+func (r RedisRequest) CreateCommand(_ context.Context, _ logger.Logger, count int) ([]string, error) {
+	if r.RequestTmpl == nil && r.Tmpl == nil {
+		return r.Command, nil
+	}
+	replaceData := massReplaceData(r.ReplaceData, map[string]any{
+		"RequestLoopCount": count,
+	})
+	var buffer bytes.Buffer
+	if r.RequestTmpl != nil {
+		if err := r.RequestTmpl.Execute(&buffer, replaceData); err != nil {
+			return nil, fmt.Errorf("failed to execute request template: %w", err)
+		}
+		var fields MassExecRedisRequestTemplateFields
+		if err := yaml.Unmarshal(buffer.Bytes(), &fields); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal request template: %w", err)
+		}
+		if fields.Command != nil {
+			return redisCommand(fields.Command)
+		}
+		return r.Command, nil
+	}
+	if err := r.Tmpl.Execute(&buffer, replaceData); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	var massExec MassExec
+	if err := yaml.Unmarshal(buffer.Bytes(), &massExec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if massExec.Redis == nil || r.ReqIndex >= len(massExec.Redis.Requests) {
+		return nil, fmt.Errorf("redis request[%d] not found in rendered template", r.ReqIndex)
+	}
+	return redisCommand(massExec.Redis.Requests[r.ReqIndex].Command)
+}
+
+var _ redisexec.ExecReq = RedisRequest{}
+
+func (r ValidMassExec) runRedis(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+) ([]MassExecResult, error) {
+	threads := make([]massExecThread, len(r.Redis.Requests))
+	for i, request := range r.Redis.Requests {
+		resChan := make(chan httpexec.ResponseContent)
+		threads[i] = massExecThread{
+			request: request.ValidMassExecRequest,
+			result: MassExecResult{
+				RequestIndex: i,
+				Method:       request.Method,
+				URL:          request.URL,
+			},
+			executor: redisexec.MassRequestContent[RedisRequest]{
+				MassDriver: request.massDriver(resChan),
+				Client:     redisexec.NewClient(request.Config),
+				Req: RedisRequest{
+					Command:     request.Command,
+					Tmpl:        request.Tmpl,
+					RequestTmpl: request.RequestTmpl,
+					ReplaceData: request.ReplaceData,
+					ReqIndex:    i,
+				},
+			},
+			resChan: resChan,
+			columns: redisexec.ColumnHeader,
+		}
+	}
+	return r.runThreads(ctx, log, outputRoot, eventCaster, threads)
+}
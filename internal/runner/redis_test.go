@@ -0,0 +1,184 @@
+package runner_test
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/redisexec"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// newRedisServer starts the RESP stand-in server which supports PING, SET, GET, INCR and HSET with the shared keys
+func newRedisServer(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	var mu sync.Mutex
+	values := make(map[string]string)
+	hashes := make(map[string]map[string]string)
+	handle := func(args []string) string {
+		mu.Lock()
+		defer mu.Unlock()
+		switch strings.ToUpper(args[0]) {
+		case "PING":
+			return "+PONG\r\n"
+		case "SET":
+			values[args[1]] = args[2]
+			return "+OK\r\n"
+		case "GET":
+			v, ok := values[args[1]]
+			if !ok {
+				return "$-1\r\n"
+			}
+			return "$" + strconv.Itoa(len(v)) + "\r\n" + v + "\r\n"
+		case "INCR":
+			n, err := strconv.Atoi(values[args[1]] + "0")
+			if err != nil {
+				return "-ERR value is not an integer or out of range\r\n"
+			}
+			values[args[1]] = strconv.Itoa(n/10 + 1)
+			return ":" + values[args[1]] + "\r\n"
+		case "HSET":
+			if hashes[args[1]] == nil {
+				hashes[args[1]] = make(map[string]string)
+			}
+			var added int
+			for i := 2; i+1 < len(args); i += 2 {
+				if _, ok := hashes[args[1]][args[i]]; !ok {
+					added++
+				}
+				hashes[args[1]][args[i]] = args[i+1]
+			}
+			return ":" + strconv.Itoa(added) + "\r\n"
+		}
+		return "-ERR unknown command '" + args[0] + "'\r\n"
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					reply, err := redisexec.ReadReply(reader)
+					if err != nil {
+						return
+					}
+					args := make([]string, len(reply.Array))
+					for i, arg := range reply.Array {
+						args[i] = arg.Str
+					}
+					if _, err := conn.Write([]byte(handle(args))); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return ln
+}
+
+// TestMassExecRedis tests the redis MassExec against the in-process RESP stand-in server.
+func TestMassExecRedis(t *testing.T) {
+	srv := newRedisServer(t)
+	targets := target.Container{
+		"cache": {Type: config.TargetTypeRedis, URL: "redis://" + srv.Addr().String()},
+	}
+
+	t.Run("SetGet", func(tt *testing.T) {
+		runMassExec(tt, targets, "redis", `
+type: redis
+redis:
+  requests:
+    - target_id: cache
+      command: ["SET", "user:{{ .Dynamic.RequestLoopCount }}", '{"id": {{ .Dynamic.RequestLoopCount }}}']
+      pool_size: 2
+      interval: 1ms
+      # the values are read back, so every SET must be replied before the count break
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 3
+`)
+		out, results := runMassExec(tt, targets, "redis", `
+type: redis
+output:
+  enabled: true
+  ids: ["memory"]
+redis:
+  requests:
+    - target_id: cache
+      command: ["GET", "user:{{ .Dynamic.RequestLoopCount }}"]
+      data:
+        - key: ID
+          extractor:
+            type: jmesPath
+            jmes_path: json.id
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - responseBody/missing
+      break:
+        count: 10
+        response_body:
+          - id: missing
+            extractor:
+              type: jmesPath
+              jmes_path: "type == 'nil'"
+`)
+		if len(results) != 1 || !results[0].Success || results[0].MatchedID != "missing" || results[0].Method != runner.RedisMethod {
+			tt.Fatalf("expected the response body break, got %+v", results)
+		}
+		rows := out.bySuffix("_0")
+		if len(rows) != 5 || rows[0][6] != "ReplyType" || rows[0][7] != "ConnectTime" || rows[4][6] != "nil" {
+			tt.Fatalf("expected header, 3 values and nil, got %v", rows)
+		}
+		for _, row := range rows[1:4] {
+			if row[0] != "true" || row[6] != "bulk_string" || row[8] != row[3] {
+				tt.Errorf("expected the stored value, got %v", row)
+			}
+		}
+	})
+
+	t.Run("ErrorReply", func(tt *testing.T) {
+		out, results := runMassExec(tt, targets, "redis", `
+type: redis
+output:
+  enabled: true
+  ids: ["memory"]
+redis:
+  requests:
+    - target_id: cache
+      command: ["{{ if eq .Dynamic.RequestLoopCount 2 }}HINCR{{ else }}INCR{{ end }}", "counter"]
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 3
+`)
+		if len(results) != 1 || !results[0].Success {
+			tt.Fatalf("expected the count break, got %+v", results)
+		}
+		rows := out.bySuffix("_0")
+		if len(rows) != 4 || rows[1][6] != "integer" || rows[2][6] != "integer" {
+			tt.Fatalf("expected header and 3 rows, got %v", rows)
+		}
+		if rows[3][0] != "false" || rows[3][6] != "error" {
+			tt.Errorf("expected the error reply, got %v", rows[3])
+		}
+	})
+}
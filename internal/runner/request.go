@@ -12,10 +12,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"text/template"
 
-	"github.com/Masterminds/sprig/v3"
 	"gopkg.in/yaml.v3"
 
 	"github.com/cresplanex/bloader/internal/executor/httpexec"
@@ -32,11 +30,24 @@ const (
 	HTTPRequestBodyTypeForm HTTPRequestBodyType = "form"
 	// HTTPRequestBodyTypeMultipart represents the multipart body type
 	HTTPRequestBodyTypeMultipart HTTPRequestBodyType = "multipart"
+	// HTTPRequestBodyTypeGraphQL represents the GraphQL body type, the body has query, variables and operation_name
+	HTTPRequestBodyTypeGraphQL HTTPRequestBodyType = "graphql"
 
 	// DefaultHTTPRequestBodyType represents the default HTTP request body type
 	DefaultHTTPRequestBodyType = HTTPRequestBodyTypeJSON
 )
 
+// validateMaxBodyBytes validates the max_body_bytes, 0 means unlimited
+func validateMaxBodyBytes(maxBodyBytes *int64) (int64, error) {
+	if maxBodyBytes == nil {
+		return 0, nil
+	}
+	if *maxBodyBytes < 1 {
+		return 0, fmt.Errorf("max_body_bytes must be greater than 0")
+	}
+	return *maxBodyBytes, nil
+}
+
 // AttachRequestInfo represents the request info
 type AttachRequestInfo func(ctx context.Context, req *http.Request) error
 
@@ -50,11 +61,12 @@ type HTTPRequest struct {
 	BodyType          HTTPRequestBodyType
 	Body              any
 	AttachRequestInfo AttachRequestInfo
-	TmplStr           string
-	ReplaceData       *sync.Map
+	Tmpl              *template.Template
+	RequestTmpl       *template.Template
+	ReplaceData       map[string]any
+	TargetURL         string
 	OutputFactor      OutputFactor
 	AuthFactor        AuthenticatorFactor
-	TargetFactor      TargetFactor
 	IsMass            bool
 	ReqIndex          int
 }
@@ -69,59 +81,10 @@ func solvePathVariables(path string, pathVariables map[string]string) string {
 // CreateRequest creates the http.Request object for the query
 func (r HTTPRequest) CreateRequest(ctx context.Context, log logger.Logger, count int) (*http.Request, error) {
 	if r.IsMass {
-		replaceData := make(map[string]any)
-		dynamicData := make(map[string]any)
-		r.ReplaceData.Range(func(key, value any) bool {
-			keyStr, ok := key.(string)
-			if !ok {
-				return true
-			}
-			if keyStr == "Dynamic" {
-				if mapV, ok := value.(map[string]any); ok {
-					for k, v := range mapV {
-						dynamicData[k] = v
-					}
-				}
-			} else {
-				replaceData[keyStr] = value
-			}
-			return true
-		})
-		dynamicData["RequestLoopCount"] = count
-		replaceData["Dynamic"] = dynamicData
-		tmpl, err := template.New("yaml").Funcs(sprig.TxtFuncMap()).Parse(r.TmplStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse yaml: %w", err)
-		}
-		var buffer bytes.Buffer
-		if err := tmpl.Execute(&buffer, replaceData); err != nil {
-			return nil, fmt.Errorf("failed to execute template: %w", err)
-		}
-		var massExec MassExec
-		if err := yaml.Unmarshal(buffer.Bytes(), &massExec); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal json: %w", err)
-		}
-		validMassExec, err := massExec.Validate(
-			ctx,
-			log,
-			r.AuthFactor,
-			r.OutputFactor,
-			r.TargetFactor,
-			r.TmplStr,
-			replaceData,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to validate mass exec: %w", err)
+		var err error
+		if r, err = r.renderMassRequest(count); err != nil {
+			return nil, err
 		}
-		request := validMassExec.Requests[r.ReqIndex]
-
-		r.URL = request.URL
-		r.Method = request.Method
-		r.Headers = request.Headers
-		r.QueryParams = request.QueryParams
-		r.PathVariables = request.PathVariables
-		r.BodyType = request.BodyType
-		r.Body = request.Body
 	}
 
 	reqURL := solvePathVariables(r.URL, r.PathVariables)
@@ -156,6 +119,17 @@ func (r HTTPRequest) CreateRequest(ctx context.Context, log logger.Logger, count
 		}
 		body = bytes.NewReader(bodyBytes)
 		header.Set("Content-Type", "application/json")
+	case HTTPRequestBodyTypeGraphQL:
+		payload, err := graphQLPayload(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build graphql payload: %w", err)
+		}
+		bodyBytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		body = bytes.NewReader(bodyBytes)
+		header.Set("Content-Type", "application/json")
 	case HTTPRequestBodyTypeForm:
 		form := url.Values{}
 		if r.Body == nil {
@@ -216,7 +190,7 @@ func (r HTTPRequest) CreateRequest(ctx context.Context, log logger.Logger, count
 		}
 	}
 
-	req, err := http.NewRequest(r.Method, fullURL.String(), body)
+	req, err := http.NewRequestWithContext(ctx, r.Method, fullURL.String(), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -241,4 +215,101 @@ func (r HTTPRequest) CreateRequest(ctx context.Context, log logger.Logger, count
 	return req, nil
 }
 
+// massReplaceData returns the copy of the replace data whose Dynamic values are extended by the given values
+func massReplaceData(data map[string]any, dynamic map[string]any) map[string]any {
+	replaceData := make(map[string]any, len(data))
+	dynamicData := make(map[string]any)
+	for k, v := range data {
+		if k == "Dynamic" {
+			if mapV, ok := v.(map[string]any); ok {
+				for dk, dv := range mapV {
+					dynamicData[dk] = dv
+				}
+			}
+			continue
+		}
+		replaceData[k] = v
+	}
+	for k, v := range dynamic {
+		dynamicData[k] = v
+	}
+	replaceData["Dynamic"] = dynamicData
+	return replaceData
+}
+
+// renderMassRequest renders the dynamic part of the request for the given count.
+// The templates are parsed once on validation, so only the execution is done here.
+func (r HTTPRequest) renderMassRequest(count int) (HTTPRequest, error) {
+	replaceData := massReplaceData(r.ReplaceData, map[string]any{
+		"RequestLoopCount": count,
+	})
+
+	if r.RequestTmpl != nil {
+		var buffer bytes.Buffer
+		if err := r.RequestTmpl.Execute(&buffer, replaceData); err != nil {
+			return HTTPRequest{}, fmt.Errorf("failed to execute request template: %w", err)
+		}
+		var fields MassExecRequestTemplateFields
+		if err := yaml.Unmarshal(buffer.Bytes(), &fields); err != nil {
+			return HTTPRequest{}, fmt.Errorf("failed to unmarshal request template: %w", err)
+		}
+		if fields.QueryParam != nil {
+			r.QueryParams = fields.QueryParam
+		}
+		if fields.PathVariables != nil {
+			r.PathVariables = fields.PathVariables
+		}
+		if fields.Headers != nil {
+			r.Headers = fields.Headers
+		}
+		if fields.Body != nil {
+			r.Body = fields.Body
+		}
+		return r, nil
+	}
+
+	if r.Tmpl == nil {
+		return r, nil
+	}
+	var buffer bytes.Buffer
+	if err := r.Tmpl.Execute(&buffer, replaceData); err != nil {
+		return HTTPRequest{}, fmt.Errorf("failed to execute template: %w", err)
+	}
+	var rendered renderedMassRequests
+	if err := yaml.Unmarshal(buffer.Bytes(), &rendered); err != nil {
+		return HTTPRequest{}, fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if r.ReqIndex >= len(rendered.Requests) {
+		return HTTPRequest{}, fmt.Errorf("request[%d] not found in rendered template", r.ReqIndex)
+	}
+	request := rendered.Requests[r.ReqIndex]
+	if request.Endpoint == nil {
+		return HTTPRequest{}, fmt.Errorf("endpoint of request[%d] is required", r.ReqIndex)
+	}
+	if request.Method == nil {
+		return HTTPRequest{}, fmt.Errorf("method of request[%d] is required", r.ReqIndex)
+	}
+
+	r.URL = r.TargetURL + *request.Endpoint
+	r.Method = *request.Method
+	r.Headers = request.Headers
+	r.QueryParams = request.QueryParam
+	r.PathVariables = request.PathVariables
+	r.Body = request.Body
+	return r, nil
+}
+
+// renderedMassRequests represents the requests of the rendered runner file.
+// Only the fields rendered for each request are decoded, the target and the body type are used as validated.
+type renderedMassRequests struct {
+	Requests []struct {
+		Endpoint      *string           `yaml:"endpoint"`
+		Method        *string           `yaml:"method"`
+		QueryParam    map[string]any    `yaml:"query_param"`
+		PathVariables map[string]string `yaml:"path_variables"`
+		Headers       map[string]any    `yaml:"headers"`
+		Body          any               `yaml:"body"`
+	} `yaml:"requests"`
+}
+
 var _ httpexec.ExecReq = (*HTTPRequest)(nil)
@@ -0,0 +1,232 @@
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// massRequestFile returns the runner file of the many requests, the first request has the given body
+func massRequestFile(prefix, body string) string {
+	var b strings.Builder
+	b.WriteString(prefix + `
+type: http
+output:
+  enabled: false
+requests:
+  - target_id: api
+    endpoint: /users/{id}
+    method: POST
+    path_variables:
+      id: "1"
+    headers:
+      X-Tenant: "{{ .Values.tenant }}"
+    ` + body + `
+    response_type: json
+    interval: 1ms
+`)
+	for i := range 30 {
+		fmt.Fprintf(&b, `  - target_id: api
+    endpoint: /other/%d
+    method: GET
+    query_param:
+      page: "{{ .Values.page }}"
+    headers:
+      X-Tenant: "{{ .Values.tenant }}"
+      X-Index: "%d"
+    body:
+      items: [{{ range $i, $e := until 10 }}{{ if $i }}, {{ end }}{{ $e }}{{ end }}]
+    response_type: json
+    interval: 1ms
+`, i, i)
+	}
+	return b.String()
+}
+
+// newMassRequest validates the runner file, and returns the HTTP request of the first request
+func newMassRequest(tb testing.TB, files map[string]string, funcs template.FuncMap, body string) runner.HTTPRequest {
+	tb.Helper()
+	ctx := context.Background()
+	dir := tb.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			tb.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	funcMap := runner.NewTmplFuncMap(ctx, nil, nil)
+	maps.Copy(funcMap, funcs)
+	tmplSet := runner.NewTmplSet(ctx, runner.NewLocalTmplFactor(dir), funcMap)
+	tmpl, err := tmplSet.Parse("yaml", body)
+	if err != nil {
+		tb.Fatalf("failed to parse template: %v", err)
+	}
+	data := map[string]any{
+		"Values":  map[string]any{"tenant": "t1", "page": 2},
+		"Dynamic": map[string]any{"LoopCount": 0},
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		tb.Fatalf("failed to execute template: %v", err)
+	}
+	var massExec runner.MassExec
+	if err := yaml.Unmarshal(buf.Bytes(), &massExec); err != nil {
+		tb.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	targetFactor := runner.NewLocalTargetFactor(target.Container{
+		"api": {Type: config.TargetTypeHTTP, URL: "http://api.test"},
+	})
+	valid, err := massExec.Validate(ctx, logger.NewSlogLogger(), nil, nil, targetFactor, tmplSet, tmpl, data)
+	if err != nil {
+		tb.Fatalf("failed to validate: %v", err)
+	}
+	request := valid.Requests[0]
+	return runner.HTTPRequest{
+		Method:        request.Method,
+		URL:           request.URL,
+		Headers:       request.Headers,
+		QueryParams:   request.QueryParams,
+		PathVariables: request.PathVariables,
+		BodyType:      request.BodyType,
+		Body:          request.Body,
+		IsMass:        true,
+		Tmpl:          request.Tmpl,
+		RequestTmpl:   request.RequestTmpl,
+		ReplaceData:   request.ReplaceData,
+		TargetURL:     request.TargetURL,
+	}
+}
+
+// massRequestCases is the ways to render the first request
+var massRequestCases = []struct {
+	name   string
+	files  map[string]string
+	prefix string
+	body   string
+	want   func(count int) string
+}{
+	{
+		name: "Static",
+		body: `body: {"id": "fixed-{{ .Dynamic.LoopCount }}"}`,
+		want: func(int) string { return `{"id":"fixed-0"}` },
+	},
+	{
+		name: "Field",
+		body: `body: {"id": "{{ .Dynamic.RequestLoopCount }}"}`,
+		want: func(count int) string { return fmt.Sprintf(`{"id":"%d"}`, count) },
+	},
+	{
+		name:   "WholeTemplate",
+		prefix: `{{ $prefix := "u" }}`,
+		body:   `body: {"id": "{{ $prefix }}{{ .Dynamic.RequestLoopCount }}"}`,
+		want:   func(count int) string { return fmt.Sprintf(`{"id":"u%d"}`, count) },
+	},
+	{
+		// the anchor is out of the request, so the request is not separated from the runner file
+		name:   "Alias",
+		prefix: `x-id: &id "a{{ .Dynamic.RequestLoopCount }}"`,
+		body:   `body: {"id": *id}`,
+		want:   func(count int) string { return fmt.Sprintf(`{"id":"a%d"}`, count) },
+	},
+	{
+		name:  "RequestTemplate",
+		files: map[string]string{"request.yaml": `body: {"id": "r{{ .Dynamic.RequestLoopCount }}"}`},
+		body:  "request_template:\n      enabled: true\n      file: request.yaml",
+		want:  func(count int) string { return fmt.Sprintf(`{"id":"r%d"}`, count) },
+	},
+}
+
+// TestMassExecRequestRender tests that each request is rendered with the static parts as validated.
+func TestMassExecRequestRender(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewSlogLogger()
+	for _, c := range massRequestCases {
+		t.Run(c.name, func(tt *testing.T) {
+			req := newMassRequest(tt, c.files, nil, massRequestFile(c.prefix, c.body))
+			for count := range 3 {
+				httpReq, err := req.CreateRequest(ctx, log, count)
+				if err != nil {
+					tt.Fatalf("failed to create request: %v", err)
+				}
+				if httpReq.Method != "POST" || httpReq.URL.String() != "http://api.test/users/1" {
+					tt.Errorf("unexpected request: %s %s", httpReq.Method, httpReq.URL)
+				}
+				if tenant := httpReq.Header.Get("X-Tenant"); tenant != "t1" {
+					tt.Errorf("expected the tenant header, got %q", tenant)
+				}
+				body, err := io.ReadAll(httpReq.Body)
+				if err != nil {
+					tt.Fatalf("failed to read body: %v", err)
+				}
+				if string(body) != c.want(count) {
+					tt.Errorf("expected the body %s, got %s", c.want(count), body)
+				}
+			}
+		})
+	}
+}
+
+// TestMassExecRequestPerCallFunc tests that the fields calling the functions not known to be static,
+// e.g. the ones supplied by the user, are rendered for each request.
+func TestMassExecRequestPerCallFunc(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewSlogLogger()
+	var ticks atomic.Int64
+	funcs := template.FuncMap{"tick": func() int64 { return ticks.Add(1) }}
+	for _, body := range []string{
+		`body: {"id": "{{ tick }}"}`,
+		`body: {"id": "{{ uuidv7 }}"}`,
+		`body: {"id": "{{ fakeName }}-{{ randAlphaNum 16 }}"}`,
+	} {
+		req := newMassRequest(t, nil, funcs, massRequestFile("", body))
+		seen := make(map[string]struct{})
+		for count := range 3 {
+			httpReq, err := req.CreateRequest(ctx, log, count)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			b, err := io.ReadAll(httpReq.Body)
+			if err != nil {
+				t.Fatalf("failed to read body: %v", err)
+			}
+			seen[string(b)] = struct{}{}
+		}
+		if len(seen) != 3 {
+			t.Errorf("%s: expected the body rendered for each request, got %v", body, seen)
+		}
+	}
+}
+
+// BenchmarkMassExecRequest compares the rendering of the request for each request.
+// Static does not render, Field renders only the request, WholeTemplate renders the whole runner file
+// and RequestTemplate renders the per-request template.
+func BenchmarkMassExecRequest(b *testing.B) {
+	ctx := context.Background()
+	log := logger.NewSlogLogger()
+	for _, c := range massRequestCases {
+		b.Run(c.name, func(bb *testing.B) {
+			req := newMassRequest(bb, c.files, nil, massRequestFile(c.prefix, c.body))
+			bb.ReportAllocs()
+			bb.ResetTimer()
+			for i := range bb.N {
+				if _, err := req.CreateRequest(ctx, log, i); err != nil {
+					bb.Fatalf("failed to create request: %v", err)
+				}
+			}
+		})
+	}
+}
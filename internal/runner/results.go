@@ -0,0 +1,163 @@
+package runner
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+)
+
+// TerminateCounts represents the counts of the responses at the termination of the request
+type TerminateCounts struct {
+	Count        int
+	SuccessCount int
+	FailureCount int
+	BodyBytes    int64
+}
+
+// MassExecResult represents the structured result of the MassExec request
+type MassExecResult struct {
+	RequestIndex  int
+	Method        string
+	URL           string
+	Operation     string
+	TerminateType matcher.TerminateType
+	MatchedID     string
+	Success       bool
+	Counts        TerminateCounts
+	EndedAt       time.Time
+}
+
+// MassExecResultHeader is the header of the results file of the MassExec runner
+var MassExecResultHeader = []string{
+	"RequestIndex",
+	"Method",
+	"URL",
+	"Operation",
+	"TerminateType",
+	"MatchedID",
+	"Success",
+	"Count",
+	"SuccessCount",
+	"FailureCount",
+	"BodyBytes",
+	"EndedDatetime",
+}
+
+// ToSlice converts MassExecResult to slice
+func (r MassExecResult) ToSlice() []string {
+	return []string{
+		strconv.Itoa(r.RequestIndex),
+		r.Method,
+		r.URL,
+		r.Operation,
+		r.TerminateType.String(),
+		r.MatchedID,
+		strconv.FormatBool(r.Success),
+		strconv.Itoa(r.Counts.Count),
+		strconv.Itoa(r.Counts.SuccessCount),
+		strconv.Itoa(r.Counts.FailureCount),
+		strconv.FormatInt(r.Counts.BodyBytes, 10),
+		r.EndedAt.Format(time.RFC3339Nano),
+	}
+}
+
+// ToValue converts MassExecResult to the template value
+func (r MassExecResult) ToValue() map[string]any {
+	return map[string]any{
+		"requestIndex":  r.RequestIndex,
+		"method":        r.Method,
+		"url":           r.URL,
+		"operation":     r.Operation,
+		"terminateType": r.TerminateType.String(),
+		"matchedID":     r.MatchedID,
+		"success":       r.Success,
+		"count":         r.Counts.Count,
+		"successCount":  r.Counts.SuccessCount,
+		"failureCount":  r.Counts.FailureCount,
+		"bodyBytes":     r.Counts.BodyBytes,
+	}
+}
+
+// MassExecResultsToValue converts the results of the requests to the template value of the flow.
+// The fields of the request terminated last are exposed at the top level, and all of them are under requests.
+func MassExecResultsToValue(results []MassExecResult) map[string]any {
+	value := make(map[string]any)
+	requests := make([]any, 0, len(results))
+	var last *MassExecResult
+	success := true
+	for i, r := range results {
+		requests = append(requests, r.ToValue())
+		if last == nil || !r.EndedAt.Before(last.EndedAt) {
+			last = &results[i]
+		}
+		success = success && r.Success
+	}
+	if last != nil {
+		for k, v := range last.ToValue() {
+			value[k] = v
+		}
+	}
+	value["success"] = success
+	value["requests"] = requests
+	return value
+}
+
+// FlowResults holds the structured results of the runners by the flow ID,
+// they are referred as .Results in the templates of the later steps.
+// The result is also held by the scope of the flow, which is the path of the flow IDs with the indexes of the count
+// and the iterations, e.g. mass#1 or parent#0/mass#0/2, so the results of the repeated flows are not overwritten.
+type FlowResults struct {
+	mu      *sync.RWMutex
+	results map[string]any
+	scope   string
+}
+
+// NewFlowResults creates a new FlowResults
+func NewFlowResults() *FlowResults {
+	return &FlowResults{
+		mu:      &sync.RWMutex{},
+		results: make(map[string]any),
+	}
+}
+
+// WithScope returns the FlowResults of the child scope, the results are shared with the parent
+func (r *FlowResults) WithScope(name string) *FlowResults {
+	if r == nil {
+		return nil
+	}
+	scope := name
+	if r.scope != "" {
+		scope = r.scope + "/" + name
+	}
+	return &FlowResults{mu: r.mu, results: r.results, scope: scope}
+}
+
+// Set sets the result of the flow by the flow ID and by the scope,
+// the flow ID holds the result set last
+func (r *FlowResults) Set(flowID string, value any) {
+	if r == nil || flowID == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results[flowID] = value
+	if r.scope != "" {
+		r.results[r.scope] = value
+	}
+}
+
+// Values returns the copy of the results
+func (r *FlowResults) Values() map[string]any {
+	values := make(map[string]any)
+	if r == nil {
+		return values
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for k, v := range r.results {
+		values[k] = v
+	}
+	return values
+}
@@ -12,12 +12,43 @@ import (
 	"github.com/cresplanex/bloader/internal/prompt"
 )
 
-// Run runs the load test
-func Run(ctr *container.Container, filename string, data map[string]any) error {
+// Run runs the load test.
+// When resumeID is not empty, the interrupted run is resumed from its checkpoint.
+// The started is called with the run ID before the runner file is executed.
+func Run(
+	ctr *container.Container,
+	filename string,
+	data map[string]any,
+	resumeID string,
+	started func(runID string),
+) error {
 	ctx, cancel := context.WithCancel(ctr.Ctx)
 	defer cancel()
 
 	var err error
+	var record CheckpointRecord
+	manifestDirs := ManifestDirs(ctr.Config.Env, ctr.Config.Outputs)
+	if resumeID != "" {
+		// the checkpoint of the finished run is deleted, the manifest tells why it is not found
+		if m, err := FindManifest(manifestDirs, resumeID); err == nil && m.Status == RunStatusSucceeded {
+			return fmt.Errorf("run %s is already finished", resumeID)
+		}
+		if record, err = LoadCheckpointRecord(ctr.Store, resumeID); err != nil {
+			return fmt.Errorf("failed to load the checkpoint: %w", err)
+		}
+		if filename == "" {
+			filename = record.File
+		} else if filename != record.File {
+			return fmt.Errorf("file %s does not match the file %s of run %s", filename, record.File, resumeID)
+		}
+		if record.Data == nil {
+			record.Data = make(map[string]any)
+		}
+		for k, v := range data {
+			record.Data[k] = v
+		}
+		data = record.Data
+	}
 	if filename == "" {
 		filename, err = prompt.Text(
 			"Enter the file to run the load test",
@@ -31,14 +62,55 @@ func Run(ctr *container.Container, filename string, data map[string]any) error {
 	globalStore := sync.Map{}
 	threadOnlyStore := sync.Map{}
 	slaveValues := make(map[string]any)
+	flowResults := NewFlowResults()
 	outputCtr := output.NewContainer(ctr.Config.Env, ctr.Config.Outputs)
 
-	outputRoot := time.Now().Format("20060102_150405")
+	// the run ID is used as the output root
+	outputRoot := NewRunID(ctr.Store, manifestDirs, time.Now())
 
 	for k, v := range data {
 		globalStore.Store(k, v)
 	}
 
+	if resumeID != "" {
+		// the values updated by the completed flows take precedence over the data
+		outputRoot = record.OutputRoot
+		for k, v := range record.Values {
+			globalStore.Store(k, v)
+		}
+		for k, v := range record.Results {
+			flowResults.Set(k, v)
+		}
+	} else {
+		record = CheckpointRecord{
+			RunID:      outputRoot,
+			File:       filename,
+			Data:       data,
+			OutputRoot: outputRoot,
+		}
+	}
+
+	var manifest *RunManifest
+	if resumeID != "" {
+		if manifest, err = FindManifest(manifestDirs, resumeID); err == nil {
+			manifest.Resume()
+		}
+	}
+	if manifest == nil {
+		manifest = NewRunManifest(record.RunID, filename, data, ctr.Config.Env)
+	}
+	if err := manifest.Save(manifestDirs); err != nil {
+		return fmt.Errorf("failed to save the manifest: %w", err)
+	}
+
+	checkpoint, err := NewCheckpoint(ctr.Store, record, &globalStore, flowResults, manifest)
+	if err != nil {
+		return fmt.Errorf("failed to create the checkpoint: %w", err)
+	}
+	if started != nil {
+		started(record.RunID)
+	}
+
 	slCtr := NewConnectionContainer()
 	defer slCtr.AllDisconnect(ctx)
 
@@ -52,11 +124,15 @@ func Run(ctr *container.Container, filename string, data map[string]any) error {
 		TmplFactor:            NewLocalTmplFactor(ctr.Config.Loader.BasePath),
 		Store:                 NewLocalStore(ctr.EncypterContainer, ctr.Store),
 		AuthFactor:            NewLocalAuthenticatorFactor(ctr.AuthenticatorContainer),
-		OutputFactor:          NewLocalOutputFactor(outputCtr),
+		OutputFactor:          manifestOutputFactor{OutputFactor: NewLocalOutputFactor(outputCtr), manifest: manifest},
 		TargetFactor:          NewLocalTargetFactor(ctr.TargetContainer),
+		Barrier:               NewLocalBarrier(),
+		Checkpoint:            checkpoint,
+		Results:               flowResults,
+		CookieJars:            NewCookieJarContainer(),
 	}
 
-	if err := baseExecutor.Execute(
+	runErr := baseExecutor.Execute(
 		ctx,
 		filename,
 		&globalStore,
@@ -66,9 +142,27 @@ func Run(ctr *container.Container, filename string, data map[string]any) error {
 		0,
 		slaveValues,
 		eventCaster,
-	); err != nil {
-		return fmt.Errorf("failed to execute the load test: %w", err)
+	)
+	for _, id := range slCtr.IDs() {
+		manifest.AddSlave(id)
+	}
+	// the interruption usually surfaces as the error of the flow, so the context is checked first
+	switch {
+	case ctx.Err() != nil:
+		manifest.Finish(RunStatusInterrupted, ctx.Err())
+		runErr = fmt.Errorf("the load test is interrupted (resume with --resume %s): %w", record.RunID, ctx.Err())
+	case runErr != nil:
+		manifest.Finish(RunStatusFailed, runErr)
+		runErr = fmt.Errorf("failed to execute the load test (resume with --resume %s): %w", record.RunID, runErr)
+	default:
+		manifest.Finish(RunStatusSucceeded, nil)
+		if err := checkpoint.Finish(); err != nil {
+			runErr = fmt.Errorf("failed to finish the checkpoint: %w", err)
+		}
+	}
+	if err := manifest.Save(manifestDirs); err != nil {
+		return fmt.Errorf("failed to save the manifest: %w", err)
 	}
 
-	return nil
+	return runErr
 }
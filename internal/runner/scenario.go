@@ -0,0 +1,593 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/auth"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/utils"
+)
+
+// ScenarioType represents the type of Scenario
+type ScenarioType string
+
+const (
+	// ScenarioTypeHTTP represents the HTTP type
+	ScenarioTypeHTTP ScenarioType = "http"
+)
+
+// Scenario represents the Scenario runner.
+// Each iteration of the executors chooses one of the scenarios by the weight
+// and sends its steps in order, so the traffic mix does not depend on the latency.
+// Only the step is rendered again for each step with .Dynamic.Iteration, .Dynamic.ExecutorID
+// and .Dynamic.Extracted, which holds the data extracted by the preceding steps of the iteration.
+type Scenario struct {
+	Type      *string              `yaml:"type"`
+	Output    MassExecOutput       `yaml:"output"`
+	Auth      MassExecAuth         `yaml:"auth"`
+	Executors *int                 `yaml:"executors"`
+	Interval  *string              `yaml:"interval"`
+	Seed      *int64               `yaml:"seed"`
+	Break     ScenarioBreak        `yaml:"break"`
+	Scenarios []ScenarioDefinition `yaml:"scenarios"`
+	Cookies   Cookies              `yaml:"cookies"`
+}
+
+// ValidScenario represents the valid Scenario runner
+type ValidScenario struct {
+	Type      ScenarioType
+	Output    []output.Output
+	Auth      auth.SetAuthor
+	Executors int
+	Interval  time.Duration
+	Seed      *int64
+	Break     ValidScenarioBreak
+	Scenarios []ValidScenarioDefinition
+	Cookies   ValidCookies
+	// ReplaceData is the data the steps are rendered with, in addition to the dynamic values of the iteration
+	ReplaceData map[string]any
+}
+
+// Validate validates the Scenario,
+// the tmpl renders the steps which change per step, and the others are used as validated
+func (r Scenario) Validate(
+	ctx context.Context,
+	authFactor AuthenticatorFactor,
+	outFactor OutputFactor,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidScenario, error) {
+	var valid ValidScenario
+	var err error
+	if r.Type == nil {
+		return ValidScenario{}, fmt.Errorf("type is required")
+	}
+	switch ScenarioType(*r.Type) {
+	case ScenarioTypeHTTP:
+		valid.Type = ScenarioType(*r.Type)
+	default:
+		return ValidScenario{}, fmt.Errorf("invalid type value: %s", *r.Type)
+	}
+	if valid.Output, err = r.Output.Validate(ctx, outFactor); err != nil {
+		return ValidScenario{}, fmt.Errorf("failed to validate output: %w", err)
+	}
+	if valid.Auth, err = r.Auth.Validate(ctx, authFactor); err != nil {
+		return ValidScenario{}, fmt.Errorf("failed to validate auth: %w", err)
+	}
+	valid.Executors = 1
+	if r.Executors != nil {
+		if *r.Executors < 1 {
+			return ValidScenario{}, fmt.Errorf("executors must be greater than 0")
+		}
+		valid.Executors = *r.Executors
+	}
+	if r.Interval != nil {
+		if valid.Interval, err = time.ParseDuration(*r.Interval); err != nil {
+			return ValidScenario{}, fmt.Errorf("failed to parse interval: %w", err)
+		}
+	}
+	valid.Seed = r.Seed
+	if valid.Break, err = r.Break.Validate(); err != nil {
+		return ValidScenario{}, fmt.Errorf("failed to validate break: %w", err)
+	}
+	if len(r.Scenarios) == 0 {
+		return ValidScenario{}, fmt.Errorf("scenarios is required")
+	}
+	names := make(map[string]struct{}, len(r.Scenarios))
+	for i, s := range r.Scenarios {
+		validScenario, err := s.Validate(ctx, targetFactor)
+		if err != nil {
+			return ValidScenario{}, fmt.Errorf("failed to validate scenarios[%d]: %w", i, err)
+		}
+		if _, ok := names[validScenario.Name]; ok {
+			return ValidScenario{}, fmt.Errorf("duplicate scenario name: %s", validScenario.Name)
+		}
+		names[validScenario.Name] = struct{}{}
+		for j := range validScenario.Steps {
+			validScenario.Steps[j].Tmpl = newFieldTmpl(tmplSet, tmpl, replaceData, "scenarios", i, "steps", j)
+		}
+		valid.Scenarios = append(valid.Scenarios, validScenario)
+	}
+	if valid.Cookies, err = r.Cookies.Validate(); err != nil {
+		return ValidScenario{}, fmt.Errorf("failed to validate cookies: %w", err)
+	}
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// ScenarioBreak represents the break configuration for the Scenario runner
+type ScenarioBreak struct {
+	Time  *string `yaml:"time"`
+	Count *int    `yaml:"count"`
+}
+
+// ValidScenarioBreak represents the valid break configuration for the Scenario runner
+type ValidScenarioBreak struct {
+	Time  time.Duration
+	Count int
+}
+
+// Validate validates the ScenarioBreak, one of the time and the count is required
+func (b ScenarioBreak) Validate() (ValidScenarioBreak, error) {
+	var valid ValidScenarioBreak
+	if b.Time != nil {
+		d, err := time.ParseDuration(*b.Time)
+		if err != nil {
+			return ValidScenarioBreak{}, fmt.Errorf("failed to parse time: %w", err)
+		}
+		valid.Time = d
+	}
+	if b.Count != nil {
+		if *b.Count < 1 {
+			return ValidScenarioBreak{}, fmt.Errorf("count must be greater than 0")
+		}
+		valid.Count = *b.Count
+	}
+	if valid.Time <= 0 && valid.Count == 0 {
+		return ValidScenarioBreak{}, fmt.Errorf("time or count is required")
+	}
+	return valid, nil
+}
+
+// ScenarioDefinition represents the weighted request sequence of the Scenario runner
+type ScenarioDefinition struct {
+	Name   *string        `yaml:"name"`
+	Weight *int           `yaml:"weight"`
+	Steps  []ScenarioStep `yaml:"steps"`
+}
+
+// ValidScenarioDefinition represents the valid weighted request sequence of the Scenario runner
+type ValidScenarioDefinition struct {
+	Name   string
+	Weight int
+	Steps  []ValidScenarioStep
+}
+
+// Validate validates the ScenarioDefinition
+func (d ScenarioDefinition) Validate(ctx context.Context, targetFactor TargetFactor) (ValidScenarioDefinition, error) {
+	var valid ValidScenarioDefinition
+	if d.Name == nil {
+		return ValidScenarioDefinition{}, fmt.Errorf("name is required")
+	}
+	valid.Name = *d.Name
+	if d.Weight == nil {
+		return ValidScenarioDefinition{}, fmt.Errorf("weight is required")
+	}
+	if *d.Weight < 1 {
+		return ValidScenarioDefinition{}, fmt.Errorf("weight must be greater than 0")
+	}
+	valid.Weight = *d.Weight
+	if len(d.Steps) == 0 {
+		return ValidScenarioDefinition{}, fmt.Errorf("steps is required")
+	}
+	for i, s := range d.Steps {
+		validStep, err := s.Validate(ctx, targetFactor)
+		if err != nil {
+			return ValidScenarioDefinition{}, fmt.Errorf("failed to validate steps[%d]: %w", i, err)
+		}
+		if validStep.Name == "" {
+			validStep.Name = strconv.Itoa(i)
+		}
+		valid.Steps = append(valid.Steps, validStep)
+	}
+	return valid, nil
+}
+
+// ScenarioStep represents the request of the scenario
+type ScenarioStep struct {
+	Name          *string           `yaml:"name"`
+	TargetID      *string           `yaml:"target_id"`
+	Endpoint      *string           `yaml:"endpoint"`
+	Method        *string           `yaml:"method"`
+	QueryParam    map[string]any    `yaml:"query_param"`
+	PathVariables map[string]string `yaml:"path_variables"`
+	Headers       map[string]any    `yaml:"headers"`
+	BodyType      *string           `yaml:"body_type"`
+	Body          any               `yaml:"body"`
+	ResponseType  *string           `yaml:"response_type"`
+	MaxBodyBytes  *int64            `yaml:"max_body_bytes"`
+	Data          []ExecRequestData `yaml:"data"`
+}
+
+// ValidScenarioStep represents the valid request of the scenario
+type ValidScenarioStep struct {
+	Name          string
+	TargetURL     string
+	URL           string
+	Method        string
+	QueryParam    map[string]any
+	PathVariables map[string]string
+	Headers       map[string]any
+	BodyType      HTTPRequestBodyType
+	Body          any
+	ResponseType  string
+	MaxBodyBytes  int64
+	Transport     httpexec.TransportConfig
+	Data          ValidExecRequestDataSlice
+	// Tmpl renders the step for each step, it is nil when the step is static
+	Tmpl *template.Template
+}
+
+// Validate validates the ScenarioStep
+func (s ScenarioStep) Validate(ctx context.Context, targetFactor TargetFactor) (ValidScenarioStep, error) {
+	var valid ValidScenarioStep
+	if s.Name != nil {
+		valid.Name = *s.Name
+	}
+	if s.TargetID == nil {
+		return ValidScenarioStep{}, fmt.Errorf("target_id is required")
+	}
+	if s.Endpoint == nil {
+		return ValidScenarioStep{}, fmt.Errorf("endpoint is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *s.TargetID)
+	if err != nil {
+		return ValidScenarioStep{}, fmt.Errorf("failed to factorize target: %w", err)
+	}
+	valid.TargetURL = tg.URL
+	valid.URL = fmt.Sprintf("%s%s", tg.URL, *s.Endpoint)
+	valid.Transport = httpTransport(tg)
+	if s.Method == nil {
+		return ValidScenarioStep{}, fmt.Errorf("method is required")
+	}
+	valid.Method = *s.Method
+	valid.QueryParam = s.QueryParam
+	valid.PathVariables = s.PathVariables
+	valid.Headers = s.Headers
+	valid.Body = s.Body
+	valid.BodyType = DefaultHTTPRequestBodyType
+	if s.BodyType != nil {
+		switch HTTPRequestBodyType(*s.BodyType) {
+		case HTTPRequestBodyTypeJSON, HTTPRequestBodyTypeForm, HTTPRequestBodyTypeMultipart:
+			valid.BodyType = HTTPRequestBodyType(*s.BodyType)
+		case HTTPRequestBodyTypeGraphQL:
+			if err := validateGraphQLBody(s.Body); err != nil {
+				return ValidScenarioStep{}, err
+			}
+			valid.BodyType = HTTPRequestBodyTypeGraphQL
+		default:
+			return ValidScenarioStep{}, fmt.Errorf("invalid body_type value: %s", *s.BodyType)
+		}
+	}
+	if s.ResponseType == nil {
+		return ValidScenarioStep{}, fmt.Errorf("response_type is required")
+	}
+	valid.ResponseType = *s.ResponseType
+	if valid.MaxBodyBytes, err = validateMaxBodyBytes(s.MaxBodyBytes); err != nil {
+		return ValidScenarioStep{}, err
+	}
+	for i, d := range s.Data {
+		validData, err := d.Validate()
+		if err != nil {
+			return ValidScenarioStep{}, fmt.Errorf("failed to validate data[%d]: %w", i, err)
+		}
+		valid.Data = append(valid.Data, validData)
+	}
+	return valid, nil
+}
+
+// renderedScenarios represents the scenarios of the rendered step, the preceding items are rendered empty.
+// Only the fields of the step rendered for each step are decoded, the others are used as validated.
+type renderedScenarios struct {
+	Scenarios []struct {
+		Steps []struct {
+			Endpoint      *string           `yaml:"endpoint"`
+			Method        *string           `yaml:"method"`
+			QueryParam    map[string]any    `yaml:"query_param"`
+			PathVariables map[string]string `yaml:"path_variables"`
+			Headers       map[string]any    `yaml:"headers"`
+			Body          any               `yaml:"body"`
+		} `yaml:"steps"`
+	} `yaml:"scenarios"`
+}
+
+// renderStep renders the step of the scenario with the dynamic values of the iteration
+func (r ValidScenario) renderStep(
+	scenarioIndex int,
+	stepIndex int,
+	step ValidScenarioStep,
+	dynamic map[string]any,
+) (ValidScenarioStep, error) {
+	if step.Tmpl == nil {
+		return step, nil
+	}
+	var buffer bytes.Buffer
+	if err := step.Tmpl.Execute(&buffer, massReplaceData(r.ReplaceData, dynamic)); err != nil {
+		return ValidScenarioStep{}, fmt.Errorf("failed to execute template: %w", err)
+	}
+	var rendered renderedScenarios
+	if err := yaml.Unmarshal(buffer.Bytes(), &rendered); err != nil {
+		return ValidScenarioStep{}, fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if scenarioIndex >= len(rendered.Scenarios) || stepIndex >= len(rendered.Scenarios[scenarioIndex].Steps) {
+		return ValidScenarioStep{}, fmt.Errorf("step %s not found in rendered template", step.Name)
+	}
+	renderedStep := rendered.Scenarios[scenarioIndex].Steps[stepIndex]
+	if renderedStep.Endpoint == nil {
+		return ValidScenarioStep{}, fmt.Errorf("endpoint of step %s is required", step.Name)
+	}
+	if renderedStep.Method == nil {
+		return ValidScenarioStep{}, fmt.Errorf("method of step %s is required", step.Name)
+	}
+	step.URL = step.TargetURL + *renderedStep.Endpoint
+	step.Method = *renderedStep.Method
+	step.QueryParam = renderedStep.QueryParam
+	step.PathVariables = renderedStep.PathVariables
+	step.Headers = renderedStep.Headers
+	step.Body = renderedStep.Body
+	return step, nil
+}
+
+// scenarioChooser chooses the scenario by the weight
+type scenarioChooser struct {
+	rnd        *rand.Rand
+	cumulative []int
+	total      int
+}
+
+func newScenarioChooser(scenarios []ValidScenarioDefinition, seed *int64, executorID int) *scenarioChooser {
+	var rnd *rand.Rand
+	if seed != nil {
+		//nolint:gosec
+		rnd = rand.New(rand.NewPCG(uint64(*seed), uint64(executorID)))
+	} else {
+		//nolint:gosec
+		rnd = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	c := &scenarioChooser{rnd: rnd}
+	for _, s := range scenarios {
+		c.total += s.Weight
+		c.cumulative = append(c.cumulative, c.total)
+	}
+	return c
+}
+
+// Choose returns the index of the chosen scenario
+func (c *scenarioChooser) Choose() int {
+	n := c.rnd.IntN(c.total)
+	for i, w := range c.cumulative {
+		if n < w {
+			return i
+		}
+	}
+	return len(c.cumulative) - 1
+}
+
+// Run runs the Scenario runner
+func (r ValidScenario) Run(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	cookieJar CookieJarFactory,
+) error {
+	switch r.Type {
+	case ScenarioTypeHTTP:
+		return r.runHTTP(ctx, log, outputRoot, cookieJar)
+	}
+	return nil
+}
+
+func (r ValidScenario) runHTTP(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	cookieJar CookieJarFactory,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if r.Break.Time > 0 {
+		ctx, cancel = context.WithTimeout(ctx, r.Break.Time)
+		defer cancel()
+	}
+	uniqueName := fmt.Sprintf("%s/%s", outputRoot, utils.GenerateUniqueID())
+	counts := make([]atomic.Int64, len(r.Scenarios))
+
+	var wg sync.WaitGroup
+	var atomicErr atomic.Pointer[syncError]
+	for i := 0; i < r.Executors; i++ {
+		writers := make([]output.HTTPDataWrite, 0, len(r.Output))
+		var closers []output.Close
+		for _, o := range r.Output {
+			writer, closer, err := o.HTTPDataWriteFactory(
+				ctx,
+				log,
+				true,
+				fmt.Sprintf("%s_%d", uniqueName, i),
+				[]string{
+					"Scenario",
+					"Step",
+					"Iteration",
+					"Success",
+					"SendDatetime",
+					"ReceivedDatetime",
+					"ResponseTime",
+					"StatusCode",
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("failed to create writer: %w", err)
+			}
+			writers = append(writers, writer)
+			closers = append(closers, closer)
+		}
+		executorJar := cookieJar
+		if r.Cookies.Scope == CookieScopeFlow {
+			// each executor is a virtual user, so it has its own session
+			jar := newCookieJar()
+			executorJar = func() http.CookieJar { return jar }
+		}
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			defer func() {
+				for _, c := range closers {
+					if err := c(); err != nil {
+						log.Error(ctx, "failed to close writer",
+							logger.Value("error", err), logger.Value("id", id))
+					}
+				}
+			}()
+			chooser := newScenarioChooser(r.Scenarios, r.Seed, id)
+			for iteration := 0; r.Break.Count == 0 || iteration < r.Break.Count; iteration++ {
+				if iteration > 0 && r.Interval > 0 {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(r.Interval):
+					}
+				}
+				if ctx.Err() != nil {
+					return
+				}
+				idx := chooser.Choose()
+				counts[idx].Add(1)
+				if err := r.runScenarioHTTP(ctx, log, idx, id, iteration, writers, executorJar); err != nil {
+					atomicErr.Store(&syncError{Err: err})
+					log.Error(ctx, "failed to run scenario",
+						logger.Value("error", err), logger.Value("id", id), logger.Value("scenario", r.Scenarios[idx].Name))
+					cancel()
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, s := range r.Scenarios {
+		log.Info(ctx, "Scenario iterations",
+			logger.Value("scenario", s.Name), logger.Value("count", counts[i].Load()))
+	}
+	if syncErr := atomicErr.Load(); syncErr != nil {
+		return syncErr.Err
+	}
+	return nil
+}
+
+// runScenarioHTTP sends the steps of the scenario in order,
+// the data extracted from each response is passed to the following steps of the iteration.
+// The failed step is written as failed and the rest of the iteration is skipped, as it may depend on the step,
+// and the error is returned only when the step cannot be rendered or written.
+func (r ValidScenario) runScenarioHTTP(
+	ctx context.Context,
+	log logger.Logger,
+	scenarioIndex int,
+	executorID int,
+	iteration int,
+	writers []output.HTTPDataWrite,
+	cookieJar CookieJarFactory,
+) error {
+	scenario := r.Scenarios[scenarioIndex]
+	extracted := make(map[string]any)
+	for i, s := range scenario.Steps {
+		step, err := r.renderStep(scenarioIndex, i, s, map[string]any{
+			"Iteration":  iteration,
+			"ExecutorID": executorID,
+			"Extracted":  extracted,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to render step %s: %w", s.Name, err)
+		}
+		exe := httpexec.RequestContent[HTTPRequest]{
+			Req: HTTPRequest{
+				Method:        step.Method,
+				URL:           step.URL,
+				Headers:       step.Headers,
+				QueryParams:   step.QueryParam,
+				PathVariables: step.PathVariables,
+				BodyType:      step.BodyType,
+				Body:          step.Body,
+				AttachRequestInfo: func(ctx context.Context, req *http.Request) error {
+					if r.Auth == nil {
+						return nil
+					}
+					r.Auth.SetOnRequest(ctx, req)
+					return nil
+				},
+			},
+			ResponseType: httpexec.ResponseType(step.ResponseType),
+			MaxBodyBytes: step.MaxBodyBytes,
+			CookieJar:    cookieJar,
+			Transport:    step.Transport,
+		}
+		// the request fails to be sent only when it cannot be created from the rendered step
+		resp, err := exe.RequestExecute(ctx, log)
+		if err != nil {
+			return fmt.Errorf("failed to execute request: %w", err)
+		}
+		if ctx.Err() != nil {
+			// the break time is reached while the request is in flight
+			return nil
+		}
+		if step.BodyType == HTTPRequestBodyTypeGraphQL && graphQLFailed(resp.StatusCode, resp.Res) {
+			resp.Success = false
+		}
+		if resp.Success {
+			for _, d := range step.Data {
+				v, err := d.Extractor.Extract(resp.Res)
+				if err != nil {
+					log.Warn(ctx, "failed to extract data, the rest of the iteration is skipped",
+						logger.Value("error", err), logger.Value("scenario", scenario.Name),
+						logger.Value("step", step.Name), logger.Value("key", d.Key))
+					resp.Success = false
+					break
+				}
+				extracted[d.Key] = v
+			}
+		}
+		w := resp.ToWriteHTTPData()
+		row := []string{
+			scenario.Name,
+			step.Name,
+			strconv.Itoa(iteration),
+			strconv.FormatBool(w.Success),
+			w.SendDatetime,
+			w.ReceivedDatetime,
+			strconv.Itoa(w.ResponseTime),
+			w.StatusCode,
+		}
+		for _, writer := range writers {
+			if err := writer(ctx, log, row); err != nil {
+				return fmt.Errorf("failed to write data: %w", err)
+			}
+		}
+		if !resp.Success {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,285 @@
+package runner_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// scenarioServer records the paths of the requests in order, and issues the token on /login
+type scenarioServer struct {
+	*httptest.Server
+	mu     sync.Mutex
+	paths  []string
+	issued int
+	// mismatched is the requests whose token is not the last one issued
+	mismatched []string
+}
+
+func newScenarioServer(t *testing.T) *scenarioServer {
+	t.Helper()
+	s := &scenarioServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.paths = append(s.paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/broken" {
+			_, _ = w.Write([]byte(`not json`))
+			return
+		}
+		if r.URL.Path == "/login" {
+			s.issued++
+			fmt.Fprintf(w, `{"token": "t%d"}`, s.issued)
+			return
+		}
+		if auth := r.Header.Get("Authorization"); auth != "" && auth != fmt.Sprintf("Bearer t%d", s.issued) {
+			s.mismatched = append(s.mismatched, r.URL.Path+" "+auth)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// requests returns the paths of the requests in order
+func (s *scenarioServer) requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.paths...)
+}
+
+// runScenario runs the Scenario runner file against the server
+func runScenario(t *testing.T, srv *scenarioServer, scenario string) error {
+	t.Helper()
+	_, err := execScenario(t, srv, scenario)
+	return err
+}
+
+// execScenario runs the Scenario runner file against the server, and returns the written rows
+func execScenario(t *testing.T, srv *scenarioServer, scenario string) (*memoryOutput, error) {
+	t.Helper()
+	env := newFlowEnv(t, map[string]string{"main.yaml": scenario})
+	env.targets["server"] = target.Target{Type: config.TargetTypeHTTP, URL: srv.URL}
+	err := env.run(t, "main.yaml", &sync.Map{})
+	return env.out, err
+}
+
+// TestScenarioWeights tests that the scenarios are chosen by the weight.
+func TestScenarioWeights(t *testing.T) {
+	srv := newScenarioServer(t)
+	if err := runScenario(t, srv, `kind: Scenario
+type: http
+output:
+  enabled: false
+executors: 2
+seed: 42
+break:
+  count: 400
+scenarios:
+  - name: browse
+    weight: 3
+    steps:
+      - {target_id: server, endpoint: /browse, method: GET, response_type: json}
+  - name: buy
+    weight: 1
+    steps:
+      - {target_id: server, endpoint: /buy, method: GET, response_type: json}
+`); err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+	counts := map[string]int{}
+	for _, p := range srv.requests() {
+		counts[p]++
+	}
+	if total := counts["/browse"] + counts["/buy"]; total != 800 {
+		t.Fatalf("expected 800 iterations of the 2 executors, got %d", total)
+	}
+	// the expected share of browse is 600 and its standard deviation is about 12
+	if counts["/browse"] < 540 || counts["/browse"] > 660 {
+		t.Errorf("expected browse to be chosen about 3 times of buy, got %v", counts)
+	}
+}
+
+// TestScenarioSteps tests that the steps are sent in order, and the data extracted by a step
+// is rendered into the following steps of the same iteration.
+func TestScenarioSteps(t *testing.T) {
+	srv := newScenarioServer(t)
+	if err := runScenario(t, srv, `kind: Scenario
+type: http
+output:
+  enabled: false
+break:
+  count: 5
+scenarios:
+  - name: session
+    weight: 1
+    steps:
+      - name: login
+        target_id: server
+        endpoint: /login
+        method: POST
+        response_type: json
+        data:
+          - key: token
+            extractor: {type: jmesPath, jmes_path: token}
+      - name: items
+        target_id: server
+        endpoint: /items/{{ .Dynamic.Iteration }}
+        method: GET
+        headers:
+          Authorization: "Bearer {{ .Dynamic.Extracted.token }}"
+        response_type: json
+      - name: logout
+        target_id: server
+        endpoint: /logout/{{ .Dynamic.ExecutorID }}
+        method: POST
+        headers:
+          Authorization: "Bearer {{ .Dynamic.Extracted.token }}"
+        response_type: json
+`); err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+	var want []string
+	for i := range 5 {
+		want = append(want, "/login", fmt.Sprintf("/items/%d", i), "/logout/0")
+	}
+	if got := srv.requests(); strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("expected the steps in order %v, got %v", want, got)
+	}
+	if len(srv.mismatched) > 0 {
+		t.Errorf("expected the token of the login of the same iteration, got %v", srv.mismatched)
+	}
+}
+
+// TestScenarioBreak tests the break of the iterations and the failure of the step.
+func TestScenarioBreak(t *testing.T) {
+	cases := []struct {
+		name       string
+		breakYAML  string
+		step       string
+		wantErr    string
+		wantMin    int
+		wantMax    int
+		wantWithin time.Duration
+	}{
+		{
+			name:      "Count",
+			breakYAML: "count: 3",
+			step:      "{target_id: server, endpoint: /a, method: GET, response_type: json}",
+			wantMin:   6,
+			wantMax:   6,
+		},
+		{
+			name:       "Time",
+			breakYAML:  "time: 200ms",
+			step:       "{target_id: server, endpoint: /a, method: GET, response_type: json}",
+			wantMin:    2,
+			wantMax:    100,
+			wantWithin: 2 * time.Second,
+		},
+		{
+			name:      "ExtractError",
+			breakYAML: "count: 3",
+			step: `{target_id: server, endpoint: /a, method: GET, response_type: json,
+          data: [{key: id, extractor: {type: jmesPath, jmes_path: id, on_nil: error}}]}`,
+			wantMin: 6,
+			wantMax: 6,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			srv := newScenarioServer(tt)
+			start := time.Now()
+			err := runScenario(tt, srv, `kind: Scenario
+type: http
+output:
+  enabled: false
+executors: 2
+interval: 10ms
+break:
+  `+c.breakYAML+`
+scenarios:
+  - name: only
+    weight: 1
+    steps:
+      - `+c.step+`
+`)
+			switch {
+			case c.wantErr == "" && err != nil:
+				tt.Fatalf("failed to run: %v", err)
+			case c.wantErr != "" && (err == nil || !strings.Contains(err.Error(), c.wantErr)):
+				tt.Fatalf("expected the error of %q, got %v", c.wantErr, err)
+			}
+			if n := len(srv.requests()); n < c.wantMin || n > c.wantMax {
+				tt.Errorf("expected %d to %d requests, got %d", c.wantMin, c.wantMax, n)
+			}
+			if c.wantWithin > 0 && time.Since(start) > c.wantWithin {
+				tt.Errorf("expected the run to break in %s, took %s", c.wantWithin, time.Since(start))
+			}
+		})
+	}
+}
+
+// TestScenarioStepFailure tests that the failed step is written as failed,
+// and the rest of the iteration is skipped while the executor keeps iterating.
+func TestScenarioStepFailure(t *testing.T) {
+	cases := []struct {
+		name string
+		step string
+	}{
+		{
+			name: "ExtractError",
+			step: `{name: first, target_id: server, endpoint: /a, method: GET, response_type: json,
+          data: [{key: id, extractor: {type: jmesPath, jmes_path: id, on_nil: error}}]}`,
+		},
+		{
+			name: "BrokenResponse",
+			step: "{name: first, target_id: server, endpoint: /broken, method: GET, response_type: json}",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			srv := newScenarioServer(tt)
+			out, err := execScenario(tt, srv, `kind: Scenario
+type: http
+output:
+  enabled: true
+  ids: ["memory"]
+break:
+  count: 3
+scenarios:
+  - name: only
+    weight: 1
+    steps:
+      - `+c.step+`
+      - {name: second, target_id: server, endpoint: /b, method: GET, response_type: json}
+`)
+			if err != nil {
+				tt.Fatalf("failed to run: %v", err)
+			}
+			for _, p := range srv.requests() {
+				if p == "/b" {
+					tt.Fatalf("expected the rest of the iteration to be skipped, got %v", srv.requests())
+				}
+			}
+			rows := out.bySuffix("_0")
+			if len(rows) != 4 {
+				tt.Fatalf("expected the header and 3 rows, got %v", rows)
+			}
+			for i, row := range rows[1:] {
+				if row[1] != "first" || row[2] != strconv.Itoa(i) || row[3] != "false" {
+					tt.Errorf("expected the failed row of the first step of iteration %d, got %v", i, row)
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -25,6 +28,17 @@ type SlaveRequestHandler struct {
 	receiveTermChan <-chan ReceiveTermType
 	// dataBufferMap is a map.
 	dataBufferMap map[string]*bytes.Buffer
+	// barrierMu guards barrierWaits.
+	barrierMu *sync.Mutex
+	// barrierWaits is the barrier waits by the request ID, the slave withdraws the arrival with it.
+	barrierWaits map[string]slaveBarrierWait
+}
+
+// slaveBarrierWait represents the barrier wait of the slave in the master
+type slaveBarrierWait struct {
+	cancel context.CancelFunc
+	// done is closed when the wait returns
+	done chan struct{}
 }
 
 // DefaultChunkSize is an integer.
@@ -42,6 +56,8 @@ func NewSlaveRequestHandler(
 		chunkSize:       DefaultChunkSize,
 		receiveTermChan: termChan,
 		dataBufferMap:   make(map[string]*bytes.Buffer),
+		barrierMu:       &sync.Mutex{},
+		barrierWaits:    make(map[string]slaveBarrierWait),
 	}
 }
 
@@ -53,6 +69,7 @@ func (rh *SlaveRequestHandler) HandleResponse(
 	authFactor AuthenticatorFactor,
 	targetFactor TargetFactor,
 	store Store,
+	barrier Barrier,
 ) error {
 	for {
 		select {
@@ -273,6 +290,17 @@ func (rh *SlaveRequestHandler) HandleResponse(
 				}
 				log.Info(ctx, "Sent target: %v",
 					logger.Value("target_id", targetResourceReq.TargetId))
+			case pb.RequestType_REQUEST_TYPE_BARRIER:
+				barrierReq := res.GetBarrierRequest()
+				if barrierReq.LeaveRequestId != "" {
+					rh.leaveBarrier(ctx, log, barrierReq)
+					break
+				}
+				// the wait is registered before the next request is handled, the leave may follow it immediately
+				waitCtx := rh.registerBarrierWait(ctx, res.RequestId)
+				// the barrier blocks until the other participants arrive,
+				// so it must not block the handling of the other requests
+				go rh.handleBarrier(ctx, waitCtx, log, barrier, res.RequestId, barrierReq)
 			case pb.RequestType_REQUEST_TYPE_UNSPECIFIED:
 				return fmt.Errorf("request type is unspecified")
 			default:
@@ -281,3 +309,88 @@ func (rh *SlaveRequestHandler) HandleResponse(
 		}
 	}
 }
+
+// registerBarrierWait returns the context of the barrier wait, it is cancelled when the slave leaves
+func (rh *SlaveRequestHandler) registerBarrierWait(ctx context.Context, requestID string) context.Context {
+	rh.barrierMu.Lock()
+	defer rh.barrierMu.Unlock()
+	waitCtx, cancel := context.WithCancel(ctx)
+	rh.barrierWaits[requestID] = slaveBarrierWait{cancel: cancel, done: make(chan struct{})}
+	return waitCtx
+}
+
+// unregisterBarrierWait releases the barrier wait which returned
+func (rh *SlaveRequestHandler) unregisterBarrierWait(requestID string) {
+	rh.barrierMu.Lock()
+	defer rh.barrierMu.Unlock()
+	if w, ok := rh.barrierWaits[requestID]; ok {
+		w.cancel()
+		close(w.done)
+		delete(rh.barrierWaits, requestID)
+	}
+}
+
+// leaveBarrier withdraws the arrival of the slave, the barrier does not count it any more.
+// It blocks until the wait returns, so the later arrivals are not counted together with the withdrawn one.
+func (rh *SlaveRequestHandler) leaveBarrier(
+	ctx context.Context,
+	log logger.Logger,
+	req *pb.ReceiveChanelConnectBarrierRequest,
+) {
+	rh.barrierMu.Lock()
+	w, ok := rh.barrierWaits[req.LeaveRequestId]
+	rh.barrierMu.Unlock()
+	if ok {
+		w.cancel()
+		<-w.done
+	}
+	log.Info(ctx, "Slave left barrier",
+		logger.Value("barrier_id", req.BarrierId), logger.Value("request_id", req.LeaveRequestId))
+}
+
+func (rh *SlaveRequestHandler) handleBarrier(
+	ctx context.Context,
+	waitCtx context.Context,
+	log logger.Logger,
+	barrier Barrier,
+	requestID string,
+	req *pb.ReceiveChanelConnectBarrierRequest,
+) {
+	defer rh.unregisterBarrierWait(requestID)
+	status := pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_RELEASED
+	var message string
+	var err error
+	if barrier == nil {
+		err = fmt.Errorf("barrier is not available")
+	} else {
+		err = barrier.Wait(
+			waitCtx,
+			req.BarrierId,
+			int(req.Participants),
+			time.Duration(req.TimeoutMilliseconds)*time.Millisecond,
+		)
+	}
+	if err != nil && waitCtx.Err() != nil && ctx.Err() == nil {
+		// the slave left the barrier, it does not wait for the release any more
+		return
+	}
+	if err != nil {
+		status = pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_ERROR
+		if errors.Is(err, ErrBarrierTimeout) {
+			status = pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_TIMEOUT
+		}
+		message = err.Error()
+	}
+	if _, err := rh.cli.SendBarrierRelease(ctx, &pb.SendBarrierReleaseRequest{
+		RequestId: requestID,
+		BarrierId: req.BarrierId,
+		Status:    status,
+		Message:   message,
+	}); err != nil {
+		log.Error(ctx, "failed to send barrier release",
+			logger.Value("barrier_id", req.BarrierId), logger.Value("error", err))
+		return
+	}
+	log.Info(ctx, "Sent barrier release",
+		logger.Value("barrier_id", req.BarrierId), logger.Value("status", status.String()))
+}
@@ -0,0 +1,378 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/executor/sockexec"
+	"github.com/cresplanex/bloader/internal/logger"
+)
+
+const (
+	// SocketMethodTCP is the method recorded in the results of the TCP requests
+	SocketMethodTCP = "TCP"
+	// SocketMethodUDP is the method recorded in the results of the UDP requests
+	SocketMethodUDP = "UDP"
+)
+
+// DefaultSocketReadTimeout is the default timeout to read the reply
+const DefaultSocketReadTimeout = 5 * time.Second
+
+// MassExecSocket represents the raw TCP or UDP socket configuration for the MassExec runner
+type MassExecSocket struct {
+	Requests []MassExecSocketRequest `yaml:"requests"`
+}
+
+// MassExecSocketRequest represents the socket request configuration for the MassExec runner
+type MassExecSocketRequest struct {
+	TargetID            *string                            `yaml:"target_id"`
+	Payload             *string                            `yaml:"payload"`
+	Encoding            *string                            `yaml:"encoding"`
+	RequestTemplate     MassExecRequestTemplate            `yaml:"request_template"`
+	Framing             MassExecSocketFraming              `yaml:"framing"`
+	KeepAlive           bool                               `yaml:"keep_alive"`
+	DialTimeout         *string                            `yaml:"dial_timeout"`
+	Read                MassExecSocketRead                 `yaml:"read"`
+	Data                []ExecRequestData                  `yaml:"data"`
+	Interval            *string                            `yaml:"interval"`
+	AwaitPrevResp       bool                               `yaml:"await_prev_response"`
+	SuccessBreak        []string                           `yaml:"success_break"`
+	Break               MassExecRequestBreak               `yaml:"break"`
+	RecordExcludeFilter MassExecRequestRecordExcludeFilter `yaml:"record_exclude_filter"`
+	Emit                []MassExecRequestEmit              `yaml:"emit"`
+}
+
+// MassExecSocketFraming represents the length prefix framing of the TCP payload
+type MassExecSocketFraming struct {
+	LengthPrefix *int    `yaml:"length_prefix"`
+	ByteOrder    *string `yaml:"byte_order"`
+}
+
+// Validate validates the MassExecSocketFraming
+func (f MassExecSocketFraming) Validate() (sockexec.Framing, error) {
+	var valid sockexec.Framing
+	if f.LengthPrefix == nil {
+		return valid, nil
+	}
+	switch *f.LengthPrefix {
+	case 0, 1, 2, 4, 8:
+		valid.LengthPrefix = *f.LengthPrefix
+	default:
+		return sockexec.Framing{}, fmt.Errorf("invalid length_prefix value: %d", *f.LengthPrefix)
+	}
+	if f.ByteOrder != nil {
+		switch *f.ByteOrder {
+		case "big":
+		case "little":
+			valid.LittleEndian = true
+		default:
+			return sockexec.Framing{}, fmt.Errorf("invalid byte_order value: %s", *f.ByteOrder)
+		}
+	}
+	return valid, nil
+}
+
+// MassExecSocketRead represents how the reply is read, the delimiter is written in the encoding of the payload
+type MassExecSocketRead struct {
+	Mode      *string `yaml:"mode"`
+	Delimiter *string `yaml:"delimiter"`
+	Length    *int    `yaml:"length"`
+	Timeout   *string `yaml:"timeout"`
+	MaxBytes  *int    `yaml:"max_bytes"`
+}
+
+// Validate validates the MassExecSocketRead,
+// the default mode is datagram for the UDP and none for the TCP
+func (r MassExecSocketRead) Validate(
+	protocol sockexec.Protocol,
+	encoding sockexec.Encoding,
+	framing sockexec.Framing,
+) (sockexec.ReadConfig, error) {
+	valid := sockexec.ReadConfig{
+		Mode:    sockexec.ReadModeNone,
+		Timeout: DefaultSocketReadTimeout,
+	}
+	if protocol == sockexec.ProtocolUDP {
+		valid.Mode = sockexec.ReadModeDatagram
+	}
+	if r.Mode != nil {
+		valid.Mode = sockexec.ReadMode(*r.Mode)
+	}
+	switch valid.Mode {
+	case sockexec.ReadModeNone:
+	case sockexec.ReadModeDatagram:
+		if protocol != sockexec.ProtocolUDP {
+			return sockexec.ReadConfig{}, fmt.Errorf("datagram mode is only for the udp target")
+		}
+	case sockexec.ReadModeDelimiter:
+		if protocol != sockexec.ProtocolTCP {
+			return sockexec.ReadConfig{}, fmt.Errorf("delimiter mode is only for the tcp target")
+		}
+		if r.Delimiter == nil {
+			return sockexec.ReadConfig{}, fmt.Errorf("delimiter is required")
+		}
+		delimiter, err := encoding.Decode(*r.Delimiter)
+		if err != nil {
+			return sockexec.ReadConfig{}, fmt.Errorf("failed to decode delimiter: %w", err)
+		}
+		if len(delimiter) == 0 {
+			return sockexec.ReadConfig{}, fmt.Errorf("delimiter must not be empty")
+		}
+		valid.Delimiter = delimiter
+	case sockexec.ReadModeLength:
+		if protocol != sockexec.ProtocolTCP {
+			return sockexec.ReadConfig{}, fmt.Errorf("length mode is only for the tcp target")
+		}
+		if r.Length == nil || *r.Length <= 0 {
+			return sockexec.ReadConfig{}, fmt.Errorf("length must be positive")
+		}
+		valid.Length = *r.Length
+	case sockexec.ReadModeLengthPrefix:
+		if protocol != sockexec.ProtocolTCP {
+			return sockexec.ReadConfig{}, fmt.Errorf("length_prefix mode is only for the tcp target")
+		}
+		if framing.LengthPrefix == 0 {
+			return sockexec.ReadConfig{}, fmt.Errorf("framing.length_prefix is required for length_prefix mode")
+		}
+	default:
+		return sockexec.ReadConfig{}, fmt.Errorf("invalid read mode value: %s", valid.Mode)
+	}
+	if r.Timeout != nil {
+		timeout, err := time.ParseDuration(*r.Timeout)
+		if err != nil {
+			return sockexec.ReadConfig{}, fmt.Errorf("failed to parse timeout: %w", err)
+		}
+		valid.Timeout = timeout
+	}
+	if r.MaxBytes != nil {
+		if *r.MaxBytes < 0 {
+			return sockexec.ReadConfig{}, fmt.Errorf("max_bytes must not be negative")
+		}
+		valid.MaxBytes = *r.MaxBytes
+	}
+	return valid, nil
+}
+
+// MassExecSocketRequestTemplateFields represents the fields which can be overridden by the per-request template
+type MassExecSocketRequestTemplateFields struct {
+	Payload *string `yaml:"payload"`
+}
+
+// ValidMassExecSocket represents the valid socket configuration for the MassExec runner
+type ValidMassExecSocket struct {
+	Requests []ValidMassExecSocketRequest
+}
+
+// ValidMassExecSocketRequest represents the valid socket request configuration for the MassExec runner.
+// The payload is held as the body, and the replies are handled as the JSON responses
+// with the text, hex, base64 and size fields.
+type ValidMassExecSocketRequest struct {
+	ValidMassExecRequest
+	Encoding sockexec.Encoding
+	Config   sockexec.Config
+}
+
+// Validate validates the MassExecSocket
+func (s MassExecSocket) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecSocket, error) {
+	var valid ValidMassExecSocket
+	for i, req := range s.Requests {
+		reqTmpl := newFieldTmpl(tmplSet, tmpl, replaceData, "socket", "requests", i)
+		validRequest, err := req.Validate(ctx, log, targetFactor, tmplSet, reqTmpl, replaceData)
+		if err != nil {
+			return ValidMassExecSocket{}, fmt.Errorf("failed to validate request[%d]: %w", i, err)
+		}
+		valid.Requests = append(valid.Requests, validRequest)
+	}
+	return valid, nil
+}
+
+// Validate validates the MassExecSocketRequest
+func (r MassExecSocketRequest) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecSocketRequest, error) {
+	var valid ValidMassExecSocketRequest
+	if r.TargetID == nil {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("target_id is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *r.TargetID)
+	if err != nil {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("failed to factorize target: %w", err)
+	}
+	switch tg.Type {
+	case config.TargetTypeTCP:
+		valid.Config.Protocol = sockexec.ProtocolTCP
+		valid.Method = SocketMethodTCP
+	case config.TargetTypeUDP:
+		valid.Config.Protocol = sockexec.ProtocolUDP
+		valid.Method = SocketMethodUDP
+	default:
+		return ValidMassExecSocketRequest{}, fmt.Errorf("target %s is not a tcp or udp target", *r.TargetID)
+	}
+	valid.Config.Address = strings.TrimPrefix(tg.URL, string(valid.Config.Protocol)+"://")
+	valid.URL = fmt.Sprintf("%s://%s", valid.Config.Protocol, valid.Config.Address)
+
+	valid.Encoding = sockexec.EncodingText
+	if r.Encoding != nil {
+		switch sockexec.Encoding(*r.Encoding) {
+		case sockexec.EncodingText, sockexec.EncodingHex, sockexec.EncodingBase64:
+			valid.Encoding = sockexec.Encoding(*r.Encoding)
+		default:
+			return ValidMassExecSocketRequest{}, fmt.Errorf("invalid encoding value: %s", *r.Encoding)
+		}
+	}
+	if r.Payload == nil {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("payload is required")
+	}
+	if _, err := valid.Encoding.Decode(*r.Payload); err != nil {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("failed to decode payload: %w", err)
+	}
+	valid.Body = *r.Payload
+
+	if valid.Config.Framing, err = r.Framing.Validate(); err != nil {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("failed to validate framing: %w", err)
+	}
+	if valid.Config.Framing.LengthPrefix > 0 && valid.Config.Protocol != sockexec.ProtocolTCP {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("framing is only for the tcp target")
+	}
+	if valid.Config.Read, err = r.Read.Validate(valid.Config.Protocol, valid.Encoding, valid.Config.Framing); err != nil {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("failed to validate read: %w", err)
+	}
+	valid.Config.KeepAlive = r.KeepAlive
+	if r.DialTimeout != nil {
+		if valid.Config.DialTimeout, err = time.ParseDuration(*r.DialTimeout); err != nil {
+			return ValidMassExecSocketRequest{}, fmt.Errorf("failed to parse dial_timeout: %w", err)
+		}
+	}
+
+	valid.ResponseType = string(httpexec.ResponseTypeJSON)
+	handling := MassExecRequest{
+		Data:                r.Data,
+		Interval:            r.Interval,
+		AwaitPrevResp:       r.AwaitPrevResp,
+		SuccessBreak:        r.SuccessBreak,
+		Break:               r.Break,
+		RecordExcludeFilter: r.RecordExcludeFilter,
+		Emit:                r.Emit,
+	}
+	if err := handling.validateHandling(ctx, log, &valid.ValidMassExecRequest); err != nil {
+		return ValidMassExecSocketRequest{}, err
+	}
+	if valid.RequestTmpl, err = r.RequestTemplate.Validate(tmplSet); err != nil {
+		return ValidMassExecSocketRequest{}, fmt.Errorf("failed to validate request template: %w", err)
+	}
+	valid.Tmpl = tmpl
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// SocketRequest represents the socket request of the MassExec runner, it is rendered for each count
+type SocketRequest struct {
+	Payload     string
+	Encoding    sockexec.Encoding
+	Tmpl        *template.Template
+	RequestTmpl *template.Template
+	ReplaceData map[string]any
+	ReqIndex    int
+}
+
+// render renders the payload for the given count
+func (r SocketRequest) render(count int) (string, error) {
+	if r.RequestTmpl == nil && r.Tmpl == nil {
+		return r.Payload, nil
+	}
+	replaceData := massReplaceData(r.ReplaceData, map[string]any{
+		"RequestLoopCount": count,
+	})
+	var buffer bytes.Buffer
+	if r.RequestTmpl != nil {
+		if err := r.RequestTmpl.Execute(&buffer, replaceData); err != nil {
+			return "", fmt.Errorf("failed to execute request template: %w", err)
+		}
+		var fields MassExecSocketRequestTemplateFields
+		if err := yaml.Unmarshal(buffer.Bytes(), &fields); err != nil {
+			return "", fmt.Errorf("failed to unmarshal request template: %w", err)
+		}
+		if fields.Payload != nil {
+			return *fields.Payload, nil
+		}
+		return r.Payload, nil
+	}
+	if err := r.Tmpl.Execute(&buffer, replaceData); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	var massExec MassExec
+	if err := yaml.Unmarshal(buffer.Bytes(), &massExec); err != nil {
+		return "", fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if massExec.Socket == nil || r.ReqIndex >= len(massExec.Socket.Requests) ||
+		massExec.Socket.Requests[r.ReqIndex].Payload == nil {
+		return "", fmt.Errorf("socket request[%d] not found in rendered template", r.ReqIndex)
+	}
+	return *massExec.Socket.Requests[r.ReqIndex].Payload, nil
+}
+
+// CreatePayload renders the payload and decodes it by the encoding
+func (r SocketRequest) CreatePayload(_ context.Context, _ logger.Logger, count int) ([]byte, error) {
+	payload, err := r.render(count)
+	if err != nil {
+		return nil, err
+	}
+	return r.Encoding.Decode(payload)
+}
+
+var _ sockexec.ExecReq = SocketRequest{}
+
+func (r ValidMassExec) runSocket(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+) ([]MassExecResult, error) {
+	threads := make([]massExecThread, len(r.Socket.Requests))
+	for i, request := range r.Socket.Requests {
+		resChan := make(chan httpexec.ResponseContent)
+		threads[i] = massExecThread{
+			request: request.ValidMassExecRequest,
+			result: MassExecResult{
+				RequestIndex: i,
+				Method:       request.Method,
+				URL:          request.URL,
+			},
+			executor: sockexec.MassRequestContent[SocketRequest]{
+				MassDriver: request.massDriver(resChan),
+				Client:     sockexec.NewClient(request.Config),
+				Req: SocketRequest{
+					Payload:     fmt.Sprint(request.Body),
+					Encoding:    request.Encoding,
+					Tmpl:        request.Tmpl,
+					RequestTmpl: request.RequestTmpl,
+					ReplaceData: request.ReplaceData,
+					ReqIndex:    i,
+				},
+			},
+			resChan: resChan,
+			columns: sockexec.ColumnHeader,
+		}
+	}
+	return r.runThreads(ctx, log, outputRoot, eventCaster, threads)
+}
@@ -0,0 +1,291 @@
+package runner_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// newTCPServer starts the TCP server, the lines are answered with the pong line,
+// and the length prefixed frames are echoed until the third frame on the connection is answered with ERR
+func newTCPServer(t *testing.T) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				first, err := reader.Peek(1)
+				if err != nil {
+					return
+				}
+				if first[0] != 0 {
+					for {
+						line, err := reader.ReadString('\n')
+						if err != nil {
+							return
+						}
+						_, _ = conn.Write([]byte("pong " + line[:len(line)-1] + "\r\n"))
+					}
+				}
+				for n := 1; ; n++ {
+					prefix := make([]byte, 2)
+					if _, err := io.ReadFull(reader, prefix); err != nil {
+						return
+					}
+					frame := make([]byte, binary.BigEndian.Uint16(prefix))
+					if _, err := io.ReadFull(reader, frame); err != nil {
+						return
+					}
+					if n == 3 {
+						frame = []byte("ERR")
+					}
+					_, _ = conn.Write(binary.BigEndian.AppendUint16(nil, uint16(len(frame))))
+					_, _ = conn.Write(frame)
+				}
+			}()
+		}
+	}()
+	return ln
+}
+
+// newReplyServer starts the TCP server which answers each read with the fixed reply
+func newReplyServer(t *testing.T, reply []byte) net.Listener {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				buf := make([]byte, 1024)
+				for {
+					if _, err := conn.Read(buf); err != nil {
+						return
+					}
+					_, _ = conn.Write(reply)
+				}
+			}()
+		}
+	}()
+	return ln
+}
+
+// newUDPServer starts the UDP server which echoes the datagrams
+func newUDPServer(t *testing.T) net.PacketConn {
+	t.Helper()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = pc.Close() })
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, addr, err := pc.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			_, _ = pc.WriteTo(buf[:n], addr)
+		}
+	}()
+	return pc
+}
+
+// TestMassExecSocket tests the socket MassExec against the in-process TCP and UDP servers.
+func TestMassExecSocket(t *testing.T) {
+	tcp := newTCPServer(t)
+	udp := newUDPServer(t)
+	targets := target.Container{
+		"tcp": {Type: config.TargetTypeTCP, URL: "tcp://" + tcp.Addr().String()},
+		"udp": {Type: config.TargetTypeUDP, URL: udp.LocalAddr().String()},
+	}
+
+	t.Run("Delimiter", func(tt *testing.T) {
+		out, results := runMassExec(tt, targets, "socket", `
+type: socket
+output:
+  enabled: true
+  ids: ["memory"]
+socket:
+  requests:
+    - target_id: tcp
+      payload: "ping {{ .Dynamic.RequestLoopCount }}\n"
+      read:
+        mode: delimiter
+        delimiter: "\r\n"
+      data:
+        - key: Reply
+          extractor:
+            type: jmesPath
+            jmes_path: text
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 3
+`)
+		if len(results) != 1 || !results[0].Success || results[0].Method != runner.SocketMethodTCP {
+			tt.Fatalf("expected the count break, got %+v", results)
+		}
+		rows := out.bySuffix("_0")
+		if len(rows) != 4 || rows[0][6] != "ConnectTime" {
+			tt.Fatalf("expected header and 3 rows, got %v", rows)
+		}
+		for _, row := range rows[1:] {
+			count, _ := strconv.Atoi(row[3])
+			if row[0] != "true" || row[7] != "pong ping "+strconv.Itoa(count) {
+				tt.Errorf("expected the pong reply of %d, got %v", count, row)
+			}
+		}
+	})
+
+	t.Run("LengthPrefix", func(tt *testing.T) {
+		out, results := runMassExec(tt, targets, "socket", `
+type: socket
+output:
+  enabled: true
+  ids: ["memory"]
+socket:
+  requests:
+    - target_id: tcp
+      payload: "0x CA FE"
+      encoding: hex
+      framing:
+        length_prefix: 2
+        byte_order: big
+      keep_alive: true
+      read:
+        mode: length_prefix
+      data:
+        - key: Reply
+          extractor:
+            type: jmesPath
+            jmes_path: hex
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - responseBytes/err
+      break:
+        count: 10
+        response_bytes:
+          - id: err
+            op: regex
+            value: "^ERR$"
+`)
+		if len(results) != 1 || !results[0].Success || results[0].MatchedID != "err" {
+			tt.Fatalf("expected the response bytes break, got %+v", results)
+		}
+		rows := out.bySuffix("_0")
+		if len(rows) != 4 || rows[1][7] != "cafe" || rows[2][7] != "cafe" || rows[3][7] != "455252" {
+			tt.Errorf("expected two echoes on the kept connection and ERR, got %v", rows)
+		}
+	})
+
+	t.Run("Datagram", func(tt *testing.T) {
+		out, results := runMassExec(tt, targets, "socket", `
+type: socket
+output:
+  enabled: true
+  ids: ["memory"]
+socket:
+  requests:
+    - target_id: udp
+      payload: "aGVsbG8="
+      encoding: base64
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - responseBytes/hello
+      break:
+        count: 5
+        response_bytes:
+          - id: hello
+            op: hexPrefix
+            value: "68656c6c6f"
+`)
+		if len(results) != 1 || !results[0].Success || results[0].MatchedID != "hello" || results[0].Method != runner.SocketMethodUDP {
+			tt.Fatalf("expected the response bytes break, got %+v", results)
+		}
+		if rows := out.bySuffix("_0"); len(rows) != 2 || rows[1][0] != "true" {
+			tt.Errorf("expected the echoed datagram, got %v", rows)
+		}
+	})
+
+	t.Run("OversizedReply", func(tt *testing.T) {
+		cases := []struct {
+			name  string
+			reply []byte
+			read  string
+		}{
+			{
+				// the length overflows int when it is converted
+				name:  "PrefixOverflow",
+				reply: bytes.Repeat([]byte{0xff}, 8),
+				read:  "{mode: length_prefix}\n      framing: {length_prefix: 8}",
+			},
+			{
+				// the length would allocate 4 GiB without the default max bytes
+				name:  "PrefixDefaultMax",
+				reply: []byte{0xff, 0xff, 0xff, 0xff},
+				read:  "{mode: length_prefix}\n      framing: {length_prefix: 4}",
+			},
+			{
+				name:  "DelimiterMaxBytes",
+				reply: bytes.Repeat([]byte("a"), 512),
+				read:  `{mode: delimiter, delimiter: "\n", max_bytes: 100}`,
+			},
+		}
+		for _, c := range cases {
+			tt.Run(c.name, func(ttt *testing.T) {
+				server := newReplyServer(ttt, c.reply)
+				_, _, err := execMassExec(ttt, ttt.TempDir(), target.Container{
+					"tcp": {Type: config.TargetTypeTCP, URL: "tcp://" + server.Addr().String()},
+				}, "socket", `
+type: socket
+output:
+  enabled: false
+socket:
+  requests:
+    - target_id: tcp
+      payload: "ping"
+      read: `+c.read+`
+      interval: 1ms
+      await_prev_response: true
+      break:
+        count: 3
+        sys_error: true
+`)
+				if err == nil || !strings.Contains(err.Error(), "sysError") {
+					ttt.Errorf("expected the read error to break, got %v", err)
+				}
+			})
+		}
+	})
+}
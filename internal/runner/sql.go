@@ -0,0 +1,392 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/executor/sqlexec"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/utils"
+)
+
+const (
+	// SQLMethodQuery is the method recorded in the results of the statements returning the rows
+	SQLMethodQuery = "QUERY"
+	// SQLMethodExec is the method recorded in the results of the statements returning the affected rows
+	SQLMethodExec = "EXEC"
+)
+
+// sqlMethods maps the mode to the method recorded in the results
+var sqlMethods = map[sqlexec.Mode]string{
+	sqlexec.ModeQuery: SQLMethodQuery,
+	sqlexec.ModeExec:  SQLMethodExec,
+}
+
+// sqlTarget returns the client configuration of the sql target
+func sqlTarget(ctx context.Context, targetFactor TargetFactor, targetID *string) (sqlexec.Config, error) {
+	if targetID == nil {
+		return sqlexec.Config{}, fmt.Errorf("target_id is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *targetID)
+	if err != nil {
+		return sqlexec.Config{}, fmt.Errorf("failed to factorize target: %w", err)
+	}
+	if tg.Type != config.TargetTypeSQL {
+		return sqlexec.Config{}, fmt.Errorf("target %s is not a sql target", *targetID)
+	}
+	if _, _, err := sqlexec.ParseURL(tg.URL); err != nil {
+		return sqlexec.Config{}, fmt.Errorf("invalid url of target %s: %w", *targetID, err)
+	}
+	return sqlexec.Config{URL: tg.URL}, nil
+}
+
+// validateSQLStatement validates the statement, the default mode is query
+func validateSQLStatement(mode *string, query *string, args []any) (sqlexec.Query, error) {
+	valid := sqlexec.Query{Mode: sqlexec.ModeQuery}
+	if mode != nil {
+		switch sqlexec.Mode(*mode) {
+		case sqlexec.ModeQuery, sqlexec.ModeExec:
+			valid.Mode = sqlexec.Mode(*mode)
+		default:
+			return sqlexec.Query{}, fmt.Errorf("invalid mode value: %s", *mode)
+		}
+	}
+	if query == nil || strings.TrimSpace(*query) == "" {
+		return sqlexec.Query{}, fmt.Errorf("query is required")
+	}
+	valid.SQL = *query
+	if err := validateSQLArgs(args); err != nil {
+		return sqlexec.Query{}, err
+	}
+	valid.Args = args
+	return valid, nil
+}
+
+// validateSQLArgs validates the bound parameters are the scalar values
+func validateSQLArgs(args []any) error {
+	for i, arg := range args {
+		switch arg.(type) {
+		case map[string]any, []any:
+			return fmt.Errorf("args[%d] must be a scalar value", i)
+		}
+	}
+	return nil
+}
+
+// validateSQLTimeout validates the timeout of each statement
+func validateSQLTimeout(timeout *string) (time.Duration, error) {
+	if timeout == nil {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(*timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse timeout: %w", err)
+	}
+	return d, nil
+}
+
+// MassExecSQL represents the SQL database configuration for the MassExec runner
+type MassExecSQL struct {
+	Requests []MassExecSQLRequest `yaml:"requests"`
+}
+
+// MassExecSQLRequest represents the SQL statement configuration for the MassExec runner
+type MassExecSQLRequest struct {
+	TargetID            *string                            `yaml:"target_id"`
+	Mode                *string                            `yaml:"mode"`
+	Query               *string                            `yaml:"query"`
+	Args                []any                              `yaml:"args"`
+	RequestTemplate     MassExecRequestTemplate            `yaml:"request_template"`
+	MaxOpenConns        *int                               `yaml:"max_open_conns"`
+	Timeout             *string                            `yaml:"timeout"`
+	Data                []ExecRequestData                  `yaml:"data"`
+	Interval            *string                            `yaml:"interval"`
+	AwaitPrevResp       bool                               `yaml:"await_prev_response"`
+	SuccessBreak        []string                           `yaml:"success_break"`
+	Break               MassExecRequestBreak               `yaml:"break"`
+	RecordExcludeFilter MassExecRequestRecordExcludeFilter `yaml:"record_exclude_filter"`
+	Emit                []MassExecRequestEmit              `yaml:"emit"`
+}
+
+// MassExecSQLRequestTemplateFields represents the fields which can be overridden by the per-request template
+type MassExecSQLRequestTemplateFields struct {
+	Query *string `yaml:"query"`
+	Args  []any   `yaml:"args"`
+}
+
+// ValidMassExecSQL represents the valid SQL database configuration for the MassExec runner
+type ValidMassExecSQL struct {
+	Requests []ValidMassExecSQLRequest
+}
+
+// ValidMassExecSQLRequest represents the valid SQL statement configuration for the MassExec runner.
+// The returned rows are handled as the JSON array of the objects keyed by the column names.
+type ValidMassExecSQLRequest struct {
+	ValidMassExecRequest
+	Config sqlexec.Config
+	Query  sqlexec.Query
+}
+
+// Validate validates the MassExecSQL
+func (s MassExecSQL) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecSQL, error) {
+	var valid ValidMassExecSQL
+	for i, req := range s.Requests {
+		reqTmpl := newFieldTmpl(tmplSet, tmpl, replaceData, "sql", "requests", i)
+		validRequest, err := req.Validate(ctx, log, targetFactor, tmplSet, reqTmpl, replaceData)
+		if err != nil {
+			return ValidMassExecSQL{}, fmt.Errorf("failed to validate request[%d]: %w", i, err)
+		}
+		valid.Requests = append(valid.Requests, validRequest)
+	}
+	return valid, nil
+}
+
+// Validate validates the MassExecSQLRequest
+func (r MassExecSQLRequest) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecSQLRequest, error) {
+	var valid ValidMassExecSQLRequest
+	var err error
+	if valid.Config, err = sqlTarget(ctx, targetFactor, r.TargetID); err != nil {
+		return ValidMassExecSQLRequest{}, err
+	}
+	if valid.Query, err = validateSQLStatement(r.Mode, r.Query, r.Args); err != nil {
+		return ValidMassExecSQLRequest{}, err
+	}
+	if r.MaxOpenConns != nil {
+		if *r.MaxOpenConns < 0 {
+			return ValidMassExecSQLRequest{}, fmt.Errorf("max_open_conns must not be negative")
+		}
+		valid.Config.MaxOpenConns = *r.MaxOpenConns
+	}
+	if valid.Config.Timeout, err = validateSQLTimeout(r.Timeout); err != nil {
+		return ValidMassExecSQLRequest{}, err
+	}
+	valid.Method = sqlMethods[valid.Query.Mode]
+	valid.URL = sqlexec.RedactURL(valid.Config.URL)
+	valid.Body = valid.Query.SQL
+
+	valid.ResponseType = string(httpexec.ResponseTypeJSON)
+	handling := MassExecRequest{
+		Data:                r.Data,
+		Interval:            r.Interval,
+		AwaitPrevResp:       r.AwaitPrevResp,
+		SuccessBreak:        r.SuccessBreak,
+		Break:               r.Break,
+		RecordExcludeFilter: r.RecordExcludeFilter,
+		Emit:                r.Emit,
+	}
+	if err := handling.validateHandling(ctx, log, &valid.ValidMassExecRequest); err != nil {
+		return ValidMassExecSQLRequest{}, err
+	}
+	if valid.RequestTmpl, err = r.RequestTemplate.Validate(tmplSet); err != nil {
+		return ValidMassExecSQLRequest{}, fmt.Errorf("failed to validate request template: %w", err)
+	}
+	valid.Tmpl = tmpl
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// SQLRequest represents the SQL statement of the MassExec runner, it is rendered for each count
+type SQLRequest struct {
+	Query       sqlexec.Query
+	Tmpl        *template.Template
+	RequestTmpl *template.Template
+	ReplaceData map[string]any
+	ReqIndex    int
+}
+
+// CreateQuery renders the statement and its parameters for the given count
+func (r SQLRequest) CreateQuery(_ context.Context, _ logger.Logger, count int) (sqlexec.Query, error) {
+	if r.RequestTmpl == nil && r.Tmpl == nil {
+		return r.Query, nil
+	}
+	replaceData := massReplaceData(r.ReplaceData, map[string]any{
+		"RequestLoopCount": count,
+	})
+	query := r.Query
+	var buffer bytes.Buffer
+	if r.RequestTmpl != nil {
+		if err := r.RequestTmpl.Execute(&buffer, replaceData); err != nil {
+			return sqlexec.Query{}, fmt.Errorf("failed to execute request template: %w", err)
+		}
+		var fields MassExecSQLRequestTemplateFields
+		if err := yaml.Unmarshal(buffer.Bytes(), &fields); err != nil {
+			return sqlexec.Query{}, fmt.Errorf("failed to unmarshal request template: %w", err)
+		}
+		if fields.Query != nil {
+			query.SQL = *fields.Query
+		}
+		if fields.Args != nil {
+			query.Args = fields.Args
+		}
+		return query, validateSQLArgs(query.Args)
+	}
+	if err := r.Tmpl.Execute(&buffer, replaceData); err != nil {
+		return sqlexec.Query{}, fmt.Errorf("failed to execute template: %w", err)
+	}
+	var massExec MassExec
+	if err := yaml.Unmarshal(buffer.Bytes(), &massExec); err != nil {
+		return sqlexec.Query{}, fmt.Errorf("failed to unmarshal yaml: %w", err)
+	}
+	if massExec.SQL == nil || r.ReqIndex >= len(massExec.SQL.Requests) ||
+		massExec.SQL.Requests[r.ReqIndex].Query == nil {
+		return sqlexec.Query{}, fmt.Errorf("sql request[%d] not found in rendered template", r.ReqIndex)
+	}
+	rendered := massExec.SQL.Requests[r.ReqIndex]
+	query.SQL = *rendered.Query
+	query.Args = rendered.Args
+	return query, validateSQLArgs(query.Args)
+}
+
+var _ sqlexec.ExecReq = SQLRequest{}
+
+func (r ValidMassExec) runSQL(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+) ([]MassExecResult, error) {
+	threads := make([]massExecThread, len(r.SQL.Requests))
+	clients := make([]*sqlexec.Client, 0, len(r.SQL.Requests))
+	for i, request := range r.SQL.Requests {
+		client, err := sqlexec.NewClient(request.Config)
+		if err != nil {
+			// the clients are closed by the executors once they are started
+			for _, c := range clients {
+				_ = c.Close()
+			}
+			return nil, fmt.Errorf("failed to create sql client: %w", err)
+		}
+		clients = append(clients, client)
+		resChan := make(chan httpexec.ResponseContent)
+		threads[i] = massExecThread{
+			request: request.ValidMassExecRequest,
+			result: MassExecResult{
+				RequestIndex: i,
+				Method:       request.Method,
+				URL:          request.URL,
+			},
+			executor: sqlexec.MassRequestContent[SQLRequest]{
+				MassDriver: request.massDriver(resChan),
+				Client:     client,
+				Req: SQLRequest{
+					Query:       request.Query,
+					Tmpl:        request.Tmpl,
+					RequestTmpl: request.RequestTmpl,
+					ReplaceData: request.ReplaceData,
+					ReqIndex:    i,
+				},
+			},
+			resChan: resChan,
+			columns: sqlexec.ColumnHeader,
+		}
+	}
+	return r.runThreads(ctx, log, outputRoot, eventCaster, threads)
+}
+
+func (r ValidOneExec) runSQL(
+	ctx context.Context,
+	outputRoot string,
+	str *sync.Map,
+	log logger.Logger,
+	store Store,
+) error {
+	client, err := sqlexec.NewClient(r.Request.SQL)
+	if err != nil {
+		return fmt.Errorf("failed to create sql client: %w", err)
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			log.Debug(ctx, "failed to close sql client",
+				logger.Value("error", err))
+		}
+	}()
+
+	writers := make([]output.HTTPDataWrite, 0)
+	uniqueName := fmt.Sprintf("%s/%s", outputRoot, utils.GenerateUniqueID())
+	header := []string{
+		"Success",
+		"SendDatetime",
+		"ReceivedDatetime",
+		"Count",
+		"ResponseTime",
+		"StatusCode",
+	}
+	header = append(header, sqlexec.ColumnHeader...)
+	for _, o := range r.Output {
+		writer, closer, err := o.HTTPDataWriteFactory(
+			ctx,
+			log,
+			true,
+			uniqueName,
+			append(header, r.Request.Data.ExtractHeader()...),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create writer: %w", err)
+		}
+		defer closer()
+		writers = append(writers, writer)
+	}
+
+	resp, err := client.Execute(ctx, r.Request.Query)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	res := sqlexec.RowsValue(resp.Rows)
+	data := resp.Columns()
+	for _, d := range r.Request.Data {
+		result, err := d.Extractor.Extract(res)
+		if err != nil {
+			return fmt.Errorf("failed to extract data: %w", err)
+		}
+		data = append(data, fmt.Sprint(result))
+	}
+	write := httpexec.ResponseContent{
+		Success:      true,
+		StartTime:    resp.StartTime,
+		EndTime:      resp.EndTime,
+		ResponseTime: resp.EndTime.Sub(resp.StartTime).Milliseconds(),
+	}
+	for _, w := range writers {
+		if err := w(ctx, log, append(write.ToWriteHTTPData().ToSlice(), data...)); err != nil {
+			return fmt.Errorf("failed to write data: %w", err)
+		}
+	}
+
+	for _, d := range r.Request.MemoryData {
+		result, err := d.Extractor.Extract(res)
+		if err != nil {
+			return fmt.Errorf("failed to extract memory data: %w", err)
+		}
+		str.Store(d.Key, result)
+	}
+
+	if err := store.StoreWithExtractor(ctx, res, r.Request.StoreData, nil); err != nil {
+		return fmt.Errorf("failed to store data: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package runner_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// nopStore discards the stored data
+type nopStore struct{}
+
+func (nopStore) Store(context.Context, []runner.ValidStoreValueData, runner.StoreCallback) error {
+	return nil
+}
+
+func (nopStore) StoreWithExtractor(context.Context, any, []runner.ValidExecRequestStoreData, runner.StoreWithExtractorCallback) error {
+	return nil
+}
+
+func (nopStore) Import(context.Context, []runner.ValidStoreImportData, runner.ImportCallback) error {
+	return nil
+}
+
+func runSQLOneExec(t *testing.T, targets target.Container, body string) (*memoryOutput, *sync.Map) {
+	t.Helper()
+	ctx := context.Background()
+	var oneExec runner.OneExec
+	if err := yaml.Unmarshal([]byte(body), &oneExec); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	out := &memoryOutput{rows: make(map[string][][]string)}
+	valid, err := oneExec.Validate(ctx, nil, outputFactor{out: out}, runner.NewLocalTargetFactor(targets))
+	if err != nil {
+		t.Fatalf("failed to validate: %v", err)
+	}
+	str := &sync.Map{}
+	if err := valid.Run(ctx, "sql", str, logger.NewSlogLogger(), nopStore{}, nil); err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+	return out, str
+}
+
+// TestSQL tests the sql OneExec and MassExec against the SQLite database file.
+func TestSQL(t *testing.T) {
+	targets := target.Container{
+		"db": {Type: config.TargetTypeSQL, URL: "sqlite://" + filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=5000&_journal_mode=WAL"},
+	}
+
+	runSQLOneExec(t, targets, `
+type: sql
+output:
+  enabled: false
+request:
+  target_id: db
+  mode: exec
+  query: CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)
+`)
+
+	out, results := runMassExec(t, targets, "sql", `
+type: sql
+output:
+  enabled: true
+  ids: ["memory"]
+sql:
+  requests:
+    - target_id: db
+      mode: exec
+      query: INSERT INTO users (name) VALUES (?)
+      args: ["user{{ .Dynamic.RequestLoopCount }}"]
+      max_open_conns: 1
+      interval: 1ms
+      await_prev_response: true
+      success_break:
+        - count
+      break:
+        count: 3
+`)
+	if len(results) != 1 || !results[0].Success || results[0].Method != runner.SQLMethodExec {
+		t.Fatalf("expected the count break, got %+v", results)
+	}
+	rows := out.bySuffix("_0")
+	if len(rows) != 4 || rows[0][6] != "RowsAffected" || rows[0][7] != "RowsReturned" {
+		t.Fatalf("expected header and 3 rows, got %v", rows)
+	}
+	for _, row := range rows[1:] {
+		if row[0] != "true" || row[6] != "1" || row[7] != "0" {
+			t.Errorf("expected the inserted row, got %v", row)
+		}
+	}
+
+	out, str := runSQLOneExec(t, targets, `
+type: sql
+output:
+  enabled: true
+  ids: ["memory"]
+request:
+  target_id: db
+  query: SELECT id, name FROM users WHERE id > ? ORDER BY id
+  args: [1]
+  data:
+    - key: Names
+      extractor:
+        type: jmesPath
+        jmes_path: "join(',', [*].name)"
+  memory_data:
+    - key: LastID
+      extractor:
+        type: jmesPath
+        jmes_path: "[-1].id"
+`)
+	rows = out.bySuffix("")
+	if len(rows) != 2 || rows[1][0] != "true" || rows[1][7] != "2" || rows[1][8] != "user1,user2" {
+		t.Errorf("expected the selected rows, got %v", rows)
+	}
+	if id, _ := str.Load("LastID"); id != float64(3) {
+		t.Errorf("expected the last id 3, got %v", id)
+	}
+}
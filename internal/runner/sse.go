@@ -0,0 +1,702 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/auth"
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/executor/sseexec"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+	"github.com/cresplanex/bloader/internal/utils"
+)
+
+// DefaultSSEConnections is the default number of the SSE subscriptions
+const DefaultSSEConnections = 1
+
+// MassExecSSE represents the Server-Sent Events configuration for the MassExec runner.
+// Each connection holds the subscription, and the received events are recorded like the responses of the HTTP requests.
+type MassExecSSE struct {
+	TargetID     *string               `yaml:"target_id"`
+	Endpoint     *string               `yaml:"endpoint"`
+	QueryParam   map[string]any        `yaml:"query_param"`
+	Headers      map[string]any        `yaml:"headers"`
+	Connections  *int                  `yaml:"connections"`
+	Events       []string              `yaml:"events"`
+	ResponseType *string               `yaml:"response_type"`
+	Timestamp    *MassExecSSETimestamp `yaml:"timestamp"`
+	Data         []ExecRequestData     `yaml:"data"`
+	SuccessBreak []string              `yaml:"success_break"`
+	Break        MassExecRequestBreak  `yaml:"break"`
+	Emit         []MassExecRequestEmit `yaml:"emit"`
+}
+
+// SSETimestampFormat represents the format of the timestamp embedded in the event data
+type SSETimestampFormat string
+
+const (
+	// SSETimestampFormatUnix represents the unix time in seconds
+	SSETimestampFormatUnix SSETimestampFormat = "unix"
+	// SSETimestampFormatUnixMilli represents the unix time in milliseconds
+	SSETimestampFormatUnixMilli SSETimestampFormat = "unixMilli"
+	// SSETimestampFormatUnixMicro represents the unix time in microseconds
+	SSETimestampFormatUnixMicro SSETimestampFormat = "unixMicro"
+	// SSETimestampFormatUnixNano represents the unix time in nanoseconds
+	SSETimestampFormatUnixNano SSETimestampFormat = "unixNano"
+	// SSETimestampFormatRFC3339 represents the RFC3339 time, the fraction of the seconds is allowed
+	SSETimestampFormatRFC3339 SSETimestampFormat = "rfc3339"
+
+	// DefaultSSETimestampFormat represents the default timestamp format
+	DefaultSSETimestampFormat = SSETimestampFormatRFC3339
+)
+
+// MassExecSSETimestamp represents the timestamp embedded in the event data,
+// the latency is measured from it to the time the event is received
+type MassExecSSETimestamp struct {
+	Extractor *matcher.DataExtractor `yaml:"extractor"`
+	Format    *string                `yaml:"format"`
+}
+
+// ValidMassExecSSETimestamp represents the valid timestamp configuration
+type ValidMassExecSSETimestamp struct {
+	Extractor matcher.ValidDataExtractor
+	Format    SSETimestampFormat
+}
+
+// Validate validates the MassExecSSETimestamp
+func (t MassExecSSETimestamp) Validate() (ValidMassExecSSETimestamp, error) {
+	var valid ValidMassExecSSETimestamp
+	if t.Extractor == nil {
+		return ValidMassExecSSETimestamp{}, fmt.Errorf("extractor is required")
+	}
+	var err error
+	if valid.Extractor, err = t.Extractor.Validate(); err != nil {
+		return ValidMassExecSSETimestamp{}, fmt.Errorf("failed to validate extractor: %w", err)
+	}
+	valid.Format = DefaultSSETimestampFormat
+	if t.Format != nil {
+		switch SSETimestampFormat(*t.Format) {
+		case SSETimestampFormatUnix, SSETimestampFormatUnixMilli, SSETimestampFormatUnixMicro,
+			SSETimestampFormatUnixNano, SSETimestampFormatRFC3339:
+			valid.Format = SSETimestampFormat(*t.Format)
+		default:
+			return ValidMassExecSSETimestamp{}, fmt.Errorf("invalid format value: %s", *t.Format)
+		}
+	}
+	return valid, nil
+}
+
+// Extract extracts the timestamp from the event data
+func (t ValidMassExecSSETimestamp) Extract(data any) (time.Time, error) {
+	value, err := t.Extractor.Extract(data)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to extract timestamp: %w", err)
+	}
+	if t.Format == SSETimestampFormatRFC3339 {
+		str, ok := value.(string)
+		if !ok {
+			return time.Time{}, fmt.Errorf("timestamp must be string: %v", value)
+		}
+		ts, err := time.Parse(time.RFC3339Nano, str)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+		}
+		return ts, nil
+	}
+	var n float64
+	switch v := value.(type) {
+	case float64:
+		n = v
+	case int:
+		n = float64(v)
+	case string:
+		if n, err = strconv.ParseFloat(v, 64); err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+		}
+	default:
+		return time.Time{}, fmt.Errorf("timestamp must be number: %v", value)
+	}
+	var nanos float64
+	switch t.Format {
+	case SSETimestampFormatUnix:
+		nanos = n * float64(time.Second)
+	case SSETimestampFormatUnixMilli:
+		nanos = n * float64(time.Millisecond)
+	case SSETimestampFormatUnixMicro:
+		nanos = n * float64(time.Microsecond)
+	default:
+		nanos = n
+	}
+	return time.Unix(0, int64(math.Round(nanos))), nil
+}
+
+// ValidMassExecSSE represents the valid Server-Sent Events configuration for the MassExec runner
+type ValidMassExecSSE struct {
+	URL          string
+	QueryParam   map[string]any
+	Headers      map[string]any
+	Connections  int
+	Events       map[string]struct{}
+	ResponseType httpexec.ResponseType
+	Timestamp    *ValidMassExecSSETimestamp
+	Data         ValidExecRequestDataSlice
+	SuccessBreak matcher.TerminateTypeAndParamsSlice
+	Break        ValidMassExecRequestBreak
+	Emit         []ValidMassExecRequestEmit
+}
+
+// Validate validates the MassExecSSE
+func (s MassExecSSE) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+) (ValidMassExecSSE, error) {
+	var valid ValidMassExecSSE
+	if s.TargetID == nil {
+		return ValidMassExecSSE{}, fmt.Errorf("target_id is required")
+	}
+	if s.Endpoint == nil {
+		return ValidMassExecSSE{}, fmt.Errorf("endpoint is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *s.TargetID)
+	if err != nil {
+		return ValidMassExecSSE{}, fmt.Errorf("failed to factorize target: %w", err)
+	}
+	if tg.Type != config.TargetTypeHTTP {
+		return ValidMassExecSSE{}, fmt.Errorf("target %s is not a http target", *s.TargetID)
+	}
+	valid.URL = fmt.Sprintf("%s%s", tg.URL, *s.Endpoint)
+	valid.QueryParam = s.QueryParam
+	valid.Headers = s.Headers
+	valid.Connections = DefaultSSEConnections
+	if s.Connections != nil {
+		if *s.Connections <= 0 {
+			return ValidMassExecSSE{}, fmt.Errorf("connections must be greater than 0")
+		}
+		valid.Connections = *s.Connections
+	}
+	if len(s.Events) > 0 {
+		valid.Events = make(map[string]struct{}, len(s.Events))
+		for _, e := range s.Events {
+			valid.Events[e] = struct{}{}
+		}
+	}
+	if s.ResponseType == nil {
+		return ValidMassExecSSE{}, fmt.Errorf("response_type is required")
+	}
+	switch httpexec.ResponseType(*s.ResponseType) {
+	case httpexec.ResponseTypeJSON, httpexec.ResponseTypeXML, httpexec.ResponseTypeYAML,
+		httpexec.ResponseTypeText, httpexec.ResponseTypeHTML, httpexec.ResponseTypeDiscard:
+		valid.ResponseType = httpexec.ResponseType(*s.ResponseType)
+	default:
+		return ValidMassExecSSE{}, fmt.Errorf("invalid response_type value: %s", *s.ResponseType)
+	}
+	if s.Timestamp != nil {
+		timestamp, err := s.Timestamp.Validate()
+		if err != nil {
+			return ValidMassExecSSE{}, fmt.Errorf("failed to validate timestamp: %w", err)
+		}
+		valid.Timestamp = &timestamp
+	}
+	for i, d := range s.Data {
+		validData, err := d.Validate()
+		if err != nil {
+			return ValidMassExecSSE{}, fmt.Errorf("failed to validate data[%d]: %w", i, err)
+		}
+		valid.Data = append(valid.Data, validData)
+	}
+	if valid.SuccessBreak, err = matcher.NewTerminateTypeAndParamsSliceFromStringSlice(s.SuccessBreak); err != nil {
+		return ValidMassExecSSE{}, fmt.Errorf("failed to parse success break: %w", err)
+	}
+	if valid.Break, err = s.Break.Validate(ctx, log); err != nil {
+		return ValidMassExecSSE{}, fmt.Errorf("failed to validate break: %w", err)
+	}
+	for i, e := range s.Emit {
+		validEmit, err := e.Validate(ctx, log)
+		if err != nil {
+			return ValidMassExecSSE{}, fmt.Errorf("failed to validate emit[%d]: %w", i, err)
+		}
+		valid.Emit = append(valid.Emit, validEmit)
+	}
+	return valid, nil
+}
+
+// newRequest creates the subscription request with the auth
+func (s ValidMassExecSSE) newRequest(ctx context.Context, setAuthor auth.SetAuthor) (*http.Request, error) {
+	fullURL, err := url.Parse(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct URL: %w", err)
+	}
+	queryParams := fullURL.Query()
+	for key, value := range s.QueryParam {
+		if arr, ok := value.([]any); ok {
+			for _, v := range arr {
+				queryParams.Add(key, fmt.Sprint(v))
+			}
+			continue
+		}
+		queryParams.Set(key, fmt.Sprint(value))
+	}
+	fullURL.RawQuery = queryParams.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if setAuthor != nil {
+		setAuthor.SetOnRequest(ctx, req)
+	}
+	for key, value := range s.Headers {
+		if array, ok := value.([]any); ok {
+			for _, v := range array {
+				req.Header.Add(key, fmt.Sprint(v))
+			}
+			continue
+		}
+		req.Header.Set(key, fmt.Sprint(value))
+	}
+	return req, nil
+}
+
+// sseStats represents the statistics of the SSE subscription
+type sseStats struct {
+	connected   bool
+	connectTime int64
+	received    int64
+	latencySum  time.Duration
+	latencySize int64
+	latencyMax  time.Duration
+}
+
+// SSEEventHeader is the header of the received events file of the SSE subscription
+var SSEEventHeader = []string{
+	"Success",
+	"ReceivedDatetime",
+	"Count",
+	"EventID",
+	"Event",
+	"EventDatetime",
+	"Latency",
+}
+
+// SSEConnectionHeader is the header of the connections file of the SSE runner
+var SSEConnectionHeader = []string{
+	"Connection",
+	"Success",
+	"ConnectDatetime",
+	"ConnectedDatetime",
+	"ConnectTime",
+	"StatusCode",
+}
+
+// SSESummaryHeader is the header of the summary file of the SSE runner
+var SSESummaryHeader = []string{
+	"Connections",
+	"Connected",
+	"Received",
+	"Duration",
+	"ReceivedPerSecond",
+	"AvgConnectTime",
+	"AvgLatency",
+	"MaxLatency",
+}
+
+func (r ValidMassExec) runSSE(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+) ([]MassExecResult, error) {
+	sse := r.SSE
+	uniqueName := fmt.Sprintf("%s/%s", outputRoot, utils.GenerateUniqueID())
+	// the subscriptions are long-lived, so the client has no timeout and they end by the breaks
+	client := &http.Client{}
+
+	var connMu sync.Mutex
+	connWriters := make([]output.HTTPDataWrite, 0, len(r.Output))
+	for _, o := range r.Output {
+		writer, closer, err := o.HTTPDataWriteFactory(
+			ctx,
+			log,
+			true,
+			fmt.Sprintf("%s_connections", uniqueName),
+			SSEConnectionHeader,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create writer: %w", err)
+		}
+		defer func() {
+			if err := closer(); err != nil {
+				log.Error(ctx, "failed to close writer",
+					logger.Value("error", err))
+			}
+		}()
+		connWriters = append(connWriters, writer)
+	}
+	// the writers of the outputs are not safe for the concurrent use
+	writeConnection := func(ctx context.Context, log logger.Logger, data []string) error {
+		connMu.Lock()
+		defer connMu.Unlock()
+		for _, w := range connWriters {
+			if err := w(ctx, log, data); err != nil {
+				return fmt.Errorf("failed to write data: %w", err)
+			}
+		}
+		return nil
+	}
+
+	results := make([]MassExecResult, sse.Connections)
+	stats := make([]sseStats, sse.Connections)
+	var wg sync.WaitGroup
+	var atomicErr atomic.Pointer[syncError]
+	startTime := time.Now()
+	for i := 0; i < sse.Connections; i++ {
+		writers := make([]output.HTTPDataWrite, 0, len(r.Output))
+		var writeCloser []output.Close
+		for _, o := range r.Output {
+			writer, closer, err := o.HTTPDataWriteFactory(
+				ctx,
+				log,
+				true,
+				fmt.Sprintf("%s_%d", uniqueName, i),
+				append(SSEEventHeader, sse.Data.ExtractHeader()...),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create writer: %w", err)
+			}
+			writeCloser = append(writeCloser, closer)
+			writers = append(writers, writer)
+		}
+		emitter := NewRequestEventEmitter(eventCaster, sse.Emit)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				for _, c := range writeCloser {
+					if err := c(); err != nil {
+						log.Error(ctx, "failed to close writer",
+							logger.Value("error", err), logger.Value("id", i))
+					}
+				}
+			}()
+			result, err := sse.runSubscription(ctx, log, i, client, r.Auth, &stats[i], writers, writeConnection, emitter)
+			results[i] = result
+			if err != nil {
+				atomicErr.Store(&syncError{Err: err})
+				log.Error(ctx, "failed to execute",
+					logger.Value("error", err), logger.Value("id", i))
+			}
+		}(i)
+	}
+	wg.Wait()
+	duration := time.Since(startTime)
+
+	// the context may be canceled, so the results are written with the parent context
+	writeCtx := context.WithoutCancel(ctx)
+	if err := writeSSESummary(writeCtx, log, r.Output, uniqueName, stats, duration); err != nil {
+		return results, err
+	}
+	if err := writeMassExecResults(writeCtx, log, r.Output, uniqueName, results); err != nil {
+		return results, err
+	}
+
+	if syncErr := atomicErr.Load(); syncErr != nil {
+		log.Error(ctx, "failed to find error",
+			logger.Value("error", syncErr.Err))
+		return results, syncErr.Err
+	}
+	return results, nil
+}
+
+// runSubscription runs the SSE subscription until it terminates by the break
+func (s ValidMassExecSSE) runSubscription(
+	ctx context.Context,
+	log logger.Logger,
+	id int,
+	client *http.Client,
+	setAuthor auth.SetAuthor,
+	stats *sseStats,
+	writers []output.HTTPDataWrite,
+	writeConnection func(ctx context.Context, log logger.Logger, data []string) error,
+	emitter *RequestEventEmitter,
+) (MassExecResult, error) {
+	result := MassExecResult{
+		RequestIndex: id,
+		Method:       http.MethodGet,
+		URL:          s.URL,
+	}
+	var counts TerminateCounts
+	finish := func(termType matcher.TerminateType, param string) (MassExecResult, error) {
+		log.Info(ctx, "Execute End For Break",
+			logger.Value("ExecuteID", id))
+		emitter.OnBreak(ctx, log, termType, param)
+		result.TerminateType = termType
+		result.MatchedID = param
+		result.Counts = counts
+		result.Success = s.SuccessBreak.Match(termType, param)
+		result.EndedAt = time.Now()
+		if result.Success {
+			log.Info(ctx, "Execute End For Success Break", logger.Value("ExecuteID", id))
+			return result, nil
+		}
+		if termType == matcher.TerminateTypeByContext {
+			log.Debug(ctx, "execute End For Context", logger.Value("ExecuteID", id))
+			return result, nil
+		}
+		return result, fmt.Errorf("execute End For Fail Break: %v(%v)", termType, param)
+	}
+
+	log.Info(ctx, "Execute Start",
+		logger.Value("ExecutorID", id))
+	req, err := s.newRequest(ctx, setAuthor)
+	if err != nil {
+		log.Error(ctx, "failed to create request",
+			logger.Value("error", err), logger.Value("id", id))
+		return finish(matcher.TerminateTypeByCreateRequestError, "")
+	}
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, connContent, err := sseexec.Connect(subCtx, log, client, req)
+	stats.connected = connContent.Success
+	stats.connectTime = connContent.ConnectTime
+	if writeErr := writeConnection(ctx, log, []string{
+		strconv.Itoa(id),
+		strconv.FormatBool(connContent.Success),
+		connContent.StartTime.Format(time.RFC3339Nano),
+		connContent.EndTime.Format(time.RFC3339Nano),
+		strconv.FormatInt(connContent.ConnectTime, 10),
+		strconv.Itoa(connContent.StatusCode),
+	}); writeErr != nil {
+		log.Error(ctx, "failed to write connection",
+			logger.Value("error", writeErr), logger.Value("id", id))
+		if s.Break.WriteError {
+			if stream != nil {
+				_ = stream.Close()
+			}
+			return finish(matcher.TerminateTypeByWriteError, "")
+		}
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return finish(matcher.TerminateTypeByContext, "")
+		}
+		if matchID, isMatch := s.Break.StatusCodeMatcher(connContent.StatusCode); isMatch {
+			return finish(matcher.TerminateTypeByStatusCode, matchID)
+		}
+		return finish(matcher.TerminateTypeBySystemError, "")
+	}
+	defer func() {
+		if err := stream.Close(); err != nil {
+			log.Debug(ctx, "failed to close event stream",
+				logger.Value("error", err), logger.Value("id", id))
+		}
+	}()
+
+	recvChan := make(chan sseexec.Event)
+	recvErrChan := make(chan error, 1)
+	go func() {
+		for {
+			event, err := stream.Next()
+			if err != nil {
+				recvErrChan <- err
+				return
+			}
+			select {
+			case recvChan <- event:
+			case <-subCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var timeout <-chan time.Time
+	if s.Break.Time.Enabled {
+		timer := time.NewTimer(s.Break.Time.Time)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	needsBody := len(s.Data) > 0 || s.Timestamp != nil || s.Break.ParseError ||
+		s.Break.ResponseBodyEnabled || len(s.Emit) > 0
+	for {
+		select {
+		case <-ctx.Done():
+			return finish(matcher.TerminateTypeByContext, "")
+		case <-timeout:
+			return finish(matcher.TerminateTypeByTimeout, "")
+		case err := <-recvErrChan:
+			if ctx.Err() != nil {
+				return finish(matcher.TerminateTypeByContext, "")
+			}
+			if errors.Is(err, io.EOF) {
+				log.Info(ctx, "event stream closed by server",
+					logger.Value("id", id))
+			} else {
+				log.Error(ctx, "failed to receive event",
+					logger.Value("error", err), logger.Value("id", id))
+			}
+			return finish(matcher.TerminateTypeBySystemError, "")
+		case event := <-recvChan:
+			if s.Events != nil {
+				if _, ok := s.Events[event.Event]; !ok {
+					continue
+				}
+			}
+			count := int(stats.received)
+			stats.received++
+			counts.Count++
+			body, parseErr := httpexec.ReadResponseBody(strings.NewReader(event.Data), s.ResponseType, 0, needsBody)
+			counts.BodyBytes += body.BodyBytes
+			if parseErr != nil {
+				counts.FailureCount++
+				log.Error(ctx, "failed to parse event",
+					logger.Value("error", parseErr), logger.Value("id", id))
+			} else {
+				counts.SuccessCount++
+			}
+
+			var eventDatetime, latency string
+			if s.Timestamp != nil && parseErr == nil {
+				ts, err := s.Timestamp.Extract(body.Res)
+				if err != nil {
+					log.Warn(ctx, "failed to extract timestamp",
+						logger.Value("error", err), logger.Value("id", id))
+				} else {
+					l := event.ReceivedTime.Sub(ts)
+					stats.latencySum += l
+					stats.latencySize++
+					stats.latencyMax = max(stats.latencyMax, l)
+					eventDatetime = ts.Format(time.RFC3339Nano)
+					latency = strconv.FormatInt(l.Milliseconds(), 10)
+				}
+			}
+			emitter.OnResponse(ctx, log, count, body.Res)
+
+			row := []string{
+				strconv.FormatBool(parseErr == nil),
+				event.ReceivedTime.Format(time.RFC3339Nano),
+				strconv.Itoa(count),
+				event.ID,
+				event.Event,
+				eventDatetime,
+				latency,
+			}
+			writeErr := func() error {
+				for _, d := range s.Data {
+					result, err := d.Extractor.Extract(body.Res)
+					if err != nil {
+						return fmt.Errorf("failed to extract data: %w", err)
+					}
+					row = append(row, fmt.Sprint(result))
+				}
+				for _, writer := range writers {
+					if err := writer(ctx, log, row); err != nil {
+						return fmt.Errorf("failed to write data: %w", err)
+					}
+				}
+				return nil
+			}()
+			if writeErr != nil {
+				log.Error(ctx, "failed to write data",
+					logger.Value("error", writeErr), logger.Value("id", id))
+				if s.Break.WriteError {
+					return finish(matcher.TerminateTypeByWriteError, "")
+				}
+			}
+
+			if parseErr != nil && s.Break.ParseError {
+				return finish(matcher.TerminateTypeByParseResponseError, "")
+			}
+			if s.Break.ResponseBodyEnabled {
+				matchID, isMatch, err := s.Break.ResponseBodyMatcher(body.Res)
+				if err != nil {
+					log.Error(ctx, "failed to match response body",
+						logger.Value("error", err), logger.Value("id", id))
+					return finish(matcher.TerminateTypeByResponseBodyBreakFilterError, matchID)
+				}
+				if isMatch {
+					return finish(matcher.TerminateTypeByResponseBody, matchID)
+				}
+			}
+			if s.Break.Count.Enabled && int(stats.received) >= s.Break.Count.Count {
+				return finish(matcher.TerminateTypeByCount, "")
+			}
+		}
+	}
+}
+
+// writeSSESummary writes the summary of the SSE subscriptions to the outputs
+func writeSSESummary(
+	ctx context.Context,
+	log logger.Logger,
+	outputs []output.Output,
+	uniqueName string,
+	stats []sseStats,
+	duration time.Duration,
+) error {
+	var connected, received, connectTimeSum, latencySize int64
+	var latencySum, latencyMax time.Duration
+	for i := range stats {
+		if stats[i].connected {
+			connected++
+			connectTimeSum += stats[i].connectTime
+		}
+		received += stats[i].received
+		latencySum += stats[i].latencySum
+		latencySize += stats[i].latencySize
+		latencyMax = max(latencyMax, stats[i].latencyMax)
+	}
+	var receivedPerSecond, avgConnectTime, avgLatency, maxLatency string
+	receivedPerSecond = "0"
+	if duration > 0 {
+		receivedPerSecond = strconv.FormatFloat(float64(received)/duration.Seconds(), 'f', 2, 64)
+	}
+	if connected > 0 {
+		avgConnectTime = strconv.FormatInt(connectTimeSum/connected, 10)
+	}
+	if latencySize > 0 {
+		avgLatency = strconv.FormatInt((latencySum / time.Duration(latencySize)).Milliseconds(), 10)
+		maxLatency = strconv.FormatInt(latencyMax.Milliseconds(), 10)
+	}
+	row := []string{
+		strconv.Itoa(len(stats)),
+		strconv.FormatInt(connected, 10),
+		strconv.FormatInt(received, 10),
+		strconv.FormatInt(duration.Milliseconds(), 10),
+		receivedPerSecond,
+		avgConnectTime,
+		avgLatency,
+		maxLatency,
+	}
+	for _, o := range outputs {
+		writer, closer, err := o.HTTPDataWriteFactory(
+			ctx,
+			log,
+			true,
+			fmt.Sprintf("%s_summary", uniqueName),
+			SSESummaryHeader,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create summary writer: %w", err)
+		}
+		if err := writer(ctx, log, row); err != nil {
+			return fmt.Errorf("failed to write summary: %w", err)
+		}
+		if err := closer(); err != nil {
+			return fmt.Errorf("failed to close summary writer: %w", err)
+		}
+	}
+	return nil
+}
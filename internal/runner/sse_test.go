@@ -0,0 +1,145 @@
+package runner_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// newSSEServer starts the server which pushes the tick events with the timestamp every 5ms,
+// a comment and a ping event are interleaved, and the stream is held until the client leaves
+func newSSEServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "text/event-stream" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		if r.URL.Query().Get("room") != "lobby" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		_, _ = fmt.Fprint(w, ": welcome\n\n")
+		flusher.Flush()
+		ticker := time.NewTicker(5 * time.Millisecond)
+		defer ticker.Stop()
+		for n := 0; ; n++ {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-ticker.C:
+			}
+			_, _ = fmt.Fprintf(w, "event: ping\ndata: {}\n\n")
+			_, _ = fmt.Fprintf(w, "id: %d\nevent: tick\ndata: {\"n\":%d,\ndata: \"ts\":%d}\n\n",
+				n, n, time.Now().UnixMilli())
+			flusher.Flush()
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestMassExecSSE tests the SSE MassExec against the in-process event stream.
+func TestMassExecSSE(t *testing.T) {
+	srv := newSSEServer(t)
+	targets := target.Container{
+		"api": {Type: config.TargetTypeHTTP, URL: srv.URL},
+	}
+
+	t.Run("Count", func(tt *testing.T) {
+		out, results := runMassExec(tt, targets, "sse", `
+type: sse
+output:
+  enabled: true
+  ids: ["memory"]
+sse:
+  target_id: api
+  endpoint: /events
+  query_param:
+    room: lobby
+  connections: 2
+  events: ["tick"]
+  response_type: json
+  timestamp:
+    extractor:
+      type: jmesPath
+      jmes_path: ts
+    format: unixMilli
+  data:
+    - key: N
+      extractor:
+        type: jmesPath
+        jmes_path: "n"
+  success_break:
+    - count
+  break:
+    count: 3
+    time: 10s
+`)
+		if len(results) != 2 {
+			tt.Fatalf("expected 2 results, got %d", len(results))
+		}
+		for _, r := range results {
+			if !r.Success || r.Counts.Count != 3 {
+				tt.Errorf("expected 3 events, got %+v", r)
+			}
+		}
+		for _, suffix := range []string{"_0", "_1"} {
+			rows := out.bySuffix(suffix)
+			if len(rows) != 4 {
+				tt.Fatalf("expected header and 3 rows, got %d", len(rows))
+			}
+			for i, row := range rows[1:] {
+				// the multi-line data is joined, and the ping events are skipped
+				if row[0] != "true" || row[4] != "tick" || row[3] != row[7] || row[7] != strconv.Itoa(i) {
+					tt.Errorf("expected the tick event %d, got %v", i, row)
+				}
+				if latency, err := strconv.Atoi(row[6]); err != nil || latency < 0 {
+					tt.Errorf("expected the latency, got %v", row)
+				}
+			}
+		}
+		if rows := out.bySuffix("_summary"); len(rows) != 2 || rows[1][1] != "2" || rows[1][2] != "6" {
+			tt.Errorf("expected 2 connections with 6 events, got %v", rows)
+		}
+	})
+
+	t.Run("StatusCode", func(tt *testing.T) {
+		out, results := runMassExec(tt, targets, "sse", `
+type: sse
+output:
+  enabled: true
+  ids: ["memory"]
+sse:
+  target_id: api
+  endpoint: /events
+  response_type: json
+  success_break:
+    - statusCode/notFound
+  break:
+    status_code:
+      - id: notFound
+        op: eq
+        value: 404
+`)
+		if len(results) != 1 || !results[0].Success || results[0].MatchedID != "notFound" {
+			tt.Errorf("expected the status code break, got %+v", results)
+		}
+		if rows := out.bySuffix("_connections"); len(rows) != 2 || rows[1][1] != "false" || rows[1][5] != "404" {
+			tt.Errorf("expected the failed subscription, got %v", rows)
+		}
+	})
+}
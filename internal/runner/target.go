@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
 	"github.com/cresplanex/bloader/internal/target"
 )
 
@@ -32,3 +34,17 @@ func (l LocalTargetFactor) Factorize(_ context.Context, targetID string) (target
 	}
 	return target.Target{}, fmt.Errorf("target not found: %s", targetID)
 }
+
+// httpTransport returns the transport configuration of the HTTP target
+func httpTransport(tg target.Target) httpexec.TransportConfig {
+	if tg.Protocol != config.TargetProtocolH3 {
+		return httpexec.TransportConfig{}
+	}
+	return httpexec.TransportConfig{
+		HTTP3:              true,
+		Fallback:           tg.HTTP3.Fallback,
+		ZeroRTT:            tg.HTTP3.ZeroRTT,
+		HandshakeTimeout:   tg.HTTP3.HandshakeTimeout,
+		InsecureSkipVerify: tg.HTTP3.InsecureSkipVerify,
+	}
+}
@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/cresplanex/bloader/internal/encrypt"
+	"github.com/cresplanex/bloader/internal/tmplfunc"
+)
+
+// NewTmplFuncMap creates the function map used for rendering the runner templates.
+// The store and encrypt functions are bound to the given store and encrypt container,
+// so they work in the same way on both master and slave.
+func NewTmplFuncMap(ctx context.Context, str Store, encCtr encrypt.Container) template.FuncMap {
+	funcMap := sprig.TxtFuncMap()
+	for k, v := range tmplfunc.FuncMap() {
+		funcMap[k] = v
+	}
+	funcMap["storeGet"] = storeGetFunc(ctx, str)
+	funcMap["jwtSign"] = jwtSignFunc(encCtr)
+	return funcMap
+}
+
+// builtinTmplFuncs is the functions predefined by text/template, call is not included since it calls any function
+var builtinTmplFuncs = []string{
+	"and", "or", "not", "len", "index", "slice", "print", "printf", "println",
+	"html", "js", "urlquery", "eq", "ne", "lt", "le", "gt", "ge",
+}
+
+// staticTmplFuncs returns the names of the functions whose results are the same for the same arguments.
+// The functions not known here, e.g. the ones supplied by the user, are regarded as changing on each call.
+var staticTmplFuncs = sync.OnceValue(func() map[string]struct{} {
+	perCall := tmplfunc.PerCallFuncs()
+	// the stored value can be updated during the run
+	perCall["storeGet"] = struct{}{}
+	names := map[string]struct{}{TmplFuncInclude: {}, TmplFuncImport: {}}
+	for _, name := range builtinTmplFuncs {
+		names[name] = struct{}{}
+	}
+	for name := range NewTmplFuncMap(context.Background(), nil, nil) {
+		if _, ok := perCall[name]; !ok {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+})
+
+// isPerCallTmplFunc reports whether the result of the template function can change on each call
+func isPerCallTmplFunc(name string) bool {
+	_, ok := staticTmplFuncs()[name]
+	return !ok
+}
+
+// storeGetFunc returns the function which imports the value from the store
+// ex. {{ storeGet "bucket" "key" }} or {{ storeGet "bucket" "key" "encryptID" }}
+func storeGetFunc(ctx context.Context, str Store) func(string, string, ...string) (any, error) {
+	return func(bucketID, key string, encryptID ...string) (any, error) {
+		if str == nil {
+			return nil, fmt.Errorf("store is not available")
+		}
+		if len(encryptID) > 1 {
+			return nil, fmt.Errorf("storeGet accepts at most one encrypt id")
+		}
+		data := ValidStoreImportData{
+			BucketID: bucketID,
+			Key:      key,
+			StoreKey: key,
+		}
+		if len(encryptID) == 1 {
+			data.Encrypt = ValidCredentialEncryptConfig{
+				Enabled:   true,
+				EncryptID: encryptID[0],
+			}
+		}
+		var result any
+		if err := str.Import(ctx, []ValidStoreImportData{data}, func(_ context.Context, _ ValidStoreImportData, val any, _ []byte) error {
+			result = val
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to get store value: %w", err)
+		}
+		return result, nil
+	}
+}
+
+// jwtSignFunc returns the function which signs the claims with the key of the encrypter (HS256)
+// ex. {{ jwtSign "encryptID" (dict "sub" "user" "exp" 1700000000) }}
+func jwtSignFunc(encCtr encrypt.Container) func(string, any) (string, error) {
+	return func(encryptID string, claims any) (string, error) {
+		e, ok := encCtr[encryptID]
+		if !ok {
+			return "", fmt.Errorf("encrypter not found: %s", encryptID)
+		}
+		kh, ok := e.(encrypt.KeyHolder)
+		if !ok {
+			return "", fmt.Errorf("encrypter does not expose the key: %s", encryptID)
+		}
+		var claimsBytes []byte
+		switch c := claims.(type) {
+		case string:
+			claimsBytes = []byte(c)
+		case []byte:
+			claimsBytes = c
+		default:
+			var err error
+			if claimsBytes, err = json.Marshal(c); err != nil {
+				return "", fmt.Errorf("failed to marshal claims: %w", err)
+			}
+		}
+		return tmplfunc.SignJWTHS256(kh.Key(), claimsBytes)
+	}
+}
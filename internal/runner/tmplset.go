@@ -0,0 +1,280 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+)
+
+const (
+	// TmplFuncInclude is the template function which renders the partial file or the named define
+	// ex. {{ include "common/headers.yaml" . | nindent 4 }}
+	TmplFuncInclude = "include"
+	// TmplFuncImport is the template function which loads the defines of the file without rendering
+	// ex. {{ import "common/defines.yaml" }}
+	TmplFuncImport = "import"
+)
+
+// TmplSet represents the set of the templates sharing the functions, the included files and the defines.
+// The included files are resolved through the TmplFactor, so it works on the slave in the same way.
+type TmplSet struct {
+	ctx        context.Context
+	tmplFactor TmplFactor
+	funcMap    template.FuncMap
+	mu         sync.Mutex
+	root       *template.Template
+}
+
+// NewTmplSet creates a new template set
+func NewTmplSet(ctx context.Context, tmplFactor TmplFactor, funcMap template.FuncMap) *TmplSet {
+	s := &TmplSet{
+		ctx:        ctx,
+		tmplFactor: tmplFactor,
+		funcMap:    funcMap,
+	}
+	funcMap[TmplFuncInclude] = s.include
+	funcMap[TmplFuncImport] = s.importFile
+	s.root = template.New("").Funcs(funcMap)
+	return s
+}
+
+// Parse parses the template with the name and loads the included files
+func (s *TmplSet) Parse(name, tmplStr string) (*template.Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.parseRoot(name, tmplStr, []string{name})
+}
+
+// ParseFile parses the file resolved through the TmplFactor, the parsed file is cached
+func (s *TmplSet) ParseFile(path string) (*template.Template, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t := s.root.Lookup(path); t != nil {
+		return t, nil
+	}
+	tmplStr, err := s.tmplFactor.TmplFactorize(s.ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to factorize template %s: %w", path, err)
+	}
+	return s.parseRoot(path, tmplStr, []string{path})
+}
+
+// ReadFile reads the raw file resolved through the TmplFactor, the file is not parsed as the template
+func (s *TmplSet) ReadFile(path string) (string, error) {
+	content, err := s.tmplFactor.TmplFactorize(s.ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to factorize file %s: %w", path, err)
+	}
+	return content, nil
+}
+
+// parseRoot parses the template with the included files,
+// and checks that the includes not resolved as the files refer to the defines of the set
+func (s *TmplSet) parseRoot(name, tmplStr string, stack []string) (*template.Template, error) {
+	unresolved := make(map[string]error)
+	tmpl, err := s.parse(name, tmplStr, stack, unresolved)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(unresolved))
+	for ref := range unresolved {
+		names = append(names, ref)
+	}
+	slices.Sort(names)
+	for _, ref := range names {
+		if s.root.Lookup(ref) == nil {
+			return nil, fmt.Errorf("included template %s is neither a file nor a define: %w", ref, unresolved[ref])
+		}
+	}
+	return tmpl, nil
+}
+
+// parse parses the template and the referred files recursively,
+// the includes which are not found as the files are added to unresolved, they may refer to the defines parsed later
+func (s *TmplSet) parse(name, tmplStr string, stack []string, unresolved map[string]error) (*template.Template, error) {
+	before := make(map[string]struct{})
+	for _, t := range s.root.Templates() {
+		before[t.Name()] = struct{}{}
+	}
+	tmpl, err := s.root.New(name).Parse(tmplStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	var refs []tmplRef
+	for _, t := range s.root.Templates() {
+		if _, ok := before[t.Name()]; ok && t.Name() != name {
+			continue
+		}
+		if t.Tree != nil {
+			refs = collectTmplRefs(t.Tree.Root, refs)
+		}
+	}
+	// imports first, so that the includes can refer to the imported defines
+	slices.SortStableFunc(refs, func(a, b tmplRef) int {
+		if a.fn == b.fn {
+			return 0
+		}
+		if a.fn == TmplFuncImport {
+			return -1
+		}
+		return 1
+	})
+	for _, ref := range refs {
+		if slices.Contains(stack, ref.name) {
+			return nil, fmt.Errorf("cyclic include detected: %s", strings.Join(append(stack, ref.name), " -> "))
+		}
+		if s.root.Lookup(ref.name) != nil {
+			continue
+		}
+		refStr, err := s.tmplFactor.TmplFactorize(s.ctx, ref.name)
+		if err != nil {
+			if ref.fn == TmplFuncImport {
+				return nil, fmt.Errorf("failed to factorize template %s: %w", ref.name, err)
+			}
+			// the include may refer to the define of the file parsed later
+			unresolved[ref.name] = err
+			continue
+		}
+		if _, err := s.parse(ref.name, refStr, append(slices.Clone(stack), ref.name), unresolved); err != nil {
+			return nil, err
+		}
+	}
+	return tmpl, nil
+}
+
+func (s *TmplSet) include(name string, data any) (string, error) {
+	tmpl, err := s.ParseFile(name)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute included template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// executeFixed executes the template with the functions changing on each call replaced by the ones
+// returning the zero values, so the results rendered from the same data can be compared
+func (s *TmplSet) executeFixed(tmpl *template.Template, data any) ([]byte, error) {
+	s.mu.Lock()
+	set, err := tmpl.Clone()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template %s: %w", tmpl.Name(), err)
+	}
+	fixed := make(template.FuncMap)
+	for name, fn := range s.funcMap {
+		if isPerCallTmplFunc(name) {
+			fixed[name] = zeroFunc(fn)
+		}
+	}
+	// the included templates are parsed on parsing the template, so they are executed with the fixed functions too
+	fixed[TmplFuncInclude] = func(name string, data any) (string, error) {
+		t := set.Lookup(name)
+		if t == nil {
+			return "", fmt.Errorf("included template %s is not parsed", name)
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("failed to execute included template %s: %w", name, err)
+		}
+		return buf.String(), nil
+	}
+	set.Funcs(fixed)
+	var buf bytes.Buffer
+	if err := set.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute template %s: %w", tmpl.Name(), err)
+	}
+	return buf.Bytes(), nil
+}
+
+// zeroFunc returns the function of the same type as fn, which returns the zero values
+func zeroFunc(fn any) any {
+	t := reflect.TypeOf(fn)
+	if t == nil || t.Kind() != reflect.Func {
+		return fn
+	}
+	return reflect.MakeFunc(t, func([]reflect.Value) []reflect.Value {
+		out := make([]reflect.Value, t.NumOut())
+		for i := range out {
+			out[i] = reflect.Zero(t.Out(i))
+		}
+		return out
+	}).Interface()
+}
+
+func (s *TmplSet) importFile(path string) (string, error) {
+	if _, err := s.ParseFile(path); err != nil {
+		return "", err
+	}
+	return "", nil
+}
+
+// tmplRef represents the reference to the other template by include or import
+type tmplRef struct {
+	fn   string
+	name string
+}
+
+// collectTmplRefs collects the include and import calls whose name is a constant string
+func collectTmplRefs(node parse.Node, refs []tmplRef) []tmplRef {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return refs
+		}
+		for _, c := range n.Nodes {
+			refs = collectTmplRefs(c, refs)
+		}
+	case *parse.ActionNode:
+		refs = collectTmplRefs(n.Pipe, refs)
+	case *parse.IfNode:
+		refs = collectBranchTmplRefs(&n.BranchNode, refs)
+	case *parse.RangeNode:
+		refs = collectBranchTmplRefs(&n.BranchNode, refs)
+	case *parse.WithNode:
+		refs = collectBranchTmplRefs(&n.BranchNode, refs)
+	case *parse.TemplateNode:
+		if n.Pipe != nil {
+			refs = collectTmplRefs(n.Pipe, refs)
+		}
+	case *parse.PipeNode:
+		if n == nil {
+			return refs
+		}
+		for _, cmd := range n.Cmds {
+			refs = collectTmplRefs(cmd, refs)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) >= 2 {
+			if ident, ok := n.Args[0].(*parse.IdentifierNode); ok &&
+				(ident.Ident == TmplFuncInclude || ident.Ident == TmplFuncImport) {
+				if str, ok := n.Args[1].(*parse.StringNode); ok {
+					refs = append(refs, tmplRef{fn: ident.Ident, name: str.Text})
+				}
+			}
+		}
+		for _, arg := range n.Args {
+			refs = collectTmplRefs(arg, refs)
+		}
+	}
+	return refs
+}
+
+func collectBranchTmplRefs(n *parse.BranchNode, refs []tmplRef) []tmplRef {
+	refs = collectTmplRefs(n.Pipe, refs)
+	refs = collectTmplRefs(n.List, refs)
+	if n.ElseList != nil {
+		refs = collectTmplRefs(n.ElseList, refs)
+	}
+	return refs
+}
@@ -0,0 +1,155 @@
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/cresplanex/bloader/internal/runner"
+)
+
+// newTmplSet returns the template set resolving the files from the temporary directory
+func newTmplSet(t *testing.T, files map[string]string) *runner.TmplSet {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	return runner.NewTmplSet(context.Background(), runner.NewLocalTmplFactor(dir), template.FuncMap{})
+}
+
+// TestTmplSet tests the includes, the imports and the defines shared in the template set.
+// The files are read with the trailing newline.
+func TestTmplSet(t *testing.T) {
+	cases := []struct {
+		name    string
+		files   map[string]string
+		main    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "IncludeFile",
+			files: map[string]string{"common/headers.yaml": "X-Tenant: {{ .tenant }}"},
+			main:  `headers: {{ include "common/headers.yaml" . }}`,
+			want:  "headers: X-Tenant: t1\n",
+		},
+		{
+			name: "NestedInclude",
+			files: map[string]string{
+				"outer.yaml": `[{{ include "inner.yaml" . }}]`,
+				"inner.yaml": "{{ .tenant }}",
+			},
+			main: `{{ include "outer.yaml" . }}`,
+			want: "[t1\n]\n",
+		},
+		{
+			name:  "ImportDefine",
+			files: map[string]string{"defines.yaml": `{{ define "auth" }}Bearer {{ .token }}{{ end }}`},
+			main:  `{{ import "defines.yaml" }}auth: {{ include "auth" . }}`,
+			want:  "auth: Bearer secret",
+		},
+		{
+			name: "DefineOfImportedFileInInclude",
+			files: map[string]string{
+				"defines.yaml": `{{ define "tenant" }}{{ .tenant }}{{ end }}`,
+				"body.yaml":    `{"tenant": "{{ include "tenant" . }}"}`,
+			},
+			main: `{{ import "defines.yaml" }}{{ include "body.yaml" . }}`,
+			want: "{\"tenant\": \"t1\"}\n",
+		},
+		{
+			name:  "DefineOfMainInInclude",
+			files: map[string]string{"body.yaml": `<{{ include "local" . }}>`},
+			main:  `{{ define "local" }}{{ .tenant }}{{ end }}{{ include "body.yaml" . }}`,
+			want:  "<t1>\n",
+		},
+		{
+			name:    "MissingInclude",
+			main:    `{{ include "missing.yaml" . }}`,
+			wantErr: "included template missing.yaml is neither a file nor a define",
+		},
+		{
+			name:    "MissingIncludeInIncludedFile",
+			files:   map[string]string{"outer.yaml": `{{ include "nope" . }}`},
+			main:    `{{ include "outer.yaml" . }}`,
+			wantErr: "included template nope is neither a file nor a define",
+		},
+		{
+			name:    "MissingImport",
+			main:    `{{ import "missing.yaml" }}`,
+			wantErr: "failed to factorize template missing.yaml",
+		},
+		{
+			name: "Cycle",
+			files: map[string]string{
+				"a.yaml": `{{ include "b.yaml" . }}`,
+				"b.yaml": `{{ include "a.yaml" . }}`,
+			},
+			main:    `{{ include "a.yaml" . }}`,
+			wantErr: "cyclic include detected: main -> a.yaml -> b.yaml -> a.yaml",
+		},
+		{
+			name:    "SelfCycle",
+			files:   map[string]string{"self.yaml": `{{ import "self.yaml" }}`},
+			main:    `{{ import "self.yaml" }}`,
+			wantErr: "cyclic include detected: main -> self.yaml -> self.yaml",
+		},
+	}
+	data := map[string]any{"tenant": "t1", "token": "secret"}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			tmpl, err := newTmplSet(tt, c.files).Parse("main", c.main)
+			if c.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+					tt.Fatalf("expected the error of %q, got %v", c.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				tt.Fatalf("failed to parse: %v", err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				tt.Fatalf("failed to execute: %v", err)
+			}
+			if buf.String() != c.want {
+				tt.Errorf("expected %q, got %q", c.want, buf.String())
+			}
+		})
+	}
+
+	t.Run("SharedDefines", func(tt *testing.T) {
+		// the define imported by one template is shared with the later templates of the set
+		set := newTmplSet(tt, map[string]string{
+			"defines.yaml": `{{ define "greet" }}hello {{ .tenant }}{{ end }}`,
+		})
+		if _, err := set.Parse("first", `{{ import "defines.yaml" }}`); err != nil {
+			tt.Fatalf("failed to parse first: %v", err)
+		}
+		second, err := set.Parse("second", `{{ include "greet" . }}`)
+		if err != nil {
+			tt.Fatalf("failed to parse second: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := second.Execute(&buf, data); err != nil {
+			tt.Fatalf("failed to execute: %v", err)
+		}
+		if buf.String() != "hello t1" {
+			tt.Errorf("expected the shared define, got %q", buf.String())
+		}
+		if _, err := newTmplSet(tt, nil).Parse("second", `{{ include "greet" . }}`); err == nil {
+			tt.Errorf("expected the define not to be shared with the other set")
+		}
+	})
+}
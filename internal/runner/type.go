@@ -23,6 +23,10 @@ const (
 	RunnerKindFlow Kind = "Flow"
 	// RunnerKindSlaveConnect represents the slave connect runner
 	RunnerKindSlaveConnect Kind = "SlaveConnect"
+	// RunnerKindBarrier represents the barrier runner
+	RunnerKindBarrier Kind = "Barrier"
+	// RunnerKindScenario represents the weighted scenarios runner
+	RunnerKindScenario Kind = "Scenario"
 )
 
 // Runner represents a runner
@@ -52,7 +56,9 @@ func (r Runner) Validate() (ValidRunner, error) {
 		RunnerKindOneExecute,
 		RunnerKindMassExecute,
 		RunnerKindFlow,
-		RunnerKindSlaveConnect:
+		RunnerKindSlaveConnect,
+		RunnerKindBarrier,
+		RunnerKindScenario:
 		kind = Kind(*r.Kind)
 	default:
 		return ValidRunner{}, fmt.Errorf("invalid kind value: %s", *r.Kind)
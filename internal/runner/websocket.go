@@ -0,0 +1,845 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/auth"
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/executor/httpexec"
+	"github.com/cresplanex/bloader/internal/executor/wsexec"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/runner/matcher"
+	"github.com/cresplanex/bloader/internal/utils"
+)
+
+// DefaultWebSocketConnections is the default number of the WebSocket connections
+const DefaultWebSocketConnections = 1
+
+// MassExecWebSocket represents the WebSocket configuration for the MassExec runner.
+// Each connection sends the message on the interval or in reply to the received messages,
+// and the received messages are recorded like the responses of the HTTP requests.
+type MassExecWebSocket struct {
+	TargetID        *string                 `yaml:"target_id"`
+	Endpoint        *string                 `yaml:"endpoint"`
+	Headers         map[string]any          `yaml:"headers"`
+	Connections     *int                    `yaml:"connections"`
+	MessageType     *string                 `yaml:"message_type"`
+	Message         any                     `yaml:"message"`
+	MessageTemplate MassExecRequestTemplate `yaml:"message_template"`
+	Interval        *string                 `yaml:"interval"`
+	Reply           MassExecWebSocketReply  `yaml:"reply"`
+	ResponseType    *string                 `yaml:"response_type"`
+	CorrelationID   *matcher.DataExtractor  `yaml:"correlation_id"`
+	Data            []ExecRequestData       `yaml:"data"`
+	SuccessBreak    []string                `yaml:"success_break"`
+	Break           MassExecRequestBreak    `yaml:"break"`
+	Emit            []MassExecRequestEmit   `yaml:"emit"`
+}
+
+// MassExecWebSocketReply represents the configuration to send the message in reply to the received messages
+type MassExecWebSocketReply struct {
+	Enabled      bool                   `yaml:"enabled"`
+	ResponseBody matcher.BodyConditions `yaml:"response_body"`
+}
+
+// ValidMassExecWebSocketReply represents the valid reply configuration
+type ValidMassExecWebSocketReply struct {
+	Enabled             bool
+	ResponseBodyEnabled bool
+	ResponseBodyMatcher matcher.BodyConditionsMatcher
+}
+
+// Validate validates the MassExecWebSocketReply
+func (r MassExecWebSocketReply) Validate(ctx context.Context, log logger.Logger) (ValidMassExecWebSocketReply, error) {
+	if !r.Enabled {
+		return ValidMassExecWebSocketReply{}, nil
+	}
+	valid := ValidMassExecWebSocketReply{
+		Enabled:             true,
+		ResponseBodyEnabled: len(r.ResponseBody) > 0,
+	}
+	var err error
+	if valid.ResponseBodyMatcher, err = r.ResponseBody.MatcherGenerate(ctx, log); err != nil {
+		return ValidMassExecWebSocketReply{}, fmt.Errorf("failed to generate response body matcher: %w", err)
+	}
+	return valid, nil
+}
+
+// ValidMassExecWebSocket represents the valid WebSocket configuration for the MassExec runner
+type ValidMassExecWebSocket struct {
+	URL           string
+	Headers       map[string]any
+	Connections   int
+	MessageType   wsexec.MessageType
+	Message       any
+	Interval      time.Duration
+	Reply         ValidMassExecWebSocketReply
+	ResponseType  httpexec.ResponseType
+	CorrelationID *matcher.ValidDataExtractor
+	Data          ValidExecRequestDataSlice
+	SuccessBreak  matcher.TerminateTypeAndParamsSlice
+	Break         ValidMassExecRequestBreak
+	Emit          []ValidMassExecRequestEmit
+	Tmpl          *template.Template
+	MessageTmpl   *template.Template
+	ReplaceData   map[string]any
+}
+
+// Validate validates the MassExecWebSocket
+func (w MassExecWebSocket) Validate(
+	ctx context.Context,
+	log logger.Logger,
+	targetFactor TargetFactor,
+	tmplSet *TmplSet,
+	tmpl *template.Template,
+	replaceData map[string]any,
+) (ValidMassExecWebSocket, error) {
+	var valid ValidMassExecWebSocket
+	if w.TargetID == nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("target_id is required")
+	}
+	if w.Endpoint == nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("endpoint is required")
+	}
+	tg, err := targetFactor.Factorize(ctx, *w.TargetID)
+	if err != nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("failed to factorize target: %w", err)
+	}
+	if tg.Type != config.TargetTypeWebSocket {
+		return ValidMassExecWebSocket{}, fmt.Errorf("target %s is not a websocket target", *w.TargetID)
+	}
+	valid.URL = fmt.Sprintf("%s%s", tg.URL, *w.Endpoint)
+	valid.Headers = w.Headers
+	valid.Connections = DefaultWebSocketConnections
+	if w.Connections != nil {
+		if *w.Connections <= 0 {
+			return ValidMassExecWebSocket{}, fmt.Errorf("connections must be greater than 0")
+		}
+		valid.Connections = *w.Connections
+	}
+	valid.MessageType = wsexec.MessageTypeText
+	if w.MessageType != nil {
+		switch wsexec.MessageType(*w.MessageType) {
+		case wsexec.MessageTypeText, wsexec.MessageTypeBinary:
+			valid.MessageType = wsexec.MessageType(*w.MessageType)
+		default:
+			return ValidMassExecWebSocket{}, fmt.Errorf("invalid message_type value: %s", *w.MessageType)
+		}
+	}
+	valid.Message = w.Message
+	if valid.MessageTmpl, err = w.MessageTemplate.Validate(tmplSet); err != nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("failed to validate message template: %w", err)
+	}
+	if w.Interval != nil {
+		if valid.Interval, err = time.ParseDuration(*w.Interval); err != nil {
+			return ValidMassExecWebSocket{}, fmt.Errorf("failed to parse interval: %w", err)
+		}
+	}
+	if valid.Reply, err = w.Reply.Validate(ctx, log); err != nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("failed to validate reply: %w", err)
+	}
+	if (valid.Interval > 0 || valid.Reply.Enabled) && valid.Message == nil && valid.MessageTmpl == nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("message or message_template is required to send")
+	}
+	if w.ResponseType == nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("response_type is required")
+	}
+	switch httpexec.ResponseType(*w.ResponseType) {
+	case httpexec.ResponseTypeJSON, httpexec.ResponseTypeXML, httpexec.ResponseTypeYAML,
+		httpexec.ResponseTypeText, httpexec.ResponseTypeHTML, httpexec.ResponseTypeDiscard:
+		valid.ResponseType = httpexec.ResponseType(*w.ResponseType)
+	default:
+		return ValidMassExecWebSocket{}, fmt.Errorf("invalid response_type value: %s", *w.ResponseType)
+	}
+	if w.CorrelationID != nil {
+		correlationID, err := w.CorrelationID.Validate()
+		if err != nil {
+			return ValidMassExecWebSocket{}, fmt.Errorf("failed to validate correlation_id: %w", err)
+		}
+		valid.CorrelationID = &correlationID
+	}
+	for i, d := range w.Data {
+		validData, err := d.Validate()
+		if err != nil {
+			return ValidMassExecWebSocket{}, fmt.Errorf("failed to validate data[%d]: %w", i, err)
+		}
+		valid.Data = append(valid.Data, validData)
+	}
+	if valid.SuccessBreak, err = matcher.NewTerminateTypeAndParamsSliceFromStringSlice(w.SuccessBreak); err != nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("failed to parse success break: %w", err)
+	}
+	if valid.Break, err = w.Break.Validate(ctx, log); err != nil {
+		return ValidMassExecWebSocket{}, fmt.Errorf("failed to validate break: %w", err)
+	}
+	for i, e := range w.Emit {
+		validEmit, err := e.Validate(ctx, log)
+		if err != nil {
+			return ValidMassExecWebSocket{}, fmt.Errorf("failed to validate emit[%d]: %w", i, err)
+		}
+		valid.Emit = append(valid.Emit, validEmit)
+	}
+	if valid.MessageTmpl == nil {
+		// only the message is rendered for each message, and the static message is sent as validated
+		valid.Tmpl = newFieldTmpl(tmplSet, tmpl, replaceData, "websocket", "message")
+	}
+	valid.ReplaceData = replaceData
+	return valid, nil
+}
+
+// MassExecWebSocketTemplateFields represents the fields which can be overridden by the message template
+type MassExecWebSocketTemplateFields struct {
+	Message any `yaml:"message"`
+}
+
+// renderedWebSocketMessage represents the message of the rendered runner file
+type renderedWebSocketMessage struct {
+	WebSocket *MassExecWebSocketTemplateFields `yaml:"websocket"`
+}
+
+// hasMessage reports whether the message to send is configured
+func (w ValidMassExecWebSocket) hasMessage() bool {
+	return w.Message != nil || w.MessageTmpl != nil
+}
+
+// renderMessage renders the message for the given connection and count.
+// The received message is available as .Dynamic.Received when the message is sent in reply.
+func (w ValidMassExecWebSocket) renderMessage(connection, count int, received any) ([]byte, error) {
+	message := w.Message
+	if w.MessageTmpl != nil || w.Tmpl != nil {
+		replaceData := massReplaceData(w.ReplaceData, map[string]any{
+			"RequestLoopCount": count,
+			"Connection":       connection,
+			"Received":         received,
+		})
+
+		var buffer bytes.Buffer
+		if w.MessageTmpl != nil {
+			if err := w.MessageTmpl.Execute(&buffer, replaceData); err != nil {
+				return nil, fmt.Errorf("failed to execute message template: %w", err)
+			}
+			var fields MassExecWebSocketTemplateFields
+			if err := yaml.Unmarshal(buffer.Bytes(), &fields); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal message template: %w", err)
+			}
+			message = fields.Message
+		} else {
+			if err := w.Tmpl.Execute(&buffer, replaceData); err != nil {
+				return nil, fmt.Errorf("failed to execute template: %w", err)
+			}
+			var rendered renderedWebSocketMessage
+			if err := yaml.Unmarshal(buffer.Bytes(), &rendered); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal yaml: %w", err)
+			}
+			if rendered.WebSocket == nil {
+				return nil, fmt.Errorf("websocket not found in rendered template")
+			}
+			message = rendered.WebSocket.Message
+		}
+	}
+
+	switch m := message.(type) {
+	case nil:
+		return nil, fmt.Errorf("message is empty")
+	case string:
+		return []byte(m), nil
+	case []byte:
+		return m, nil
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return payload, nil
+}
+
+// correlationID extracts the correlation ID from the message, false is returned if it is not found
+func (w ValidMassExecWebSocket) correlationID(message any) (string, bool) {
+	if w.CorrelationID == nil || message == nil {
+		return "", false
+	}
+	id, err := w.CorrelationID.Extract(message)
+	if err != nil || id == nil {
+		return "", false
+	}
+	return fmt.Sprint(id), true
+}
+
+// handshakeHeader creates the header of the opening handshake with the auth
+func (w ValidMassExecWebSocket) handshakeHeader(ctx context.Context, setAuthor auth.SetAuthor) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, w.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if setAuthor != nil {
+		setAuthor.SetOnRequest(ctx, req)
+	}
+	for key, value := range w.Headers {
+		if array, ok := value.([]any); ok {
+			for _, v := range array {
+				req.Header.Add(key, fmt.Sprint(v))
+			}
+			continue
+		}
+		req.Header.Set(key, fmt.Sprint(value))
+	}
+	return req.Header, nil
+}
+
+// webSocketStats represents the statistics of the WebSocket connection
+type webSocketStats struct {
+	connected     bool
+	connectTime   int64
+	sent          atomic.Int64
+	received      int64
+	roundTripSum  time.Duration
+	roundTripSize int64
+}
+
+const (
+	// WebSocketPendingTTL is how long the sent messages wait for the replies of the same correlation ID
+	WebSocketPendingTTL = 5 * time.Minute
+	// WebSocketPendingLimit is the number of the sent messages waiting for the replies on each connection
+	WebSocketPendingLimit = 10000
+)
+
+// webSocketPendingEntry is the sent message in the order of sending
+type webSocketPendingEntry struct {
+	id       string
+	sentTime time.Time
+}
+
+// webSocketPending holds the send time of the messages by the correlation ID.
+// The messages not replied in WebSocketPendingTTL are dropped,
+// and the oldest ones are dropped when more than WebSocketPendingLimit messages are waiting.
+type webSocketPending struct {
+	mu    *sync.Mutex
+	sent  map[string]time.Time
+	order []webSocketPendingEntry
+}
+
+func newWebSocketPending() *webSocketPending {
+	return &webSocketPending{
+		mu:   &sync.Mutex{},
+		sent: make(map[string]time.Time),
+	}
+}
+
+// set sets the send time of the message, and drops the expired and the overflowed messages
+func (p *webSocketPending) set(id string, sentTime time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.sent[id] = sentTime
+	p.order = append(p.order, webSocketPendingEntry{id: id, sentTime: sentTime})
+	for len(p.order) > 0 {
+		oldest := p.order[0]
+		if t, ok := p.sent[oldest.id]; ok && t.Equal(oldest.sentTime) {
+			if len(p.sent) <= WebSocketPendingLimit && sentTime.Sub(oldest.sentTime) <= WebSocketPendingTTL {
+				break
+			}
+			delete(p.sent, oldest.id)
+		}
+		p.order = p.order[1:]
+	}
+	// the replied messages are left in the order, so it is compacted when it grows
+	if len(p.order) > 2*WebSocketPendingLimit {
+		order := make([]webSocketPendingEntry, 0, len(p.sent))
+		for _, e := range p.order {
+			if t, ok := p.sent[e.id]; ok && t.Equal(e.sentTime) {
+				order = append(order, e)
+			}
+		}
+		p.order = order
+	}
+}
+
+// pop returns the send time of the message and removes it
+func (p *webSocketPending) pop(id string) (time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sentTime, ok := p.sent[id]
+	delete(p.sent, id)
+	return sentTime, ok
+}
+
+// WebSocketMessageHeader is the header of the received messages file of the WebSocket connection
+var WebSocketMessageHeader = []string{
+	"Success",
+	"SendDatetime",
+	"ReceivedDatetime",
+	"Count",
+	"RoundTripTime",
+	"CorrelationID",
+}
+
+// WebSocketConnectionHeader is the header of the connections file of the WebSocket runner
+var WebSocketConnectionHeader = []string{
+	"Connection",
+	"Success",
+	"ConnectDatetime",
+	"ConnectedDatetime",
+	"ConnectTime",
+	"StatusCode",
+}
+
+// WebSocketSummaryHeader is the header of the summary file of the WebSocket runner
+var WebSocketSummaryHeader = []string{
+	"Connections",
+	"Connected",
+	"Sent",
+	"Received",
+	"Duration",
+	"SentPerSecond",
+	"ReceivedPerSecond",
+	"AvgConnectTime",
+	"AvgRoundTripTime",
+}
+
+func (r ValidMassExec) runWebSocket(
+	ctx context.Context,
+	log logger.Logger,
+	outputRoot string,
+	eventCaster EventCaster,
+) ([]MassExecResult, error) {
+	ws := r.WebSocket
+	uniqueName := fmt.Sprintf("%s/%s", outputRoot, utils.GenerateUniqueID())
+	header, err := ws.handshakeHeader(ctx, r.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create handshake header: %w", err)
+	}
+
+	var connMu sync.Mutex
+	connWriters := make([]output.HTTPDataWrite, 0, len(r.Output))
+	for _, o := range r.Output {
+		writer, closer, err := o.HTTPDataWriteFactory(
+			ctx,
+			log,
+			true,
+			fmt.Sprintf("%s_connections", uniqueName),
+			WebSocketConnectionHeader,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create writer: %w", err)
+		}
+		defer func() {
+			if err := closer(); err != nil {
+				log.Error(ctx, "failed to close writer",
+					logger.Value("error", err))
+			}
+		}()
+		connWriters = append(connWriters, writer)
+	}
+	// the writers of the outputs are not safe for the concurrent use
+	writeConnection := func(ctx context.Context, log logger.Logger, data []string) error {
+		connMu.Lock()
+		defer connMu.Unlock()
+		for _, w := range connWriters {
+			if err := w(ctx, log, data); err != nil {
+				return fmt.Errorf("failed to write data: %w", err)
+			}
+		}
+		return nil
+	}
+
+	results := make([]MassExecResult, ws.Connections)
+	stats := make([]webSocketStats, ws.Connections)
+	var wg sync.WaitGroup
+	var atomicErr atomic.Pointer[syncError]
+	startTime := time.Now()
+	for i := 0; i < ws.Connections; i++ {
+		writers := make([]output.HTTPDataWrite, 0, len(r.Output))
+		var writeCloser []output.Close
+		for _, o := range r.Output {
+			writer, closer, err := o.HTTPDataWriteFactory(
+				ctx,
+				log,
+				true,
+				fmt.Sprintf("%s_%d", uniqueName, i),
+				append(WebSocketMessageHeader, ws.Data.ExtractHeader()...),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create writer: %w", err)
+			}
+			writeCloser = append(writeCloser, closer)
+			writers = append(writers, writer)
+		}
+		emitter := NewRequestEventEmitter(eventCaster, ws.Emit)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() {
+				for _, c := range writeCloser {
+					if err := c(); err != nil {
+						log.Error(ctx, "failed to close writer",
+							logger.Value("error", err), logger.Value("id", i))
+					}
+				}
+			}()
+			result, err := ws.runConnection(ctx, log, i, header, &stats[i], writers, writeConnection, emitter)
+			results[i] = result
+			if err != nil {
+				atomicErr.Store(&syncError{Err: err})
+				log.Error(ctx, "failed to execute",
+					logger.Value("error", err), logger.Value("id", i))
+			}
+		}(i)
+	}
+	wg.Wait()
+	duration := time.Since(startTime)
+
+	// the context may be canceled, so the results are written with the parent context
+	writeCtx := context.WithoutCancel(ctx)
+	if err := writeWebSocketSummary(writeCtx, log, r.Output, uniqueName, stats, duration); err != nil {
+		return results, err
+	}
+	if err := writeMassExecResults(writeCtx, log, r.Output, uniqueName, results); err != nil {
+		return results, err
+	}
+
+	if syncErr := atomicErr.Load(); syncErr != nil {
+		log.Error(ctx, "failed to find error",
+			logger.Value("error", syncErr.Err))
+		return results, syncErr.Err
+	}
+	return results, nil
+}
+
+// runConnection runs the WebSocket connection until it terminates by the break
+func (w ValidMassExecWebSocket) runConnection(
+	ctx context.Context,
+	log logger.Logger,
+	id int,
+	header http.Header,
+	stats *webSocketStats,
+	writers []output.HTTPDataWrite,
+	writeConnection func(ctx context.Context, log logger.Logger, data []string) error,
+	emitter *RequestEventEmitter,
+) (MassExecResult, error) {
+	result := MassExecResult{
+		RequestIndex: id,
+		Method:       http.MethodGet,
+		URL:          w.URL,
+	}
+	var counts TerminateCounts
+	finish := func(termType matcher.TerminateType, param string) (MassExecResult, error) {
+		log.Info(ctx, "Execute End For Break",
+			logger.Value("ExecuteID", id))
+		emitter.OnBreak(ctx, log, termType, param)
+		result.TerminateType = termType
+		result.MatchedID = param
+		result.Counts = counts
+		result.Success = w.SuccessBreak.Match(termType, param)
+		result.EndedAt = time.Now()
+		if result.Success {
+			log.Info(ctx, "Execute End For Success Break", logger.Value("ExecuteID", id))
+			return result, nil
+		}
+		if termType == matcher.TerminateTypeByContext {
+			log.Debug(ctx, "execute End For Context", logger.Value("ExecuteID", id))
+			return result, nil
+		}
+		return result, fmt.Errorf("execute End For Fail Break: %v(%v)", termType, param)
+	}
+
+	log.Info(ctx, "Execute Start",
+		logger.Value("ExecutorID", id))
+	conn, connContent, err := wsexec.Dial(ctx, log, w.URL, header)
+	stats.connected = connContent.Success
+	stats.connectTime = connContent.ConnectTime
+	if writeErr := writeConnection(ctx, log, []string{
+		strconv.Itoa(id),
+		strconv.FormatBool(connContent.Success),
+		connContent.StartTime.Format(time.RFC3339Nano),
+		connContent.EndTime.Format(time.RFC3339Nano),
+		strconv.FormatInt(connContent.ConnectTime, 10),
+		strconv.Itoa(connContent.StatusCode),
+	}); writeErr != nil {
+		log.Error(ctx, "failed to write connection",
+			logger.Value("error", writeErr), logger.Value("id", id))
+		if w.Break.WriteError {
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return finish(matcher.TerminateTypeByWriteError, "")
+		}
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return finish(matcher.TerminateTypeByContext, "")
+		}
+		if matchID, isMatch := w.Break.StatusCodeMatcher(connContent.StatusCode); isMatch {
+			return finish(matcher.TerminateTypeByStatusCode, matchID)
+		}
+		return finish(matcher.TerminateTypeBySystemError, "")
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Debug(ctx, "failed to close websocket",
+				logger.Value("error", err), logger.Value("id", id))
+		}
+	}()
+
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	recvChan := make(chan wsexec.MessageContent)
+	recvErrChan := make(chan error, 1)
+	go func() {
+		for {
+			msg, err := conn.Receive()
+			if err != nil {
+				recvErrChan <- err
+				return
+			}
+			select {
+			case recvChan <- msg:
+			case <-connCtx.Done():
+				return
+			}
+		}
+	}()
+
+	pending := newWebSocketPending()
+	send := func(count int, received any) error {
+		payload, err := w.renderMessage(id, count, received)
+		if err != nil {
+			return fmt.Errorf("failed to render message: %w", err)
+		}
+		sentTime, err := conn.Send(w.MessageType, payload)
+		if err != nil {
+			return fmt.Errorf("failed to send message: %w", err)
+		}
+		stats.sent.Add(1)
+		if w.CorrelationID != nil {
+			var message any
+			if err := json.Unmarshal(payload, &message); err == nil {
+				if correlationID, ok := w.correlationID(message); ok {
+					pending.set(correlationID, sentTime)
+				}
+			}
+		}
+		return nil
+	}
+
+	sendErrChan := make(chan error, 1)
+	if w.Interval > 0 {
+		go func() {
+			ticker := time.NewTicker(w.Interval)
+			defer ticker.Stop()
+			for count := 0; ; count++ {
+				if err := send(count, nil); err != nil {
+					sendErrChan <- err
+					return
+				}
+				select {
+				case <-connCtx.Done():
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+
+	var timeout <-chan time.Time
+	if w.Break.Time.Enabled {
+		timer := time.NewTimer(w.Break.Time.Time)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	needsBody := len(w.Data) > 0 || w.CorrelationID != nil || w.Break.ParseError ||
+		w.Break.ResponseBodyEnabled || w.Reply.Enabled || len(w.Emit) > 0
+	for {
+		select {
+		case <-ctx.Done():
+			return finish(matcher.TerminateTypeByContext, "")
+		case <-timeout:
+			return finish(matcher.TerminateTypeByTimeout, "")
+		case err := <-sendErrChan:
+			log.Error(ctx, "failed to send message",
+				logger.Value("error", err), logger.Value("id", id))
+			return finish(matcher.TerminateTypeBySystemError, "")
+		case err := <-recvErrChan:
+			if ctx.Err() != nil {
+				return finish(matcher.TerminateTypeByContext, "")
+			}
+			if wsexec.IsClosed(err) {
+				log.Info(ctx, "websocket closed by peer",
+					logger.Value("error", err), logger.Value("id", id))
+			} else {
+				log.Error(ctx, "failed to receive message",
+					logger.Value("error", err), logger.Value("id", id))
+			}
+			return finish(matcher.TerminateTypeBySystemError, "")
+		case msg := <-recvChan:
+			count := int(stats.received)
+			stats.received++
+			counts.Count++
+			body, parseErr := httpexec.ReadResponseBody(bytes.NewReader(msg.Payload), w.ResponseType, 0, needsBody)
+			counts.BodyBytes += body.BodyBytes
+			if parseErr != nil {
+				counts.FailureCount++
+				log.Error(ctx, "failed to parse message",
+					logger.Value("error", parseErr), logger.Value("id", id))
+			} else {
+				counts.SuccessCount++
+			}
+
+			var sendDatetime, roundTripTime, correlationID string
+			if cid, ok := w.correlationID(body.Res); ok {
+				correlationID = cid
+				if sentTime, ok := pending.pop(cid); ok {
+					rtt := msg.ReceivedTime.Sub(sentTime)
+					stats.roundTripSum += rtt
+					stats.roundTripSize++
+					sendDatetime = sentTime.Format(time.RFC3339Nano)
+					roundTripTime = strconv.FormatInt(rtt.Milliseconds(), 10)
+				}
+			}
+			emitter.OnResponse(ctx, log, count, body.Res)
+
+			row := []string{
+				strconv.FormatBool(parseErr == nil),
+				sendDatetime,
+				msg.ReceivedTime.Format(time.RFC3339Nano),
+				strconv.Itoa(count),
+				roundTripTime,
+				correlationID,
+			}
+			writeErr := func() error {
+				for _, d := range w.Data {
+					result, err := d.Extractor.Extract(body.Res)
+					if err != nil {
+						return fmt.Errorf("failed to extract data: %w", err)
+					}
+					row = append(row, fmt.Sprint(result))
+				}
+				for _, writer := range writers {
+					if err := writer(ctx, log, row); err != nil {
+						return fmt.Errorf("failed to write data: %w", err)
+					}
+				}
+				return nil
+			}()
+			if writeErr != nil {
+				log.Error(ctx, "failed to write data",
+					logger.Value("error", writeErr), logger.Value("id", id))
+				if w.Break.WriteError {
+					return finish(matcher.TerminateTypeByWriteError, "")
+				}
+			}
+
+			if parseErr != nil && w.Break.ParseError {
+				return finish(matcher.TerminateTypeByParseResponseError, "")
+			}
+			if w.Break.ResponseBodyEnabled {
+				matchID, isMatch, err := w.Break.ResponseBodyMatcher(body.Res)
+				if err != nil {
+					log.Error(ctx, "failed to match response body",
+						logger.Value("error", err), logger.Value("id", id))
+					return finish(matcher.TerminateTypeByResponseBodyBreakFilterError, matchID)
+				}
+				if isMatch {
+					return finish(matcher.TerminateTypeByResponseBody, matchID)
+				}
+			}
+			if w.Break.Count.Enabled && int(stats.received) >= w.Break.Count.Count {
+				return finish(matcher.TerminateTypeByCount, "")
+			}
+			if w.Reply.Enabled {
+				isMatch := true
+				if w.Reply.ResponseBodyEnabled {
+					var err error
+					if _, isMatch, err = w.Reply.ResponseBodyMatcher(body.Res); err != nil {
+						log.Warn(ctx, "failed to match response body for reply",
+							logger.Value("error", err), logger.Value("id", id))
+						isMatch = false
+					}
+				}
+				if isMatch {
+					if err := send(count, body.Res); err != nil {
+						log.Error(ctx, "failed to send message",
+							logger.Value("error", err), logger.Value("id", id))
+						return finish(matcher.TerminateTypeBySystemError, "")
+					}
+				}
+			}
+		}
+	}
+}
+
+// writeWebSocketSummary writes the summary of the WebSocket connections to the outputs
+func writeWebSocketSummary(
+	ctx context.Context,
+	log logger.Logger,
+	outputs []output.Output,
+	uniqueName string,
+	stats []webSocketStats,
+	duration time.Duration,
+) error {
+	var connected, sent, received, connectTimeSum, roundTripSize int64
+	var roundTripSum time.Duration
+	for i := range stats {
+		if stats[i].connected {
+			connected++
+			connectTimeSum += stats[i].connectTime
+		}
+		sent += stats[i].sent.Load()
+		received += stats[i].received
+		roundTripSum += stats[i].roundTripSum
+		roundTripSize += stats[i].roundTripSize
+	}
+	perSecond := func(n int64) string {
+		if duration <= 0 {
+			return "0"
+		}
+		return strconv.FormatFloat(float64(n)/duration.Seconds(), 'f', 2, 64)
+	}
+	var avgConnectTime, avgRoundTripTime string
+	if connected > 0 {
+		avgConnectTime = strconv.FormatInt(connectTimeSum/connected, 10)
+	}
+	if roundTripSize > 0 {
+		avgRoundTripTime = strconv.FormatInt((roundTripSum / time.Duration(roundTripSize)).Milliseconds(), 10)
+	}
+	row := []string{
+		strconv.Itoa(len(stats)),
+		strconv.FormatInt(connected, 10),
+		strconv.FormatInt(sent, 10),
+		strconv.FormatInt(received, 10),
+		strconv.FormatInt(duration.Milliseconds(), 10),
+		perSecond(sent),
+		perSecond(received),
+		avgConnectTime,
+		avgRoundTripTime,
+	}
+	for _, o := range outputs {
+		writer, closer, err := o.HTTPDataWriteFactory(
+			ctx,
+			log,
+			true,
+			fmt.Sprintf("%s_summary", uniqueName),
+			WebSocketSummaryHeader,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create summary writer: %w", err)
+		}
+		if err := writer(ctx, log, row); err != nil {
+			return fmt.Errorf("failed to write summary: %w", err)
+		}
+		if err := closer(); err != nil {
+			return fmt.Errorf("failed to close summary writer: %w", err)
+		}
+	}
+	return nil
+}
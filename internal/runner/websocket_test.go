@@ -0,0 +1,307 @@
+package runner_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"text/template"
+
+	"github.com/gorilla/websocket"
+	"gopkg.in/yaml.v3"
+
+	"github.com/cresplanex/bloader/internal/config"
+	"github.com/cresplanex/bloader/internal/logger"
+	"github.com/cresplanex/bloader/internal/output"
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/target"
+)
+
+// memoryOutput records the written rows by the unique name
+type memoryOutput struct {
+	mu   sync.Mutex
+	rows map[string][][]string
+}
+
+func (o *memoryOutput) HTTPDataWriteFactory(
+	_ context.Context,
+	_ logger.Logger,
+	_ bool,
+	uniqueName string,
+	header []string,
+) (output.HTTPDataWrite, output.Close, error) {
+	o.mu.Lock()
+	o.rows[uniqueName] = [][]string{header}
+	o.mu.Unlock()
+	write := func(_ context.Context, _ logger.Logger, data []string) error {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.rows[uniqueName] = append(o.rows[uniqueName], data)
+		return nil
+	}
+	return write, func() error { return nil }, nil
+}
+
+// bySuffix returns the rows whose unique name ends with the suffix
+func (o *memoryOutput) bySuffix(suffix string) [][]string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for name, rows := range o.rows {
+		if strings.HasSuffix(name, suffix) {
+			return rows
+		}
+	}
+	return nil
+}
+
+type outputFactor struct {
+	out *memoryOutput
+}
+
+func (f outputFactor) Factorize(_ context.Context, _ string) (output.Output, error) {
+	return f.out, nil
+}
+
+// runMassExec runs the MassExec runner file against the targets, and fails the test on the error of the run
+func runMassExec(t *testing.T, targets target.Container, name, body string) (*memoryOutput, []runner.MassExecResult) {
+	t.Helper()
+	out, results, err := execMassExec(t, t.TempDir(), targets, name, body)
+	if err != nil {
+		t.Fatalf("failed to run: %v", err)
+	}
+	return out, results
+}
+
+// execMassExec runs the MassExec runner file with the template set of the directory,
+// and returns the error of the run
+func execMassExec(
+	t *testing.T,
+	dir string,
+	targets target.Container,
+	name, body string,
+) (*memoryOutput, []runner.MassExecResult, error) {
+	t.Helper()
+	ctx := context.Background()
+	log := logger.NewSlogLogger()
+	tmplSet := runner.NewTmplSet(ctx, runner.NewLocalTmplFactor(dir), template.FuncMap{})
+	tmpl, err := tmplSet.Parse("yaml", body)
+	if err != nil {
+		t.Fatalf("failed to parse template: %v", err)
+	}
+	data := map[string]any{"Dynamic": map[string]any{"LoopCount": 0}}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Fatalf("failed to execute template: %v", err)
+	}
+	var massExec runner.MassExec
+	if err := yaml.Unmarshal(buf.Bytes(), &massExec); err != nil {
+		t.Fatalf("failed to unmarshal yaml: %v", err)
+	}
+	out := &memoryOutput{rows: make(map[string][][]string)}
+	targetFactor := runner.NewLocalTargetFactor(targets)
+	valid, err := massExec.Validate(ctx, log, nil, outputFactor{out: out}, targetFactor, tmplSet, tmpl, data)
+	if err != nil {
+		t.Fatalf("failed to validate: %v", err)
+	}
+	results, err := valid.Run(ctx, log, name, nil, nil, targetFactor, nil, nil)
+	return out, results, err
+}
+
+// newEchoServer starts the WebSocket server which greets on connect and echoes the messages
+func newEchoServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"greeting","n":0}`)); err != nil {
+			return
+		}
+		for {
+			messageType, payload, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(messageType, payload); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// echoTargets returns the targets with the echo server
+func echoTargets(srv *httptest.Server) target.Container {
+	return target.Container{
+		"echo": {Type: config.TargetTypeWebSocket, URL: "ws" + strings.TrimPrefix(srv.URL, "http")},
+	}
+}
+
+// TestMassExecWebSocket tests the WebSocket MassExec against the in-process echo server.
+func TestMassExecWebSocket(t *testing.T) {
+	srv := newEchoServer(t)
+
+	t.Run("Interval", func(tt *testing.T) {
+		out, results := runMassExec(tt, echoTargets(srv), "ws", `
+type: websocket
+output:
+  enabled: true
+  ids: ["memory"]
+websocket:
+  target_id: echo
+  endpoint: /
+  connections: 2
+  interval: 5ms
+  message:
+    id: "c{{ .Dynamic.Connection }}-{{ .Dynamic.RequestLoopCount }}"
+  response_type: json
+  correlation_id:
+    type: jmesPath
+    jmes_path: id
+  data:
+    - key: ID
+      extractor:
+        type: jmesPath
+        jmes_path: id
+  success_break:
+    - count
+  break:
+    count: 4
+`)
+		if len(results) != 2 {
+			tt.Fatalf("expected 2 results, got %d", len(results))
+		}
+		for _, r := range results {
+			if !r.Success || r.Counts.Count != 4 {
+				tt.Errorf("expected success with 4 messages, got %+v", r)
+			}
+		}
+		for _, suffix := range []string{"_0", "_1"} {
+			rows := out.bySuffix(suffix)
+			if len(rows) != 5 {
+				tt.Fatalf("expected header and 4 rows for %s, got %d", suffix, len(rows))
+			}
+			// the first frame is the greeting which is not correlated to the sent message
+			for _, row := range rows[2:] {
+				if row[4] == "" || row[5] != row[6] {
+					tt.Errorf("expected correlated row, got %v", row)
+				}
+			}
+		}
+		if rows := out.bySuffix("_connections"); len(rows) != 3 {
+			tt.Errorf("expected header and 2 connection rows, got %d", len(rows))
+		}
+		summary := out.bySuffix("_summary")
+		if len(summary) != 2 || summary[1][0] != "2" || summary[1][1] != "2" || summary[1][3] != "8" {
+			tt.Errorf("unexpected summary: %v", summary)
+		}
+	})
+
+	t.Run("Reply", func(tt *testing.T) {
+		out, results := runMassExec(tt, echoTargets(srv), "ws", `
+type: websocket
+output:
+  enabled: true
+  ids: ["memory"]
+websocket:
+  target_id: echo
+  endpoint: /
+  message:
+    id: "r{{ .Dynamic.RequestLoopCount }}"
+    n: "{{ .Dynamic.Received.n }}"
+  reply:
+    enabled: true
+  response_type: json
+  success_break:
+    - responseBody/last
+  break:
+    response_body:
+      - id: last
+        extractor:
+          type: jmesPath
+          jmes_path: "id == 'r2'"
+`)
+		if len(results) != 1 || !results[0].Success || results[0].MatchedID != "last" {
+			tt.Fatalf("expected success by the response body, got %+v", results)
+		}
+		// the greeting and the replies r0, r1 and r2 are received
+		if rows := out.bySuffix("_0"); len(rows) != 5 {
+			tt.Errorf("expected header and 4 rows, got %d", len(rows))
+		}
+	})
+	t.Run("Message", func(tt *testing.T) {
+		cases := []struct {
+			name    string
+			prefix  string
+			message string
+			want    []string
+		}{
+			{
+				name:    "Static",
+				message: "message:\n    id: \"fixed-{{ .Dynamic.LoopCount }}\"",
+				want:    []string{"fixed-0", "fixed-0"},
+			},
+			{
+				name:    "Field",
+				message: "message:\n    id: \"m{{ .Dynamic.RequestLoopCount }}\"",
+				want:    []string{"m0", "m1"},
+			},
+			{
+				name:    "TopLevelVariable",
+				prefix:  `{{ $prefix := "v" }}`,
+				message: "message:\n    id: \"{{ $prefix }}{{ .Dynamic.RequestLoopCount }}\"",
+				want:    []string{"v0", "v1"},
+			},
+			{
+				name:    "InsideIf",
+				message: "{{ if true }}message:\n    id: \"if{{ .Dynamic.RequestLoopCount }}\"\n  {{ end }}",
+				want:    []string{"if0", "if1"},
+			},
+		}
+		for _, c := range cases {
+			tt.Run(c.name, func(ttt *testing.T) {
+				out, results := runMassExec(ttt, echoTargets(srv), "ws", c.prefix+`
+type: websocket
+output:
+  enabled: true
+  ids: ["memory"]
+websocket:
+  target_id: echo
+  endpoint: /
+  interval: 5ms
+  `+c.message+`
+  response_type: json
+  data:
+    - key: ID
+      extractor:
+        type: jmesPath
+        jmes_path: id
+  success_break:
+    - count
+  break:
+    count: 3
+`)
+				if len(results) != 1 || !results[0].Success {
+					ttt.Fatalf("expected success, got %+v", results)
+				}
+				rows := out.bySuffix("_0")
+				if len(rows) != 4 {
+					ttt.Fatalf("expected header and 3 rows, got %d", len(rows))
+				}
+				// the first frame is the greeting
+				for i, row := range rows[2:] {
+					if id := row[len(row)-1]; id != c.want[i] {
+						ttt.Errorf("expected the message %s, got %s", c.want[i], id)
+					}
+				}
+			})
+		}
+	})
+}
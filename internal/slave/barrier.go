@@ -0,0 +1,68 @@
+package slave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/cresplanex/bloader/gen/pb/cresplanex/bloader/v1"
+
+	"github.com/cresplanex/bloader/internal/runner"
+	"github.com/cresplanex/bloader/internal/slave/slcontainer"
+)
+
+// barrierLeaveTimeout is the time to send the leave to the master node after the wait is cancelled
+const barrierLeaveTimeout = 5 * time.Second
+
+// Barrier represents the slave barrier, the master node coordinates the release
+type Barrier struct {
+	barrier                       *slcontainer.Barrier
+	connectionID                  string
+	receiveChanelRequestContainer *slcontainer.ReceiveChanelRequestContainer
+	mapper                        *slcontainer.RequestConnectionMapper
+}
+
+// Wait sends the arrival to the master node and blocks until it is released
+func (s *Barrier) Wait(ctx context.Context, id string, participants int, timeout time.Duration) error {
+	reqID, term := s.receiveChanelRequestContainer.SendBarrierRequests(
+		ctx,
+		s.connectionID,
+		s.mapper,
+		slcontainer.BarrierRequest{
+			BarrierID:    id,
+			Participants: participants,
+			Timeout:      timeout,
+		},
+	)
+	if term == nil {
+		return fmt.Errorf("failed to send barrier request")
+	}
+	select {
+	case <-ctx.Done():
+		// the master node must not count the arrival any more, as the local barrier does on cancel
+		leaveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), barrierLeaveTimeout)
+		defer cancel()
+		if err := s.receiveChanelRequestContainer.SendBarrierLeaveRequest(leaveCtx, s.mapper, reqID, id); err != nil {
+			return fmt.Errorf("failed to leave barrier %s: %w", id, errors.Join(ctx.Err(), err))
+		}
+		return ctx.Err()
+	case <-term:
+	}
+
+	result, ok := s.barrier.PopResult(reqID)
+	if !ok {
+		return fmt.Errorf("barrier result not found: %s", id)
+	}
+	switch result.Status {
+	case pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_RELEASED:
+		return nil
+	case pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_TIMEOUT:
+		return fmt.Errorf("%w: %s", runner.ErrBarrierTimeout, result.Message)
+	case pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_ERROR,
+		pb.BarrierReleaseStatus_BARRIER_RELEASE_STATUS_UNSPECIFIED:
+	}
+	return fmt.Errorf("failed to wait at barrier %s: %s", id, result.Message)
+}
+
+var _ runner.Barrier = &Barrier{}
@@ -276,6 +276,13 @@ func (s *Server) CallExec(req *pb.CallExecRequest, stream grpc.ServerStreamingSe
 		mapper:                        s.reqConMap,
 	}
 
+	barrier := &Barrier{
+		barrier:                       slCtr.Barrier,
+		connectionID:                  req.ConnectionId,
+		receiveChanelRequestContainer: slCtr.ReceiveChanelRequestContainer,
+		mapper:                        s.reqConMap,
+	}
+
 	outputChan := make(chan *pb.CallExecResponse)
 	outputFactor := &OutputFactor{
 		outputChan: outputChan,
@@ -311,6 +318,9 @@ func (s *Server) CallExec(req *pb.CallExecRequest, stream grpc.ServerStreamingSe
 		AuthFactor:            authFactor,
 		Store:                 store,
 		OutputFactor:          outputFactor,
+		Barrier:               barrier,
+		Results:               runner.NewFlowResults(),
+		CookieJars:            runner.NewCookieJarContainer(),
 	}
 	if err = exec.Execute(
 		stream.Context(),
@@ -530,3 +540,28 @@ func (s *Server) ReceiveLoadTermChannel(
 		return nil, nil
 	}
 }
+
+// SendBarrierRelease handles the barrier release request from the master node
+func (s *Server) SendBarrierRelease(
+	_ context.Context,
+	req *pb.SendBarrierReleaseRequest,
+) (*pb.SendBarrierReleaseResponse, error) {
+	conID, ok := s.reqConMap.GetConnectionID(req.RequestId)
+	if !ok {
+		return nil, ErrRequestNotFound
+	}
+	s.mu.RLock()
+	slCtr, ok := s.slCtrMap[conID]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrRequestNotFound
+	}
+	slCtr.Barrier.SetResult(req.RequestId, slcontainer.BarrierResult{
+		Status:  req.Status,
+		Message: req.Message,
+	})
+	slCtr.ReceiveChanelRequestContainer.Cast(req.RequestId)
+	s.reqConMap.DeleteRequest(req.RequestId)
+
+	return &pb.SendBarrierReleaseResponse{}, nil
+}
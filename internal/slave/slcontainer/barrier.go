@@ -0,0 +1,53 @@
+package slcontainer
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/cresplanex/bloader/gen/pb/cresplanex/bloader/v1"
+)
+
+// BarrierResult represents the release result of the barrier sent from the master node
+type BarrierResult struct {
+	Status  pb.BarrierReleaseStatus
+	Message string
+}
+
+// Barrier represents the barrier container for the slave node
+type Barrier struct {
+	mu      *sync.RWMutex
+	results map[string]BarrierResult // Key: requestID
+}
+
+// NewBarrier creates a new barrier container for the slave node
+func NewBarrier() *Barrier {
+	return &Barrier{
+		mu:      &sync.RWMutex{},
+		results: make(map[string]BarrierResult),
+	}
+}
+
+// SetResult sets the release result of the request
+func (b *Barrier) SetResult(reqID string, result BarrierResult) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.results[reqID] = result
+}
+
+// PopResult returns and removes the release result of the request
+func (b *Barrier) PopResult(reqID string) (BarrierResult, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result, ok := b.results[reqID]
+	delete(b.results, reqID)
+	return result, ok
+}
+
+// BarrierRequest represents a barrier request
+type BarrierRequest struct {
+	BarrierID    string
+	Participants int
+	Timeout      time.Duration
+}
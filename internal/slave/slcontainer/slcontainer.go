@@ -12,6 +12,7 @@ type SlaveContainer struct {
 	Store                         *Store
 	Target                        *Target
 	Loader                        *Loader
+	Barrier                       *Barrier
 	CommandMap                    *sync.Map
 	ReceiveChanelRequestContainer *ReceiveChanelRequestContainer
 }
@@ -23,6 +24,7 @@ func NewSlaveContainer() *SlaveContainer {
 		Store:                         NewStore(),                         // DON'T CHANGE POINTER TO VALUE
 		Target:                        NewTarget(),                        // DON'T CHANGE POINTER TO VALUE
 		Loader:                        NewLoader(),                        // DON'T CHANGE POINTER TO VALUE
+		Barrier:                       NewBarrier(),                       // DON'T CHANGE POINTER TO VALUE
 		CommandMap:                    &sync.Map{},                        // DON'T CHANGE POINTER TO VALUE
 		ReceiveChanelRequestContainer: NewReceiveChanelRequestContainer(), // DON'T CHANGE POINTER TO VALUE
 	}
@@ -294,6 +294,76 @@ func (r *ReceiveChanelRequestContainer) SendTargetResourceRequests(
 	return r.termCaster.RegisterRequest(requestID)
 }
 
+// SendBarrierRequests sends the barrier request, the returned request id identifies the release result.
+// The request is registered before it is sent, because the master may release it immediately.
+func (r *ReceiveChanelRequestContainer) SendBarrierRequests(
+	ctx context.Context,
+	connectionID string,
+	mapper *RequestConnectionMapper,
+	req BarrierRequest,
+) (string, <-chan struct{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requestID := utils.GenerateUniqueID()
+
+	pbReq := &pb.ReceiveChanelConnectResponse{
+		RequestId:   requestID,
+		RequestType: pb.RequestType_REQUEST_TYPE_BARRIER,
+		Request: &pb.ReceiveChanelConnectResponse_BarrierRequest{
+			BarrierRequest: &pb.ReceiveChanelConnectBarrierRequest{
+				BarrierId:           req.BarrierID,
+				Participants:        int32(req.Participants), //nolint:gosec
+				TimeoutMilliseconds: req.Timeout.Milliseconds(),
+			},
+		},
+	}
+
+	mapper.RegisterRequestConnection(requestID, connectionID)
+	term := r.termCaster.RegisterRequest(requestID)
+
+	select {
+	case <-ctx.Done():
+		mapper.DeleteRequest(requestID)
+		return "", nil
+	case r.ReqChan <- pbReq: // nothing
+	}
+
+	return requestID, term
+}
+
+// SendBarrierLeaveRequest withdraws the arrival of the barrier request,
+// the master node does not release the withdrawn request.
+func (r *ReceiveChanelRequestContainer) SendBarrierLeaveRequest(
+	ctx context.Context,
+	mapper *RequestConnectionMapper,
+	arrivalReqID string,
+	barrierID string,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mapper.DeleteRequest(arrivalReqID)
+
+	pbReq := &pb.ReceiveChanelConnectResponse{
+		RequestId:   utils.GenerateUniqueID(),
+		RequestType: pb.RequestType_REQUEST_TYPE_BARRIER,
+		Request: &pb.ReceiveChanelConnectResponse_BarrierRequest{
+			BarrierRequest: &pb.ReceiveChanelConnectBarrierRequest{
+				BarrierId:      barrierID,
+				LeaveRequestId: arrivalReqID,
+			},
+		},
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case r.ReqChan <- pbReq: // nothing
+	}
+	return nil
+}
+
 // Cast casts a term to the request
 func (r *ReceiveChanelRequestContainer) Cast(reqID string) {
 	r.mu.RLock()
@@ -3,6 +3,7 @@ package slcontainer
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	pb "github.com/cresplanex/bloader/gen/pb/cresplanex/bloader/v1"
 
@@ -53,9 +54,63 @@ func (t *Target) Remove(id string) {
 func (t Target) AddFromProto(id string, pbT *pb.Target) error {
 	switch pbT.Type {
 	case pb.TargetType_TARGET_TYPE_HTTP:
+		http3 := pbT.GetHttp().GetHttp3()
+		protocol := config.TargetProtocol(pbT.GetHttp().Protocol)
+		if protocol == "" {
+			protocol = config.TargetProtocolAuto
+		}
 		t.Add(id, target.Target{
-			Type: config.TargetTypeHTTP,
-			URL:  pbT.GetHttp().Url,
+			Type:     config.TargetTypeHTTP,
+			URL:      pbT.GetHttp().Url,
+			Protocol: protocol,
+			HTTP3: config.ValidTargetHTTP3Config{
+				Fallback:           http3.GetFallback(),
+				ZeroRTT:            http3.GetZeroRtt(),
+				HandshakeTimeout:   time.Duration(http3.GetHandshakeTimeoutMilliseconds()) * time.Millisecond,
+				InsecureSkipVerify: http3.GetInsecureSkipVerify(),
+			},
+		})
+		return nil
+	case pb.TargetType_TARGET_TYPE_WEBSOCKET:
+		t.Add(id, target.Target{
+			Type: config.TargetTypeWebSocket,
+			URL:  pbT.GetWebsocket().Url,
+		})
+		return nil
+	case pb.TargetType_TARGET_TYPE_GRPC:
+		t.Add(id, target.Target{
+			Type: config.TargetTypeGRPC,
+			URL:  pbT.GetGrpc().Url,
+		})
+		return nil
+	case pb.TargetType_TARGET_TYPE_TCP:
+		t.Add(id, target.Target{
+			Type: config.TargetTypeTCP,
+			URL:  pbT.GetTcp().Url,
+		})
+		return nil
+	case pb.TargetType_TARGET_TYPE_UDP:
+		t.Add(id, target.Target{
+			Type: config.TargetTypeUDP,
+			URL:  pbT.GetUdp().Url,
+		})
+		return nil
+	case pb.TargetType_TARGET_TYPE_SQL:
+		t.Add(id, target.Target{
+			Type: config.TargetTypeSQL,
+			URL:  pbT.GetSql().Url,
+		})
+		return nil
+	case pb.TargetType_TARGET_TYPE_REDIS:
+		t.Add(id, target.Target{
+			Type: config.TargetTypeRedis,
+			URL:  pbT.GetRedis().Url,
+		})
+		return nil
+	case pb.TargetType_TARGET_TYPE_MQTT:
+		t.Add(id, target.Target{
+			Type: config.TargetTypeMQTT,
+			URL:  pbT.GetMqtt().Url,
 		})
 		return nil
 	case pb.TargetType_TARGET_TYPE_UNSPECIFIED:
@@ -13,6 +13,10 @@ type Target struct {
 	Type config.TargetType
 	// URL of the target
 	URL string
+	// Protocol of the HTTP target
+	Protocol config.TargetProtocol
+	// HTTP3 is the configuration used when the protocol is h3
+	HTTP3 config.ValidTargetHTTP3Config
 }
 
 // GetTarget returns the target
@@ -23,6 +27,76 @@ func (t Target) GetTarget() *pb.Target {
 			Type: pb.TargetType_TARGET_TYPE_HTTP,
 			Target: &pb.Target_Http{
 				Http: &pb.TargetHTTPData{
+					Url:      t.URL,
+					Protocol: string(t.Protocol),
+					Http3: &pb.TargetHTTP3Data{
+						Fallback:                     t.HTTP3.Fallback,
+						ZeroRtt:                      t.HTTP3.ZeroRTT,
+						HandshakeTimeoutMilliseconds: t.HTTP3.HandshakeTimeout.Milliseconds(),
+						InsecureSkipVerify:           t.HTTP3.InsecureSkipVerify,
+					},
+				},
+			},
+		}
+	case config.TargetTypeWebSocket:
+		return &pb.Target{
+			Type: pb.TargetType_TARGET_TYPE_WEBSOCKET,
+			Target: &pb.Target_Websocket{
+				Websocket: &pb.TargetWebSocketData{
+					Url: t.URL,
+				},
+			},
+		}
+	case config.TargetTypeGRPC:
+		return &pb.Target{
+			Type: pb.TargetType_TARGET_TYPE_GRPC,
+			Target: &pb.Target_Grpc{
+				Grpc: &pb.TargetGRPCData{
+					Url: t.URL,
+				},
+			},
+		}
+	case config.TargetTypeTCP:
+		return &pb.Target{
+			Type: pb.TargetType_TARGET_TYPE_TCP,
+			Target: &pb.Target_Tcp{
+				Tcp: &pb.TargetTCPData{
+					Url: t.URL,
+				},
+			},
+		}
+	case config.TargetTypeUDP:
+		return &pb.Target{
+			Type: pb.TargetType_TARGET_TYPE_UDP,
+			Target: &pb.Target_Udp{
+				Udp: &pb.TargetUDPData{
+					Url: t.URL,
+				},
+			},
+		}
+	case config.TargetTypeSQL:
+		return &pb.Target{
+			Type: pb.TargetType_TARGET_TYPE_SQL,
+			Target: &pb.Target_Sql{
+				Sql: &pb.TargetSQLData{
+					Url: t.URL,
+				},
+			},
+		}
+	case config.TargetTypeRedis:
+		return &pb.Target{
+			Type: pb.TargetType_TARGET_TYPE_REDIS,
+			Target: &pb.Target_Redis{
+				Redis: &pb.TargetRedisData{
+					Url: t.URL,
+				},
+			},
+		}
+	case config.TargetTypeMQTT:
+		return &pb.Target{
+			Type: pb.TargetType_TARGET_TYPE_MQTT,
+			Target: &pb.Target_Mqtt{
+				Mqtt: &pb.TargetMQTTData{
 					Url: t.URL,
 				},
 			},
@@ -40,7 +114,9 @@ func NewContainer(env string, cfg config.ValidTargetConfig) Container {
 	targets := make(Container)
 	for _, target := range cfg {
 		t := Target{
-			Type: target.Type,
+			Type:     target.Type,
+			Protocol: target.Protocol,
+			HTTP3:    target.HTTP3,
 		}
 		var ok bool
 		for _, val := range target.Values {
@@ -0,0 +1,112 @@
+package tmplfunc
+
+import (
+	"crypto/hmac"
+	"crypto/md5"  //nolint:gosec
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+)
+
+func newHash(algo string) (func() hash.Hash, error) {
+	switch algo {
+	case "md5":
+		return md5.New, nil
+	case "sha1":
+		return sha1.New, nil
+	case "sha256":
+		return sha256.New, nil
+	case "sha384":
+		return sha512.New384, nil
+	case "sha512":
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("unsupported hash algorithm: %s", algo)
+	}
+}
+
+func sum(algo, data string) ([]byte, error) {
+	h, err := newHash(algo)
+	if err != nil {
+		return nil, err
+	}
+	hh := h()
+	hh.Write([]byte(data))
+	return hh.Sum(nil), nil
+}
+
+func hmacSum(algo, key, data string) ([]byte, error) {
+	h, err := newHash(algo)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(h, []byte(key))
+	mac.Write([]byte(data))
+	return mac.Sum(nil), nil
+}
+
+// HashHex returns the hex encoded digest of the data
+func HashHex(algo, data string) (string, error) {
+	b, err := sum(algo, data)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashBase64 returns the base64 encoded digest of the data
+func HashBase64(algo, data string) (string, error) {
+	b, err := sum(algo, data)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// HMACHex returns the hex encoded HMAC of the data
+func HMACHex(algo, key, data string) (string, error) {
+	b, err := hmacSum(algo, key, data)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HMACBase64 returns the base64 encoded HMAC of the data
+func HMACBase64(algo, key, data string) (string, error) {
+	b, err := hmacSum(algo, key, data)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// Base64URLEncode encodes the data with the unpadded base64url encoding
+func Base64URLEncode(data string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(data))
+}
+
+// Base64URLDecode decodes the data encoded with the base64url encoding, padded or not
+func Base64URLDecode(data string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil {
+		b, err = base64.URLEncoding.DecodeString(data)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode base64url: %w", err)
+		}
+	}
+	return string(b), nil
+}
+
+// SignJWTHS256 signs the claims with the key using HS256
+func SignJWTHS256(key []byte, claims []byte) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(header + "." + payload))
+	return header + "." + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
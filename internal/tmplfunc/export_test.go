@@ -0,0 +1,7 @@
+package tmplfunc
+
+// LuhnCheckDigit exports luhnCheckDigit for the tests
+var LuhnCheckDigit = luhnCheckDigit
+
+// EncodeULID exports encodeULID for the tests
+var EncodeULID = encodeULID
@@ -0,0 +1,238 @@
+package tmplfunc
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	firstNames = []string{
+		"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda",
+		"William", "Elizabeth", "David", "Barbara", "Richard", "Susan", "Joseph", "Jessica",
+		"Thomas", "Sarah", "Charles", "Karen", "Hiroshi", "Yuki", "Kenji", "Sakura",
+	}
+	lastNames = []string{
+		"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis",
+		"Rodriguez", "Martinez", "Hernandez", "Lopez", "Gonzalez", "Wilson", "Anderson", "Thomas",
+		"Taylor", "Moore", "Jackson", "Martin", "Sato", "Suzuki", "Takahashi", "Tanaka",
+	}
+	emailDomains = []string{"example.com", "example.net", "example.org", "test.example"}
+	streetNames  = []string{
+		"Main", "Oak", "Pine", "Maple", "Cedar", "Elm", "Washington", "Lake", "Hill", "Park",
+	}
+	streetSuffixes = []string{"St", "Ave", "Rd", "Blvd", "Ln", "Dr"}
+	cities         = []string{
+		"Springfield", "Riverside", "Franklin", "Greenville", "Bristol", "Clinton", "Fairview", "Salem",
+	}
+	loremWords = []string{
+		"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+		"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+		"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi",
+	}
+	// creditCardPrefixes are the prefixes of the well known test card numbers
+	creditCardPrefixes = []string{"4", "51", "52", "53", "54", "55", "37"}
+)
+
+// Faker generates the fake data for the load test
+type Faker struct {
+	mu  *sync.Mutex
+	rnd *rand.Rand
+}
+
+var globalFaker = &Faker{
+	mu: &sync.Mutex{},
+	//nolint:gosec
+	rnd: rand.New(rand.NewSource(time.Now().UnixNano())),
+}
+
+// NewSeededFaker creates a new faker which always generates the same sequence for the same seed
+func NewSeededFaker(seed any) (*Faker, error) {
+	s, err := toInt64(seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed: %w", err)
+	}
+	return &Faker{
+		mu: &sync.Mutex{},
+		//nolint:gosec
+		rnd: rand.New(rand.NewSource(s)),
+	}, nil
+}
+
+func (f *Faker) intn(n int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rnd.Intn(n)
+}
+
+func (f *Faker) pick(list []string) string {
+	return list[f.intn(len(list))]
+}
+
+// Intn returns a random int in [0, n)
+func (f *Faker) Intn(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return f.intn(n)
+}
+
+// IntRange returns a random int in [minV, maxV]
+func (f *Faker) IntRange(minV, maxV int) int {
+	if maxV < minV {
+		minV, maxV = maxV, minV
+	}
+	return minV + f.intn(maxV-minV+1)
+}
+
+// Float returns a random float in [0.0, 1.0)
+func (f *Faker) Float() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rnd.Float64()
+}
+
+// Choice returns a random element of the list
+func (f *Faker) Choice(list any) (any, error) {
+	items, err := toSlice(list)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("list is empty")
+	}
+	return items[f.intn(len(items))], nil
+}
+
+// WeightedChoice returns a random value from the value and weight pairs
+// ex. weightedChoice "browse" 70 "search" 25 "checkout" 5
+func (f *Faker) WeightedChoice(pairs ...any) (any, error) {
+	if len(pairs) == 0 || len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("weightedChoice requires value and weight pairs")
+	}
+	values := make([]any, 0, len(pairs)/2)
+	weights := make([]int64, 0, len(pairs)/2)
+	var total int64
+	for i := 0; i < len(pairs); i += 2 {
+		w, err := toInt64(pairs[i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight at %d: %w", i+1, err)
+		}
+		if w < 0 {
+			return nil, fmt.Errorf("weight must be greater than or equal to 0")
+		}
+		values = append(values, pairs[i])
+		weights = append(weights, w)
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("total weight must be greater than 0")
+	}
+	f.mu.Lock()
+	r := f.rnd.Int63n(total)
+	f.mu.Unlock()
+	for i, w := range weights {
+		if r < w {
+			return values[i], nil
+		}
+		r -= w
+	}
+	return values[len(values)-1], nil
+}
+
+// FirstName returns a fake first name
+func (f *Faker) FirstName() string {
+	return f.pick(firstNames)
+}
+
+// LastName returns a fake last name
+func (f *Faker) LastName() string {
+	return f.pick(lastNames)
+}
+
+// Name returns a fake full name
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Email returns a fake email address
+func (f *Faker) Email() string {
+	return fmt.Sprintf(
+		"%s.%s%d@%s",
+		strings.ToLower(f.FirstName()),
+		strings.ToLower(f.LastName()),
+		f.intn(10000),
+		f.pick(emailDomains),
+	)
+}
+
+// Address returns a fake address
+func (f *Faker) Address() string {
+	return fmt.Sprintf(
+		"%d %s %s, %s %05d",
+		f.IntRange(1, 9999),
+		f.pick(streetNames),
+		f.pick(streetSuffixes),
+		f.pick(cities),
+		f.intn(100000),
+	)
+}
+
+// Phone returns a fake phone number in the reserved 555 range
+func (f *Faker) Phone() string {
+	return fmt.Sprintf("+1-%03d-555-%04d", f.IntRange(200, 999), f.IntRange(100, 199))
+}
+
+// Lorem returns the fake sentence with the given number of words
+func (f *Faker) Lorem(words int) string {
+	if words <= 0 {
+		return ""
+	}
+	ws := make([]string, words)
+	for i := range ws {
+		ws[i] = f.pick(loremWords)
+	}
+	return strings.Join(ws, " ")
+}
+
+// CreditCard returns a 16 digits (15 for 37) number which passes the Luhn check,
+// it starts with the prefixes used for the test cards.
+func (f *Faker) CreditCard() string {
+	prefix := f.pick(creditCardPrefixes)
+	length := 16
+	if prefix == "37" {
+		length = 15
+	}
+	digits := make([]int, 0, length)
+	for _, c := range prefix {
+		digits = append(digits, int(c-'0'))
+	}
+	for len(digits) < length-1 {
+		digits = append(digits, f.intn(10))
+	}
+	digits = append(digits, luhnCheckDigit(digits))
+	var b strings.Builder
+	for _, d := range digits {
+		b.WriteByte(byte('0' + d))
+	}
+	return b.String()
+}
+
+func luhnCheckDigit(digits []int) int {
+	var sum int
+	double := true
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := digits[i]
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
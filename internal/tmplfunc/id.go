@@ -0,0 +1,58 @@
+package tmplfunc
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// crockfordBase32 is the alphabet used by ULID
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// UUIDv7 returns a new time ordered UUID (version 7)
+func UUIDv7() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate uuidv7: %w", err)
+	}
+	return id.String(), nil
+}
+
+// ULID returns a new ULID
+func ULID() (string, error) {
+	var b [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+	if _, err := rand.Read(b[6:]); err != nil {
+		return "", fmt.Errorf("failed to generate ulid entropy: %w", err)
+	}
+	return encodeULID(b), nil
+}
+
+// encodeULID encodes the 128 bits into 26 characters of crockford base32
+func encodeULID(b [16]byte) string {
+	out := make([]byte, 26)
+	// 130 bits are encoded, the first 2 bits are always zero
+	var acc uint32
+	var bits uint
+	idx := 0
+	// leading 2 zero bits
+	bits = 2
+	for _, v := range b {
+		acc = acc<<8 | uint32(v)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[idx] = crockfordBase32[(acc>>bits)&0x1f]
+			idx++
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,90 @@
+// Package tmplfunc provides the bloader specific template functions.
+package tmplfunc
+
+import (
+	"text/template"
+)
+
+// funcDef represents the template function, and whether its result changes on each call with the same arguments
+type funcDef struct {
+	fn      any
+	perCall bool
+}
+
+// funcDefs returns the template functions which don't depend on the execution context
+func funcDefs() map[string]funcDef {
+	return map[string]funcDef{
+		// fake data
+		"fakeName":       {fn: globalFaker.Name, perCall: true},
+		"fakeFirstName":  {fn: globalFaker.FirstName, perCall: true},
+		"fakeLastName":   {fn: globalFaker.LastName, perCall: true},
+		"fakeEmail":      {fn: globalFaker.Email, perCall: true},
+		"fakeAddress":    {fn: globalFaker.Address, perCall: true},
+		"fakePhone":      {fn: globalFaker.Phone, perCall: true},
+		"fakeLorem":      {fn: globalFaker.Lorem, perCall: true},
+		"fakeCreditCard": {fn: globalFaker.CreditCard, perCall: true},
+		// identifiers
+		"uuidv7": {fn: UUIDv7, perCall: true},
+		"ulid":   {fn: ULID, perCall: true},
+		// random
+		"weightedChoice": {fn: globalFaker.WeightedChoice, perCall: true},
+		"seeded":         {fn: NewSeededFaker},
+		// hash
+		"hashHex":    {fn: HashHex},
+		"hashBase64": {fn: HashBase64},
+		"hmacHex":    {fn: HMACHex},
+		"hmacBase64": {fn: HMACBase64},
+		// encoding
+		"b64urlenc": {fn: Base64URLEncode},
+		"b64urldec": {fn: Base64URLDecode},
+	}
+}
+
+// sprigPerCallFuncs is the sprig functions whose results change on each call with the same arguments
+var sprigPerCallFuncs = []string{
+	"now",
+	"ago",
+	"randAlphaNum",
+	"randAlpha",
+	"randAscii",
+	"randNumeric",
+	"randInt",
+	"randBytes",
+	"shuffle",
+	"uuidv4",
+	"getHostByName",
+	"bcrypt",
+	"htpasswd",
+	"encryptAES",
+	"genPrivateKey",
+	"genCA",
+	"genCAWithKey",
+	"genSelfSignedCert",
+	"genSelfSignedCertWithKey",
+	"genSignedCert",
+	"genSignedCertWithKey",
+}
+
+// FuncMap returns the template functions which don't depend on the execution context
+func FuncMap() template.FuncMap {
+	funcMap := make(template.FuncMap)
+	for name, def := range funcDefs() {
+		funcMap[name] = def.fn
+	}
+	return funcMap
+}
+
+// PerCallFuncs returns the names of the functions of FuncMap and sprig whose results change on each call
+// with the same arguments, so the result of the template calling them cannot be reused
+func PerCallFuncs() map[string]struct{} {
+	names := make(map[string]struct{}, len(sprigPerCallFuncs))
+	for _, name := range sprigPerCallFuncs {
+		names[name] = struct{}{}
+	}
+	for name, def := range funcDefs() {
+		if def.perCall {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
@@ -0,0 +1,304 @@
+package tmplfunc_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cresplanex/bloader/internal/tmplfunc"
+)
+
+// TestHash tests the digests and the HMACs against the known vectors.
+func TestHash(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func() (string, error)
+		want string
+	}{
+		{"MD5", func() (string, error) { return tmplfunc.HashHex("md5", "abc") }, "900150983cd24fb0d6963f7d28e17f72"},
+		{"SHA1", func() (string, error) { return tmplfunc.HashHex("sha1", "abc") }, "a9993e364706816aba3e25717850c26c9cd0d89d"},
+		{
+			"SHA256",
+			func() (string, error) { return tmplfunc.HashHex("sha256", "abc") },
+			"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			"SHA384",
+			func() (string, error) { return tmplfunc.HashHex("sha384", "abc") },
+			"cb00753f45a35e8bb5a03d699ac65007272c32ab0eded1631a8b605a43ff5bed8086072ba1e7cc2358baeca134c825a7",
+		},
+		{
+			"SHA512",
+			func() (string, error) { return tmplfunc.HashHex("sha512", "abc") },
+			"ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a" +
+				"2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f",
+		},
+		{
+			"SHA256Base64",
+			func() (string, error) { return tmplfunc.HashBase64("sha256", "abc") },
+			"ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=",
+		},
+		// RFC 2104 and RFC 4231
+		{
+			"HMACMD5",
+			func() (string, error) { return tmplfunc.HMACHex("md5", "Jefe", "what do ya want for nothing?") },
+			"750c783e6ab0b503eaa86e310a5db738",
+		},
+		{
+			"HMACSHA256",
+			func() (string, error) { return tmplfunc.HMACHex("sha256", "Jefe", "what do ya want for nothing?") },
+			"5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		},
+		{
+			"HMACSHA256Base64",
+			func() (string, error) { return tmplfunc.HMACBase64("sha256", "Jefe", "what do ya want for nothing?") },
+			"W9zBRr9gdU5qBCQmCJV1x1oAPwidJzmDnexYuWTsOEM=",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			got, err := c.fn()
+			if err != nil {
+				tt.Fatalf("failed to hash: %v", err)
+			}
+			if got != c.want {
+				tt.Errorf("expected %s, got %s", c.want, got)
+			}
+		})
+	}
+
+	t.Run("Unsupported", func(tt *testing.T) {
+		if _, err := tmplfunc.HashHex("sha3", "abc"); err == nil {
+			tt.Errorf("expected the error of the unsupported algorithm")
+		}
+		if _, err := tmplfunc.HMACHex("crc32", "key", "abc"); err == nil {
+			tt.Errorf("expected the error of the unsupported algorithm")
+		}
+	})
+}
+
+// TestBase64URL tests the base64url encoding and the decoding of the padded and the unpadded data.
+func TestBase64URL(t *testing.T) {
+	if got := tmplfunc.Base64URLEncode("\xfb\xff"); got != "-_8" {
+		t.Errorf("expected -_8, got %s", got)
+	}
+	for _, encoded := range []string{"aGk", "aGk="} {
+		got, err := tmplfunc.Base64URLDecode(encoded)
+		if err != nil || got != "hi" {
+			t.Errorf("expected hi from %s, got %q %v", encoded, got, err)
+		}
+	}
+	if _, err := tmplfunc.Base64URLDecode("a+b/"); err == nil {
+		t.Errorf("expected the error of the standard encoding")
+	}
+}
+
+// TestSignJWTHS256 tests the JWT against the known token and verifies it with the same key.
+func TestSignJWTHS256(t *testing.T) {
+	claims := []byte(`{"sub":"1234567890","name":"John Doe","iat":1516239022}`)
+	token, err := tmplfunc.SignJWTHS256([]byte("your-256-bit-secret"), claims)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	const want = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9." +
+		"eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ." +
+		"SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+	if token != want {
+		t.Errorf("expected %s, got %s", want, token)
+	}
+
+	verify := func(token string, key []byte) bool {
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			return false
+		}
+		sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		return hmac.Equal(sig, mac.Sum(nil))
+	}
+	key := []byte("load-test-key")
+	token, err = tmplfunc.SignJWTHS256(key, []byte(`{"sub":"alice"}`))
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if !verify(token, key) {
+		t.Errorf("expected %s to be verified with the same key", token)
+	}
+	if verify(token, []byte("other-key")) {
+		t.Errorf("expected %s not to be verified with the other key", token)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+	if err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(payload, &decoded); err != nil || decoded["sub"] != "alice" {
+		t.Errorf("expected the claims, got %s", payload)
+	}
+}
+
+// decodeULID decodes the crockford base32 ULID into the 16 bytes
+func decodeULID(t *testing.T, id string) [16]byte {
+	t.Helper()
+	const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	if len(id) != 26 {
+		t.Fatalf("expected 26 characters, got %s", id)
+	}
+	var b [16]byte
+	var acc uint32
+	var bits uint
+	idx := 0
+	for i, c := range id {
+		v := strings.IndexRune(alphabet, c)
+		if v < 0 {
+			t.Fatalf("invalid character %c in %s", c, id)
+		}
+		acc = acc<<5 | uint32(v)
+		bits += 5
+		// the first 2 bits are always zero
+		if i == 0 {
+			if v > 7 {
+				t.Fatalf("overflowed ULID %s", id)
+			}
+			bits -= 2
+		}
+		if bits >= 8 {
+			bits -= 8
+			b[idx] = byte(acc >> bits)
+			idx++
+		}
+	}
+	return b
+}
+
+// TestULID tests the ULID encoding against the known vectors, the round trip and the time order.
+func TestULID(t *testing.T) {
+	cases := []struct {
+		name  string
+		bytes [16]byte
+		want  string
+	}{
+		{"Zero", [16]byte{}, "00000000000000000000000000"},
+		{
+			"Max",
+			[16]byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255},
+			"7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		},
+		// the timestamp 1469918176385 of the example of the specification
+		{"Timestamp", [16]byte{0x01, 0x56, 0x3d, 0xf3, 0x64, 0x81}, "01ARYZ6S410000000000000000"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(tt *testing.T) {
+			if got := tmplfunc.EncodeULID(c.bytes); got != c.want {
+				tt.Errorf("expected %s, got %s", c.want, got)
+			}
+			if got := decodeULID(tt, c.want); got != c.bytes {
+				tt.Errorf("expected %v, got %v", c.bytes, got)
+			}
+		})
+	}
+
+	t.Run("RoundTrip", func(tt *testing.T) {
+		before := time.Now().UnixMilli()
+		id, err := tmplfunc.ULID()
+		if err != nil {
+			tt.Fatalf("failed to generate: %v", err)
+		}
+		b := decodeULID(tt, id)
+		if got := tmplfunc.EncodeULID(b); got != id {
+			tt.Errorf("expected %s to round trip, got %s", id, got)
+		}
+		var ms int64
+		for _, v := range b[:6] {
+			ms = ms<<8 | int64(v)
+		}
+		if ms < before || ms > time.Now().UnixMilli() {
+			tt.Errorf("expected the timestamp of %s to be now, got %d", id, ms)
+		}
+	})
+
+	t.Run("TimeOrder", func(tt *testing.T) {
+		var ids []string
+		for range 3 {
+			id, err := tmplfunc.ULID()
+			if err != nil {
+				tt.Fatalf("failed to generate: %v", err)
+			}
+			ids = append(ids, id)
+			time.Sleep(2 * time.Millisecond)
+		}
+		if !slices.IsSorted(ids) {
+			tt.Errorf("expected the ULIDs to sort in the time order, got %v", ids)
+		}
+	})
+}
+
+// luhnValid reports whether the number passes the Luhn check
+func luhnValid(number string) bool {
+	digits := make([]int, 0, len(number))
+	for _, c := range number {
+		digits = append(digits, int(c-'0'))
+	}
+	return tmplfunc.LuhnCheckDigit(digits[:len(digits)-1]) == digits[len(digits)-1]
+}
+
+// TestLuhn tests the check digit against the known numbers and the generated credit cards.
+func TestLuhn(t *testing.T) {
+	cases := []struct {
+		number string
+		valid  bool
+	}{
+		{"79927398713", true},
+		{"4111111111111111", true},
+		{"5555555555554444", true},
+		{"378282246310005", true},
+		{"4111111111111112", false},
+		{"79927398710", false},
+	}
+	for _, c := range cases {
+		if got := luhnValid(c.number); got != c.valid {
+			t.Errorf("expected the Luhn check of %s to be %v, got %v", c.number, c.valid, got)
+		}
+	}
+
+	faker, err := tmplfunc.NewSeededFaker(42)
+	if err != nil {
+		t.Fatalf("failed to create faker: %v", err)
+	}
+	for range 100 {
+		number := faker.CreditCard()
+		if (len(number) != 16 && !strings.HasPrefix(number, "37")) || !luhnValid(number) {
+			t.Errorf("expected the valid credit card, got %s", number)
+		}
+	}
+}
+
+// TestPerCallFuncs tests the functions declared to change on each call.
+func TestPerCallFuncs(t *testing.T) {
+	perCall := tmplfunc.PerCallFuncs()
+	funcMap := tmplfunc.FuncMap()
+	for _, name := range []string{
+		"fakeName", "fakeEmail", "uuidv7", "ulid", "weightedChoice", "now", "randAlphaNum", "uuidv4",
+	} {
+		if _, ok := perCall[name]; !ok {
+			t.Errorf("expected %s to change on each call", name)
+		}
+	}
+	for _, name := range []string{"seeded", "hashHex", "hmacBase64", "b64urlenc", "b64urldec"} {
+		if _, ok := perCall[name]; ok {
+			t.Errorf("expected %s not to change on each call", name)
+		}
+		if _, ok := funcMap[name]; !ok {
+			t.Errorf("expected %s in the function map", name)
+		}
+	}
+}